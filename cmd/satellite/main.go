@@ -34,6 +34,7 @@ import (
 	_ "storj.io/private/process/googleprofiler" // This attaches google cloud profiler.
 	"storj.io/private/version"
 	"storj.io/storj/cmd/satellite/reports"
+	"storj.io/storj/private/nodeextpb"
 	"storj.io/storj/private/revocation"
 	_ "storj.io/storj/private/version" // This attaches version information during release builds.
 	"storj.io/storj/satellite"
@@ -267,7 +268,10 @@ var (
 		Use:   "restore-trash [node-id-1 node-id-2 node-id-3 ...]",
 		Short: "Restore trash",
 		Long: "Tell storage nodes to undo garbage collection. " +
-			"If node ids aren't provided, *all* nodes are used.",
+			"If node ids aren't provided, *all* nodes are used. " +
+			"The restored pieces can be restricted to the pieces trashed after a time, " +
+			"or to the listed piece IDs of a single node. Nodes without support for the " +
+			"restrictions restore all pieces, which is logged as a warning.",
 		RunE: cmdRestoreTrash,
 	}
 	registerLostSegments = &cobra.Command{
@@ -324,6 +328,10 @@ var (
 	}
 	reportsVerifyGracefulExitReceiptCfg struct {
 	}
+	restoreTrashCfg struct {
+		TrashedAfter string
+		PieceIDs     []string
+	}
 	consistencyGECleanupCfg struct {
 		Database string `help:"satellite database connection string" releaseDefault:"postgres://" devDefault:"postgres://"`
 		Before   string `help:"select only exited nodes before this UTC date formatted like YYYY-MM. Date cannot be newer than the current time (required)"`
@@ -382,6 +390,8 @@ func init() {
 	process.Bind(runGCBloomFilterCmd, &runCfg, defaults, cfgstruct.ConfDir(confDir), cfgstruct.IdentityDir(identityDir))
 	process.Bind(runRangedLoopCmd, &runCfg, defaults, cfgstruct.ConfDir(confDir), cfgstruct.IdentityDir(identityDir))
 	process.Bind(restoreTrashCmd, &runCfg, defaults, cfgstruct.ConfDir(confDir), cfgstruct.IdentityDir(identityDir))
+	restoreTrashCmd.Flags().StringVar(&restoreTrashCfg.TrashedAfter, "trashed-after", "", "restore only the pieces trashed at or after this time, formatted like RFC3339")
+	restoreTrashCmd.Flags().StringSliceVar(&restoreTrashCfg.PieceIDs, "piece-ids", nil, "restore only these pieces, requires exactly one node id")
 	process.Bind(registerLostSegments, &runCfg, defaults, cfgstruct.ConfDir(confDir), cfgstruct.IdentityDir(identityDir))
 	process.Bind(fetchPiecesCmd, &runCfg, defaults, cfgstruct.ConfDir(confDir), cfgstruct.IdentityDir(identityDir))
 	process.Bind(repairSegmentCmd, &runCfg, defaults, cfgstruct.ConfDir(confDir), cfgstruct.IdentityDir(identityDir))
@@ -840,6 +850,11 @@ func cmdRestoreTrash(cmd *cobra.Command, args []string) error {
 	ctx, _ := process.Ctx(cmd)
 	log := zap.L()
 
+	request, err := restoreTrashRequest(args)
+	if err != nil {
+		return err
+	}
+
 	db, err := satellitedb.Open(ctx, log.Named("restore-trash"), runCfg.Database, satellitedb.Options{ApplicationName: "satellite-restore-trash"})
 	if err != nil {
 		return errs.New("Error creating new master database connection: %+v", err)
@@ -891,8 +906,13 @@ func cmdRestoreTrash(cmd *cobra.Command, args []string) error {
 			}
 		}()
 
-		client := pb.NewDRPCPiecestoreClient(conn)
-		_, err = client.RestoreTrash(ctx, &pb.RestoreTrashRequest{})
+		if request != nil {
+			// nodes, which don't support the options, fail the request instead of
+			// restoring all trashed pieces.
+			_, err = nodeextpb.NewDRPCPiecestoreClient(conn).RestoreTrashWithOptions(ctx, request)
+		} else {
+			_, err = pb.NewDRPCPiecestoreClient(conn).RestoreTrash(ctx, &pb.RestoreTrashRequest{})
+		}
 		if err != nil {
 			atomic.AddInt64(failures, 1)
 			log.Error("unable to restore trash", zap.String("Node ID", node.ID.String()), zap.Error(err))
@@ -946,6 +966,40 @@ func cmdRestoreTrash(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// restoreTrashRequest creates the restore trash request with the restrictions of the
+// restored pieces. It returns nil when all trashed pieces are restored.
+func restoreTrashRequest(nodeIDs []string) (*nodeextpb.RestoreTrashWithOptionsRequest, error) {
+	if restoreTrashCfg.TrashedAfter == "" && len(restoreTrashCfg.PieceIDs) == 0 {
+		return nil, nil
+	}
+
+	request := &nodeextpb.RestoreTrashWithOptionsRequest{}
+
+	if restoreTrashCfg.TrashedAfter != "" {
+		trashedAfter, err := time.Parse(time.RFC3339, restoreTrashCfg.TrashedAfter)
+		if err != nil {
+			return nil, errs.New("trashed-after flag value isn't of the expected format. %+v", err)
+		}
+		request.TrashedAfter = &trashedAfter
+	}
+
+	if len(restoreTrashCfg.PieceIDs) > 0 {
+		// piece IDs are derived per node, so they only apply to a single node.
+		if len(nodeIDs) != 1 {
+			return nil, errs.New("piece-ids flag requires exactly one node id")
+		}
+		for _, id := range restoreTrashCfg.PieceIDs {
+			pieceID, err := storj.PieceIDFromString(id)
+			if err != nil {
+				return nil, errs.New("invalid piece id %q: %+v", id, err)
+			}
+			request.PieceIds = append(request.PieceIds, pieceID)
+		}
+	}
+
+	return request, nil
+}
+
 func cmdRegisterLostSegments(cmd *cobra.Command, args []string) error {
 	ctx, _ := process.Ctx(cmd)
 	log := zap.L()
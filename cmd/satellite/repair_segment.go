@@ -122,7 +122,8 @@ func cmdRepairSegment(cmd *cobra.Command, args []string) (err error) {
 		dialer,
 		signing.SigneeFromPeerIdentity(identity.PeerIdentity()),
 		config.Repairer.DownloadTimeout,
-		true) // force inmemory download of pieces
+		true, // force inmemory download of pieces
+		config.Repairer.DownloadHedge)
 
 	segmentRepairer := repairer.NewSegmentRepairer(
 		log.Named("segment-repair"),
@@ -0,0 +1,65 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"storj.io/common/identity"
+	"storj.io/common/signing"
+	"storj.io/common/storj"
+	"storj.io/storj/private/nodetag"
+)
+
+func usage() {
+	fmt.Fprintf(os.Stderr, "usage: %s <identity-cert> <identity-key> <nodeid> <name=value>...\n", os.Args[0])
+	fmt.Fprintf(os.Stderr, "\nsigns the tags of the node with the identity of a tag authority, the output can be used as contact.signed-tags-path of the node\n")
+	os.Exit(1)
+}
+
+func main() {
+	if len(os.Args) < 5 {
+		usage()
+	}
+
+	if err := run(context.Background(), os.Args[1], os.Args[2], os.Args[3], os.Args[4:]); err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(ctx context.Context, certPath, keyPath, node string, args []string) error {
+	authority, err := identity.Config{CertPath: certPath, KeyPath: keyPath}.Load()
+	if err != nil {
+		return err
+	}
+
+	nodeID, err := storj.NodeIDFromString(node)
+	if err != nil {
+		return err
+	}
+
+	tags, err := nodetag.ParseTags(strings.Join(args, ","))
+	if err != nil {
+		return err
+	}
+
+	signed, err := nodetag.Sign(ctx, &nodetag.TagSet{
+		NodeID:   nodeID,
+		SignedAt: time.Now().UTC(),
+		Tags:     tags,
+	}, signing.SignerFromFullIdentity(authority))
+	if err != nil {
+		return err
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode([]*nodetag.SignedTagSet{signed})
+}
@@ -0,0 +1,8 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+// Package metainfoextpb contains protobuf definitions for the metainfo
+// features, which aren't part of the common metainfo protocol yet.
+package metainfoextpb
+
+//go:generate go run gen.go
@@ -0,0 +1,711 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: metainfoext.proto
+
+package metainfoextpb
+
+import (
+	fmt "fmt"
+	math "math"
+	time "time"
+
+	proto "github.com/gogo/protobuf/proto"
+
+	pb "storj.io/common/pb"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+var _ = time.Kitchen
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
+
+type ListObjectsRequest struct {
+	List *pb.ObjectListRequest `protobuf:"bytes,1,opt,name=list,proto3" json:"list,omitempty"`
+	// all_versions lists all committed versions and delete markers of the objects
+	// instead of only their latest versions.
+	AllVersions bool `protobuf:"varint,2,opt,name=all_versions,json=allVersions,proto3" json:"all_versions,omitempty"`
+	// cursor_version is the version of the cursor object, when listing all versions.
+	CursorVersion int32 `protobuf:"varint,3,opt,name=cursor_version,json=cursorVersion,proto3" json:"cursor_version,omitempty"`
+	// tags lists only the committed objects, which have all the specified tags.
+	Tags                 []*ObjectTag `protobuf:"bytes,4,rep,name=tags,proto3" json:"tags,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *ListObjectsRequest) Reset()         { *m = ListObjectsRequest{} }
+func (m *ListObjectsRequest) String() string { return proto.CompactTextString(m) }
+func (*ListObjectsRequest) ProtoMessage()    {}
+func (*ListObjectsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0ade661ecd304013, []int{0}
+}
+func (m *ListObjectsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListObjectsRequest.Unmarshal(m, b)
+}
+func (m *ListObjectsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListObjectsRequest.Marshal(b, m, deterministic)
+}
+func (m *ListObjectsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListObjectsRequest.Merge(m, src)
+}
+func (m *ListObjectsRequest) XXX_Size() int {
+	return xxx_messageInfo_ListObjectsRequest.Size(m)
+}
+func (m *ListObjectsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListObjectsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListObjectsRequest proto.InternalMessageInfo
+
+func (m *ListObjectsRequest) GetList() *pb.ObjectListRequest {
+	if m != nil {
+		return m.List
+	}
+	return nil
+}
+
+func (m *ListObjectsRequest) GetAllVersions() bool {
+	if m != nil {
+		return m.AllVersions
+	}
+	return false
+}
+
+func (m *ListObjectsRequest) GetCursorVersion() int32 {
+	if m != nil {
+		return m.CursorVersion
+	}
+	return 0
+}
+
+func (m *ListObjectsRequest) GetTags() []*ObjectTag {
+	if m != nil {
+		return m.Tags
+	}
+	return nil
+}
+
+type GetObjectLockRequest struct {
+	Header               *pb.RequestHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	Bucket               []byte            `protobuf:"bytes,2,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	EncryptedObjectKey   []byte            `protobuf:"bytes,3,opt,name=encrypted_object_key,json=encryptedObjectKey,proto3" json:"encrypted_object_key,omitempty"`
+	Version              int32             `protobuf:"varint,4,opt,name=version,proto3" json:"version,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *GetObjectLockRequest) Reset()         { *m = GetObjectLockRequest{} }
+func (m *GetObjectLockRequest) String() string { return proto.CompactTextString(m) }
+func (*GetObjectLockRequest) ProtoMessage()    {}
+func (*GetObjectLockRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0ade661ecd304013, []int{1}
+}
+func (m *GetObjectLockRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetObjectLockRequest.Unmarshal(m, b)
+}
+func (m *GetObjectLockRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetObjectLockRequest.Marshal(b, m, deterministic)
+}
+func (m *GetObjectLockRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetObjectLockRequest.Merge(m, src)
+}
+func (m *GetObjectLockRequest) XXX_Size() int {
+	return xxx_messageInfo_GetObjectLockRequest.Size(m)
+}
+func (m *GetObjectLockRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetObjectLockRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetObjectLockRequest proto.InternalMessageInfo
+
+func (m *GetObjectLockRequest) GetHeader() *pb.RequestHeader {
+	if m != nil {
+		return m.Header
+	}
+	return nil
+}
+
+func (m *GetObjectLockRequest) GetBucket() []byte {
+	if m != nil {
+		return m.Bucket
+	}
+	return nil
+}
+
+func (m *GetObjectLockRequest) GetEncryptedObjectKey() []byte {
+	if m != nil {
+		return m.EncryptedObjectKey
+	}
+	return nil
+}
+
+func (m *GetObjectLockRequest) GetVersion() int32 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+type GetObjectLockResponse struct {
+	// retain_until is zero, when the object version has no retention.
+	RetainUntil          time.Time `protobuf:"bytes,1,opt,name=retain_until,json=retainUntil,proto3,stdtime" json:"retain_until"`
+	LegalHold            bool      `protobuf:"varint,2,opt,name=legal_hold,json=legalHold,proto3" json:"legal_hold,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *GetObjectLockResponse) Reset()         { *m = GetObjectLockResponse{} }
+func (m *GetObjectLockResponse) String() string { return proto.CompactTextString(m) }
+func (*GetObjectLockResponse) ProtoMessage()    {}
+func (*GetObjectLockResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0ade661ecd304013, []int{2}
+}
+func (m *GetObjectLockResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetObjectLockResponse.Unmarshal(m, b)
+}
+func (m *GetObjectLockResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetObjectLockResponse.Marshal(b, m, deterministic)
+}
+func (m *GetObjectLockResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetObjectLockResponse.Merge(m, src)
+}
+func (m *GetObjectLockResponse) XXX_Size() int {
+	return xxx_messageInfo_GetObjectLockResponse.Size(m)
+}
+func (m *GetObjectLockResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetObjectLockResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetObjectLockResponse proto.InternalMessageInfo
+
+func (m *GetObjectLockResponse) GetRetainUntil() time.Time {
+	if m != nil {
+		return m.RetainUntil
+	}
+	return time.Time{}
+}
+
+func (m *GetObjectLockResponse) GetLegalHold() bool {
+	if m != nil {
+		return m.LegalHold
+	}
+	return false
+}
+
+type SetObjectRetentionRequest struct {
+	Header             *pb.RequestHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	Bucket             []byte            `protobuf:"bytes,2,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	EncryptedObjectKey []byte            `protobuf:"bytes,3,opt,name=encrypted_object_key,json=encryptedObjectKey,proto3" json:"encrypted_object_key,omitempty"`
+	Version            int32             `protobuf:"varint,4,opt,name=version,proto3" json:"version,omitempty"`
+	// retain_until must be in the future and it can only be extended afterwards.
+	RetainUntil          time.Time `protobuf:"bytes,5,opt,name=retain_until,json=retainUntil,proto3,stdtime" json:"retain_until"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *SetObjectRetentionRequest) Reset()         { *m = SetObjectRetentionRequest{} }
+func (m *SetObjectRetentionRequest) String() string { return proto.CompactTextString(m) }
+func (*SetObjectRetentionRequest) ProtoMessage()    {}
+func (*SetObjectRetentionRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0ade661ecd304013, []int{3}
+}
+func (m *SetObjectRetentionRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetObjectRetentionRequest.Unmarshal(m, b)
+}
+func (m *SetObjectRetentionRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetObjectRetentionRequest.Marshal(b, m, deterministic)
+}
+func (m *SetObjectRetentionRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetObjectRetentionRequest.Merge(m, src)
+}
+func (m *SetObjectRetentionRequest) XXX_Size() int {
+	return xxx_messageInfo_SetObjectRetentionRequest.Size(m)
+}
+func (m *SetObjectRetentionRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetObjectRetentionRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetObjectRetentionRequest proto.InternalMessageInfo
+
+func (m *SetObjectRetentionRequest) GetHeader() *pb.RequestHeader {
+	if m != nil {
+		return m.Header
+	}
+	return nil
+}
+
+func (m *SetObjectRetentionRequest) GetBucket() []byte {
+	if m != nil {
+		return m.Bucket
+	}
+	return nil
+}
+
+func (m *SetObjectRetentionRequest) GetEncryptedObjectKey() []byte {
+	if m != nil {
+		return m.EncryptedObjectKey
+	}
+	return nil
+}
+
+func (m *SetObjectRetentionRequest) GetVersion() int32 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+func (m *SetObjectRetentionRequest) GetRetainUntil() time.Time {
+	if m != nil {
+		return m.RetainUntil
+	}
+	return time.Time{}
+}
+
+type SetObjectRetentionResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SetObjectRetentionResponse) Reset()         { *m = SetObjectRetentionResponse{} }
+func (m *SetObjectRetentionResponse) String() string { return proto.CompactTextString(m) }
+func (*SetObjectRetentionResponse) ProtoMessage()    {}
+func (*SetObjectRetentionResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0ade661ecd304013, []int{4}
+}
+func (m *SetObjectRetentionResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetObjectRetentionResponse.Unmarshal(m, b)
+}
+func (m *SetObjectRetentionResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetObjectRetentionResponse.Marshal(b, m, deterministic)
+}
+func (m *SetObjectRetentionResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetObjectRetentionResponse.Merge(m, src)
+}
+func (m *SetObjectRetentionResponse) XXX_Size() int {
+	return xxx_messageInfo_SetObjectRetentionResponse.Size(m)
+}
+func (m *SetObjectRetentionResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetObjectRetentionResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetObjectRetentionResponse proto.InternalMessageInfo
+
+type SetObjectLegalHoldRequest struct {
+	Header               *pb.RequestHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	Bucket               []byte            `protobuf:"bytes,2,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	EncryptedObjectKey   []byte            `protobuf:"bytes,3,opt,name=encrypted_object_key,json=encryptedObjectKey,proto3" json:"encrypted_object_key,omitempty"`
+	Version              int32             `protobuf:"varint,4,opt,name=version,proto3" json:"version,omitempty"`
+	Enabled              bool              `protobuf:"varint,5,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *SetObjectLegalHoldRequest) Reset()         { *m = SetObjectLegalHoldRequest{} }
+func (m *SetObjectLegalHoldRequest) String() string { return proto.CompactTextString(m) }
+func (*SetObjectLegalHoldRequest) ProtoMessage()    {}
+func (*SetObjectLegalHoldRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0ade661ecd304013, []int{5}
+}
+func (m *SetObjectLegalHoldRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetObjectLegalHoldRequest.Unmarshal(m, b)
+}
+func (m *SetObjectLegalHoldRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetObjectLegalHoldRequest.Marshal(b, m, deterministic)
+}
+func (m *SetObjectLegalHoldRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetObjectLegalHoldRequest.Merge(m, src)
+}
+func (m *SetObjectLegalHoldRequest) XXX_Size() int {
+	return xxx_messageInfo_SetObjectLegalHoldRequest.Size(m)
+}
+func (m *SetObjectLegalHoldRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetObjectLegalHoldRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetObjectLegalHoldRequest proto.InternalMessageInfo
+
+func (m *SetObjectLegalHoldRequest) GetHeader() *pb.RequestHeader {
+	if m != nil {
+		return m.Header
+	}
+	return nil
+}
+
+func (m *SetObjectLegalHoldRequest) GetBucket() []byte {
+	if m != nil {
+		return m.Bucket
+	}
+	return nil
+}
+
+func (m *SetObjectLegalHoldRequest) GetEncryptedObjectKey() []byte {
+	if m != nil {
+		return m.EncryptedObjectKey
+	}
+	return nil
+}
+
+func (m *SetObjectLegalHoldRequest) GetVersion() int32 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+func (m *SetObjectLegalHoldRequest) GetEnabled() bool {
+	if m != nil {
+		return m.Enabled
+	}
+	return false
+}
+
+type SetObjectLegalHoldResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SetObjectLegalHoldResponse) Reset()         { *m = SetObjectLegalHoldResponse{} }
+func (m *SetObjectLegalHoldResponse) String() string { return proto.CompactTextString(m) }
+func (*SetObjectLegalHoldResponse) ProtoMessage()    {}
+func (*SetObjectLegalHoldResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0ade661ecd304013, []int{6}
+}
+func (m *SetObjectLegalHoldResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetObjectLegalHoldResponse.Unmarshal(m, b)
+}
+func (m *SetObjectLegalHoldResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetObjectLegalHoldResponse.Marshal(b, m, deterministic)
+}
+func (m *SetObjectLegalHoldResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetObjectLegalHoldResponse.Merge(m, src)
+}
+func (m *SetObjectLegalHoldResponse) XXX_Size() int {
+	return xxx_messageInfo_SetObjectLegalHoldResponse.Size(m)
+}
+func (m *SetObjectLegalHoldResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetObjectLegalHoldResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetObjectLegalHoldResponse proto.InternalMessageInfo
+
+type ObjectTag struct {
+	Key                  string   `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Value                string   `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ObjectTag) Reset()         { *m = ObjectTag{} }
+func (m *ObjectTag) String() string { return proto.CompactTextString(m) }
+func (*ObjectTag) ProtoMessage()    {}
+func (*ObjectTag) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0ade661ecd304013, []int{7}
+}
+func (m *ObjectTag) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ObjectTag.Unmarshal(m, b)
+}
+func (m *ObjectTag) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ObjectTag.Marshal(b, m, deterministic)
+}
+func (m *ObjectTag) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ObjectTag.Merge(m, src)
+}
+func (m *ObjectTag) XXX_Size() int {
+	return xxx_messageInfo_ObjectTag.Size(m)
+}
+func (m *ObjectTag) XXX_DiscardUnknown() {
+	xxx_messageInfo_ObjectTag.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ObjectTag proto.InternalMessageInfo
+
+func (m *ObjectTag) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *ObjectTag) GetValue() string {
+	if m != nil {
+		return m.Value
+	}
+	return ""
+}
+
+type GetObjectTagsRequest struct {
+	Header               *pb.RequestHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	Bucket               []byte            `protobuf:"bytes,2,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	EncryptedObjectKey   []byte            `protobuf:"bytes,3,opt,name=encrypted_object_key,json=encryptedObjectKey,proto3" json:"encrypted_object_key,omitempty"`
+	Version              int32             `protobuf:"varint,4,opt,name=version,proto3" json:"version,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *GetObjectTagsRequest) Reset()         { *m = GetObjectTagsRequest{} }
+func (m *GetObjectTagsRequest) String() string { return proto.CompactTextString(m) }
+func (*GetObjectTagsRequest) ProtoMessage()    {}
+func (*GetObjectTagsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0ade661ecd304013, []int{8}
+}
+func (m *GetObjectTagsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetObjectTagsRequest.Unmarshal(m, b)
+}
+func (m *GetObjectTagsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetObjectTagsRequest.Marshal(b, m, deterministic)
+}
+func (m *GetObjectTagsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetObjectTagsRequest.Merge(m, src)
+}
+func (m *GetObjectTagsRequest) XXX_Size() int {
+	return xxx_messageInfo_GetObjectTagsRequest.Size(m)
+}
+func (m *GetObjectTagsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetObjectTagsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetObjectTagsRequest proto.InternalMessageInfo
+
+func (m *GetObjectTagsRequest) GetHeader() *pb.RequestHeader {
+	if m != nil {
+		return m.Header
+	}
+	return nil
+}
+
+func (m *GetObjectTagsRequest) GetBucket() []byte {
+	if m != nil {
+		return m.Bucket
+	}
+	return nil
+}
+
+func (m *GetObjectTagsRequest) GetEncryptedObjectKey() []byte {
+	if m != nil {
+		return m.EncryptedObjectKey
+	}
+	return nil
+}
+
+func (m *GetObjectTagsRequest) GetVersion() int32 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+type GetObjectTagsResponse struct {
+	Tags                 []*ObjectTag `protobuf:"bytes,1,rep,name=tags,proto3" json:"tags,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *GetObjectTagsResponse) Reset()         { *m = GetObjectTagsResponse{} }
+func (m *GetObjectTagsResponse) String() string { return proto.CompactTextString(m) }
+func (*GetObjectTagsResponse) ProtoMessage()    {}
+func (*GetObjectTagsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0ade661ecd304013, []int{9}
+}
+func (m *GetObjectTagsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetObjectTagsResponse.Unmarshal(m, b)
+}
+func (m *GetObjectTagsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetObjectTagsResponse.Marshal(b, m, deterministic)
+}
+func (m *GetObjectTagsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetObjectTagsResponse.Merge(m, src)
+}
+func (m *GetObjectTagsResponse) XXX_Size() int {
+	return xxx_messageInfo_GetObjectTagsResponse.Size(m)
+}
+func (m *GetObjectTagsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetObjectTagsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetObjectTagsResponse proto.InternalMessageInfo
+
+func (m *GetObjectTagsResponse) GetTags() []*ObjectTag {
+	if m != nil {
+		return m.Tags
+	}
+	return nil
+}
+
+type SetObjectTagsRequest struct {
+	Header             *pb.RequestHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	Bucket             []byte            `protobuf:"bytes,2,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	EncryptedObjectKey []byte            `protobuf:"bytes,3,opt,name=encrypted_object_key,json=encryptedObjectKey,proto3" json:"encrypted_object_key,omitempty"`
+	Version            int32             `protobuf:"varint,4,opt,name=version,proto3" json:"version,omitempty"`
+	// tags replace all the previous tags of the object version.
+	Tags                 []*ObjectTag `protobuf:"bytes,5,rep,name=tags,proto3" json:"tags,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *SetObjectTagsRequest) Reset()         { *m = SetObjectTagsRequest{} }
+func (m *SetObjectTagsRequest) String() string { return proto.CompactTextString(m) }
+func (*SetObjectTagsRequest) ProtoMessage()    {}
+func (*SetObjectTagsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0ade661ecd304013, []int{10}
+}
+func (m *SetObjectTagsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetObjectTagsRequest.Unmarshal(m, b)
+}
+func (m *SetObjectTagsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetObjectTagsRequest.Marshal(b, m, deterministic)
+}
+func (m *SetObjectTagsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetObjectTagsRequest.Merge(m, src)
+}
+func (m *SetObjectTagsRequest) XXX_Size() int {
+	return xxx_messageInfo_SetObjectTagsRequest.Size(m)
+}
+func (m *SetObjectTagsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetObjectTagsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetObjectTagsRequest proto.InternalMessageInfo
+
+func (m *SetObjectTagsRequest) GetHeader() *pb.RequestHeader {
+	if m != nil {
+		return m.Header
+	}
+	return nil
+}
+
+func (m *SetObjectTagsRequest) GetBucket() []byte {
+	if m != nil {
+		return m.Bucket
+	}
+	return nil
+}
+
+func (m *SetObjectTagsRequest) GetEncryptedObjectKey() []byte {
+	if m != nil {
+		return m.EncryptedObjectKey
+	}
+	return nil
+}
+
+func (m *SetObjectTagsRequest) GetVersion() int32 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+func (m *SetObjectTagsRequest) GetTags() []*ObjectTag {
+	if m != nil {
+		return m.Tags
+	}
+	return nil
+}
+
+type SetObjectTagsResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SetObjectTagsResponse) Reset()         { *m = SetObjectTagsResponse{} }
+func (m *SetObjectTagsResponse) String() string { return proto.CompactTextString(m) }
+func (*SetObjectTagsResponse) ProtoMessage()    {}
+func (*SetObjectTagsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0ade661ecd304013, []int{11}
+}
+func (m *SetObjectTagsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetObjectTagsResponse.Unmarshal(m, b)
+}
+func (m *SetObjectTagsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetObjectTagsResponse.Marshal(b, m, deterministic)
+}
+func (m *SetObjectTagsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetObjectTagsResponse.Merge(m, src)
+}
+func (m *SetObjectTagsResponse) XXX_Size() int {
+	return xxx_messageInfo_SetObjectTagsResponse.Size(m)
+}
+func (m *SetObjectTagsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetObjectTagsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetObjectTagsResponse proto.InternalMessageInfo
+
+func init() {
+	proto.RegisterType((*ListObjectsRequest)(nil), "metainfoext.ListObjectsRequest")
+	proto.RegisterType((*GetObjectLockRequest)(nil), "metainfoext.GetObjectLockRequest")
+	proto.RegisterType((*GetObjectLockResponse)(nil), "metainfoext.GetObjectLockResponse")
+	proto.RegisterType((*SetObjectRetentionRequest)(nil), "metainfoext.SetObjectRetentionRequest")
+	proto.RegisterType((*SetObjectRetentionResponse)(nil), "metainfoext.SetObjectRetentionResponse")
+	proto.RegisterType((*SetObjectLegalHoldRequest)(nil), "metainfoext.SetObjectLegalHoldRequest")
+	proto.RegisterType((*SetObjectLegalHoldResponse)(nil), "metainfoext.SetObjectLegalHoldResponse")
+	proto.RegisterType((*ObjectTag)(nil), "metainfoext.ObjectTag")
+	proto.RegisterType((*GetObjectTagsRequest)(nil), "metainfoext.GetObjectTagsRequest")
+	proto.RegisterType((*GetObjectTagsResponse)(nil), "metainfoext.GetObjectTagsResponse")
+	proto.RegisterType((*SetObjectTagsRequest)(nil), "metainfoext.SetObjectTagsRequest")
+	proto.RegisterType((*SetObjectTagsResponse)(nil), "metainfoext.SetObjectTagsResponse")
+}
+
+func init() { proto.RegisterFile("metainfoext.proto", fileDescriptor_0ade661ecd304013) }
+
+var fileDescriptor_0ade661ecd304013 = []byte{
+	// 654 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xd4, 0x55, 0xc1, 0x4e, 0xdb, 0x4c,
+	0x10, 0xfe, 0xfd, 0x93, 0x40, 0x32, 0x09, 0xa8, 0x5d, 0x05, 0x48, 0x5d, 0xaa, 0x04, 0xab, 0xa5,
+	0x51, 0x0f, 0x4e, 0x05, 0x6f, 0x40, 0x0f, 0x20, 0x95, 0x0a, 0x69, 0x43, 0x41, 0xea, 0x25, 0xda,
+	0x24, 0x83, 0x31, 0x2c, 0x5e, 0xd7, 0xbb, 0x41, 0xe5, 0xd4, 0x57, 0xe8, 0x7b, 0xf4, 0x09, 0xaa,
+	0xbe, 0x40, 0xd5, 0x17, 0xe8, 0xad, 0x7d, 0x8e, 0xde, 0x2a, 0xaf, 0xd7, 0xc1, 0x26, 0x0e, 0xa0,
+	0x9e, 0xe8, 0xcd, 0xb3, 0xfe, 0x66, 0xe7, 0xfb, 0xbe, 0xf1, 0x8c, 0xe1, 0xe1, 0x39, 0x2a, 0xe6,
+	0x07, 0xc7, 0x02, 0x3f, 0x28, 0x37, 0x8c, 0x84, 0x12, 0xa4, 0x96, 0x39, 0xb2, 0xc1, 0x13, 0x9e,
+	0x48, 0x5e, 0xd8, 0x2d, 0x4f, 0x08, 0x8f, 0x63, 0x57, 0x47, 0x83, 0xf1, 0x71, 0x57, 0xf9, 0xe7,
+	0x28, 0x15, 0x3b, 0x0f, 0x0d, 0x60, 0x29, 0xcd, 0x4c, 0x62, 0xe7, 0xab, 0x05, 0x64, 0xcf, 0x97,
+	0x6a, 0x7f, 0x70, 0x8a, 0x43, 0x25, 0x29, 0xbe, 0x1f, 0xa3, 0x54, 0xa4, 0x0b, 0x25, 0xee, 0x4b,
+	0xd5, 0xb4, 0xda, 0x56, 0xa7, 0xb6, 0xf9, 0xd8, 0x9d, 0x64, 0x25, 0xb8, 0x38, 0xc3, 0x40, 0xa9,
+	0x06, 0x92, 0x75, 0xa8, 0x33, 0xce, 0xfb, 0x17, 0x18, 0x49, 0x5f, 0x04, 0xb2, 0xf9, 0x7f, 0xdb,
+	0xea, 0x54, 0x68, 0x8d, 0x71, 0x7e, 0x68, 0x8e, 0xc8, 0x33, 0x58, 0x1a, 0x8e, 0x23, 0x29, 0xa2,
+	0x14, 0xd5, 0x9c, 0x6b, 0x5b, 0x9d, 0x32, 0x5d, 0x4c, 0x4e, 0x0d, 0x8e, 0xbc, 0x80, 0x92, 0x62,
+	0x9e, 0x6c, 0x96, 0xda, 0x73, 0x9d, 0xda, 0xe6, 0x8a, 0x9b, 0x55, 0x9f, 0x54, 0x3f, 0x60, 0x1e,
+	0xd5, 0x18, 0xe7, 0xb3, 0x05, 0x8d, 0x1d, 0x34, 0xe4, 0xf7, 0xc4, 0xf0, 0xec, 0x8a, 0xff, 0xfc,
+	0x09, 0xb2, 0x11, 0x46, 0x46, 0xc1, 0xea, 0x95, 0x02, 0x03, 0xd9, 0xd5, 0xaf, 0xa9, 0x81, 0x91,
+	0x15, 0x98, 0x1f, 0x8c, 0x87, 0x67, 0xa8, 0x34, 0xf3, 0x3a, 0x35, 0x11, 0x79, 0x09, 0x0d, 0x0c,
+	0x86, 0xd1, 0x65, 0xa8, 0x70, 0xd4, 0x17, 0xba, 0x4e, 0xff, 0x0c, 0x2f, 0x35, 0xf5, 0x3a, 0x25,
+	0x93, 0x77, 0x09, 0x85, 0xd7, 0x78, 0x49, 0x9a, 0xb0, 0x90, 0xea, 0x2b, 0x69, 0x7d, 0x69, 0xe8,
+	0x7c, 0x84, 0xe5, 0x6b, 0x64, 0x65, 0x28, 0x02, 0x89, 0x64, 0x07, 0xea, 0x91, 0xa6, 0xd7, 0x1f,
+	0x07, 0xca, 0xe7, 0x86, 0xb3, 0xed, 0x26, 0xcd, 0x74, 0xd3, 0x66, 0xba, 0x07, 0x69, 0x33, 0xb7,
+	0x2b, 0xdf, 0x7e, 0xb6, 0xfe, 0xfb, 0xf4, 0xab, 0x65, 0xd1, 0x5a, 0x92, 0xf9, 0x36, 0x4e, 0x24,
+	0x4f, 0x00, 0x38, 0x7a, 0x8c, 0xf7, 0x4f, 0x04, 0x1f, 0x99, 0x1e, 0x54, 0xf5, 0xc9, 0xae, 0xe0,
+	0x23, 0xe7, 0xb7, 0x05, 0x8f, 0x7a, 0x29, 0x03, 0x8a, 0x0a, 0x03, 0xe5, 0x8b, 0xe0, 0x3e, 0x7b,
+	0x36, 0x65, 0x4d, 0xf9, 0x2f, 0xad, 0x71, 0xd6, 0xc0, 0x2e, 0x92, 0x9e, 0x74, 0xc0, 0xf9, 0x9e,
+	0x75, 0x66, 0x2f, 0x35, 0xec, 0x5e, 0x3b, 0xd3, 0x84, 0x05, 0x0c, 0xd8, 0x80, 0xe3, 0x48, 0x9b,
+	0x52, 0xa1, 0x69, 0x98, 0x93, 0x9a, 0xd1, 0x62, 0xa4, 0x6e, 0x41, 0x75, 0x32, 0x46, 0xe4, 0x01,
+	0xcc, 0xc5, 0xf5, 0x63, 0x59, 0x55, 0x1a, 0x3f, 0x92, 0x06, 0x94, 0x2f, 0x18, 0x1f, 0xa3, 0x66,
+	0x5e, 0xa5, 0x49, 0x90, 0x1f, 0xb4, 0x03, 0xe6, 0xc9, 0x7b, 0x3d, 0x68, 0xaf, 0x32, 0x83, 0x96,
+	0x90, 0x35, 0x83, 0x96, 0xee, 0x16, 0xeb, 0x0e, 0xbb, 0xe5, 0x87, 0x05, 0x8d, 0xde, 0xbf, 0x22,
+	0x79, 0xa2, 0xac, 0x7c, 0x07, 0x65, 0xab, 0xb0, 0xdc, 0x2b, 0xb2, 0x67, 0xf3, 0x4b, 0x09, 0x2a,
+	0x6f, 0x4c, 0x22, 0x39, 0x82, 0x95, 0xcc, 0x8f, 0xe1, 0xc8, 0x57, 0x27, 0xfb, 0xa1, 0xd2, 0x8b,
+	0xbc, 0x95, 0xbb, 0x7d, 0xfa, 0xef, 0x61, 0xaf, 0x15, 0xff, 0x2f, 0x4c, 0x13, 0x0e, 0x61, 0x31,
+	0xb7, 0x06, 0xc9, 0x7a, 0xee, 0xbe, 0xa2, 0x7d, 0x6e, 0x3b, 0x37, 0x41, 0xcc, 0xbd, 0x08, 0x64,
+	0x7a, 0xc2, 0xc9, 0x46, 0x2e, 0x73, 0xe6, 0xf6, 0xb3, 0x9f, 0xdf, 0x8a, 0x2b, 0x28, 0x33, 0x99,
+	0xae, 0x59, 0x65, 0xae, 0xaf, 0x92, 0x59, 0x65, 0xa6, 0xc6, 0x34, 0xe7, 0x52, 0xdc, 0xa4, 0x59,
+	0x2e, 0x65, 0xbe, 0xcc, 0x59, 0x2e, 0xe5, 0x46, 0xe0, 0x10, 0x16, 0x7b, 0x37, 0xdc, 0xdb, 0xbb,
+	0xfd, 0xde, 0xc2, 0x6f, 0x67, 0x7b, 0xe3, 0xdd, 0x53, 0xa9, 0x44, 0x74, 0xea, 0xfa, 0xa2, 0xab,
+	0x1f, 0xba, 0x61, 0xe4, 0x5f, 0x30, 0x85, 0xdd, 0x4c, 0x6e, 0x38, 0x18, 0xcc, 0xeb, 0x95, 0xbd,
+	0xf5, 0x27, 0x00, 0x00, 0xff, 0xff, 0x7b, 0x5e, 0xd5, 0x9e, 0xd6, 0x08, 0x00, 0x00,
+}
@@ -0,0 +1,316 @@
+// Code generated by protoc-gen-go-drpc. DO NOT EDIT.
+// protoc-gen-go-drpc version: v0.0.32
+// source: metainfoext.proto
+
+package metainfoextpb
+
+import (
+	bytes "bytes"
+	context "context"
+	errors "errors"
+
+	jsonpb "github.com/gogo/protobuf/jsonpb"
+	proto "github.com/gogo/protobuf/proto"
+
+	pb "storj.io/common/pb"
+	drpc "storj.io/drpc"
+	drpcerr "storj.io/drpc/drpcerr"
+)
+
+type drpcEncoding_File_metainfoext_proto struct{}
+
+func (drpcEncoding_File_metainfoext_proto) Marshal(msg drpc.Message) ([]byte, error) {
+	return proto.Marshal(msg.(proto.Message))
+}
+
+func (drpcEncoding_File_metainfoext_proto) Unmarshal(buf []byte, msg drpc.Message) error {
+	return proto.Unmarshal(buf, msg.(proto.Message))
+}
+
+func (drpcEncoding_File_metainfoext_proto) JSONMarshal(msg drpc.Message) ([]byte, error) {
+	var buf bytes.Buffer
+	err := new(jsonpb.Marshaler).Marshal(&buf, msg.(proto.Message))
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (drpcEncoding_File_metainfoext_proto) JSONUnmarshal(buf []byte, msg drpc.Message) error {
+	return jsonpb.Unmarshal(bytes.NewReader(buf), msg.(proto.Message))
+}
+
+type DRPCMetainfoClient interface {
+	DRPCConn() drpc.Conn
+
+	ListObjectsWithOptions(ctx context.Context, in *ListObjectsRequest) (*pb.ObjectListResponse, error)
+	GetObjectLock(ctx context.Context, in *GetObjectLockRequest) (*GetObjectLockResponse, error)
+	SetObjectRetention(ctx context.Context, in *SetObjectRetentionRequest) (*SetObjectRetentionResponse, error)
+	SetObjectLegalHold(ctx context.Context, in *SetObjectLegalHoldRequest) (*SetObjectLegalHoldResponse, error)
+	GetObjectTags(ctx context.Context, in *GetObjectTagsRequest) (*GetObjectTagsResponse, error)
+	SetObjectTags(ctx context.Context, in *SetObjectTagsRequest) (*SetObjectTagsResponse, error)
+}
+
+type drpcMetainfoClient struct {
+	cc drpc.Conn
+}
+
+func NewDRPCMetainfoClient(cc drpc.Conn) DRPCMetainfoClient {
+	return &drpcMetainfoClient{cc}
+}
+
+func (c *drpcMetainfoClient) DRPCConn() drpc.Conn { return c.cc }
+
+func (c *drpcMetainfoClient) ListObjectsWithOptions(ctx context.Context, in *ListObjectsRequest) (*pb.ObjectListResponse, error) {
+	out := new(pb.ObjectListResponse)
+	err := c.cc.Invoke(ctx, "/metainfoext.Metainfo/ListObjectsWithOptions", drpcEncoding_File_metainfoext_proto{}, in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *drpcMetainfoClient) GetObjectLock(ctx context.Context, in *GetObjectLockRequest) (*GetObjectLockResponse, error) {
+	out := new(GetObjectLockResponse)
+	err := c.cc.Invoke(ctx, "/metainfoext.Metainfo/GetObjectLock", drpcEncoding_File_metainfoext_proto{}, in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *drpcMetainfoClient) SetObjectRetention(ctx context.Context, in *SetObjectRetentionRequest) (*SetObjectRetentionResponse, error) {
+	out := new(SetObjectRetentionResponse)
+	err := c.cc.Invoke(ctx, "/metainfoext.Metainfo/SetObjectRetention", drpcEncoding_File_metainfoext_proto{}, in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *drpcMetainfoClient) SetObjectLegalHold(ctx context.Context, in *SetObjectLegalHoldRequest) (*SetObjectLegalHoldResponse, error) {
+	out := new(SetObjectLegalHoldResponse)
+	err := c.cc.Invoke(ctx, "/metainfoext.Metainfo/SetObjectLegalHold", drpcEncoding_File_metainfoext_proto{}, in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *drpcMetainfoClient) GetObjectTags(ctx context.Context, in *GetObjectTagsRequest) (*GetObjectTagsResponse, error) {
+	out := new(GetObjectTagsResponse)
+	err := c.cc.Invoke(ctx, "/metainfoext.Metainfo/GetObjectTags", drpcEncoding_File_metainfoext_proto{}, in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *drpcMetainfoClient) SetObjectTags(ctx context.Context, in *SetObjectTagsRequest) (*SetObjectTagsResponse, error) {
+	out := new(SetObjectTagsResponse)
+	err := c.cc.Invoke(ctx, "/metainfoext.Metainfo/SetObjectTags", drpcEncoding_File_metainfoext_proto{}, in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+type DRPCMetainfoServer interface {
+	ListObjectsWithOptions(context.Context, *ListObjectsRequest) (*pb.ObjectListResponse, error)
+	GetObjectLock(context.Context, *GetObjectLockRequest) (*GetObjectLockResponse, error)
+	SetObjectRetention(context.Context, *SetObjectRetentionRequest) (*SetObjectRetentionResponse, error)
+	SetObjectLegalHold(context.Context, *SetObjectLegalHoldRequest) (*SetObjectLegalHoldResponse, error)
+	GetObjectTags(context.Context, *GetObjectTagsRequest) (*GetObjectTagsResponse, error)
+	SetObjectTags(context.Context, *SetObjectTagsRequest) (*SetObjectTagsResponse, error)
+}
+
+type DRPCMetainfoUnimplementedServer struct{}
+
+func (s *DRPCMetainfoUnimplementedServer) ListObjectsWithOptions(context.Context, *ListObjectsRequest) (*pb.ObjectListResponse, error) {
+	return nil, drpcerr.WithCode(errors.New("Unimplemented"), drpcerr.Unimplemented)
+}
+
+func (s *DRPCMetainfoUnimplementedServer) GetObjectLock(context.Context, *GetObjectLockRequest) (*GetObjectLockResponse, error) {
+	return nil, drpcerr.WithCode(errors.New("Unimplemented"), drpcerr.Unimplemented)
+}
+
+func (s *DRPCMetainfoUnimplementedServer) SetObjectRetention(context.Context, *SetObjectRetentionRequest) (*SetObjectRetentionResponse, error) {
+	return nil, drpcerr.WithCode(errors.New("Unimplemented"), drpcerr.Unimplemented)
+}
+
+func (s *DRPCMetainfoUnimplementedServer) SetObjectLegalHold(context.Context, *SetObjectLegalHoldRequest) (*SetObjectLegalHoldResponse, error) {
+	return nil, drpcerr.WithCode(errors.New("Unimplemented"), drpcerr.Unimplemented)
+}
+
+func (s *DRPCMetainfoUnimplementedServer) GetObjectTags(context.Context, *GetObjectTagsRequest) (*GetObjectTagsResponse, error) {
+	return nil, drpcerr.WithCode(errors.New("Unimplemented"), drpcerr.Unimplemented)
+}
+
+func (s *DRPCMetainfoUnimplementedServer) SetObjectTags(context.Context, *SetObjectTagsRequest) (*SetObjectTagsResponse, error) {
+	return nil, drpcerr.WithCode(errors.New("Unimplemented"), drpcerr.Unimplemented)
+}
+
+type DRPCMetainfoDescription struct{}
+
+func (DRPCMetainfoDescription) NumMethods() int { return 6 }
+
+func (DRPCMetainfoDescription) Method(n int) (string, drpc.Encoding, drpc.Receiver, interface{}, bool) {
+	switch n {
+	case 0:
+		return "/metainfoext.Metainfo/ListObjectsWithOptions", drpcEncoding_File_metainfoext_proto{},
+			func(srv interface{}, ctx context.Context, in1, in2 interface{}) (drpc.Message, error) {
+				return srv.(DRPCMetainfoServer).
+					ListObjectsWithOptions(
+						ctx,
+						in1.(*ListObjectsRequest),
+					)
+			}, DRPCMetainfoServer.ListObjectsWithOptions, true
+	case 1:
+		return "/metainfoext.Metainfo/GetObjectLock", drpcEncoding_File_metainfoext_proto{},
+			func(srv interface{}, ctx context.Context, in1, in2 interface{}) (drpc.Message, error) {
+				return srv.(DRPCMetainfoServer).
+					GetObjectLock(
+						ctx,
+						in1.(*GetObjectLockRequest),
+					)
+			}, DRPCMetainfoServer.GetObjectLock, true
+	case 2:
+		return "/metainfoext.Metainfo/SetObjectRetention", drpcEncoding_File_metainfoext_proto{},
+			func(srv interface{}, ctx context.Context, in1, in2 interface{}) (drpc.Message, error) {
+				return srv.(DRPCMetainfoServer).
+					SetObjectRetention(
+						ctx,
+						in1.(*SetObjectRetentionRequest),
+					)
+			}, DRPCMetainfoServer.SetObjectRetention, true
+	case 3:
+		return "/metainfoext.Metainfo/SetObjectLegalHold", drpcEncoding_File_metainfoext_proto{},
+			func(srv interface{}, ctx context.Context, in1, in2 interface{}) (drpc.Message, error) {
+				return srv.(DRPCMetainfoServer).
+					SetObjectLegalHold(
+						ctx,
+						in1.(*SetObjectLegalHoldRequest),
+					)
+			}, DRPCMetainfoServer.SetObjectLegalHold, true
+	case 4:
+		return "/metainfoext.Metainfo/GetObjectTags", drpcEncoding_File_metainfoext_proto{},
+			func(srv interface{}, ctx context.Context, in1, in2 interface{}) (drpc.Message, error) {
+				return srv.(DRPCMetainfoServer).
+					GetObjectTags(
+						ctx,
+						in1.(*GetObjectTagsRequest),
+					)
+			}, DRPCMetainfoServer.GetObjectTags, true
+	case 5:
+		return "/metainfoext.Metainfo/SetObjectTags", drpcEncoding_File_metainfoext_proto{},
+			func(srv interface{}, ctx context.Context, in1, in2 interface{}) (drpc.Message, error) {
+				return srv.(DRPCMetainfoServer).
+					SetObjectTags(
+						ctx,
+						in1.(*SetObjectTagsRequest),
+					)
+			}, DRPCMetainfoServer.SetObjectTags, true
+	default:
+		return "", nil, nil, nil, false
+	}
+}
+
+func DRPCRegisterMetainfo(mux drpc.Mux, impl DRPCMetainfoServer) error {
+	return mux.Register(impl, DRPCMetainfoDescription{})
+}
+
+type DRPCMetainfo_ListObjectsWithOptionsStream interface {
+	drpc.Stream
+	SendAndClose(*pb.ObjectListResponse) error
+}
+
+type drpcMetainfo_ListObjectsWithOptionsStream struct {
+	drpc.Stream
+}
+
+func (x *drpcMetainfo_ListObjectsWithOptionsStream) SendAndClose(m *pb.ObjectListResponse) error {
+	if err := x.MsgSend(m, drpcEncoding_File_metainfoext_proto{}); err != nil {
+		return err
+	}
+	return x.CloseSend()
+}
+
+type DRPCMetainfo_GetObjectLockStream interface {
+	drpc.Stream
+	SendAndClose(*GetObjectLockResponse) error
+}
+
+type drpcMetainfo_GetObjectLockStream struct {
+	drpc.Stream
+}
+
+func (x *drpcMetainfo_GetObjectLockStream) SendAndClose(m *GetObjectLockResponse) error {
+	if err := x.MsgSend(m, drpcEncoding_File_metainfoext_proto{}); err != nil {
+		return err
+	}
+	return x.CloseSend()
+}
+
+type DRPCMetainfo_SetObjectRetentionStream interface {
+	drpc.Stream
+	SendAndClose(*SetObjectRetentionResponse) error
+}
+
+type drpcMetainfo_SetObjectRetentionStream struct {
+	drpc.Stream
+}
+
+func (x *drpcMetainfo_SetObjectRetentionStream) SendAndClose(m *SetObjectRetentionResponse) error {
+	if err := x.MsgSend(m, drpcEncoding_File_metainfoext_proto{}); err != nil {
+		return err
+	}
+	return x.CloseSend()
+}
+
+type DRPCMetainfo_SetObjectLegalHoldStream interface {
+	drpc.Stream
+	SendAndClose(*SetObjectLegalHoldResponse) error
+}
+
+type drpcMetainfo_SetObjectLegalHoldStream struct {
+	drpc.Stream
+}
+
+func (x *drpcMetainfo_SetObjectLegalHoldStream) SendAndClose(m *SetObjectLegalHoldResponse) error {
+	if err := x.MsgSend(m, drpcEncoding_File_metainfoext_proto{}); err != nil {
+		return err
+	}
+	return x.CloseSend()
+}
+
+type DRPCMetainfo_GetObjectTagsStream interface {
+	drpc.Stream
+	SendAndClose(*GetObjectTagsResponse) error
+}
+
+type drpcMetainfo_GetObjectTagsStream struct {
+	drpc.Stream
+}
+
+func (x *drpcMetainfo_GetObjectTagsStream) SendAndClose(m *GetObjectTagsResponse) error {
+	if err := x.MsgSend(m, drpcEncoding_File_metainfoext_proto{}); err != nil {
+		return err
+	}
+	return x.CloseSend()
+}
+
+type DRPCMetainfo_SetObjectTagsStream interface {
+	drpc.Stream
+	SendAndClose(*SetObjectTagsResponse) error
+}
+
+type drpcMetainfo_SetObjectTagsStream struct {
+	drpc.Stream
+}
+
+func (x *drpcMetainfo_SetObjectTagsStream) SendAndClose(m *SetObjectTagsResponse) error {
+	if err := x.MsgSend(m, drpcEncoding_File_metainfoext_proto{}); err != nil {
+		return err
+	}
+	return x.CloseSend()
+}
@@ -0,0 +1,117 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: disqualification.proto
+
+package nodeextpb
+
+import (
+	fmt "fmt"
+	math "math"
+
+	proto "github.com/gogo/protobuf/proto"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
+
+type GetDisqualificationExplanationRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetDisqualificationExplanationRequest) Reset()         { *m = GetDisqualificationExplanationRequest{} }
+func (m *GetDisqualificationExplanationRequest) String() string { return proto.CompactTextString(m) }
+func (*GetDisqualificationExplanationRequest) ProtoMessage()    {}
+func (*GetDisqualificationExplanationRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f98e960cff1b29f6, []int{0}
+}
+func (m *GetDisqualificationExplanationRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetDisqualificationExplanationRequest.Unmarshal(m, b)
+}
+func (m *GetDisqualificationExplanationRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetDisqualificationExplanationRequest.Marshal(b, m, deterministic)
+}
+func (m *GetDisqualificationExplanationRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetDisqualificationExplanationRequest.Merge(m, src)
+}
+func (m *GetDisqualificationExplanationRequest) XXX_Size() int {
+	return xxx_messageInfo_GetDisqualificationExplanationRequest.Size(m)
+}
+func (m *GetDisqualificationExplanationRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetDisqualificationExplanationRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetDisqualificationExplanationRequest proto.InternalMessageInfo
+
+type GetDisqualificationExplanationResponse struct {
+	// explanation is the JSON encoded explanation of the latest disqualification
+	// of the calling node.
+	Explanation          []byte   `protobuf:"bytes,1,opt,name=explanation,proto3" json:"explanation,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetDisqualificationExplanationResponse) Reset() {
+	*m = GetDisqualificationExplanationResponse{}
+}
+func (m *GetDisqualificationExplanationResponse) String() string { return proto.CompactTextString(m) }
+func (*GetDisqualificationExplanationResponse) ProtoMessage()    {}
+func (*GetDisqualificationExplanationResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f98e960cff1b29f6, []int{1}
+}
+func (m *GetDisqualificationExplanationResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetDisqualificationExplanationResponse.Unmarshal(m, b)
+}
+func (m *GetDisqualificationExplanationResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetDisqualificationExplanationResponse.Marshal(b, m, deterministic)
+}
+func (m *GetDisqualificationExplanationResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetDisqualificationExplanationResponse.Merge(m, src)
+}
+func (m *GetDisqualificationExplanationResponse) XXX_Size() int {
+	return xxx_messageInfo_GetDisqualificationExplanationResponse.Size(m)
+}
+func (m *GetDisqualificationExplanationResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetDisqualificationExplanationResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetDisqualificationExplanationResponse proto.InternalMessageInfo
+
+func (m *GetDisqualificationExplanationResponse) GetExplanation() []byte {
+	if m != nil {
+		return m.Explanation
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*GetDisqualificationExplanationRequest)(nil), "nodeext.GetDisqualificationExplanationRequest")
+	proto.RegisterType((*GetDisqualificationExplanationResponse)(nil), "nodeext.GetDisqualificationExplanationResponse")
+}
+
+func init() { proto.RegisterFile("disqualification.proto", fileDescriptor_f98e960cff1b29f6) }
+
+var fileDescriptor_f98e960cff1b29f6 = []byte{
+	// 180 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0x12, 0x4b, 0xc9, 0x2c, 0x2e,
+	0x2c, 0x4d, 0xcc, 0xc9, 0x4c, 0xcb, 0x4c, 0x4e, 0x2c, 0xc9, 0xcc, 0xcf, 0xd3, 0x2b, 0x28, 0xca,
+	0x2f, 0xc9, 0x17, 0x62, 0xcf, 0xcb, 0x4f, 0x49, 0x4d, 0xad, 0x28, 0x51, 0x52, 0xe7, 0x52, 0x75,
+	0x4f, 0x2d, 0x71, 0x41, 0x53, 0xe5, 0x5a, 0x51, 0x90, 0x93, 0x98, 0x07, 0x66, 0x06, 0xa5, 0x16,
+	0x96, 0xa6, 0x16, 0x97, 0x28, 0x79, 0x71, 0xa9, 0x11, 0x52, 0x58, 0x5c, 0x90, 0x9f, 0x57, 0x9c,
+	0x2a, 0xa4, 0xc0, 0xc5, 0x9d, 0x8a, 0x10, 0x96, 0x60, 0x54, 0x60, 0xd4, 0xe0, 0x09, 0x42, 0x16,
+	0x32, 0xea, 0x67, 0xe4, 0xe2, 0xf4, 0xcb, 0x4f, 0x49, 0x0d, 0x2e, 0x49, 0x2c, 0x29, 0x16, 0x6a,
+	0x64, 0xe4, 0x92, 0xc3, 0x6f, 0xb4, 0x90, 0x9e, 0x1e, 0xd4, 0xbd, 0x7a, 0x44, 0x39, 0x56, 0x4a,
+	0x9f, 0x68, 0xf5, 0x10, 0x37, 0x3b, 0x29, 0x45, 0x29, 0x14, 0x97, 0xe4, 0x17, 0x65, 0xe9, 0x65,
+	0xe6, 0xeb, 0x83, 0x19, 0xfa, 0x05, 0x45, 0x99, 0x65, 0x89, 0x25, 0xa9, 0xfa, 0x50, 0x83, 0x0a,
+	0x92, 0x92, 0xd8, 0xc0, 0x41, 0x67, 0x0c, 0x08, 0x00, 0x00, 0xff, 0xff, 0xfe, 0xbd, 0x12, 0x0c,
+	0x54, 0x01, 0x00, 0x00,
+}
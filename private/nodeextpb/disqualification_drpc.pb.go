@@ -0,0 +1,115 @@
+// Code generated by protoc-gen-go-drpc. DO NOT EDIT.
+// protoc-gen-go-drpc version: v0.0.32
+// source: disqualification.proto
+
+package nodeextpb
+
+import (
+	bytes "bytes"
+	context "context"
+	errors "errors"
+
+	jsonpb "github.com/gogo/protobuf/jsonpb"
+	proto "github.com/gogo/protobuf/proto"
+
+	drpc "storj.io/drpc"
+	drpcerr "storj.io/drpc/drpcerr"
+)
+
+type drpcEncoding_File_disqualification_proto struct{}
+
+func (drpcEncoding_File_disqualification_proto) Marshal(msg drpc.Message) ([]byte, error) {
+	return proto.Marshal(msg.(proto.Message))
+}
+
+func (drpcEncoding_File_disqualification_proto) Unmarshal(buf []byte, msg drpc.Message) error {
+	return proto.Unmarshal(buf, msg.(proto.Message))
+}
+
+func (drpcEncoding_File_disqualification_proto) JSONMarshal(msg drpc.Message) ([]byte, error) {
+	var buf bytes.Buffer
+	err := new(jsonpb.Marshaler).Marshal(&buf, msg.(proto.Message))
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (drpcEncoding_File_disqualification_proto) JSONUnmarshal(buf []byte, msg drpc.Message) error {
+	return jsonpb.Unmarshal(bytes.NewReader(buf), msg.(proto.Message))
+}
+
+type DRPCNodeStatsClient interface {
+	DRPCConn() drpc.Conn
+
+	GetDisqualificationExplanation(ctx context.Context, in *GetDisqualificationExplanationRequest) (*GetDisqualificationExplanationResponse, error)
+}
+
+type drpcNodeStatsClient struct {
+	cc drpc.Conn
+}
+
+func NewDRPCNodeStatsClient(cc drpc.Conn) DRPCNodeStatsClient {
+	return &drpcNodeStatsClient{cc}
+}
+
+func (c *drpcNodeStatsClient) DRPCConn() drpc.Conn { return c.cc }
+
+func (c *drpcNodeStatsClient) GetDisqualificationExplanation(ctx context.Context, in *GetDisqualificationExplanationRequest) (*GetDisqualificationExplanationResponse, error) {
+	out := new(GetDisqualificationExplanationResponse)
+	err := c.cc.Invoke(ctx, "/nodeext.NodeStats/GetDisqualificationExplanation", drpcEncoding_File_disqualification_proto{}, in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+type DRPCNodeStatsServer interface {
+	GetDisqualificationExplanation(context.Context, *GetDisqualificationExplanationRequest) (*GetDisqualificationExplanationResponse, error)
+}
+
+type DRPCNodeStatsUnimplementedServer struct{}
+
+func (s *DRPCNodeStatsUnimplementedServer) GetDisqualificationExplanation(context.Context, *GetDisqualificationExplanationRequest) (*GetDisqualificationExplanationResponse, error) {
+	return nil, drpcerr.WithCode(errors.New("Unimplemented"), drpcerr.Unimplemented)
+}
+
+type DRPCNodeStatsDescription struct{}
+
+func (DRPCNodeStatsDescription) NumMethods() int { return 1 }
+
+func (DRPCNodeStatsDescription) Method(n int) (string, drpc.Encoding, drpc.Receiver, interface{}, bool) {
+	switch n {
+	case 0:
+		return "/nodeext.NodeStats/GetDisqualificationExplanation", drpcEncoding_File_disqualification_proto{},
+			func(srv interface{}, ctx context.Context, in1, in2 interface{}) (drpc.Message, error) {
+				return srv.(DRPCNodeStatsServer).
+					GetDisqualificationExplanation(
+						ctx,
+						in1.(*GetDisqualificationExplanationRequest),
+					)
+			}, DRPCNodeStatsServer.GetDisqualificationExplanation, true
+	default:
+		return "", nil, nil, nil, false
+	}
+}
+
+func DRPCRegisterNodeStats(mux drpc.Mux, impl DRPCNodeStatsServer) error {
+	return mux.Register(impl, DRPCNodeStatsDescription{})
+}
+
+type DRPCNodeStats_GetDisqualificationExplanationStream interface {
+	drpc.Stream
+	SendAndClose(*GetDisqualificationExplanationResponse) error
+}
+
+type drpcNodeStats_GetDisqualificationExplanationStream struct {
+	drpc.Stream
+}
+
+func (x *drpcNodeStats_GetDisqualificationExplanationStream) SendAndClose(m *GetDisqualificationExplanationResponse) error {
+	if err := x.MsgSend(m, drpcEncoding_File_disqualification_proto{}); err != nil {
+		return err
+	}
+	return x.CloseSend()
+}
@@ -0,0 +1,8 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+// Package nodeextpb contains protobuf definitions for the storage node and
+// satellite features, which aren't part of the common protocol yet.
+package nodeextpb
+
+//go:generate go run gen.go
@@ -0,0 +1,170 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: nodetags.proto
+
+package nodeextpb
+
+import (
+	fmt "fmt"
+	math "math"
+
+	proto "github.com/gogo/protobuf/proto"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
+
+// SignedNodeTagSet is a serialized tag set with the signature of its signer.
+type SignedNodeTagSet struct {
+	SignerNodeId NodeID `protobuf:"bytes,1,opt,name=signer_node_id,json=signerNodeId,proto3,customtype=NodeID" json:"signer_node_id"`
+	Signature    []byte `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+	// serialized_tag_set is the JSON encoded tag set, as it was signed.
+	SerializedTagSet     []byte   `protobuf:"bytes,3,opt,name=serialized_tag_set,json=serializedTagSet,proto3" json:"serialized_tag_set,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SignedNodeTagSet) Reset()         { *m = SignedNodeTagSet{} }
+func (m *SignedNodeTagSet) String() string { return proto.CompactTextString(m) }
+func (*SignedNodeTagSet) ProtoMessage()    {}
+func (*SignedNodeTagSet) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3bc3b2ad6e71b396, []int{0}
+}
+func (m *SignedNodeTagSet) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SignedNodeTagSet.Unmarshal(m, b)
+}
+func (m *SignedNodeTagSet) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SignedNodeTagSet.Marshal(b, m, deterministic)
+}
+func (m *SignedNodeTagSet) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SignedNodeTagSet.Merge(m, src)
+}
+func (m *SignedNodeTagSet) XXX_Size() int {
+	return xxx_messageInfo_SignedNodeTagSet.Size(m)
+}
+func (m *SignedNodeTagSet) XXX_DiscardUnknown() {
+	xxx_messageInfo_SignedNodeTagSet.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SignedNodeTagSet proto.InternalMessageInfo
+
+func (m *SignedNodeTagSet) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+func (m *SignedNodeTagSet) GetSerializedTagSet() []byte {
+	if m != nil {
+		return m.SerializedTagSet
+	}
+	return nil
+}
+
+type SetNodeTagsRequest struct {
+	// tags replace all the previous tags of the calling node.
+	Tags                 []*SignedNodeTagSet `protobuf:"bytes,1,rep,name=tags,proto3" json:"tags,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
+	XXX_unrecognized     []byte              `json:"-"`
+	XXX_sizecache        int32               `json:"-"`
+}
+
+func (m *SetNodeTagsRequest) Reset()         { *m = SetNodeTagsRequest{} }
+func (m *SetNodeTagsRequest) String() string { return proto.CompactTextString(m) }
+func (*SetNodeTagsRequest) ProtoMessage()    {}
+func (*SetNodeTagsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3bc3b2ad6e71b396, []int{1}
+}
+func (m *SetNodeTagsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetNodeTagsRequest.Unmarshal(m, b)
+}
+func (m *SetNodeTagsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetNodeTagsRequest.Marshal(b, m, deterministic)
+}
+func (m *SetNodeTagsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetNodeTagsRequest.Merge(m, src)
+}
+func (m *SetNodeTagsRequest) XXX_Size() int {
+	return xxx_messageInfo_SetNodeTagsRequest.Size(m)
+}
+func (m *SetNodeTagsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetNodeTagsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetNodeTagsRequest proto.InternalMessageInfo
+
+func (m *SetNodeTagsRequest) GetTags() []*SignedNodeTagSet {
+	if m != nil {
+		return m.Tags
+	}
+	return nil
+}
+
+type SetNodeTagsResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SetNodeTagsResponse) Reset()         { *m = SetNodeTagsResponse{} }
+func (m *SetNodeTagsResponse) String() string { return proto.CompactTextString(m) }
+func (*SetNodeTagsResponse) ProtoMessage()    {}
+func (*SetNodeTagsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3bc3b2ad6e71b396, []int{2}
+}
+func (m *SetNodeTagsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetNodeTagsResponse.Unmarshal(m, b)
+}
+func (m *SetNodeTagsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetNodeTagsResponse.Marshal(b, m, deterministic)
+}
+func (m *SetNodeTagsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetNodeTagsResponse.Merge(m, src)
+}
+func (m *SetNodeTagsResponse) XXX_Size() int {
+	return xxx_messageInfo_SetNodeTagsResponse.Size(m)
+}
+func (m *SetNodeTagsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetNodeTagsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetNodeTagsResponse proto.InternalMessageInfo
+
+func init() {
+	proto.RegisterType((*SignedNodeTagSet)(nil), "nodeext.SignedNodeTagSet")
+	proto.RegisterType((*SetNodeTagsRequest)(nil), "nodeext.SetNodeTagsRequest")
+	proto.RegisterType((*SetNodeTagsResponse)(nil), "nodeext.SetNodeTagsResponse")
+}
+
+func init() { proto.RegisterFile("nodetags.proto", fileDescriptor_3bc3b2ad6e71b396) }
+
+var fileDescriptor_3bc3b2ad6e71b396 = []byte{
+	// 274 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x50, 0xcb, 0x4e, 0xc3, 0x30,
+	0x10, 0x24, 0x14, 0x15, 0xd8, 0x56, 0x51, 0x65, 0x84, 0x14, 0x4a, 0xa5, 0x46, 0x39, 0xf5, 0x00,
+	0x89, 0x54, 0xf8, 0x82, 0xc2, 0x01, 0x2e, 0x1c, 0x92, 0x9e, 0xb8, 0x44, 0xae, 0xbc, 0xb2, 0x8c,
+	0x50, 0x1c, 0xec, 0x2d, 0x42, 0x7c, 0x07, 0x1f, 0xc5, 0x37, 0x70, 0xc8, 0xb7, 0x20, 0x3b, 0x41,
+	0xe1, 0x75, 0xf3, 0xcc, 0xce, 0x8c, 0x77, 0x16, 0xc2, 0x4a, 0x0b, 0x24, 0x2e, 0x6d, 0x5a, 0x1b,
+	0x4d, 0x9a, 0xed, 0x3b, 0x8c, 0x2f, 0x34, 0x05, 0xa9, 0xa5, 0x6e, 0xc9, 0xe4, 0x2d, 0x80, 0x49,
+	0xa1, 0x64, 0x85, 0xe2, 0x4e, 0x0b, 0x5c, 0x73, 0x59, 0x20, 0xb1, 0x4b, 0x08, 0xad, 0xe3, 0x4c,
+	0xe9, 0x2c, 0xa5, 0x12, 0x51, 0x10, 0x07, 0x8b, 0xf1, 0x2a, 0x7c, 0x6f, 0xe6, 0x3b, 0x1f, 0xcd,
+	0x7c, 0xe8, 0xb4, 0xb7, 0xd7, 0xf9, 0xb8, 0x55, 0x79, 0x24, 0xd8, 0x0c, 0x0e, 0x1d, 0xe6, 0xb4,
+	0x35, 0x18, 0xed, 0x3a, 0x43, 0xde, 0x13, 0xec, 0x0c, 0x98, 0x45, 0xa3, 0xf8, 0xa3, 0x7a, 0x45,
+	0x51, 0x12, 0x97, 0xa5, 0x45, 0x8a, 0x06, 0x5e, 0x36, 0xe9, 0x27, 0xed, 0x06, 0xc9, 0x15, 0xb0,
+	0x02, 0xa9, 0x5b, 0xc9, 0xe6, 0xf8, 0xb4, 0x45, 0x4b, 0xec, 0x1c, 0xf6, 0x5c, 0x9f, 0x28, 0x88,
+	0x07, 0x8b, 0xd1, 0xf2, 0x24, 0xed, 0x0a, 0xa5, 0xbf, 0x0b, 0xe4, 0x5e, 0x96, 0x1c, 0xc3, 0xd1,
+	0x8f, 0x10, 0x5b, 0xeb, 0xca, 0xe2, 0x72, 0x0d, 0x07, 0x5f, 0x1c, 0xbb, 0x81, 0xd1, 0x37, 0x09,
+	0x3b, 0xed, 0x23, 0xff, 0xfc, 0x3e, 0x9d, 0xfd, 0x3f, 0x6c, 0x53, 0x57, 0xc9, 0x7d, 0x6c, 0x49,
+	0x9b, 0x87, 0x54, 0xe9, 0xcc, 0x3f, 0xb2, 0xda, 0xa8, 0x67, 0x4e, 0x98, 0x75, 0xae, 0x7a, 0xb3,
+	0x19, 0xfa, 0x9b, 0x5f, 0x7c, 0x06, 0x00, 0x00, 0xff, 0xff, 0x5c, 0x25, 0x83, 0x70, 0x9a, 0x01,
+	0x00, 0x00,
+}
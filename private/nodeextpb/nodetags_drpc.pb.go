@@ -0,0 +1,115 @@
+// Code generated by protoc-gen-go-drpc. DO NOT EDIT.
+// protoc-gen-go-drpc version: v0.0.32
+// source: nodetags.proto
+
+package nodeextpb
+
+import (
+	bytes "bytes"
+	context "context"
+	errors "errors"
+
+	jsonpb "github.com/gogo/protobuf/jsonpb"
+	proto "github.com/gogo/protobuf/proto"
+
+	drpc "storj.io/drpc"
+	drpcerr "storj.io/drpc/drpcerr"
+)
+
+type drpcEncoding_File_nodetags_proto struct{}
+
+func (drpcEncoding_File_nodetags_proto) Marshal(msg drpc.Message) ([]byte, error) {
+	return proto.Marshal(msg.(proto.Message))
+}
+
+func (drpcEncoding_File_nodetags_proto) Unmarshal(buf []byte, msg drpc.Message) error {
+	return proto.Unmarshal(buf, msg.(proto.Message))
+}
+
+func (drpcEncoding_File_nodetags_proto) JSONMarshal(msg drpc.Message) ([]byte, error) {
+	var buf bytes.Buffer
+	err := new(jsonpb.Marshaler).Marshal(&buf, msg.(proto.Message))
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (drpcEncoding_File_nodetags_proto) JSONUnmarshal(buf []byte, msg drpc.Message) error {
+	return jsonpb.Unmarshal(bytes.NewReader(buf), msg.(proto.Message))
+}
+
+type DRPCNodeTagsClient interface {
+	DRPCConn() drpc.Conn
+
+	SetNodeTags(ctx context.Context, in *SetNodeTagsRequest) (*SetNodeTagsResponse, error)
+}
+
+type drpcNodeTagsClient struct {
+	cc drpc.Conn
+}
+
+func NewDRPCNodeTagsClient(cc drpc.Conn) DRPCNodeTagsClient {
+	return &drpcNodeTagsClient{cc}
+}
+
+func (c *drpcNodeTagsClient) DRPCConn() drpc.Conn { return c.cc }
+
+func (c *drpcNodeTagsClient) SetNodeTags(ctx context.Context, in *SetNodeTagsRequest) (*SetNodeTagsResponse, error) {
+	out := new(SetNodeTagsResponse)
+	err := c.cc.Invoke(ctx, "/nodeext.NodeTags/SetNodeTags", drpcEncoding_File_nodetags_proto{}, in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+type DRPCNodeTagsServer interface {
+	SetNodeTags(context.Context, *SetNodeTagsRequest) (*SetNodeTagsResponse, error)
+}
+
+type DRPCNodeTagsUnimplementedServer struct{}
+
+func (s *DRPCNodeTagsUnimplementedServer) SetNodeTags(context.Context, *SetNodeTagsRequest) (*SetNodeTagsResponse, error) {
+	return nil, drpcerr.WithCode(errors.New("Unimplemented"), drpcerr.Unimplemented)
+}
+
+type DRPCNodeTagsDescription struct{}
+
+func (DRPCNodeTagsDescription) NumMethods() int { return 1 }
+
+func (DRPCNodeTagsDescription) Method(n int) (string, drpc.Encoding, drpc.Receiver, interface{}, bool) {
+	switch n {
+	case 0:
+		return "/nodeext.NodeTags/SetNodeTags", drpcEncoding_File_nodetags_proto{},
+			func(srv interface{}, ctx context.Context, in1, in2 interface{}) (drpc.Message, error) {
+				return srv.(DRPCNodeTagsServer).
+					SetNodeTags(
+						ctx,
+						in1.(*SetNodeTagsRequest),
+					)
+			}, DRPCNodeTagsServer.SetNodeTags, true
+	default:
+		return "", nil, nil, nil, false
+	}
+}
+
+func DRPCRegisterNodeTags(mux drpc.Mux, impl DRPCNodeTagsServer) error {
+	return mux.Register(impl, DRPCNodeTagsDescription{})
+}
+
+type DRPCNodeTags_SetNodeTagsStream interface {
+	drpc.Stream
+	SendAndClose(*SetNodeTagsResponse) error
+}
+
+type drpcNodeTags_SetNodeTagsStream struct {
+	drpc.Stream
+}
+
+func (x *drpcNodeTags_SetNodeTagsStream) SendAndClose(m *SetNodeTagsResponse) error {
+	if err := x.MsgSend(m, drpcEncoding_File_nodetags_proto{}); err != nil {
+		return err
+	}
+	return x.CloseSend()
+}
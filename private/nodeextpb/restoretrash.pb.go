@@ -0,0 +1,124 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: restoretrash.proto
+
+package nodeextpb
+
+import (
+	fmt "fmt"
+	math "math"
+	time "time"
+
+	proto "github.com/gogo/protobuf/proto"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+var _ = time.Kitchen
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
+
+type RestoreTrashWithOptionsRequest struct {
+	// trashed_after restricts the restored pieces to the pieces trashed at or after it.
+	TrashedAfter *time.Time `protobuf:"bytes,1,opt,name=trashed_after,json=trashedAfter,proto3,stdtime" json:"trashed_after,omitempty"`
+	// piece_ids restricts the restored pieces to these pieces.
+	PieceIds             []PieceID `protobuf:"bytes,2,rep,name=piece_ids,json=pieceIds,proto3,customtype=PieceID" json:"piece_ids"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *RestoreTrashWithOptionsRequest) Reset()         { *m = RestoreTrashWithOptionsRequest{} }
+func (m *RestoreTrashWithOptionsRequest) String() string { return proto.CompactTextString(m) }
+func (*RestoreTrashWithOptionsRequest) ProtoMessage()    {}
+func (*RestoreTrashWithOptionsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_28fb97d295ef157f, []int{0}
+}
+func (m *RestoreTrashWithOptionsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RestoreTrashWithOptionsRequest.Unmarshal(m, b)
+}
+func (m *RestoreTrashWithOptionsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RestoreTrashWithOptionsRequest.Marshal(b, m, deterministic)
+}
+func (m *RestoreTrashWithOptionsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RestoreTrashWithOptionsRequest.Merge(m, src)
+}
+func (m *RestoreTrashWithOptionsRequest) XXX_Size() int {
+	return xxx_messageInfo_RestoreTrashWithOptionsRequest.Size(m)
+}
+func (m *RestoreTrashWithOptionsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RestoreTrashWithOptionsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RestoreTrashWithOptionsRequest proto.InternalMessageInfo
+
+func (m *RestoreTrashWithOptionsRequest) GetTrashedAfter() *time.Time {
+	if m != nil {
+		return m.TrashedAfter
+	}
+	return nil
+}
+
+type RestoreTrashWithOptionsResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RestoreTrashWithOptionsResponse) Reset()         { *m = RestoreTrashWithOptionsResponse{} }
+func (m *RestoreTrashWithOptionsResponse) String() string { return proto.CompactTextString(m) }
+func (*RestoreTrashWithOptionsResponse) ProtoMessage()    {}
+func (*RestoreTrashWithOptionsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_28fb97d295ef157f, []int{1}
+}
+func (m *RestoreTrashWithOptionsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RestoreTrashWithOptionsResponse.Unmarshal(m, b)
+}
+func (m *RestoreTrashWithOptionsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RestoreTrashWithOptionsResponse.Marshal(b, m, deterministic)
+}
+func (m *RestoreTrashWithOptionsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RestoreTrashWithOptionsResponse.Merge(m, src)
+}
+func (m *RestoreTrashWithOptionsResponse) XXX_Size() int {
+	return xxx_messageInfo_RestoreTrashWithOptionsResponse.Size(m)
+}
+func (m *RestoreTrashWithOptionsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RestoreTrashWithOptionsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RestoreTrashWithOptionsResponse proto.InternalMessageInfo
+
+func init() {
+	proto.RegisterType((*RestoreTrashWithOptionsRequest)(nil), "nodeext.RestoreTrashWithOptionsRequest")
+	proto.RegisterType((*RestoreTrashWithOptionsResponse)(nil), "nodeext.RestoreTrashWithOptionsResponse")
+}
+
+func init() { proto.RegisterFile("restoretrash.proto", fileDescriptor_28fb97d295ef157f) }
+
+var fileDescriptor_28fb97d295ef157f = []byte{
+	// 275 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x90, 0x3f, 0x4e, 0xc3, 0x30,
+	0x14, 0xc6, 0x09, 0x20, 0x0a, 0xa6, 0x08, 0xc9, 0x0b, 0x55, 0x06, 0x12, 0xb2, 0x90, 0x01, 0xd9,
+	0x52, 0x39, 0x01, 0x11, 0x0c, 0x9d, 0x40, 0x51, 0x25, 0x24, 0x96, 0x2a, 0x21, 0xaf, 0xa9, 0x51,
+	0x9b, 0x67, 0xec, 0x57, 0x84, 0x38, 0x05, 0x0b, 0x77, 0xe2, 0x0c, 0x0c, 0xe5, 0x2a, 0x28, 0x76,
+	0x3a, 0x96, 0x6e, 0xfe, 0xf3, 0xfb, 0x7e, 0xcf, 0xfe, 0x18, 0x37, 0x60, 0x09, 0x0d, 0x90, 0x29,
+	0xec, 0x4c, 0x68, 0x83, 0x84, 0xbc, 0xd7, 0x60, 0x05, 0xf0, 0x4e, 0x21, 0xab, 0xb1, 0x46, 0x7f,
+	0x18, 0x46, 0x35, 0x62, 0x3d, 0x07, 0xe9, 0x76, 0xe5, 0x72, 0x2a, 0x49, 0x2d, 0xc0, 0x52, 0xb1,
+	0xd0, 0x1e, 0x48, 0xbe, 0x02, 0x76, 0x9e, 0x7b, 0xd9, 0xb8, 0x95, 0x3d, 0x2a, 0x9a, 0xdd, 0x6b,
+	0x52, 0xd8, 0xd8, 0x1c, 0x5e, 0x97, 0x60, 0x89, 0xdf, 0xb1, 0x13, 0x37, 0x07, 0xaa, 0x49, 0x31,
+	0x25, 0x30, 0x83, 0x20, 0x0e, 0xd2, 0xe3, 0x61, 0x28, 0xbc, 0x5b, 0xac, 0xdd, 0x62, 0xbc, 0x76,
+	0x67, 0xfb, 0x9f, 0xbf, 0x51, 0x90, 0xf7, 0xbb, 0xd8, 0x4d, 0x9b, 0xe2, 0x57, 0xec, 0x48, 0x2b,
+	0x78, 0x86, 0x89, 0xaa, 0xec, 0x60, 0x37, 0xde, 0x4b, 0xfb, 0xd9, 0xe9, 0xf7, 0x2a, 0xda, 0xf9,
+	0x59, 0x45, 0xbd, 0x87, 0xf6, 0x62, 0x74, 0x9b, 0x1f, 0x3a, 0x62, 0x54, 0xd9, 0xe4, 0x82, 0x45,
+	0x1b, 0x9f, 0x65, 0x35, 0x36, 0x16, 0x86, 0x1f, 0x8c, 0xb9, 0x9c, 0xa3, 0xf8, 0x9c, 0x9d, 0x6d,
+	0x08, 0xf0, 0x4b, 0xd1, 0x55, 0x23, 0xfe, 0xff, 0x69, 0x98, 0x6e, 0x07, 0xfd, 0xec, 0x2c, 0x79,
+	0x8a, 0x5b, 0xe0, 0x45, 0x28, 0x94, 0x6e, 0x21, 0xb5, 0x51, 0x6f, 0x05, 0x81, 0xec, 0x0c, 0xba,
+	0x2c, 0x0f, 0x5c, 0x31, 0xd7, 0x7f, 0x01, 0x00, 0x00, 0xff, 0xff, 0xa4, 0xb2, 0x71, 0xe5, 0xad,
+	0x01, 0x00, 0x00,
+}
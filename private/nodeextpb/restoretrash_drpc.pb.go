@@ -0,0 +1,115 @@
+// Code generated by protoc-gen-go-drpc. DO NOT EDIT.
+// protoc-gen-go-drpc version: v0.0.32
+// source: restoretrash.proto
+
+package nodeextpb
+
+import (
+	bytes "bytes"
+	context "context"
+	errors "errors"
+
+	jsonpb "github.com/gogo/protobuf/jsonpb"
+	proto "github.com/gogo/protobuf/proto"
+
+	drpc "storj.io/drpc"
+	drpcerr "storj.io/drpc/drpcerr"
+)
+
+type drpcEncoding_File_restoretrash_proto struct{}
+
+func (drpcEncoding_File_restoretrash_proto) Marshal(msg drpc.Message) ([]byte, error) {
+	return proto.Marshal(msg.(proto.Message))
+}
+
+func (drpcEncoding_File_restoretrash_proto) Unmarshal(buf []byte, msg drpc.Message) error {
+	return proto.Unmarshal(buf, msg.(proto.Message))
+}
+
+func (drpcEncoding_File_restoretrash_proto) JSONMarshal(msg drpc.Message) ([]byte, error) {
+	var buf bytes.Buffer
+	err := new(jsonpb.Marshaler).Marshal(&buf, msg.(proto.Message))
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (drpcEncoding_File_restoretrash_proto) JSONUnmarshal(buf []byte, msg drpc.Message) error {
+	return jsonpb.Unmarshal(bytes.NewReader(buf), msg.(proto.Message))
+}
+
+type DRPCPiecestoreClient interface {
+	DRPCConn() drpc.Conn
+
+	RestoreTrashWithOptions(ctx context.Context, in *RestoreTrashWithOptionsRequest) (*RestoreTrashWithOptionsResponse, error)
+}
+
+type drpcPiecestoreClient struct {
+	cc drpc.Conn
+}
+
+func NewDRPCPiecestoreClient(cc drpc.Conn) DRPCPiecestoreClient {
+	return &drpcPiecestoreClient{cc}
+}
+
+func (c *drpcPiecestoreClient) DRPCConn() drpc.Conn { return c.cc }
+
+func (c *drpcPiecestoreClient) RestoreTrashWithOptions(ctx context.Context, in *RestoreTrashWithOptionsRequest) (*RestoreTrashWithOptionsResponse, error) {
+	out := new(RestoreTrashWithOptionsResponse)
+	err := c.cc.Invoke(ctx, "/nodeext.Piecestore/RestoreTrashWithOptions", drpcEncoding_File_restoretrash_proto{}, in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+type DRPCPiecestoreServer interface {
+	RestoreTrashWithOptions(context.Context, *RestoreTrashWithOptionsRequest) (*RestoreTrashWithOptionsResponse, error)
+}
+
+type DRPCPiecestoreUnimplementedServer struct{}
+
+func (s *DRPCPiecestoreUnimplementedServer) RestoreTrashWithOptions(context.Context, *RestoreTrashWithOptionsRequest) (*RestoreTrashWithOptionsResponse, error) {
+	return nil, drpcerr.WithCode(errors.New("Unimplemented"), drpcerr.Unimplemented)
+}
+
+type DRPCPiecestoreDescription struct{}
+
+func (DRPCPiecestoreDescription) NumMethods() int { return 1 }
+
+func (DRPCPiecestoreDescription) Method(n int) (string, drpc.Encoding, drpc.Receiver, interface{}, bool) {
+	switch n {
+	case 0:
+		return "/nodeext.Piecestore/RestoreTrashWithOptions", drpcEncoding_File_restoretrash_proto{},
+			func(srv interface{}, ctx context.Context, in1, in2 interface{}) (drpc.Message, error) {
+				return srv.(DRPCPiecestoreServer).
+					RestoreTrashWithOptions(
+						ctx,
+						in1.(*RestoreTrashWithOptionsRequest),
+					)
+			}, DRPCPiecestoreServer.RestoreTrashWithOptions, true
+	default:
+		return "", nil, nil, nil, false
+	}
+}
+
+func DRPCRegisterPiecestore(mux drpc.Mux, impl DRPCPiecestoreServer) error {
+	return mux.Register(impl, DRPCPiecestoreDescription{})
+}
+
+type DRPCPiecestore_RestoreTrashWithOptionsStream interface {
+	drpc.Stream
+	SendAndClose(*RestoreTrashWithOptionsResponse) error
+}
+
+type drpcPiecestore_RestoreTrashWithOptionsStream struct {
+	drpc.Stream
+}
+
+func (x *drpcPiecestore_RestoreTrashWithOptionsStream) SendAndClose(m *RestoreTrashWithOptionsResponse) error {
+	if err := x.MsgSend(m, drpcEncoding_File_restoretrash_proto{}); err != nil {
+		return err
+	}
+	return x.CloseSend()
+}
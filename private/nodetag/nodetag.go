@@ -0,0 +1,183 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+// Package nodetag implements signing and verification of the key/value tags
+// which storage nodes report about themselves (e.g. datacenter, provider).
+package nodetag
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/zeebo/errs"
+
+	"storj.io/common/identity"
+	"storj.io/common/signing"
+	"storj.io/common/storj"
+)
+
+// Error is the default error class for node tags.
+var Error = errs.Class("nodetag")
+
+// ErrSignature is returned when the signature of a tag set cannot be verified.
+var ErrSignature = errs.Class("node tag signature")
+
+const (
+	// MaxTags is the maximum number of tags in a tag set.
+	MaxTags = 32
+	// MaxNameLength is the maximum length of a tag name.
+	MaxNameLength = 64
+	// MaxValueLength is the maximum length of a tag value.
+	MaxValueLength = 256
+)
+
+// Tag is a single key/value attribute of a node.
+type Tag struct {
+	Name  string `json:"name"`
+	Value []byte `json:"value"`
+}
+
+// TagSet is the list of tags of a node, which is signed as a whole.
+type TagSet struct {
+	NodeID   storj.NodeID `json:"node_id"`
+	SignedAt time.Time    `json:"signed_at"`
+	Tags     []Tag        `json:"tags"`
+}
+
+// Verify checks that the tags are within the limits.
+func (tagSet *TagSet) Verify() error {
+	if len(tagSet.Tags) > MaxTags {
+		return Error.New("too many tags: %d > %d", len(tagSet.Tags), MaxTags)
+	}
+	names := map[string]struct{}{}
+	for _, tag := range tagSet.Tags {
+		switch {
+		case tag.Name == "":
+			return Error.New("tag name is empty")
+		case len(tag.Name) > MaxNameLength:
+			return Error.New("tag name %q is too long: %d > %d", tag.Name, len(tag.Name), MaxNameLength)
+		case len(tag.Value) > MaxValueLength:
+			return Error.New("value of tag %q is too long: %d > %d", tag.Name, len(tag.Value), MaxValueLength)
+		}
+		if _, ok := names[tag.Name]; ok {
+			return Error.New("duplicate tag %q", tag.Name)
+		}
+		names[tag.Name] = struct{}{}
+	}
+	return nil
+}
+
+// ParseTags parses comma-separated tags in the form of name=value.
+func ParseTags(list string) ([]Tag, error) {
+	var tags []Tag
+	for _, item := range strings.Split(list, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		eq := strings.Index(item, "=")
+		if eq < 0 {
+			return nil, Error.New("tag should be in the form name=value: %q", item)
+		}
+		tags = append(tags, Tag{
+			Name:  strings.TrimSpace(item[:eq]),
+			Value: []byte(strings.TrimSpace(item[eq+1:])),
+		})
+	}
+	return tags, nil
+}
+
+// LoadSignedTagSets loads a JSON list of signed tag sets from the file.
+func LoadSignedTagSets(path string) (signedTags []*SignedTagSet, err error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	if err := json.Unmarshal(data, &signedTags); err != nil {
+		return nil, Error.New("invalid signed tags in %q: %w", path, err)
+	}
+	return signedTags, nil
+}
+
+// SignedTagSet is a serialized TagSet with the signature of the signer.
+type SignedTagSet struct {
+	SignerNodeID     storj.NodeID
+	Signature        []byte
+	SerializedTagSet []byte
+}
+
+// Sign serializes and signs the tag set.
+func Sign(ctx context.Context, tagSet *TagSet, signer signing.Signer) (*SignedTagSet, error) {
+	if err := tagSet.Verify(); err != nil {
+		return nil, err
+	}
+
+	serialized, err := json.Marshal(tagSet)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	signature, err := signer.HashAndSign(ctx, serialized)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	return &SignedTagSet{
+		SignerNodeID:     signer.ID(),
+		Signature:        signature,
+		SerializedTagSet: serialized,
+	}, nil
+}
+
+// Verify checks the signature of the tag set and returns the deserialized tags.
+func Verify(ctx context.Context, signed *SignedTagSet, signee signing.Signee) (*TagSet, error) {
+	if signed.SignerNodeID != signee.ID() {
+		return nil, ErrSignature.New("tag set is signed by %s instead of %s", signed.SignerNodeID, signee.ID())
+	}
+	if err := signee.HashAndVerifySignature(ctx, signed.SerializedTagSet, signed.Signature); err != nil {
+		return nil, ErrSignature.Wrap(err)
+	}
+
+	var tagSet TagSet
+	if err := json.Unmarshal(signed.SerializedTagSet, &tagSet); err != nil {
+		return nil, Error.Wrap(err)
+	}
+	if err := tagSet.Verify(); err != nil {
+		return nil, err
+	}
+	return &tagSet, nil
+}
+
+// Authority is the list of the signers which are trusted to sign node tags.
+type Authority []signing.Signee
+
+// LoadAuthorities loads the signers from comma-separated certificate file paths.
+func LoadAuthorities(certPaths string) (Authority, error) {
+	var authority Authority
+	for _, path := range strings.Split(certPaths, ",") {
+		path = strings.TrimSpace(path)
+		if path == "" {
+			continue
+		}
+		peer, err := identity.PeerConfig{CertPath: path}.Load()
+		if err != nil {
+			return nil, Error.New("failed to load tag authority %q: %w", path, err)
+		}
+		authority = append(authority, signing.SigneeFromPeerIdentity(peer))
+	}
+	return authority, nil
+}
+
+// Verify checks the signature of the tag set with the matching signer of the
+// authority and returns the deserialized tags.
+func (authority Authority) Verify(ctx context.Context, signed *SignedTagSet) (*TagSet, error) {
+	for _, signee := range authority {
+		if signee.ID() == signed.SignerNodeID {
+			return Verify(ctx, signed, signee)
+		}
+	}
+	return nil, ErrSignature.New("tag set is signed by an unknown signer %s", signed.SignerNodeID)
+}
@@ -0,0 +1,110 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package nodetag_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"storj.io/common/identity/testidentity"
+	"storj.io/common/signing"
+	"storj.io/common/storj"
+	"storj.io/common/testcontext"
+	"storj.io/common/testrand"
+	"storj.io/storj/private/nodetag"
+)
+
+func TestSignAndVerify(t *testing.T) {
+	ctx := testcontext.New(t)
+
+	authority := testidentity.MustPregeneratedSignedIdentity(0, storj.LatestIDVersion())
+	other := testidentity.MustPregeneratedSignedIdentity(1, storj.LatestIDVersion())
+
+	tagSet := &nodetag.TagSet{
+		NodeID:   testrand.NodeID(),
+		SignedAt: time.Now().UTC().Truncate(time.Second),
+		Tags: []nodetag.Tag{
+			{Name: "provider", Value: []byte("hetzner")},
+			{Name: "datacenter", Value: []byte("fsn1")},
+		},
+	}
+
+	signed, err := nodetag.Sign(ctx, tagSet, signing.SignerFromFullIdentity(authority))
+	require.NoError(t, err)
+	require.Equal(t, authority.ID, signed.SignerNodeID)
+
+	verified, err := nodetag.Verify(ctx, signed, signing.SigneeFromPeerIdentity(authority.PeerIdentity()))
+	require.NoError(t, err)
+	require.Equal(t, tagSet, verified)
+
+	verified, err = nodetag.Authority{
+		signing.SigneeFromPeerIdentity(other.PeerIdentity()),
+		signing.SigneeFromPeerIdentity(authority.PeerIdentity()),
+	}.Verify(ctx, signed)
+	require.NoError(t, err)
+	require.Equal(t, tagSet, verified)
+
+	_, err = nodetag.Authority{
+		signing.SigneeFromPeerIdentity(other.PeerIdentity()),
+	}.Verify(ctx, signed)
+	require.True(t, nodetag.ErrSignature.Has(err))
+
+	// signer id doesn't match
+	_, err = nodetag.Verify(ctx, signed, signing.SigneeFromPeerIdentity(other.PeerIdentity()))
+	require.True(t, nodetag.ErrSignature.Has(err))
+
+	// tampered tags
+	tampered := *signed
+	tampered.SerializedTagSet = []byte(strings.Replace(string(signed.SerializedTagSet), "provider", "provides", 1))
+	_, err = nodetag.Verify(ctx, &tampered, signing.SigneeFromPeerIdentity(authority.PeerIdentity()))
+	require.True(t, nodetag.ErrSignature.Has(err))
+}
+
+func TestTagSet_Verify(t *testing.T) {
+	var tooMany []nodetag.Tag
+	for i := 0; i <= nodetag.MaxTags; i++ {
+		tooMany = append(tooMany, nodetag.Tag{Name: strings.Repeat("t", i+1)})
+	}
+
+	for _, tc := range []struct {
+		name  string
+		tags  []nodetag.Tag
+		valid bool
+	}{
+		{name: "empty", valid: true},
+		{name: "valid", tags: []nodetag.Tag{{Name: "provider", Value: []byte("x")}, {Name: "empty"}}, valid: true},
+		{name: "too many", tags: tooMany},
+		{name: "empty name", tags: []nodetag.Tag{{Value: []byte("x")}}},
+		{name: "long name", tags: []nodetag.Tag{{Name: strings.Repeat("n", nodetag.MaxNameLength+1)}}},
+		{name: "long value", tags: []nodetag.Tag{{Name: "n", Value: make([]byte, nodetag.MaxValueLength+1)}}},
+		{name: "duplicate", tags: []nodetag.Tag{{Name: "n"}, {Name: "n"}}},
+	} {
+		err := (&nodetag.TagSet{Tags: tc.tags}).Verify()
+		if tc.valid {
+			require.NoError(t, err, tc.name)
+		} else {
+			require.True(t, nodetag.Error.Has(err), tc.name)
+		}
+	}
+}
+
+func TestParseTags(t *testing.T) {
+	tags, err := nodetag.ParseTags("provider=hetzner, datacenter = fsn1,,empty=")
+	require.NoError(t, err)
+	require.Equal(t, []nodetag.Tag{
+		{Name: "provider", Value: []byte("hetzner")},
+		{Name: "datacenter", Value: []byte("fsn1")},
+		{Name: "empty", Value: []byte{}},
+	}, tags)
+
+	tags, err = nodetag.ParseTags("")
+	require.NoError(t, err)
+	require.Empty(t, tags)
+
+	_, err = nodetag.ParseTags("provider")
+	require.Error(t, err)
+}
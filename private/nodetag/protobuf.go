@@ -0,0 +1,34 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package nodetag
+
+import (
+	"storj.io/storj/private/nodeextpb"
+)
+
+// ToProtobuf converts the signed tag sets to protobuf.
+func ToProtobuf(signedTags []*SignedTagSet) []*nodeextpb.SignedNodeTagSet {
+	result := make([]*nodeextpb.SignedNodeTagSet, 0, len(signedTags))
+	for _, signed := range signedTags {
+		result = append(result, &nodeextpb.SignedNodeTagSet{
+			SignerNodeId:     signed.SignerNodeID,
+			Signature:        signed.Signature,
+			SerializedTagSet: signed.SerializedTagSet,
+		})
+	}
+	return result
+}
+
+// FromProtobuf converts the signed tag sets from protobuf.
+func FromProtobuf(signedTags []*nodeextpb.SignedNodeTagSet) []*SignedTagSet {
+	result := make([]*SignedTagSet, 0, len(signedTags))
+	for _, signed := range signedTags {
+		result = append(result, &SignedTagSet{
+			SignerNodeID:     signed.SignerNodeId,
+			Signature:        signed.Signature,
+			SerializedTagSet: signed.SerializedTagSet,
+		})
+	}
+	return result
+}
@@ -0,0 +1,51 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package nodetag_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"storj.io/common/identity/testidentity"
+	"storj.io/common/pb"
+	"storj.io/common/signing"
+	"storj.io/common/storj"
+	"storj.io/common/testcontext"
+	"storj.io/storj/private/nodeextpb"
+	"storj.io/storj/private/nodetag"
+)
+
+func TestProtobuf(t *testing.T) {
+	ctx := testcontext.New(t)
+
+	ident := testidentity.MustPregeneratedSignedIdentity(0, storj.LatestIDVersion())
+
+	var signedTags []*nodetag.SignedTagSet
+	for _, provider := range []string{"hetzner", "ovh"} {
+		signed, err := nodetag.Sign(ctx, &nodetag.TagSet{
+			NodeID:   ident.ID,
+			SignedAt: time.Now().UTC().Truncate(time.Second),
+			Tags:     []nodetag.Tag{{Name: "provider", Value: []byte(provider)}},
+		}, signing.SignerFromFullIdentity(ident))
+		require.NoError(t, err)
+		signedTags = append(signedTags, signed)
+	}
+
+	// the tags survive the encoding of the request
+	data, err := pb.Marshal(&nodeextpb.SetNodeTagsRequest{Tags: nodetag.ToProtobuf(signedTags)})
+	require.NoError(t, err)
+
+	var received nodeextpb.SetNodeTagsRequest
+	require.NoError(t, pb.Unmarshal(data, &received))
+
+	actual := nodetag.FromProtobuf(received.Tags)
+	require.Equal(t, signedTags, actual)
+
+	for _, signed := range actual {
+		_, err := nodetag.Verify(ctx, signed, signing.SigneeFromPeerIdentity(ident.PeerIdentity()))
+		require.NoError(t, err)
+	}
+}
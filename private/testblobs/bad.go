@@ -132,6 +132,14 @@ func (bad *BadBlobs) RestoreTrash(ctx context.Context, namespace []byte) ([][]by
 	return bad.blobs.RestoreTrash(ctx, namespace)
 }
 
+// RestoreTrashWithOptions restores the files in the trash, which are selected by the options.
+func (bad *BadBlobs) RestoreTrashWithOptions(ctx context.Context, namespace []byte, opts storage.RestoreTrashOptions) ([][]byte, error) {
+	if err := bad.err.Err(); err != nil {
+		return nil, err
+	}
+	return bad.blobs.RestoreTrashWithOptions(ctx, namespace, opts)
+}
+
 // EmptyTrash empties the trash.
 func (bad *BadBlobs) EmptyTrash(ctx context.Context, namespace []byte, trashedBefore time.Time) (int64, [][]byte, error) {
 	if err := bad.err.Err(); err != nil {
@@ -107,6 +107,14 @@ func (slow *SlowBlobs) RestoreTrash(ctx context.Context, namespace []byte) ([][]
 	return slow.blobs.RestoreTrash(ctx, namespace)
 }
 
+// RestoreTrashWithOptions restores the files in the trash, which are selected by the options.
+func (slow *SlowBlobs) RestoreTrashWithOptions(ctx context.Context, namespace []byte, opts storage.RestoreTrashOptions) ([][]byte, error) {
+	if err := slow.sleep(ctx); err != nil {
+		return nil, errs.Wrap(err)
+	}
+	return slow.blobs.RestoreTrashWithOptions(ctx, namespace, opts)
+}
+
 // EmptyTrash empties the trash.
 func (slow *SlowBlobs) EmptyTrash(ctx context.Context, namespace []byte, trashedBefore time.Time) (int64, [][]byte, error) {
 	if err := slow.sleep(ctx); err != nil {
@@ -49,6 +49,7 @@ import (
 	"storj.io/storj/satellite/metabase/segmentloop"
 	"storj.io/storj/satellite/metabase/zombiedeletion"
 	"storj.io/storj/satellite/metainfo"
+	"storj.io/storj/satellite/metainfo/bucketlifecycle"
 	"storj.io/storj/satellite/metainfo/expireddeletion"
 	"storj.io/storj/satellite/metrics"
 	"storj.io/storj/satellite/nodeevents"
@@ -138,6 +139,7 @@ type Satellite struct {
 		Chore       *audit.Chore
 		Verifier    *audit.Verifier
 		Reporter    audit.Reporter
+		StatWorker  *audit.StatWorker
 	}
 
 	Reputation struct {
@@ -153,6 +155,10 @@ type Satellite struct {
 		Chore *expireddeletion.Chore
 	}
 
+	BucketLifecycle struct {
+		Chore *bucketlifecycle.Chore
+	}
+
 	ZombieDeletion struct {
 		Chore *zombiedeletion.Chore
 	}
@@ -613,11 +619,13 @@ func createNewSystem(name string, log *zap.Logger, config satellite.Config, peer
 	system.Audit.Chore = peer.Audit.Chore
 	system.Audit.Verifier = peer.Audit.Verifier
 	system.Audit.Reporter = peer.Audit.Reporter
+	system.Audit.StatWorker = peer.Audit.StatWorker
 
 	system.GarbageCollection.Sender = gcPeer.GarbageCollection.Sender
 	system.GarbageCollection.BloomFilters = gcBFPeer.GarbageCollection.Service
 
 	system.ExpiredDeletion.Chore = peer.ExpiredDeletion.Chore
+	system.BucketLifecycle.Chore = peer.BucketLifecycle.Chore
 	system.ZombieDeletion.Chore = peer.ZombieDeletion.Chore
 
 	system.Accounting.Tally = peer.Accounting.Tally
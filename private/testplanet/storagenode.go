@@ -39,6 +39,7 @@ import (
 	"storj.io/storj/storagenode/piecestore"
 	"storj.io/storj/storagenode/preflight"
 	"storj.io/storj/storagenode/retain"
+	"storj.io/storj/storagenode/scrubber"
 	"storj.io/storj/storagenode/storagenodedb"
 	"storj.io/storj/storagenode/trust"
 )
@@ -151,6 +152,11 @@ func (planet *Planet) newStorageNode(ctx context.Context, prefix string, index,
 		Collector: collector.Config{
 			Interval: defaultInterval,
 		},
+		// tests corrupt pieces on purpose, so they mustn't be quarantined.
+		Scrubber: scrubber.Config{
+			Enabled:  false,
+			Interval: defaultInterval,
+		},
 		Nodestats: nodestats.Config{
 			MaxSleep:       0,
 			ReputationSync: defaultInterval,
@@ -130,3 +130,126 @@ func (server *Server) getBucketInfo(w http.ResponseWriter, r *http.Request) {
 		sendJSONData(w, http.StatusOK, data)
 	}
 }
+
+func (server *Server) getBucketVersioning(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	project, bucket, err := validateBucketPathParameters(mux.Vars(r))
+	if err != nil {
+		sendJSONError(w, err.Error(), "", http.StatusBadRequest)
+		return
+	}
+
+	versioning, err := server.buckets.GetBucketVersioningState(ctx, bucket, project.UUID)
+	if err != nil {
+		if storj.ErrBucketNotFound.Has(err) {
+			sendJSONError(w, "bucket does not exist", "", http.StatusBadRequest)
+		} else {
+			sendJSONError(w, "unable to check bucket versioning", err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	data, err := json.Marshal(struct {
+		Versioning string `json:"versioning"`
+	}{
+		Versioning: versioning.String(),
+	})
+	if err != nil {
+		sendJSONError(w, "failed to marshal bucket versioning", err.Error(), http.StatusInternalServerError)
+	} else {
+		sendJSONData(w, http.StatusOK, data)
+	}
+}
+
+func (server *Server) enableBucketVersioning(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	project, bucket, err := validateBucketPathParameters(mux.Vars(r))
+	if err != nil {
+		sendJSONError(w, err.Error(), "", http.StatusBadRequest)
+		return
+	}
+
+	err = server.buckets.SetBucketVersioningState(ctx, bucket, project.UUID, buckets.VersioningEnabled)
+	if err != nil {
+		switch {
+		case storj.ErrBucketNotFound.Has(err):
+			sendJSONError(w, "bucket does not exist", "", http.StatusBadRequest)
+		case buckets.ErrInvalidVersioningState.Has(err):
+			sendJSONError(w, "invalid versioning state", err.Error(), http.StatusBadRequest)
+		default:
+			sendJSONError(w, "unable to enable bucket versioning", err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+func (server *Server) getBucketLifecycle(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	project, bucket, err := validateBucketPathParameters(mux.Vars(r))
+	if err != nil {
+		sendJSONError(w, err.Error(), "", http.StatusBadRequest)
+		return
+	}
+
+	rules, err := server.buckets.GetBucketLifecycleRules(ctx, bucket, project.UUID)
+	if err != nil {
+		if storj.ErrBucketNotFound.Has(err) {
+			sendJSONError(w, "bucket does not exist", "", http.StatusBadRequest)
+		} else {
+			sendJSONError(w, "unable to get bucket lifecycle rules", err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+	if rules == nil {
+		rules = buckets.LifecycleRules{}
+	}
+
+	data, err := json.Marshal(struct {
+		Rules buckets.LifecycleRules `json:"rules"`
+	}{
+		Rules: rules,
+	})
+	if err != nil {
+		sendJSONError(w, "failed to marshal bucket lifecycle rules", err.Error(), http.StatusInternalServerError)
+	} else {
+		sendJSONData(w, http.StatusOK, data)
+	}
+}
+
+func (server *Server) setBucketLifecycle(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	project, bucket, err := validateBucketPathParameters(mux.Vars(r))
+	if err != nil {
+		sendJSONError(w, err.Error(), "", http.StatusBadRequest)
+		return
+	}
+
+	var input struct {
+		Rules buckets.LifecycleRules `json:"rules"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		sendJSONError(w, "invalid json", err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	err = server.buckets.SetBucketLifecycleRules(ctx, bucket, project.UUID, input.Rules)
+	if err != nil {
+		switch {
+		case storj.ErrBucketNotFound.Has(err):
+			sendJSONError(w, "bucket does not exist", "", http.StatusBadRequest)
+		case buckets.ErrInvalidLifecycleRule.Has(err):
+			sendJSONError(w, "invalid lifecycle rules", err.Error(), http.StatusBadRequest)
+		default:
+			sendJSONError(w, "unable to set bucket lifecycle rules", err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
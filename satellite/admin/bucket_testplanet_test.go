@@ -113,3 +113,75 @@ func TestAdminBucketGeofenceAPI(t *testing.T) {
 		}
 	})
 }
+
+func TestAdminBucketVersioningAPI(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount:   1,
+		StorageNodeCount: 0,
+		UplinkCount:      1,
+		Reconfigure: testplanet.Reconfigure{
+			Satellite: func(_ *zap.Logger, _ int, config *satellite.Config) {
+				config.Admin.Address = "127.0.0.1:0"
+			},
+		},
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		uplink := planet.Uplinks[0]
+		sat := planet.Satellites[0]
+		address := sat.Admin.Admin.Listener.Addr()
+		projectID := uplink.Projects[0].ID
+		authToken := sat.Config.Console.AuthToken
+
+		err := uplink.CreateBucket(ctx, sat, "versioned")
+		require.NoError(t, err)
+
+		versioningURL := fmt.Sprintf("http://%s/api/projects/%s/buckets/versioned/versioning", address, projectID)
+
+		assertGet(ctx, t, versioningURL, `{"versioning":"Unversioned"}`, authToken)
+
+		assertReq(ctx, t, versioningURL, "POST", "", http.StatusOK, "", authToken)
+		assertGet(ctx, t, versioningURL, `{"versioning":"Enabled"}`, authToken)
+
+		// enabling versioning again is a no-op
+		assertReq(ctx, t, versioningURL, "POST", "", http.StatusOK, "", authToken)
+
+		missingURL := fmt.Sprintf("http://%s/api/projects/%s/buckets/missing/versioning", address, projectID)
+		assertReq(ctx, t, missingURL, "POST", "", http.StatusBadRequest, `{"error":"bucket does not exist","detail":""}`, authToken)
+	})
+}
+
+func TestAdminBucketLifecycleAPI(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount:   1,
+		StorageNodeCount: 0,
+		UplinkCount:      1,
+		Reconfigure: testplanet.Reconfigure{
+			Satellite: func(_ *zap.Logger, _ int, config *satellite.Config) {
+				config.Admin.Address = "127.0.0.1:0"
+			},
+		},
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		uplink := planet.Uplinks[0]
+		sat := planet.Satellites[0]
+		address := sat.Admin.Admin.Listener.Addr()
+		projectID := uplink.Projects[0].ID
+		authToken := sat.Config.Console.AuthToken
+
+		err := uplink.CreateBucket(ctx, sat, "lifecycle")
+		require.NoError(t, err)
+
+		lifecycleURL := fmt.Sprintf("http://%s/api/projects/%s/buckets/lifecycle/lifecycle", address, projectID)
+
+		assertGet(ctx, t, lifecycleURL, `{"rules":[]}`, authToken)
+
+		rules := `{"rules":[{"id":"logs","prefix":"logs/","expirationDays":30}]}`
+		assertReq(ctx, t, lifecycleURL, "PUT", rules, http.StatusOK, "", authToken)
+		assertGet(ctx, t, lifecycleURL, rules, authToken)
+
+		invalid := `{"rules":[{"id":"logs","prefix":"logs/"}]}`
+		assertReq(ctx, t, lifecycleURL, "PUT", invalid, http.StatusBadRequest,
+			`{"error":"invalid lifecycle rules","detail":"invalid lifecycle rule: rule \"logs\" has no action"}`, authToken)
+
+		assertReq(ctx, t, lifecycleURL, "PUT", `{"rules":[]}`, http.StatusOK, "", authToken)
+		assertGet(ctx, t, lifecycleURL, `{"rules":[]}`, authToken)
+	})
+}
@@ -0,0 +1,267 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package admin
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"storj.io/common/storj"
+	"storj.io/common/uuid"
+	"storj.io/storj/satellite/audit"
+)
+
+// defaultContainmentListLimit is the number of listed entries when the request
+// doesn't specify the limit.
+const defaultContainmentListLimit = 100
+
+type containedNode struct {
+	NodeID        storj.NodeID  `json:"nodeId"`
+	StreamID      uuid.UUID     `json:"streamId"`
+	Position      uint64        `json:"position"`
+	PieceID       storj.PieceID `json:"pieceId"`
+	StripeIndex   int32         `json:"stripeIndex"`
+	ShareSize     int32         `json:"shareSize"`
+	ReverifyCount int32         `json:"reverifyCount"`
+	InsertedAt    time.Time     `json:"insertedAt"`
+	Age           string        `json:"age"`
+}
+
+type reverificationJob struct {
+	NodeID        storj.NodeID `json:"nodeId"`
+	StreamID      uuid.UUID    `json:"streamId"`
+	Position      uint64       `json:"position"`
+	PieceNum      int          `json:"pieceNum"`
+	ReverifyCount int          `json:"reverifyCount"`
+	InsertedAt    time.Time    `json:"insertedAt"`
+	LastAttempt   *time.Time   `json:"lastAttempt"`
+	Age           string       `json:"age"`
+}
+
+func (server *Server) convertContainedNode(contained audit.ContainedNode) containedNode {
+	pending := contained.PendingAudit
+	return containedNode{
+		NodeID:        pending.NodeID,
+		StreamID:      pending.StreamID,
+		Position:      pending.Position.Encode(),
+		PieceID:       pending.PieceID,
+		StripeIndex:   pending.StripeIndex,
+		ShareSize:     pending.ShareSize,
+		ReverifyCount: pending.ReverifyCount,
+		InsertedAt:    contained.InsertedAt,
+		Age:           server.nowFn().Sub(contained.InsertedAt).Truncate(time.Second).String(),
+	}
+}
+
+func (server *Server) convertReverificationJobs(jobs []audit.ReverificationJob) []reverificationJob {
+	converted := make([]reverificationJob, 0, len(jobs))
+	for _, job := range jobs {
+		converted = append(converted, reverificationJob{
+			NodeID:        job.Locator.NodeID,
+			StreamID:      job.Locator.StreamID,
+			Position:      job.Locator.Position.Encode(),
+			PieceNum:      job.Locator.PieceNum,
+			ReverifyCount: job.ReverifyCount,
+			InsertedAt:    job.InsertedAt,
+			LastAttempt:   job.LastAttempt,
+			Age:           server.nowFn().Sub(job.InsertedAt).Truncate(time.Second).String(),
+		})
+	}
+	return converted
+}
+
+func parseListLimit(value string) (int, error) {
+	if value == "" {
+		return defaultContainmentListLimit, nil
+	}
+	limit, err := strconv.Atoi(value)
+	if err != nil || limit <= 0 {
+		return 0, fmt.Errorf("limit must be a positive integer")
+	}
+	return limit, nil
+}
+
+func validateNodeIDPathParameter(vars map[string]string) (storj.NodeID, error) {
+	nodeIDString, ok := vars["nodeid"]
+	if !ok {
+		return storj.NodeID{}, fmt.Errorf("node-id missing")
+	}
+
+	nodeID, err := storj.NodeIDFromString(nodeIDString)
+	if err != nil {
+		return storj.NodeID{}, fmt.Errorf("node-id is not a valid node ID")
+	}
+	return nodeID, nil
+}
+
+func (server *Server) listContainedNodes(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	limit, err := parseListLimit(r.URL.Query().Get("limit"))
+	if err != nil {
+		sendJSONError(w, err.Error(), "", http.StatusBadRequest)
+		return
+	}
+
+	contained, err := server.db.Containment().ListContained(ctx, limit)
+	if err != nil {
+		sendJSONError(w, "unable to list contained nodes", err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	output := struct {
+		Nodes []containedNode `json:"nodes"`
+	}{
+		Nodes: make([]containedNode, 0, len(contained)),
+	}
+	for _, node := range contained {
+		output.Nodes = append(output.Nodes, server.convertContainedNode(node))
+	}
+
+	data, err := json.Marshal(output)
+	if err != nil {
+		sendJSONError(w, "json encoding failed", err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	sendJSONData(w, http.StatusOK, data)
+}
+
+func (server *Server) getContainedNode(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	nodeID, err := validateNodeIDPathParameter(mux.Vars(r))
+	if err != nil {
+		sendJSONError(w, err.Error(), "", http.StatusBadRequest)
+		return
+	}
+
+	var output struct {
+		PendingAudit    *containedNode      `json:"pendingAudit"`
+		Reverifications []reverificationJob `json:"reverifications"`
+	}
+
+	contained, err := server.db.Containment().GetContained(ctx, nodeID)
+	switch {
+	case err == nil:
+		node := server.convertContainedNode(*contained)
+		output.PendingAudit = &node
+	case audit.ErrContainedNotFound.Has(err):
+	default:
+		sendJSONError(w, "unable to get contained node", err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	jobs, err := server.db.ReverifyQueue().List(ctx, nodeID, defaultContainmentListLimit)
+	if err != nil {
+		sendJSONError(w, "unable to list reverification jobs", err.Error(), http.StatusInternalServerError)
+		return
+	}
+	output.Reverifications = server.convertReverificationJobs(jobs)
+
+	if output.PendingAudit == nil && len(output.Reverifications) == 0 {
+		sendJSONError(w, "node is not contained", "", http.StatusNotFound)
+		return
+	}
+
+	data, err := json.Marshal(output)
+	if err != nil {
+		sendJSONError(w, "json encoding failed", err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	sendJSONData(w, http.StatusOK, data)
+}
+
+func (server *Server) deleteContainedNode(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	nodeID, err := validateNodeIDPathParameter(mux.Vars(r))
+	if err != nil {
+		sendJSONError(w, err.Error(), "", http.StatusBadRequest)
+		return
+	}
+
+	deleted, err := server.db.Containment().Delete(ctx, nodeID)
+	if err != nil {
+		sendJSONError(w, "unable to delete pending audit", err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if !deleted {
+		sendJSONError(w, "node is not contained", "", http.StatusNotFound)
+		return
+	}
+}
+
+func (server *Server) listReverifications(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	limit, err := parseListLimit(r.URL.Query().Get("limit"))
+	if err != nil {
+		sendJSONError(w, err.Error(), "", http.StatusBadRequest)
+		return
+	}
+
+	var nodeID storj.NodeID
+	if value := r.URL.Query().Get("node"); value != "" {
+		nodeID, err = storj.NodeIDFromString(value)
+		if err != nil {
+			sendJSONError(w, "node is not a valid node ID", "", http.StatusBadRequest)
+			return
+		}
+	}
+
+	jobs, err := server.db.ReverifyQueue().List(ctx, nodeID, limit)
+	if err != nil {
+		sendJSONError(w, "unable to list reverification jobs", err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	data, err := json.Marshal(struct {
+		Jobs []reverificationJob `json:"jobs"`
+	}{
+		Jobs: server.convertReverificationJobs(jobs),
+	})
+	if err != nil {
+		sendJSONError(w, "json encoding failed", err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	sendJSONData(w, http.StatusOK, data)
+}
+
+func (server *Server) deleteReverification(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	vars := mux.Vars(r)
+	nodeID, err := validateNodeIDPathParameter(vars)
+	if err != nil {
+		sendJSONError(w, err.Error(), "", http.StatusBadRequest)
+		return
+	}
+	streamID, position, err := validateRepairSegmentPathParameters(vars)
+	if err != nil {
+		sendJSONError(w, err.Error(), "", http.StatusBadRequest)
+		return
+	}
+
+	deleted, err := server.db.ReverifyQueue().Remove(ctx, audit.PieceLocator{
+		StreamID: streamID,
+		Position: position,
+		NodeID:   nodeID,
+	})
+	if err != nil {
+		sendJSONError(w, "unable to delete reverification job", err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if !deleted {
+		sendJSONError(w, "reverification job not found", "", http.StatusNotFound)
+		return
+	}
+}
@@ -0,0 +1,40 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package admin
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"storj.io/common/testrand"
+)
+
+func TestParseListLimit(t *testing.T) {
+	limit, err := parseListLimit("")
+	require.NoError(t, err)
+	require.Equal(t, defaultContainmentListLimit, limit)
+
+	limit, err = parseListLimit("5")
+	require.NoError(t, err)
+	require.Equal(t, 5, limit)
+
+	for _, value := range []string{"0", "-1", "many"} {
+		_, err := parseListLimit(value)
+		require.Error(t, err, value)
+	}
+}
+
+func TestValidateNodeIDPathParameter(t *testing.T) {
+	nodeID := testrand.NodeID()
+
+	got, err := validateNodeIDPathParameter(map[string]string{"nodeid": nodeID.String()})
+	require.NoError(t, err)
+	require.Equal(t, nodeID, got)
+
+	_, err = validateNodeIDPathParameter(map[string]string{})
+	require.Error(t, err)
+	_, err = validateNodeIDPathParameter(map[string]string{"nodeid": "invalid"})
+	require.Error(t, err)
+}
@@ -0,0 +1,91 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package admin_test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"storj.io/common/pkcrypto"
+	"storj.io/common/testcontext"
+	"storj.io/common/testrand"
+	"storj.io/storj/private/testplanet"
+	"storj.io/storj/satellite"
+	"storj.io/storj/satellite/audit"
+	"storj.io/storj/satellite/metabase"
+)
+
+func TestAdminContainmentAPI(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount:   1,
+		StorageNodeCount: 0,
+		UplinkCount:      0,
+		Reconfigure: testplanet.Reconfigure{
+			Satellite: func(_ *zap.Logger, _ int, config *satellite.Config) {
+				config.Admin.Address = "127.0.0.1:0"
+			},
+		},
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		sat := planet.Satellites[0]
+		address := sat.Admin.Admin.Listener.Addr()
+		authToken := sat.Config.Console.AuthToken
+		containment := sat.DB.Containment()
+		reverifyQueue := sat.DB.ReverifyQueue()
+
+		pending := &audit.PendingAudit{
+			NodeID:            testrand.NodeID(),
+			PieceID:           testrand.PieceID(),
+			StreamID:          testrand.UUID(),
+			ExpectedShareHash: pkcrypto.SHA256Hash(testrand.Bytes(10)),
+		}
+		require.NoError(t, containment.IncrementPending(ctx, pending))
+		require.NoError(t, containment.IncrementPending(ctx, pending))
+
+		locator := audit.PieceLocator{
+			StreamID: testrand.UUID(),
+			Position: metabase.SegmentPosition{Part: 1, Index: 2},
+			NodeID:   pending.NodeID,
+			PieceNum: 3,
+		}
+		require.NoError(t, reverifyQueue.Insert(ctx, locator))
+
+		containmentURL := fmt.Sprintf("http://%s/api/audit/containment", address)
+		nodeURL := fmt.Sprintf("%s/%s", containmentURL, pending.NodeID)
+		reverificationsURL := fmt.Sprintf("http://%s/api/audit/reverifications", address)
+		jobURL := fmt.Sprintf("%s/%s/%s/%d", reverificationsURL, locator.NodeID, locator.StreamID, locator.Position.Encode())
+
+		body := assertReq(ctx, t, containmentURL, http.MethodGet, "", http.StatusOK, "", authToken)
+		require.Contains(t, string(body), fmt.Sprintf(`"nodeId":"%s"`, pending.NodeID))
+		require.Contains(t, string(body), `"reverifyCount":1`)
+		assertReq(ctx, t, containmentURL+"?limit=0", http.MethodGet, "", http.StatusBadRequest, "", authToken)
+
+		body = assertReq(ctx, t, nodeURL, http.MethodGet, "", http.StatusOK, "", authToken)
+		require.Contains(t, string(body), fmt.Sprintf(`"pieceId":"%s"`, pending.PieceID))
+		require.Contains(t, string(body), fmt.Sprintf(`"streamId":"%s"`, locator.StreamID))
+		require.Contains(t, string(body), `"lastAttempt":null`)
+
+		body = assertReq(ctx, t, reverificationsURL+"?node="+testrand.NodeID().String(), http.MethodGet, "", http.StatusOK, "", authToken)
+		require.JSONEq(t, `{"jobs":[]}`, string(body))
+		body = assertReq(ctx, t, reverificationsURL, http.MethodGet, "", http.StatusOK, "", authToken)
+		require.Contains(t, string(body), `"pieceNum":3`)
+
+		assertReq(ctx, t, nodeURL, http.MethodDelete, "", http.StatusOK, "", authToken)
+		assertReq(ctx, t, nodeURL, http.MethodDelete, "", http.StatusNotFound, "", authToken)
+		_, err := containment.Get(ctx, pending.NodeID)
+		require.True(t, audit.ErrContainedNotFound.Has(err))
+
+		// the node still has a reverification job.
+		body = assertReq(ctx, t, nodeURL, http.MethodGet, "", http.StatusOK, "", authToken)
+		require.Contains(t, string(body), `"pendingAudit":null`)
+
+		assertReq(ctx, t, jobURL, http.MethodDelete, "", http.StatusOK, "", authToken)
+		assertReq(ctx, t, jobURL, http.MethodDelete, "", http.StatusNotFound, "", authToken)
+		assertReq(ctx, t, nodeURL, http.MethodGet, "", http.StatusNotFound, "", authToken)
+		assertReq(ctx, t, containmentURL+"/invalid", http.MethodGet, "", http.StatusBadRequest, "", authToken)
+	})
+}
@@ -0,0 +1,41 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package admin
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"storj.io/storj/satellite/overlay"
+)
+
+func (server *Server) getDisqualificationExplanation(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	nodeID, err := validateNodeIDPathParameter(mux.Vars(r))
+	if err != nil {
+		sendJSONError(w, err.Error(), "", http.StatusBadRequest)
+		return
+	}
+
+	explanation, err := server.db.OverlayCache().GetDisqualificationExplanation(ctx, nodeID)
+	if err != nil {
+		if overlay.ErrExplanationNotFound.Has(err) {
+			sendJSONError(w, "disqualification explanation not found", "", http.StatusNotFound)
+			return
+		}
+		sendJSONError(w, "unable to get disqualification explanation", err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	data, err := json.Marshal(explanation)
+	if err != nil {
+		sendJSONError(w, "json encoding failed", err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	sendJSONData(w, http.StatusOK, data)
+}
@@ -0,0 +1,47 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package admin_test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"storj.io/common/testcontext"
+	"storj.io/storj/private/testplanet"
+	"storj.io/storj/satellite"
+	"storj.io/storj/satellite/overlay"
+)
+
+func TestAdminDisqualificationAPI(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount:   1,
+		StorageNodeCount: 1,
+		UplinkCount:      0,
+		Reconfigure: testplanet.Reconfigure{
+			Satellite: func(_ *zap.Logger, _ int, config *satellite.Config) {
+				config.Admin.Address = "127.0.0.1:0"
+			},
+		},
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		sat := planet.Satellites[0]
+		address := sat.Admin.Admin.Listener.Addr()
+		authToken := sat.Config.Console.AuthToken
+		nodeID := planet.StorageNodes[0].ID()
+
+		link := fmt.Sprintf("http://%s/api/nodes/%s/disqualification", address, nodeID)
+
+		assertReq(ctx, t, link, http.MethodGet, "", http.StatusNotFound, "", authToken)
+		assertReq(ctx, t, fmt.Sprintf("http://%s/api/nodes/invalid/disqualification", address), http.MethodGet, "", http.StatusBadRequest, "", authToken)
+
+		require.NoError(t, sat.Reputation.Service.TestDisqualifyNode(ctx, nodeID, overlay.DisqualificationReasonNodeOffline))
+
+		body := assertReq(ctx, t, link, http.MethodGet, "", http.StatusOK, "", authToken)
+		require.Contains(t, string(body), fmt.Sprintf(`"nodeId":"%s"`, nodeID))
+		require.Contains(t, string(body), fmt.Sprintf(`"reason":%d`, overlay.DisqualificationReasonNodeOffline))
+	})
+}
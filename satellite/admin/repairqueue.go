@@ -0,0 +1,210 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package admin
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"storj.io/common/uuid"
+	"storj.io/storj/satellite/metabase"
+	"storj.io/storj/satellite/repair/queue"
+)
+
+// defaultHealthBoundaries are the boundaries of the health buckets when the
+// request doesn't specify them.
+var defaultHealthBoundaries = []float64{0, 1, 10, 100, 1000}
+
+func validateRepairSegmentPathParameters(vars map[string]string) (streamID uuid.UUID, position metabase.SegmentPosition, err error) {
+	streamIDString, ok := vars["streamid"]
+	if !ok {
+		return streamID, position, fmt.Errorf("stream-id missing")
+	}
+
+	streamID, err = uuid.FromString(streamIDString)
+	if err != nil {
+		return streamID, position, fmt.Errorf("stream-id is not a valid uuid")
+	}
+
+	positionString, ok := vars["position"]
+	if !ok {
+		return streamID, position, fmt.Errorf("position missing")
+	}
+
+	encoded, err := strconv.ParseUint(positionString, 10, 64)
+	if err != nil {
+		return streamID, position, fmt.Errorf("position is not a valid encoded segment position")
+	}
+
+	return streamID, metabase.SegmentPositionFromEncoded(encoded), nil
+}
+
+func parseHealthBoundaries(value string) ([]float64, error) {
+	if value == "" {
+		return defaultHealthBoundaries, nil
+	}
+
+	var boundaries []float64
+	for _, s := range strings.Split(value, ",") {
+		boundary, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid health boundary %q", s)
+		}
+		boundaries = append(boundaries, boundary)
+	}
+	if !sort.Float64sAreSorted(boundaries) {
+		return nil, fmt.Errorf("health boundaries must be in ascending order")
+	}
+	return boundaries, nil
+}
+
+func (server *Server) repairQueueStats(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	boundaries, err := parseHealthBoundaries(r.URL.Query().Get("boundaries"))
+	if err != nil {
+		sendJSONError(w, err.Error(), "", http.StatusBadRequest)
+		return
+	}
+
+	counts, err := server.db.RepairQueue().CountByHealth(ctx, boundaries)
+	if err != nil {
+		sendJSONError(w, "unable to count repair queue segments", err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	type healthBucket struct {
+		// Min and Max are nil for the unbounded sides of the first and last bucket.
+		Min   *float64 `json:"min"`
+		Max   *float64 `json:"max"`
+		Count int      `json:"count"`
+	}
+
+	var output struct {
+		Count   int            `json:"count"`
+		Buckets []healthBucket `json:"buckets"`
+	}
+	for i, count := range counts {
+		var bucket healthBucket
+		if i > 0 {
+			bucket.Min = &boundaries[i-1]
+		}
+		if i < len(boundaries) {
+			bucket.Max = &boundaries[i]
+		}
+		bucket.Count = count
+
+		output.Count += count
+		output.Buckets = append(output.Buckets, bucket)
+	}
+
+	data, err := json.Marshal(output)
+	if err != nil {
+		sendJSONError(w, "json encoding failed", err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	sendJSONData(w, http.StatusOK, data)
+}
+
+func (server *Server) getRepairSegment(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	streamID, position, err := validateRepairSegmentPathParameters(mux.Vars(r))
+	if err != nil {
+		sendJSONError(w, err.Error(), "", http.StatusBadRequest)
+		return
+	}
+
+	segment, err := server.db.RepairQueue().Get(ctx, streamID, position)
+	if err != nil {
+		if queue.ErrSegmentNotFound.Has(err) {
+			sendJSONError(w, "segment is not in the repair queue", "", http.StatusNotFound)
+		} else {
+			sendJSONError(w, "unable to get repair queue segment", err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	data, err := json.Marshal(struct {
+		StreamID      uuid.UUID  `json:"streamId"`
+		Position      uint64     `json:"position"`
+		SegmentHealth float64    `json:"segmentHealth"`
+		AttemptedAt   *time.Time `json:"attemptedAt"`
+		UpdatedAt     time.Time  `json:"updatedAt"`
+		InsertedAt    time.Time  `json:"insertedAt"`
+		Prioritized   bool       `json:"prioritized"`
+		DoNotRepair   bool       `json:"doNotRepair"`
+	}{
+		StreamID:      segment.StreamID,
+		Position:      segment.Position.Encode(),
+		SegmentHealth: segment.SegmentHealth,
+		AttemptedAt:   segment.AttemptedAt,
+		UpdatedAt:     segment.UpdatedAt,
+		InsertedAt:    segment.InsertedAt,
+		Prioritized:   segment.Prioritized,
+		DoNotRepair:   segment.DoNotRepair,
+	})
+	if err != nil {
+		sendJSONError(w, "json encoding failed", err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	sendJSONData(w, http.StatusOK, data)
+}
+
+func (server *Server) prioritizeRepairSegment(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	streamID, position, err := validateRepairSegmentPathParameters(mux.Vars(r))
+	if err != nil {
+		sendJSONError(w, err.Error(), "", http.StatusBadRequest)
+		return
+	}
+
+	err = server.db.RepairQueue().Prioritize(ctx, streamID, position)
+	if err != nil {
+		if queue.ErrSegmentNotFound.Has(err) {
+			sendJSONError(w, "segment is not in the repair queue", "", http.StatusNotFound)
+		} else {
+			sendJSONError(w, "unable to prioritize repair queue segment", err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+}
+
+func (server *Server) pinRepairSegment(w http.ResponseWriter, r *http.Request) {
+	server.setRepairSegmentDoNotRepair(w, r, true)
+}
+
+func (server *Server) unpinRepairSegment(w http.ResponseWriter, r *http.Request) {
+	server.setRepairSegmentDoNotRepair(w, r, false)
+}
+
+func (server *Server) setRepairSegmentDoNotRepair(w http.ResponseWriter, r *http.Request, doNotRepair bool) {
+	ctx := r.Context()
+
+	streamID, position, err := validateRepairSegmentPathParameters(mux.Vars(r))
+	if err != nil {
+		sendJSONError(w, err.Error(), "", http.StatusBadRequest)
+		return
+	}
+
+	err = server.db.RepairQueue().SetDoNotRepair(ctx, streamID, position, doNotRepair)
+	if err != nil {
+		if queue.ErrSegmentNotFound.Has(err) {
+			sendJSONError(w, "segment is not in the repair queue", "", http.StatusNotFound)
+		} else {
+			sendJSONError(w, "unable to update repair queue segment", err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+}
@@ -0,0 +1,53 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package admin
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"storj.io/common/testrand"
+	"storj.io/storj/satellite/metabase"
+)
+
+func TestValidateRepairSegmentPathParameters(t *testing.T) {
+	streamID := testrand.UUID()
+	position := metabase.SegmentPosition{Part: 3, Index: 7}
+
+	gotStreamID, gotPosition, err := validateRepairSegmentPathParameters(map[string]string{
+		"streamid": streamID.String(),
+		"position": strconv.FormatUint(position.Encode(), 10),
+	})
+	require.NoError(t, err)
+	require.Equal(t, streamID, gotStreamID)
+	require.Equal(t, position, gotPosition)
+
+	for _, vars := range []map[string]string{
+		{},
+		{"streamid": "invalid", "position": "0"},
+		{"streamid": streamID.String()},
+		{"streamid": streamID.String(), "position": "-1"},
+	} {
+		_, _, err := validateRepairSegmentPathParameters(vars)
+		require.Error(t, err, vars)
+	}
+}
+
+func TestParseHealthBoundaries(t *testing.T) {
+	boundaries, err := parseHealthBoundaries("")
+	require.NoError(t, err)
+	require.Equal(t, defaultHealthBoundaries, boundaries)
+
+	boundaries, err = parseHealthBoundaries("-1, 0.5,10")
+	require.NoError(t, err)
+	require.Equal(t, []float64{-1, 0.5, 10}, boundaries)
+
+	_, err = parseHealthBoundaries("1,x")
+	require.Error(t, err)
+
+	_, err = parseHealthBoundaries("10,1")
+	require.Error(t, err)
+}
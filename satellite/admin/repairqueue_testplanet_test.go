@@ -0,0 +1,81 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package admin_test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"storj.io/common/testcontext"
+	"storj.io/common/testrand"
+	"storj.io/storj/private/testplanet"
+	"storj.io/storj/satellite"
+	"storj.io/storj/satellite/metabase"
+	"storj.io/storj/satellite/repair/queue"
+)
+
+func TestAdminRepairQueueAPI(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount:   1,
+		StorageNodeCount: 0,
+		UplinkCount:      0,
+		Reconfigure: testplanet.Reconfigure{
+			Satellite: func(_ *zap.Logger, _ int, config *satellite.Config) {
+				config.Admin.Address = "127.0.0.1:0"
+			},
+		},
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		sat := planet.Satellites[0]
+		address := sat.Admin.Admin.Listener.Addr()
+		authToken := sat.Config.Console.AuthToken
+		repairQueue := sat.DB.RepairQueue()
+
+		segment := &queue.InjuredSegment{
+			StreamID:      testrand.UUID(),
+			Position:      metabase.SegmentPosition{Part: 1, Index: 2},
+			SegmentHealth: 5,
+		}
+		_, err := repairQueue.Insert(ctx, segment)
+		require.NoError(t, err)
+		_, err = repairQueue.Insert(ctx, &queue.InjuredSegment{
+			StreamID:      testrand.UUID(),
+			SegmentHealth: 50,
+		})
+		require.NoError(t, err)
+
+		queueURL := fmt.Sprintf("http://%s/api/repair/queue", address)
+		segmentURL := fmt.Sprintf("%s/%s/%d", queueURL, segment.StreamID, segment.Position.Encode())
+
+		assertGet(ctx, t, queueURL+"?boundaries=10",
+			`{"count":2,"buckets":[{"min":null,"max":10,"count":1},{"min":10,"max":null,"count":1}]}`, authToken)
+		assertReq(ctx, t, queueURL+"?boundaries=10,1", http.MethodGet, "", http.StatusBadRequest, "", authToken)
+
+		assertReq(ctx, t, segmentURL+"/prioritize", http.MethodPost, "", http.StatusOK, "", authToken)
+		assertReq(ctx, t, segmentURL+"/do-not-repair", http.MethodPut, "", http.StatusOK, "", authToken)
+
+		got, err := repairQueue.Get(ctx, segment.StreamID, segment.Position)
+		require.NoError(t, err)
+		require.True(t, got.Prioritized)
+		require.True(t, got.DoNotRepair)
+		require.Equal(t, float64(queue.PrioritizedSegmentHealth), got.SegmentHealth)
+
+		body := assertReq(ctx, t, segmentURL, http.MethodGet, "", http.StatusOK, "", authToken)
+		require.Contains(t, string(body), `"prioritized":true`)
+		require.Contains(t, string(body), `"doNotRepair":true`)
+
+		assertReq(ctx, t, segmentURL+"/do-not-repair", http.MethodDelete, "", http.StatusOK, "", authToken)
+		got, err = repairQueue.Get(ctx, segment.StreamID, segment.Position)
+		require.NoError(t, err)
+		require.False(t, got.DoNotRepair)
+
+		missingURL := fmt.Sprintf("%s/%s/0", queueURL, testrand.UUID())
+		assertReq(ctx, t, missingURL, http.MethodGet, "", http.StatusNotFound, "", authToken)
+		assertReq(ctx, t, missingURL+"/prioritize", http.MethodPost, "", http.StatusNotFound, "", authToken)
+		assertReq(ctx, t, queueURL+"/invalid/0", http.MethodGet, "", http.StatusBadRequest, "", authToken)
+	})
+}
@@ -19,13 +19,16 @@ import (
 	"storj.io/common/errs2"
 	"storj.io/storj/satellite/accounting"
 	adminui "storj.io/storj/satellite/admin/ui"
+	"storj.io/storj/satellite/audit"
 	"storj.io/storj/satellite/buckets"
 	"storj.io/storj/satellite/console"
 	"storj.io/storj/satellite/console/consoleweb"
 	"storj.io/storj/satellite/console/restkeys"
 	"storj.io/storj/satellite/oidc"
+	"storj.io/storj/satellite/overlay"
 	"storj.io/storj/satellite/payments"
 	"storj.io/storj/satellite/payments/stripecoinpayments"
+	"storj.io/storj/satellite/repair/queue"
 )
 
 // Config defines configuration for debug server.
@@ -46,6 +49,14 @@ type DB interface {
 	OIDC() oidc.DB
 	// StripeCoinPayments returns database for satellite stripe coin payments
 	StripeCoinPayments() stripecoinpayments.DB
+	// RepairQueue returns the queue of the segments which need repair.
+	RepairQueue() queue.RepairQueue
+	// Containment returns the database for the pending audits of contained nodes.
+	Containment() audit.Containment
+	// ReverifyQueue returns the queue of the pieces which need reverification.
+	ReverifyQueue() audit.ReverifyQueue
+	// OverlayCache returns the database for the storage nodes.
+	OverlayCache() overlay.DB
 }
 
 // Server provides endpoints for administrative tasks.
@@ -111,9 +122,24 @@ func NewServer(log *zap.Logger, listener net.Listener, db DB, buckets *buckets.S
 	api.HandleFunc("/projects/{project}/buckets/{bucket}", server.getBucketInfo).Methods("GET")
 	api.HandleFunc("/projects/{project}/buckets/{bucket}/geofence", server.createGeofenceForBucket).Methods("POST")
 	api.HandleFunc("/projects/{project}/buckets/{bucket}/geofence", server.deleteGeofenceForBucket).Methods("DELETE")
+	api.HandleFunc("/projects/{project}/buckets/{bucket}/versioning", server.getBucketVersioning).Methods("GET")
+	api.HandleFunc("/projects/{project}/buckets/{bucket}/versioning", server.enableBucketVersioning).Methods("POST")
+	api.HandleFunc("/projects/{project}/buckets/{bucket}/lifecycle", server.getBucketLifecycle).Methods("GET")
+	api.HandleFunc("/projects/{project}/buckets/{bucket}/lifecycle", server.setBucketLifecycle).Methods("PUT")
 	api.HandleFunc("/apikeys/{apikey}", server.deleteAPIKey).Methods("DELETE")
 	api.HandleFunc("/restkeys/{useremail}", server.addRESTKey).Methods("POST")
 	api.HandleFunc("/restkeys/{apikey}/revoke", server.revokeRESTKey).Methods("PUT")
+	api.HandleFunc("/repair/queue", server.repairQueueStats).Methods("GET")
+	api.HandleFunc("/repair/queue/{streamid}/{position}", server.getRepairSegment).Methods("GET")
+	api.HandleFunc("/repair/queue/{streamid}/{position}/prioritize", server.prioritizeRepairSegment).Methods("POST")
+	api.HandleFunc("/repair/queue/{streamid}/{position}/do-not-repair", server.pinRepairSegment).Methods("PUT")
+	api.HandleFunc("/repair/queue/{streamid}/{position}/do-not-repair", server.unpinRepairSegment).Methods("DELETE")
+	api.HandleFunc("/audit/containment", server.listContainedNodes).Methods("GET")
+	api.HandleFunc("/audit/containment/{nodeid}", server.getContainedNode).Methods("GET")
+	api.HandleFunc("/audit/containment/{nodeid}", server.deleteContainedNode).Methods("DELETE")
+	api.HandleFunc("/audit/reverifications", server.listReverifications).Methods("GET")
+	api.HandleFunc("/audit/reverifications/{nodeid}/{streamid}/{position}", server.deleteReverification).Methods("DELETE")
+	api.HandleFunc("/nodes/{nodeid}/disqualification", server.getDisqualificationExplanation).Methods("GET")
 
 	// This handler must be the last one because it uses the root as prefix,
 	// otherwise will try to serve all the handlers set after this one.
@@ -6,10 +6,12 @@ package audit
 import (
 	"context"
 	"math/rand"
+	"sort"
 	"time"
 
 	"go.uber.org/zap"
 
+	"storj.io/common/storj"
 	"storj.io/common/sync2"
 	"storj.io/common/uuid"
 	"storj.io/storj/satellite/metabase/segmentloop"
@@ -25,11 +27,12 @@ type Chore struct {
 	Loop  *sync2.Cycle
 
 	segmentLoop *segmentloop.Service
+	auditStats  AuditStatsDB
 	config      Config
 }
 
 // NewChore instantiates Chore.
-func NewChore(log *zap.Logger, queue VerifyQueue, loop *segmentloop.Service, config Config) *Chore {
+func NewChore(log *zap.Logger, queue VerifyQueue, loop *segmentloop.Service, auditStats AuditStatsDB, config Config) *Chore {
 	if config.VerificationPushBatchSize < 1 {
 		config.VerificationPushBatchSize = 1
 	}
@@ -40,6 +43,7 @@ func NewChore(log *zap.Logger, queue VerifyQueue, loop *segmentloop.Service, con
 		Loop:  sync2.NewCycle(config.ChoreInterval),
 
 		segmentLoop: loop,
+		auditStats:  auditStats,
 		config:      config,
 	}
 }
@@ -51,46 +55,90 @@ func (chore *Chore) Run(ctx context.Context) (err error) {
 		defer mon.Task()(&ctx)(&err)
 
 		collector := NewCollector(chore.config.Slots, chore.rand)
+		if chore.config.Weighting.Enabled {
+			nodeSlots, err := LoadNodeSlots(ctx, chore.auditStats, chore.config, chore.rand, time.Now())
+			if err != nil {
+				chore.log.Error("error loading audit statistics, not weighting the reservoirs", zap.Error(err))
+			} else {
+				collector = NewWeightedCollector(nodeSlots, chore.rand)
+			}
+		}
+
 		err = chore.segmentLoop.Join(ctx, collector)
 		if err != nil {
 			chore.log.Error("error joining segmentloop", zap.Error(err))
 			return nil
 		}
 
-		type SegmentKey struct {
-			StreamID uuid.UUID
-			Position uint64
+		newQueue := createAuditQueue(collector.Reservoirs)
+
+		// Push new queue to queues struct so it can be fetched by worker.
+		return chore.queue.Push(ctx, newQueue, chore.config.VerificationPushBatchSize)
+	})
+}
+
+// createAuditQueue picks segments from the reservoirs in pseudorandom order.
+//
+// The segments of a reservoir are spread evenly over the queue relative to its
+// number of slots, so that the nodes with larger reservoirs are audited more
+// frequently, rather than only at the end of the queue.
+func createAuditQueue(reservoirs map[storj.NodeID]*Reservoir) []Segment {
+	type SegmentKey struct {
+		StreamID uuid.UUID
+		Position uint64
+	}
+
+	type queuedSegment struct {
+		segment  *segmentloop.Segment
+		position float64
+	}
+
+	maxSlots := 0
+	for _, res := range reservoirs {
+		if len(res.Segments) > maxSlots {
+			maxSlots = len(res.Segments)
 		}
+	}
+
+	var queued []queuedSegment
 
-		var newQueue []Segment
-		queueSegments := make(map[SegmentKey]struct{})
-
-		// Add reservoir segments to queue in pseudorandom order.
-		for i := 0; i < chore.config.Slots; i++ {
-			for _, res := range collector.Reservoirs {
-				// Skip reservoir if no segment at this index.
-				if len(res.Segments) <= i {
-					continue
-				}
-				segment := res.Segments[i]
-				segmentKey := SegmentKey{
-					StreamID: segment.StreamID,
-					Position: segment.Position.Encode(),
-				}
-				if segmentKey == (SegmentKey{}) {
-					continue
-				}
-
-				if _, ok := queueSegments[segmentKey]; !ok {
-					newQueue = append(newQueue, NewSegment(segment))
-					queueSegments[segmentKey] = struct{}{}
-				}
+	// Add reservoir segments to queue in pseudorandom order.
+	for i := 0; i < maxSlots; i++ {
+		for _, res := range reservoirs {
+			// Skip reservoir if no segment at this index.
+			if len(res.Segments) <= i {
+				continue
 			}
+			queued = append(queued, queuedSegment{
+				segment:  &res.Segments[i],
+				position: float64(i) / float64(len(res.Segments)),
+			})
 		}
+	}
 
-		// Push new queue to queues struct so it can be fetched by worker.
-		return chore.queue.Push(ctx, newQueue, chore.config.VerificationPushBatchSize)
+	sort.SliceStable(queued, func(a, b int) bool {
+		return queued[a].position < queued[b].position
 	})
+
+	var newQueue []Segment
+	queueSegments := make(map[SegmentKey]struct{})
+
+	for _, item := range queued {
+		segmentKey := SegmentKey{
+			StreamID: item.segment.StreamID,
+			Position: item.segment.Position.Encode(),
+		}
+		if segmentKey == (SegmentKey{}) {
+			continue
+		}
+
+		if _, ok := queueSegments[segmentKey]; !ok {
+			newQueue = append(newQueue, NewSegment(*item.segment))
+			queueSegments[segmentKey] = struct{}{}
+		}
+	}
+
+	return newQueue
 }
 
 // Close closes chore.
@@ -17,6 +17,7 @@ var _ segmentloop.Observer = (*Collector)(nil)
 type Collector struct {
 	Reservoirs map[storj.NodeID]*Reservoir
 	slotCount  int
+	nodeSlots  *NodeSlots
 	rand       *rand.Rand
 }
 
@@ -29,6 +30,16 @@ func NewCollector(reservoirSlots int, r *rand.Rand) *Collector {
 	}
 }
 
+// NewWeightedCollector instantiates a segment collector, which creates the reservoirs
+// with the number of slots of the nodes.
+func NewWeightedCollector(nodeSlots *NodeSlots, r *rand.Rand) *Collector {
+	return &Collector{
+		Reservoirs: make(map[storj.NodeID]*Reservoir),
+		nodeSlots:  nodeSlots,
+		rand:       r,
+	}
+}
+
 // LoopStarted is called at each start of a loop.
 func (collector *Collector) LoopStarted(context.Context, segmentloop.LoopInfo) (err error) {
 	return nil
@@ -41,7 +52,11 @@ func (collector *Collector) RemoteSegment(ctx context.Context, segment *segmentl
 	for _, piece := range segment.Pieces {
 		res, ok := collector.Reservoirs[piece.StorageNode]
 		if !ok {
-			res = NewReservoir(collector.slotCount)
+			if collector.nodeSlots != nil {
+				res = newReservoir(collector.nodeSlots.Slots(piece.StorageNode))
+			} else {
+				res = NewReservoir(collector.slotCount)
+			}
 			collector.Reservoirs[piece.StorageNode] = res
 		}
 		res.Sample(collector.rand, segment)
@@ -49,6 +64,19 @@ func (collector *Collector) RemoteSegment(ctx context.Context, segment *segmentl
 	return nil
 }
 
+// Process is called repeatedly with batches of segments by the ranged loop.
+func (collector *Collector) Process(ctx context.Context, segments []segmentloop.Segment) (err error) {
+	for i := range segments {
+		if segments[i].Inline() {
+			continue
+		}
+		if err := collector.RemoteSegment(ctx, &segments[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // InlineSegment returns nil because we're only auditing for storage nodes for now.
 func (collector *Collector) InlineSegment(ctx context.Context, segment *segmentloop.Segment) (err error) {
 	return nil
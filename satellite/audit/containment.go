@@ -5,6 +5,7 @@ package audit
 
 import (
 	"context"
+	"time"
 
 	"github.com/zeebo/errs"
 
@@ -37,6 +38,13 @@ type PendingAudit struct {
 	Position          metabase.SegmentPosition
 }
 
+// ContainedNode contains the pending audit of a contained node and when the node
+// was contained.
+type ContainedNode struct {
+	PendingAudit PendingAudit
+	InsertedAt   time.Time
+}
+
 // Containment holds information about pending audits for contained nodes.
 //
 // architecture: Database
@@ -44,4 +52,9 @@ type Containment interface {
 	Get(ctx context.Context, nodeID pb.NodeID) (*PendingAudit, error)
 	IncrementPending(ctx context.Context, pendingAudit *PendingAudit) error
 	Delete(ctx context.Context, nodeID pb.NodeID) (bool, error)
+
+	// GetContained returns the pending audit of the node together with when the node was contained.
+	GetContained(ctx context.Context, nodeID pb.NodeID) (*ContainedNode, error)
+	// ListContained returns the contained nodes, the longest contained first.
+	ListContained(ctx context.Context, limit int) ([]ContainedNode, error)
 }
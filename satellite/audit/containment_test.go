@@ -44,6 +44,48 @@ func TestContainIncrementAndGet(t *testing.T) {
 	})
 }
 
+func TestContainListContained(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount: 1, StorageNodeCount: 2,
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		containment := planet.Satellites[0].DB.Containment()
+
+		contained, err := containment.ListContained(ctx, 10)
+		require.NoError(t, err)
+		require.Empty(t, contained)
+
+		var pending []*audit.PendingAudit
+		for _, node := range planet.StorageNodes {
+			input := &audit.PendingAudit{
+				NodeID:            node.ID(),
+				ExpectedShareHash: pkcrypto.SHA256Hash(testrand.Bytes(10)),
+			}
+			require.NoError(t, containment.IncrementPending(ctx, input))
+			pending = append(pending, input)
+		}
+
+		contained, err = containment.ListContained(ctx, 10)
+		require.NoError(t, err)
+		require.Len(t, contained, 2)
+		for i, node := range contained {
+			assert.Equal(t, *pending[i], node.PendingAudit)
+			assert.False(t, node.InsertedAt.IsZero())
+		}
+		assert.False(t, contained[1].InsertedAt.Before(contained[0].InsertedAt))
+
+		contained, err = containment.ListContained(ctx, 1)
+		require.NoError(t, err)
+		require.Len(t, contained, 1)
+
+		node, err := containment.GetContained(ctx, pending[1].NodeID)
+		require.NoError(t, err)
+		assert.Equal(t, *pending[1], node.PendingAudit)
+
+		_, err = containment.GetContained(ctx, testrand.NodeID())
+		assert.True(t, audit.ErrContainedNotFound.Has(err))
+	})
+}
+
 func TestContainIncrementPendingEntryExists(t *testing.T) {
 	testplanet.Run(t, testplanet.Config{
 		SatelliteCount: 1, StorageNodeCount: 1,
@@ -0,0 +1,118 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package audit
+
+import (
+	"context"
+	"math/rand"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"storj.io/common/storj"
+	"storj.io/storj/satellite/metabase"
+	"storj.io/storj/satellite/metabase/rangedloop"
+)
+
+var _ rangedloop.Observer = (*RangedLoopObserver)(nil)
+
+// RangedLoopObserver populates reservoirs and the audit queue using the ranged loop.
+//
+// architecture: Observer
+type RangedLoopObserver struct {
+	log        *zap.Logger
+	queue      VerifyQueue
+	auditStats AuditStatsDB
+	config     Config
+
+	mu         sync.Mutex
+	rand       *rand.Rand
+	reservoirs map[storj.NodeID]*Reservoir
+	nodeSlots  *NodeSlots
+}
+
+// NewRangedLoopObserver instantiates RangedLoopObserver.
+func NewRangedLoopObserver(log *zap.Logger, queue VerifyQueue, auditStats AuditStatsDB, config Config) *RangedLoopObserver {
+	if config.VerificationPushBatchSize < 1 {
+		config.VerificationPushBatchSize = 1
+	}
+	return &RangedLoopObserver{
+		log:        log,
+		queue:      queue,
+		auditStats: auditStats,
+		config:     config,
+		rand:       rand.New(rand.NewSource(time.Now().Unix())),
+	}
+}
+
+// Start prepares the reservoirs for a new iteration.
+func (observer *RangedLoopObserver) Start(ctx context.Context, startTime time.Time, aliasMap metabase.NodeAliasMap) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	observer.mu.Lock()
+	defer observer.mu.Unlock()
+
+	observer.reservoirs = make(map[storj.NodeID]*Reservoir)
+	observer.nodeSlots = nil
+
+	if observer.config.Weighting.Enabled {
+		nodeSlots, err := LoadNodeSlots(ctx, observer.auditStats, observer.config, observer.rand, startTime)
+		if err != nil {
+			observer.log.Error("error loading audit statistics, not weighting the reservoirs", zap.Error(err))
+			return nil
+		}
+		observer.nodeSlots = nodeSlots
+	}
+	return nil
+}
+
+// Fork creates a Collector to sample a chunk of all the segments.
+func (observer *RangedLoopObserver) Fork(ctx context.Context) (_ rangedloop.Partial, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	observer.mu.Lock()
+	defer observer.mu.Unlock()
+
+	// each collector needs its own source, since rand.Rand is not safe for concurrent use.
+	r := rand.New(rand.NewSource(observer.rand.Int63()))
+	if observer.nodeSlots != nil {
+		return NewWeightedCollector(observer.nodeSlots, r), nil
+	}
+	return NewCollector(observer.config.Slots, r), nil
+}
+
+// Join merges the reservoirs of the collector.
+func (observer *RangedLoopObserver) Join(ctx context.Context, partial rangedloop.Partial) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	collector, ok := partial.(*Collector)
+	if !ok {
+		return Error.New("expected partial type %T but got %T", collector, partial)
+	}
+
+	observer.mu.Lock()
+	defer observer.mu.Unlock()
+
+	for nodeID, res := range collector.Reservoirs {
+		existing, ok := observer.reservoirs[nodeID]
+		if !ok {
+			observer.reservoirs[nodeID] = res
+			continue
+		}
+		existing.Merge(observer.rand, res)
+	}
+	return nil
+}
+
+// Finish pushes the sampled segments to the verify queue.
+func (observer *RangedLoopObserver) Finish(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	observer.mu.Lock()
+	defer observer.mu.Unlock()
+
+	newQueue := createAuditQueue(observer.reservoirs)
+	return observer.queue.Push(ctx, newQueue, observer.config.VerificationPushBatchSize)
+}
@@ -0,0 +1,95 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package audit_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"storj.io/common/storj"
+	"storj.io/common/testcontext"
+	"storj.io/common/testrand"
+	"storj.io/storj/satellite/audit"
+	"storj.io/storj/satellite/metabase"
+	"storj.io/storj/satellite/metabase/rangedloop"
+	"storj.io/storj/satellite/metabase/segmentloop"
+)
+
+// pushedQueue records the segments pushed to the verify queue.
+type pushedQueue struct {
+	pushed []audit.Segment
+}
+
+func (queue *pushedQueue) Push(ctx context.Context, segments []audit.Segment, maxBatchSize int) error {
+	queue.pushed = append(queue.pushed, segments...)
+	return nil
+}
+
+func (queue *pushedQueue) Next(ctx context.Context) (audit.Segment, error) {
+	return audit.Segment{}, audit.ErrEmptyQueue.New("")
+}
+
+func TestRangedLoopObserver(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	nodes := []storj.NodeID{testrand.NodeID(), testrand.NodeID(), testrand.NodeID()}
+	newSegment := func() segmentloop.Segment {
+		segment := segmentloop.Segment{
+			StreamID:   testrand.UUID(),
+			Redundancy: storj.RedundancyScheme{Algorithm: storj.ReedSolomon, RequiredShares: 1, RepairShares: 2, OptimalShares: 3, TotalShares: 3, ShareSize: 256},
+		}
+		for i, node := range nodes {
+			segment.Pieces = append(segment.Pieces, metabase.Piece{Number: uint16(i), StorageNode: node})
+		}
+		return segment
+	}
+
+	queue := &pushedQueue{}
+	observer := audit.NewRangedLoopObserver(zaptest.NewLogger(t), queue, nil, audit.Config{
+		Slots:                     3,
+		VerificationPushBatchSize: 10,
+	})
+	require.NoError(t, observer.Start(ctx, time.Now(), metabase.NodeAliasMap{}))
+
+	// every range gets its own partial
+	var partials []rangedloop.Partial
+	var expected []audit.Segment
+	for _, remote := range []int{2, 1} {
+		partial, err := observer.Fork(ctx)
+		require.NoError(t, err)
+		partials = append(partials, partial)
+
+		segments := []segmentloop.Segment{{StreamID: testrand.UUID()}}
+		for i := 0; i < remote; i++ {
+			segment := newSegment()
+			segments = append(segments, segment)
+			expected = append(expected, audit.NewSegment(segment))
+		}
+		require.NoError(t, partial.Process(ctx, segments))
+	}
+
+	for _, partial := range partials {
+		require.NoError(t, observer.Join(ctx, partial))
+	}
+	require.NoError(t, observer.Finish(ctx))
+
+	// the reservoirs are large enough for all remote segments, and inline segments are skipped
+	require.ElementsMatch(t, expected, queue.pushed)
+
+	// the next iteration starts with empty reservoirs
+	queue.pushed = nil
+	require.NoError(t, observer.Start(ctx, time.Now(), metabase.NodeAliasMap{}))
+	partial, err := observer.Fork(ctx)
+	require.NoError(t, err)
+	segment := newSegment()
+	require.NoError(t, partial.Process(ctx, []segmentloop.Segment{segment}))
+	require.NoError(t, observer.Join(ctx, partial))
+	require.NoError(t, observer.Finish(ctx))
+	require.Equal(t, []audit.Segment{audit.NewSegment(segment)}, queue.pushed)
+}
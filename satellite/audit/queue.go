@@ -8,6 +8,8 @@ import (
 	"sync"
 
 	"github.com/zeebo/errs"
+
+	"storj.io/common/storj"
 )
 
 // ErrEmptyQueue is used to indicate that the queue is empty.
@@ -148,6 +150,10 @@ type ReverifyQueue interface {
 	Insert(ctx context.Context, piece PieceLocator) (err error)
 	GetNextJob(ctx context.Context) (job ReverificationJob, err error)
 	Remove(ctx context.Context, piece PieceLocator) (wasDeleted bool, err error)
+
+	// List returns the jobs of the node, or of all nodes when nodeID is zero,
+	// the oldest first.
+	List(ctx context.Context, nodeID storj.NodeID, limit int) (jobs []ReverificationJob, err error)
 }
 
 // ByStreamIDAndPosition allows sorting of a slice of segments by stream ID and position.
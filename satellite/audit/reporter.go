@@ -10,6 +10,8 @@ import (
 	"go.uber.org/zap"
 
 	"storj.io/common/storj"
+	"storj.io/common/uuid"
+	"storj.io/storj/satellite/metabase"
 	"storj.io/storj/satellite/overlay"
 	"storj.io/storj/satellite/reputation"
 )
@@ -42,6 +44,8 @@ type Report struct {
 	PendingAudits   []*PendingAudit
 	Unknown         storj.NodeIDList
 	NodesReputation map[storj.NodeID]overlay.ReputationStatus
+	// Segment is the audited segment, when the audits are of a single segment.
+	Segment *Segment
 }
 
 // NewReporter instantiates a reporter.
@@ -78,6 +82,11 @@ func (reporter *reporter) RecordAudits(ctx context.Context, req Report) (_ Repor
 	var errlist errs.Group
 	nodesReputation := req.NodesReputation
 
+	var segment *overlay.AuditedSegment
+	if req.Segment != nil {
+		segment = auditedSegment(req.Segment.StreamID, req.Segment.Position)
+	}
+
 	tries := 0
 	for tries <= reporter.maxRetries {
 		if len(successes) == 0 && len(fails) == 0 && len(unknowns) == 0 && len(offlines) == 0 && len(pendingAudits) == 0 {
@@ -86,13 +95,13 @@ func (reporter *reporter) RecordAudits(ctx context.Context, req Report) (_ Repor
 
 		errlist = errs.Group{}
 
-		successes, err = reporter.recordAuditStatus(ctx, successes, nodesReputation, reputation.AuditSuccess)
+		successes, err = reporter.recordAuditStatus(ctx, successes, segment, nodesReputation, reputation.AuditSuccess)
 		errlist.Add(err)
-		fails, err = reporter.recordAuditStatus(ctx, fails, nodesReputation, reputation.AuditFailure)
+		fails, err = reporter.recordAuditStatus(ctx, fails, segment, nodesReputation, reputation.AuditFailure)
 		errlist.Add(err)
-		unknowns, err = reporter.recordAuditStatus(ctx, unknowns, nodesReputation, reputation.AuditUnknown)
+		unknowns, err = reporter.recordAuditStatus(ctx, unknowns, segment, nodesReputation, reputation.AuditUnknown)
 		errlist.Add(err)
-		offlines, err = reporter.recordAuditStatus(ctx, offlines, nodesReputation, reputation.AuditOffline)
+		offlines, err = reporter.recordAuditStatus(ctx, offlines, segment, nodesReputation, reputation.AuditOffline)
 		errlist.Add(err)
 		pendingAudits, err = reporter.recordPendingAudits(ctx, pendingAudits, nodesReputation)
 		errlist.Add(err)
@@ -113,7 +122,7 @@ func (reporter *reporter) RecordAudits(ctx context.Context, req Report) (_ Repor
 	return Report{}, nil
 }
 
-func (reporter *reporter) recordAuditStatus(ctx context.Context, nodeIDs storj.NodeIDList, nodesReputation map[storj.NodeID]overlay.ReputationStatus, auditOutcome reputation.AuditType) (failed storj.NodeIDList, err error) {
+func (reporter *reporter) recordAuditStatus(ctx context.Context, nodeIDs storj.NodeIDList, segment *overlay.AuditedSegment, nodesReputation map[storj.NodeID]overlay.ReputationStatus, auditOutcome reputation.AuditType) (failed storj.NodeIDList, err error) {
 	defer mon.Task()(&ctx)(&err)
 
 	if len(nodeIDs) == 0 {
@@ -121,7 +130,7 @@ func (reporter *reporter) recordAuditStatus(ctx context.Context, nodeIDs storj.N
 	}
 	var errors errs.Group
 	for _, nodeID := range nodeIDs {
-		err = reporter.reputations.ApplyAudit(ctx, nodeID, nodesReputation[nodeID], auditOutcome)
+		err = reporter.reputations.ApplySegmentAudit(ctx, nodeID, segment, nodesReputation[nodeID], auditOutcome)
 		if err != nil {
 			failed = append(failed, nodeID)
 			errors.Add(Error.New("failed to record audit status %s in overlay for node %s: %w", auditOutcome.String(), nodeID.String(), err))
@@ -148,7 +157,7 @@ func (reporter *reporter) recordPendingAudits(ctx context.Context, pendingAudits
 		} else {
 			// record failure -- max reverify count reached
 			reporter.log.Info("max reverify count reached (audit failed)", zap.Stringer("Node ID", pendingAudit.NodeID))
-			err = reporter.reputations.ApplyAudit(ctx, pendingAudit.NodeID, nodesReputation[pendingAudit.NodeID], reputation.AuditFailure)
+			err = reporter.reputations.ApplySegmentAudit(ctx, pendingAudit.NodeID, auditedSegment(pendingAudit.StreamID, pendingAudit.Position), nodesReputation[pendingAudit.NodeID], reputation.AuditFailure)
 			if err != nil {
 				errlist.Add(err)
 				failed = append(failed, pendingAudit)
@@ -172,3 +181,11 @@ func (reporter *reporter) recordPendingAudits(ctx context.Context, pendingAudits
 	}
 	return nil, nil
 }
+
+// auditedSegment identifies the audited segment for the reputation service.
+func auditedSegment(streamID uuid.UUID, position metabase.SegmentPosition) *overlay.AuditedSegment {
+	return &overlay.AuditedSegment{
+		StreamID: streamID,
+		Position: position.Encode(),
+	}
+}
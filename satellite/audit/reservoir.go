@@ -16,22 +16,28 @@ const maxReservoirSize = 3
 
 // Reservoir holds a certain number of segments to reflect a random sample.
 type Reservoir struct {
-	Segments [maxReservoirSize]segmentloop.Segment
-	size     int8
+	Segments []segmentloop.Segment
 	index    int64
 	wSum     int64
 }
 
 // NewReservoir instantiates a Reservoir.
 func NewReservoir(size int) *Reservoir {
+	if size > maxReservoirSize {
+		size = maxReservoirSize
+	}
+	return newReservoir(size)
+}
+
+// newReservoir instantiates a Reservoir without limiting its size to
+// maxReservoirSize, which is used for weighted reservoirs.
+func newReservoir(size int) *Reservoir {
 	if size < 1 {
 		size = 1
-	} else if size > maxReservoirSize {
-		size = maxReservoirSize
 	}
 	return &Reservoir{
-		size:  int8(size),
-		index: 0,
+		Segments: make([]segmentloop.Segment, size),
+		index:    0,
 	}
 }
 
@@ -42,19 +48,47 @@ func NewReservoir(size int) *Reservoir {
 // segment. See https://en.wikipedia.org/wiki/Reservoir_sampling#Algorithm_A-Chao
 // for the algorithm used.
 func (reservoir *Reservoir) Sample(r *rand.Rand, segment *segmentloop.Segment) {
-	if reservoir.index < int64(reservoir.size) {
+	reservoir.sample(r, segment, int64(segment.EncryptedSize))
+	reservoir.index++
+}
+
+// Merge merges other into the reservoir, as if all the segments sampled by
+// other had been sampled by this reservoir. Each segment held by other
+// represents an equal share of the total weight seen by other.
+func (reservoir *Reservoir) Merge(r *rand.Rand, other *Reservoir) {
+	count := other.index
+	if count > int64(len(other.Segments)) {
+		count = int64(len(other.Segments))
+	}
+	if count == 0 {
+		return
+	}
+
+	weight := other.wSum / count
+	for i := int64(0); i < count; i++ {
+		reservoir.sample(r, &other.Segments[i], weight)
+		reservoir.index++
+	}
+
+	// account for the segments and weight that other has seen but not kept.
+	reservoir.index += other.index - count
+	reservoir.wSum += other.wSum - weight*count
+}
+
+func (reservoir *Reservoir) sample(r *rand.Rand, segment *segmentloop.Segment, weight int64) {
+	if reservoir.index < int64(len(reservoir.Segments)) {
 		reservoir.Segments[reservoir.index] = *segment
-		reservoir.wSum += int64(segment.EncryptedSize)
-	} else {
-		reservoir.wSum += int64(segment.EncryptedSize)
-		p := float64(segment.EncryptedSize) / float64(reservoir.wSum)
-		random := r.Float64()
-		if random < p {
-			index := r.Int31n(int32(reservoir.size))
-			reservoir.Segments[index] = *segment
-		}
+		reservoir.wSum += weight
+		return
+	}
+
+	reservoir.wSum += weight
+	p := float64(weight) / float64(reservoir.wSum)
+	random := r.Float64()
+	if random < p {
+		index := r.Int31n(int32(len(reservoir.Segments)))
+		reservoir.Segments[index] = *segment
 	}
-	reservoir.index++
 }
 
 // Segment is a segment to audit.
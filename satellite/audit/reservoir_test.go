@@ -92,3 +92,43 @@ func TestReservoirBias(t *testing.T) {
 	require.Greater(t, streamIDCountsMap[weight5StreamID], streamIDCountsMap[weight2StreamID])
 	require.Greater(t, streamIDCountsMap[weight2StreamID], streamIDCountsMap[weight1StreamID])
 }
+
+func TestReservoirMerge(t *testing.T) {
+	rng := rand.New(rand.NewSource(0))
+	seg := func(n byte) *segmentloop.Segment {
+		return &segmentloop.Segment{StreamID: uuid.UUID{0: n}, EncryptedSize: 1}
+	}
+
+	t.Run("fills empty slots", func(t *testing.T) {
+		r1 := NewReservoir(3)
+		r1.Sample(rng, seg(1))
+
+		r2 := NewReservoir(3)
+		r2.Sample(rng, seg(2))
+		r2.Sample(rng, seg(3))
+
+		r1.Merge(rng, r2)
+		require.Equal(t, r1.Segments[:], []segmentloop.Segment{*seg(1), *seg(2), *seg(3)})
+		require.EqualValues(t, 3, r1.index)
+		require.EqualValues(t, 3, r1.wSum)
+	})
+
+	t.Run("accounts for everything seen", func(t *testing.T) {
+		r1 := NewReservoir(3)
+		for i := byte(1); i <= 10; i++ {
+			r1.Sample(rng, seg(i))
+		}
+
+		r2 := NewReservoir(3)
+		for i := byte(11); i <= 30; i++ {
+			r2.Sample(rng, seg(i))
+		}
+
+		r1.Merge(rng, r2)
+		require.EqualValues(t, 30, r1.index)
+		require.EqualValues(t, 30, r1.wSum)
+		for _, segment := range r1.Segments {
+			require.NotZero(t, segment.StreamID[0])
+		}
+	})
+}
@@ -41,6 +41,7 @@ type ReverificationJob struct {
 	Locator       PieceLocator
 	InsertedAt    time.Time
 	ReverifyCount int
+	LastAttempt   *time.Time
 }
 
 // Outcome enumerates the possible results of a piecewise audit.
@@ -275,10 +276,18 @@ func (verifier *Verifier) DoReverifyPiece(ctx context.Context, logger *zap.Logge
 func (verifier *Verifier) GetPiece(ctx context.Context, limit *pb.AddressedOrderLimit, piecePrivateKey storj.PiecePrivateKey, cachedIPAndPort string, pieceSize int32) (pieceData []byte, hash *pb.PieceHash, origLimit *pb.OrderLimit, err error) {
 	defer mon.Task()(&ctx)(&err)
 
+	return verifier.getPieceRange(ctx, limit, piecePrivateKey, cachedIPAndPort, 0, int64(pieceSize))
+}
+
+// getPieceRange uses the piecestore client to download a range of a piece (and the
+// associated original OrderLimit and PieceHash) from a node.
+func (verifier *Verifier) getPieceRange(ctx context.Context, limit *pb.AddressedOrderLimit, piecePrivateKey storj.PiecePrivateKey, cachedIPAndPort string, offset, size int64) (data []byte, hash *pb.PieceHash, origLimit *pb.OrderLimit, err error) {
+	defer mon.Task()(&ctx)(&err)
+
 	// determines number of seconds allotted for receiving data from a storage node
 	timedCtx := ctx
 	if verifier.minBytesPerSecond > 0 {
-		maxTransferTime := time.Duration(int64(time.Second) * int64(pieceSize) / verifier.minBytesPerSecond.Int64())
+		maxTransferTime := time.Duration(int64(time.Second) * size / verifier.minBytesPerSecond.Int64())
 		if maxTransferTime < verifier.minDownloadTimeout {
 			maxTransferTime = verifier.minDownloadTimeout
 		}
@@ -322,13 +331,13 @@ func (verifier *Verifier) GetPiece(ctx context.Context, limit *pb.AddressedOrder
 		}
 	}()
 
-	downloader, err := ps.Download(timedCtx, limit.GetLimit(), piecePrivateKey, 0, int64(pieceSize))
+	downloader, err := ps.Download(timedCtx, limit.GetLimit(), piecePrivateKey, offset, size)
 	if err != nil {
 		return nil, nil, nil, Error.Wrap(err)
 	}
 	defer func() { err = errs.Combine(err, Error.Wrap(downloader.Close())) }()
 
-	buf := make([]byte, pieceSize)
+	buf := make([]byte, size)
 	_, err = io.ReadFull(downloader, buf)
 	if err != nil {
 		return nil, nil, nil, Error.Wrap(err)
@@ -0,0 +1,158 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package audit
+
+import (
+	"context"
+	"math/rand"
+	"time"
+
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	"storj.io/common/errs2"
+	"storj.io/common/pb"
+	"storj.io/common/rpc"
+	"storj.io/common/rpc/rpcstatus"
+	"storj.io/common/signing"
+	"storj.io/common/storj"
+	"storj.io/storj/satellite/metabase"
+	"storj.io/storj/satellite/overlay"
+	"storj.io/uplink/private/eestream"
+)
+
+// StatConfig contains configurable values for stat audits.
+type StatConfig struct {
+	Enabled     bool          `help:"whether to run stat audits, which check the piece headers of random pieces and verify the pieces with invalid headers" default:"false"`
+	Interval    time.Duration `help:"how often to run stat audits" releaseDefault:"1m" devDefault:"10s" testDefault:"$TESTINTERVAL"`
+	Pieces      int           `help:"number of random pieces to stat audit in every interval" default:"100"`
+	Concurrency int           `help:"number of concurrent stat audits" default:"10"`
+}
+
+// CheckPieceHeader checks that a node still has the header of a piece, i.e. the
+// original order limit and the piece hash signed by the uplink. They are sent by the
+// node with the download of a single byte at a random offset of the piece. It verifies
+// the signatures, the piece ID and the piece size of the piece hash, but it doesn't
+// verify the data of the piece, so it's only a check for the presence of the piece.
+//
+// The check fails when the node doesn't have the piece or it doesn't send a valid
+// piece hash and original order limit. The reputation status of the node is returned
+// for reporting the outcome of a following verification.
+func (verifier *Verifier) CheckPieceHeader(ctx context.Context, locator PieceLocator) (outcome Outcome, reputation overlay.ReputationStatus, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	logger := verifier.log.With(
+		zap.Stringer("stream-id", locator.StreamID),
+		zap.Uint32("position-part", locator.Position.Part),
+		zap.Uint32("position-index", locator.Position.Index),
+		zap.Stringer("node-id", locator.NodeID),
+		zap.Int("piece-num", locator.PieceNum))
+
+	segment, err := verifier.metabase.GetSegmentByPosition(ctx, metabase.GetSegmentByPosition{
+		StreamID: locator.StreamID,
+		Position: locator.Position,
+	})
+	if err != nil {
+		if metabase.ErrSegmentNotFound.Has(err) {
+			return OutcomeNotNecessary, reputation, nil
+		}
+		return OutcomeNotPerformed, reputation, Error.Wrap(err)
+	}
+	if segment.Expired(verifier.nowFn()) {
+		return OutcomeNotNecessary, reputation, nil
+	}
+	piece, found := segment.Pieces.FindByNum(locator.PieceNum)
+	if !found || piece.StorageNode != locator.NodeID {
+		return OutcomeNotNecessary, reputation, nil
+	}
+
+	redundancy, err := eestream.NewRedundancyStrategyFromStorj(segment.Redundancy)
+	if err != nil {
+		return OutcomeNotPerformed, reputation, Error.Wrap(err)
+	}
+
+	pieceSize := eestream.CalcPieceSize(int64(segment.EncryptedSize), redundancy)
+	// the node must not be able to predict the offset.
+	const rangeSize = 1
+	offset := rand.New(cryptoSource{}).Int63n(pieceSize)
+
+	limit, piecePrivateKey, cachedNodeInfo, err := verifier.orders.CreateAuditPieceOrderLimit(ctx, locator.NodeID, uint16(locator.PieceNum), segment.RootPieceID, int32(rangeSize))
+	if cachedNodeInfo != nil {
+		reputation = cachedNodeInfo.Reputation
+	}
+	if err != nil {
+		if overlay.ErrNodeDisqualified.Has(err) || overlay.ErrNodeFinishedGE.Has(err) {
+			return OutcomeNotNecessary, reputation, nil
+		}
+		if overlay.ErrNodeOffline.Has(err) {
+			return OutcomeNotPerformed, reputation, nil
+		}
+		return OutcomeNotPerformed, reputation, Error.Wrap(err)
+	}
+
+	_, pieceHash, pieceOriginalLimit, err := verifier.getPieceRange(ctx, limit, piecePrivateKey, cachedNodeInfo.LastIPPort, offset, rangeSize)
+	if err != nil {
+		if rpc.Error.Has(err) {
+			if errs.Is(err, context.DeadlineExceeded) {
+				return OutcomeTimedOut, reputation, nil
+			}
+			if errs2.IsRPC(err, rpcstatus.Unknown) {
+				return OutcomeNodeOffline, reputation, nil
+			}
+			logger.Debug("CheckPieceHeader: unknown transport error", zap.Error(err))
+			return OutcomeUnknownError, reputation, nil
+		}
+		if errs2.IsRPC(err, rpcstatus.NotFound) {
+			if err := verifier.checkIfSegmentAltered(ctx, segment); err != nil {
+				logger.Debug("CheckPieceHeader: audit source segment changed during piece header check", zap.Error(err))
+				return OutcomeNotNecessary, reputation, nil
+			}
+			logger.Info("CheckPieceHeader: audit failure; node indicates piece not found")
+			return OutcomeFailure, reputation, nil
+		}
+		if errs2.IsRPC(err, rpcstatus.DeadlineExceeded) {
+			return OutcomeTimedOut, reputation, nil
+		}
+		logger.Debug("CheckPieceHeader: unknown error from node", zap.Error(err))
+		return OutcomeUnknownError, reputation, nil
+	}
+
+	if reason := verifier.checkPieceHeader(ctx, limit.GetLimit().PieceId, pieceSize, pieceHash, pieceOriginalLimit); reason != "" {
+		if err := verifier.checkIfSegmentAltered(ctx, segment); err != nil {
+			logger.Debug("CheckPieceHeader: audit source segment changed during piece header check", zap.Error(err))
+			return OutcomeNotNecessary, reputation, nil
+		}
+		logger.Info("CheckPieceHeader: audit failure; " + reason)
+		return OutcomeFailure, reputation, nil
+	}
+
+	return OutcomeSuccess, reputation, nil
+}
+
+// checkPieceHeader verifies the piece hash and the original order limit sent by the
+// node. It returns the reason of the failure, or an empty string when they are valid.
+func (verifier *Verifier) checkPieceHeader(ctx context.Context, pieceID storj.PieceID, pieceSize int64, hash *pb.PieceHash, originalLimit *pb.OrderLimit) (reason string) {
+	if hash == nil {
+		return "node did not send piece hash as requested"
+	}
+	if originalLimit == nil {
+		return "node did not send original order limit as requested"
+	}
+	if hash.PieceId != pieceID || originalLimit.PieceId != pieceID {
+		return "piece hash or original order limit is for another piece"
+	}
+	// older uplinks don't send the piece size.
+	if hash.PieceSize != 0 && hash.PieceSize != pieceSize {
+		return "piece hash has incorrect piece size"
+	}
+
+	signer := signing.SigneeFromPeerIdentity(verifier.auditor)
+	if err := signing.VerifyOrderLimitSignature(ctx, signer, originalLimit); err != nil {
+		return "invalid original order limit signature"
+	}
+	if err := signing.VerifyUplinkPieceHashSignature(ctx, originalLimit.UplinkPublicKey, hash); err != nil {
+		return "invalid piece hash signature"
+	}
+	return ""
+}
@@ -0,0 +1,120 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package audit_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"storj.io/common/memory"
+	"storj.io/common/testcontext"
+	"storj.io/common/testrand"
+	"storj.io/storj/private/testplanet"
+	"storj.io/storj/satellite"
+	"storj.io/storj/satellite/audit"
+)
+
+func TestCheckPieceHeader(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount: 1, StorageNodeCount: 4, UplinkCount: 1,
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		satellite := planet.Satellites[0]
+		satellite.Audit.Worker.Loop.Pause()
+		satellite.Audit.Chore.Loop.Pause()
+
+		err := planet.Uplinks[0].Upload(ctx, satellite, "testbucket", "test/path", testrand.Bytes(8*memory.KiB))
+		require.NoError(t, err)
+
+		segments, err := satellite.Metabase.DB.TestingAllSegments(ctx)
+		require.NoError(t, err)
+		require.Len(t, segments, 1)
+		segment := segments[0]
+
+		locator := func(i int) audit.PieceLocator {
+			return audit.PieceLocator{
+				StreamID: segment.StreamID,
+				Position: segment.Position,
+				NodeID:   segment.Pieces[i].StorageNode,
+				PieceNum: int(segment.Pieces[i].Number),
+			}
+		}
+
+		// the node holds the piece.
+		outcome, reputation, err := satellite.Audit.Verifier.CheckPieceHeader(ctx, locator(0))
+		require.NoError(t, err)
+		require.Equal(t, audit.OutcomeSuccess, outcome)
+		require.Nil(t, reputation.VettedAt)
+
+		// the node lost the piece.
+		piece := segment.Pieces[1]
+		pieceID := segment.RootPieceID.Derive(piece.StorageNode, int32(piece.Number))
+		node := planet.FindNode(piece.StorageNode)
+		require.NoError(t, node.Storage2.Store.Delete(ctx, satellite.ID(), pieceID))
+
+		outcome, _, err = satellite.Audit.Verifier.CheckPieceHeader(ctx, locator(1))
+		require.NoError(t, err)
+		require.Equal(t, audit.OutcomeFailure, outcome)
+
+		// the piece isn't part of the segment anymore.
+		other := locator(2)
+		other.NodeID = testrand.NodeID()
+		outcome, _, err = satellite.Audit.Verifier.CheckPieceHeader(ctx, other)
+		require.NoError(t, err)
+		require.Equal(t, audit.OutcomeNotNecessary, outcome)
+	})
+}
+
+func TestStatWorker(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount: 1, StorageNodeCount: 4, UplinkCount: 1,
+		Reconfigure: testplanet.Reconfigure{
+			Satellite: func(log *zap.Logger, index int, config *satellite.Config) {
+				config.Audit.Stat.Enabled = true
+				config.Audit.Stat.Pieces = 10
+			},
+		},
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		satellite := planet.Satellites[0]
+		satellite.Audit.Worker.Loop.Pause()
+		satellite.Audit.Chore.Loop.Pause()
+		satellite.Audit.StatWorker.Loop.Pause()
+
+		err := planet.Uplinks[0].Upload(ctx, satellite, "testbucket", "test/path", testrand.Bytes(8*memory.KiB))
+		require.NoError(t, err)
+
+		segments, err := satellite.Metabase.DB.TestingAllSegments(ctx)
+		require.NoError(t, err)
+		require.Len(t, segments, 1)
+
+		// the successful checks of the piece headers are not reported.
+		satellite.Audit.StatWorker.Loop.TriggerWait()
+		for _, piece := range segments[0].Pieces {
+			info, err := satellite.Reputation.Service.Get(ctx, piece.StorageNode)
+			require.NoError(t, err)
+			require.Zero(t, info.TotalAuditCount)
+		}
+
+		// delete the pieces from all nodes, so whichever piece is picked fails.
+		for _, piece := range segments[0].Pieces {
+			pieceID := segments[0].RootPieceID.Derive(piece.StorageNode, int32(piece.Number))
+			node := planet.FindNode(piece.StorageNode)
+			require.NoError(t, node.Storage2.Store.Delete(ctx, satellite.ID(), pieceID))
+		}
+
+		satellite.Audit.StatWorker.Loop.TriggerWait()
+
+		var failed int
+		for _, piece := range segments[0].Pieces {
+			info, err := satellite.Reputation.Service.Get(ctx, piece.StorageNode)
+			require.NoError(t, err)
+			if info.TotalAuditCount > 0 {
+				require.Zero(t, info.AuditSuccessCount)
+				failed++
+			}
+		}
+		require.Equal(t, 1, failed)
+	})
+}
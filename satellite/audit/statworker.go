@@ -0,0 +1,227 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package audit
+
+import (
+	"context"
+	"math/rand"
+	"time"
+
+	"go.uber.org/zap"
+
+	"storj.io/common/storj"
+	"storj.io/common/sync2"
+	"storj.io/common/uuid"
+	"storj.io/storj/satellite/metabase"
+	"storj.io/storj/satellite/overlay"
+)
+
+// StatWorker runs stat audits of random pieces.
+//
+// A stat audit checks the header of the piece, which doesn't verify the data of
+// the piece, so the outcome of the check isn't reported. When the check fails,
+// the whole piece is verified like the pending audit of a contained node, and
+// the outcome of the verification is reported, successes and failures alike.
+//
+// architecture: Worker
+type StatWorker struct {
+	log      *zap.Logger
+	metabase *metabase.DB
+	verifier *Verifier
+	reporter Reporter
+	config   StatConfig
+	rand     *rand.Rand
+
+	Loop *sync2.Cycle
+}
+
+// NewStatWorker instantiates StatWorker.
+func NewStatWorker(log *zap.Logger, metabase *metabase.DB, verifier *Verifier, reporter Reporter, config StatConfig) *StatWorker {
+	return &StatWorker{
+		log:      log,
+		metabase: metabase,
+		verifier: verifier,
+		reporter: reporter,
+		config:   config,
+		rand:     rand.New(rand.NewSource(time.Now().UnixNano())),
+
+		Loop: sync2.NewCycle(config.Interval),
+	}
+}
+
+// Run runs the stat audits.
+func (worker *StatWorker) Run(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	return worker.Loop.Run(ctx, func(ctx context.Context) (err error) {
+		defer mon.Task()(&ctx)(&err)
+		err = worker.process(ctx)
+		if err != nil {
+			worker.log.Error("process", zap.Error(Error.Wrap(err)))
+		}
+		return nil
+	})
+}
+
+// Close halts the worker.
+func (worker *StatWorker) Close() error {
+	worker.Loop.Close()
+	return nil
+}
+
+// process stat audits a random piece of every segment of a random range of segments.
+func (worker *StatWorker) process(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	pieces, err := worker.randomPieces(ctx)
+	if err != nil {
+		return err
+	}
+
+	limiter := sync2.NewLimiter(worker.config.Concurrency)
+	defer limiter.Wait()
+
+	for _, piece := range pieces {
+		piece := piece
+		started := limiter.Go(ctx, func() {
+			worker.work(ctx, piece)
+		})
+		if !started {
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
+// randomPieces picks a random piece of every segment listed from a random cursor.
+func (worker *StatWorker) randomPieces(ctx context.Context) (_ []PieceLocator, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if worker.config.Pieces <= 0 {
+		return nil, nil
+	}
+
+	aliasMap, err := worker.metabase.LatestNodesAliasMap(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	cursor, err := uuid.New()
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := worker.metabase.ListVerifySegments(ctx, metabase.ListVerifySegments{
+		CursorStreamID: cursor,
+		Limit:          worker.config.Pieces,
+	})
+	if err != nil {
+		return nil, err
+	}
+	segments := result.Segments
+
+	// wrap around, when the cursor was close to the end.
+	if remaining := worker.config.Pieces - len(segments); remaining > 0 {
+		result, err := worker.metabase.ListVerifySegments(ctx, metabase.ListVerifySegments{
+			Limit: remaining,
+		})
+		if err != nil {
+			return nil, err
+		}
+		segments = append(segments, result.Segments...)
+	}
+
+	seen := make(map[PieceLocator]struct{}, len(segments))
+	var pieces []PieceLocator
+	for _, segment := range segments {
+		if len(segment.AliasPieces) == 0 {
+			continue
+		}
+		piece := segment.AliasPieces[worker.rand.Intn(len(segment.AliasPieces))]
+		nodeID, ok := aliasMap.Node(piece.Alias)
+		if !ok {
+			continue
+		}
+		locator := PieceLocator{
+			StreamID: segment.StreamID,
+			Position: segment.Position,
+			NodeID:   nodeID,
+			PieceNum: int(piece.Number),
+		}
+		// the wrapped around segments may overlap with the first ones.
+		if _, ok := seen[locator]; ok {
+			continue
+		}
+		seen[locator] = struct{}{}
+		pieces = append(pieces, locator)
+	}
+	return pieces, nil
+}
+
+// work checks the header of the piece and verifies the piece, when the check fails.
+func (worker *StatWorker) work(ctx context.Context, piece PieceLocator) {
+	defer mon.Task()(&ctx)(nil)
+
+	outcome, reputation, err := worker.verifier.CheckPieceHeader(ctx, piece)
+	if err != nil {
+		worker.log.Error("could not perform stat audit due to error",
+			zap.Stringer("Segment StreamID", piece.StreamID),
+			zap.Uint64("Segment Position", piece.Position.Encode()),
+			zap.Error(err))
+		return
+	}
+
+	var successes, fails, offlines, unknown int
+	switch outcome {
+	case OutcomeSuccess:
+		successes++
+	case OutcomeFailure:
+		fails++
+	case OutcomeNodeOffline:
+		offlines++
+	case OutcomeTimedOut, OutcomeUnknownError:
+		unknown++
+	}
+	mon.Meter("stat_audit_successes_global").Mark(successes)
+	mon.Meter("stat_audit_fails_global").Mark(fails)
+	mon.Meter("stat_audit_offlines_global").Mark(offlines)
+	mon.Meter("stat_audit_unknown_global").Mark(unknown)
+
+	if outcome != OutcomeFailure {
+		return
+	}
+
+	logger := worker.log.With(
+		zap.Stringer("stream-id", piece.StreamID),
+		zap.Uint32("position-part", piece.Position.Part),
+		zap.Uint32("position-index", piece.Position.Index),
+		zap.Stringer("node-id", piece.NodeID),
+		zap.Int("piece-num", piece.PieceNum))
+
+	outcome, err = worker.verifier.DoReverifyPiece(ctx, logger, piece)
+	if err != nil {
+		logger.Error("could not verify piece after failed stat audit", zap.Error(err))
+		return
+	}
+
+	report := Report{
+		NodesReputation: map[storj.NodeID]overlay.ReputationStatus{piece.NodeID: reputation},
+		Segment:         &Segment{StreamID: piece.StreamID, Position: piece.Position},
+	}
+	switch outcome {
+	case OutcomeSuccess:
+		report.Successes = storj.NodeIDList{piece.NodeID}
+	case OutcomeFailure:
+		report.Fails = storj.NodeIDList{piece.NodeID}
+	default:
+		return
+	}
+	mon.Meter("stat_audit_verified_successes_global").Mark(len(report.Successes))
+	mon.Meter("stat_audit_verified_fails_global").Mark(len(report.Fails))
+
+	_, err = worker.reporter.RecordAudits(ctx, report)
+	if err != nil {
+		logger.Error("could not record piece verification after failed stat audit", zap.Error(err))
+	}
+}
@@ -0,0 +1,125 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package audit
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"time"
+
+	"storj.io/common/storj"
+	"storj.io/storj/satellite/reputation"
+)
+
+// WeightingConfig configures the weighting of the reservoir slots toward unvetted nodes
+// and nodes with few recent audits.
+type WeightingConfig struct {
+	Enabled            bool          `help:"whether to give unvetted nodes and nodes with few recent audits more reservoir slots, without changing the total number of slots" default:"false"`
+	UnvettedFactor     float64       `help:"weight of the unvetted nodes relative to the vetted nodes" default:"3"`
+	FewAuditsFactor    float64       `help:"weight of the nodes without recent audits relative to the nodes with at least the recent audits target" default:"2"`
+	RecentAuditsTarget int           `help:"number of recent audits, below which nodes get a larger weight" default:"20"`
+	RecentAuditsWindow time.Duration `help:"how far back the audits of a node are counted as recent" default:"168h0m0s"`
+	MaxSlots           int           `help:"maximum number of reservoir slots of a node" default:"9"`
+}
+
+// AuditStatsDB returns the audit statistics of the nodes.
+type AuditStatsDB interface {
+	// AuditStats returns the audit statistics of all nodes which are not disqualified.
+	AuditStats(ctx context.Context, since time.Time) (map[storj.NodeID]reputation.AuditStats, error)
+}
+
+// NodeSlots contains the number of reservoir slots of the nodes.
+type NodeSlots struct {
+	nodes   map[storj.NodeID]int
+	unknown int
+}
+
+// Slots returns the number of reservoir slots of the node.
+func (slots *NodeSlots) Slots(nodeID storj.NodeID) int {
+	if count, ok := slots.nodes[nodeID]; ok {
+		return count
+	}
+	return slots.unknown
+}
+
+// LoadNodeSlots loads the audit statistics of the nodes and distributes the reservoir slots
+// among them proportional to their weight.
+func LoadNodeSlots(ctx context.Context, db AuditStatsDB, config Config, r *rand.Rand, now time.Time) (_ *NodeSlots, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	stats, err := db.AuditStats(ctx, now.Add(-config.Weighting.RecentAuditsWindow))
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	return WeightNodeSlots(config.Weighting, config.Slots, stats, r), nil
+}
+
+// WeightNodeSlots distributes the reservoir slots among the nodes proportional to their
+// weight, so that a node gets on average as many slots as without weighting. The nodes
+// without audit statistics are weighted as unvetted nodes without recent audits.
+func WeightNodeSlots(config WeightingConfig, slots int, stats map[storj.NodeID]reputation.AuditStats, r *rand.Rand) *NodeSlots {
+	maxSlots := config.MaxSlots
+	if maxSlots < slots {
+		maxSlots = slots
+	}
+
+	unknownWeight := config.weight(reputation.AuditStats{})
+
+	weights := make(map[storj.NodeID]float64, len(stats))
+	var total float64
+	for nodeID, nodeStats := range stats {
+		weight := config.weight(nodeStats)
+		weights[nodeID] = weight
+		total += weight
+	}
+
+	mean := unknownWeight
+	if len(weights) > 0 {
+		mean = total / float64(len(weights))
+	}
+
+	// slotsFor rounds randomly, so that the expected number of slots is exact.
+	slotsFor := func(weight float64) int {
+		exact := float64(slots) * weight / mean
+		count := int(math.Floor(exact))
+		if r.Float64() < exact-float64(count) {
+			count++
+		}
+		if count < 1 {
+			count = 1
+		} else if count > maxSlots {
+			count = maxSlots
+		}
+		return count
+	}
+
+	nodeSlots := &NodeSlots{
+		nodes:   make(map[storj.NodeID]int, len(weights)),
+		unknown: slotsFor(unknownWeight),
+	}
+
+	var totalSlots int
+	for nodeID, weight := range weights {
+		count := slotsFor(weight)
+		nodeSlots.nodes[nodeID] = count
+		totalSlots += count
+	}
+	mon.IntVal("audit_weighted_reservoir_slots").Observe(int64(totalSlots))
+
+	return nodeSlots
+}
+
+// weight returns the relative weight of a node with the audit statistics.
+func (config WeightingConfig) weight(stats reputation.AuditStats) float64 {
+	weight := 1.0
+	if !stats.Vetted && config.UnvettedFactor > 0 {
+		weight *= config.UnvettedFactor
+	}
+	if target := int64(config.RecentAuditsTarget); target > 0 && stats.RecentAudits < target && config.FewAuditsFactor > 0 {
+		missing := float64(target-stats.RecentAudits) / float64(target)
+		weight *= 1 + (config.FewAuditsFactor-1)*missing
+	}
+	return weight
+}
@@ -0,0 +1,115 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package audit
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"storj.io/common/storj"
+	"storj.io/common/testrand"
+	"storj.io/common/uuid"
+	"storj.io/storj/satellite/metabase/segmentloop"
+	"storj.io/storj/satellite/reputation"
+)
+
+func TestWeightNodeSlots(t *testing.T) {
+	config := WeightingConfig{
+		Enabled:            true,
+		UnvettedFactor:     3,
+		FewAuditsFactor:    2,
+		RecentAuditsTarget: 20,
+		MaxSlots:           9,
+	}
+	r := rand.New(rand.NewSource(0))
+
+	t.Run("total is kept", func(t *testing.T) {
+		stats := map[storj.NodeID]reputation.AuditStats{}
+		var vetted, unvetted []storj.NodeID
+		for i := 0; i < 500; i++ {
+			nodeID := testrand.NodeID()
+			stats[nodeID] = reputation.AuditStats{Vetted: true, RecentAudits: 100}
+			vetted = append(vetted, nodeID)
+
+			nodeID = testrand.NodeID()
+			stats[nodeID] = reputation.AuditStats{Vetted: false, RecentAudits: 20}
+			unvetted = append(unvetted, nodeID)
+		}
+
+		// the weights are 1 and 3, so the slots are 1.5 and 4.5 on average.
+		slots := WeightNodeSlots(config, 3, stats, r)
+
+		var vettedTotal, unvettedTotal int
+		for _, nodeID := range vetted {
+			require.Contains(t, []int{1, 2}, slots.Slots(nodeID))
+			vettedTotal += slots.Slots(nodeID)
+		}
+		for _, nodeID := range unvetted {
+			require.Contains(t, []int{4, 5}, slots.Slots(nodeID))
+			unvettedTotal += slots.Slots(nodeID)
+		}
+
+		require.InDelta(t, 750, vettedTotal, 75)
+		require.InDelta(t, 2250, unvettedTotal, 75)
+
+		// unknown nodes are weighted as unvetted nodes without audits.
+		require.Equal(t, 9, slots.Slots(testrand.NodeID()))
+	})
+
+	t.Run("limits", func(t *testing.T) {
+		vetted, unvetted := testrand.NodeID(), testrand.NodeID()
+		stats := map[storj.NodeID]reputation.AuditStats{
+			vetted:            {Vetted: true, RecentAudits: 1000},
+			unvetted:          {Vetted: false, RecentAudits: 0},
+			testrand.NodeID(): {Vetted: false, RecentAudits: 0},
+			testrand.NodeID(): {Vetted: false, RecentAudits: 0},
+		}
+
+		// the weights are 1 and 6, so the slots are 0.63 and 3.79 on average.
+		slots := WeightNodeSlots(config, 3, stats, r)
+		require.Equal(t, 1, slots.Slots(vetted))
+		require.Contains(t, []int{3, 4}, slots.Slots(unvetted))
+		require.Contains(t, []int{3, 4}, slots.Slots(testrand.NodeID()))
+
+		config := config
+		config.MaxSlots = 2
+		slots = WeightNodeSlots(config, 3, stats, r)
+		require.Equal(t, 1, slots.Slots(vetted))
+		require.Equal(t, 3, slots.Slots(unvetted))
+	})
+
+	t.Run("no stats", func(t *testing.T) {
+		slots := WeightNodeSlots(config, 3, nil, r)
+		require.Equal(t, 3, slots.Slots(testrand.NodeID()))
+	})
+}
+
+func TestCreateAuditQueue_Weighted(t *testing.T) {
+	seg := func(n byte) segmentloop.Segment { return segmentloop.Segment{StreamID: uuid.UUID{0: n}} }
+
+	large := newReservoir(6)
+	for i := range large.Segments {
+		large.Segments[i] = seg(byte(10 + i))
+	}
+	small := newReservoir(3)
+	for i := range small.Segments {
+		small.Segments[i] = seg(byte(20 + i))
+	}
+
+	queue := createAuditQueue(map[storj.NodeID]*Reservoir{
+		testrand.NodeID(): large,
+		testrand.NodeID(): small,
+	})
+
+	var order []byte
+	for _, segment := range queue {
+		order = append(order, segment.StreamID[0])
+	}
+
+	require.Len(t, order, 9)
+	require.ElementsMatch(t, []byte{10, 20}, order[:2])
+	require.Equal(t, []byte{11, 21, 12, 13, 22, 14, 15}, order[2:])
+}
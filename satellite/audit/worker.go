@@ -34,6 +34,11 @@ type Config struct {
 
 	ReverifyWorkerConcurrency   int           `help:"number of workers to run reverify audits on pieces" default:"2"`
 	ReverificationRetryInterval time.Duration `help:"how long a single reverification job can take before it may be taken over by another worker" releaseDefault:"6h" devDefault:"10m"`
+
+	UseRangedLoop bool `help:"whether to use the ranged loop instead of the segment loop for populating reservoirs" default:"false"`
+
+	Weighting WeightingConfig
+	Stat      StatConfig
 }
 
 // Worker contains information for populating audit queue and processing audits.
@@ -160,6 +165,7 @@ func (worker *Worker) work(ctx context.Context, segment Segment) (err error) {
 	if err != nil {
 		errlist.Add(err)
 	}
+	report.Segment = &segment
 
 	// TODO(moby) we need to decide if we want to do something with nodes that the reporter failed to update
 	_, err = worker.reporter.RecordAudits(ctx, report)
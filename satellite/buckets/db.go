@@ -5,6 +5,7 @@ package buckets
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"storj.io/common/macaroon"
@@ -19,6 +20,33 @@ type Bucket struct {
 	CreatedAt time.Time
 }
 
+// Versioning represents the S3-style versioning state of a bucket.
+type Versioning int
+
+const (
+	// Unversioned is the state of a bucket which never had versioning enabled.
+	// Committing an object replaces all its previous versions.
+	Unversioned Versioning = 0
+	// VersioningEnabled keeps all committed versions of an object and
+	// deleting an object creates a delete marker.
+	VersioningEnabled Versioning = 1
+)
+
+// IsVersioned returns whether objects in the bucket keep their previous versions.
+func (v Versioning) IsVersioned() bool { return v == VersioningEnabled }
+
+// String returns a string representation of the versioning state.
+func (v Versioning) String() string {
+	switch v {
+	case Unversioned:
+		return "Unversioned"
+	case VersioningEnabled:
+		return "Enabled"
+	default:
+		return fmt.Sprintf("Versioning(%d)", int(v))
+	}
+}
+
 // DB is the interface for the database to interact with buckets.
 //
 // architecture: Database
@@ -29,6 +57,16 @@ type DB interface {
 	GetBucket(ctx context.Context, bucketName []byte, projectID uuid.UUID) (bucket storj.Bucket, err error)
 	// GetBucketPlacement returns with the placement constraint identifier.
 	GetBucketPlacement(ctx context.Context, bucketName []byte, projectID uuid.UUID) (placement storj.PlacementConstraint, err error)
+	// GetBucketVersioningState returns the versioning state of the bucket.
+	GetBucketVersioningState(ctx context.Context, bucketName []byte, projectID uuid.UUID) (versioning Versioning, err error)
+	// SetBucketVersioningState updates the versioning state of the bucket.
+	SetBucketVersioningState(ctx context.Context, bucketName []byte, projectID uuid.UUID, versioning Versioning) (err error)
+	// GetBucketLifecycleRules returns the lifecycle rules of the bucket.
+	GetBucketLifecycleRules(ctx context.Context, bucketName []byte, projectID uuid.UUID) (rules LifecycleRules, err error)
+	// SetBucketLifecycleRules replaces the lifecycle rules of the bucket.
+	SetBucketLifecycleRules(ctx context.Context, bucketName []byte, projectID uuid.UUID, rules LifecycleRules) (err error)
+	// ListBucketLifecycles returns all buckets which have lifecycle rules.
+	ListBucketLifecycles(ctx context.Context) (lifecycles []BucketLifecycle, err error)
 	// GetMinimalBucket returns existing bucket with minimal number of fields.
 	GetMinimalBucket(ctx context.Context, bucketName []byte, projectID uuid.UUID) (bucket Bucket, err error)
 	// HasBucket returns if a bucket exists.
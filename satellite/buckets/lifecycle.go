@@ -0,0 +1,114 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package buckets
+
+import (
+	"bytes"
+	"time"
+
+	"github.com/zeebo/errs"
+
+	"storj.io/storj/satellite/metabase"
+)
+
+// ErrInvalidLifecycleRule is returned when a lifecycle rule cannot be applied.
+var ErrInvalidLifecycleRule = errs.Class("invalid lifecycle rule")
+
+// maxLifecycleRules is the maximum number of rules a bucket can have, same as S3.
+const maxLifecycleRules = 1000
+
+// LifecycleRule describes which objects of a bucket should be removed based on their age.
+//
+// Prefix is matched against the object key as it is stored in the metabase,
+// so for buckets with encrypted paths it has to be the encrypted key prefix.
+type LifecycleRule struct {
+	ID     string `json:"id"`
+	Prefix string `json:"prefix,omitempty"`
+
+	// ExpirationDays removes the current version of the object after the
+	// specified number of days since its creation. In versioned buckets a
+	// delete marker is inserted instead.
+	ExpirationDays int `json:"expirationDays,omitempty"`
+	// AbortIncompleteUploadDays removes pending objects after the specified
+	// number of days since the upload was started.
+	AbortIncompleteUploadDays int `json:"abortIncompleteUploadDays,omitempty"`
+	// NoncurrentVersionExpirationDays removes previous versions of the object
+	// after the specified number of days since they became noncurrent.
+	NoncurrentVersionExpirationDays int `json:"noncurrentVersionExpirationDays,omitempty"`
+}
+
+// Matches returns whether the rule applies to the object key.
+func (rule LifecycleRule) Matches(key metabase.ObjectKey) bool {
+	return bytes.HasPrefix([]byte(key), []byte(rule.Prefix))
+}
+
+// LifecycleRules is the list of lifecycle rules of a bucket.
+type LifecycleRules []LifecycleRule
+
+// Validate checks whether the rules can be stored.
+func (rules LifecycleRules) Validate() error {
+	if len(rules) > maxLifecycleRules {
+		return ErrInvalidLifecycleRule.New("too many rules: %d, maximum is %d", len(rules), maxLifecycleRules)
+	}
+
+	ids := map[string]struct{}{}
+	for _, rule := range rules {
+		if rule.ID == "" {
+			return ErrInvalidLifecycleRule.New("rule ID is missing")
+		}
+		if _, ok := ids[rule.ID]; ok {
+			return ErrInvalidLifecycleRule.New("duplicate rule ID %q", rule.ID)
+		}
+		ids[rule.ID] = struct{}{}
+
+		switch {
+		case rule.ExpirationDays < 0, rule.AbortIncompleteUploadDays < 0, rule.NoncurrentVersionExpirationDays < 0:
+			return ErrInvalidLifecycleRule.New("rule %q has negative number of days", rule.ID)
+		case rule.ExpirationDays == 0 && rule.AbortIncompleteUploadDays == 0 && rule.NoncurrentVersionExpirationDays == 0:
+			return ErrInvalidLifecycleRule.New("rule %q has no action", rule.ID)
+		}
+	}
+	return nil
+}
+
+// LifecycleAge contains the shortest ages after which objects with a specific
+// key are removed. Zero means that objects are kept forever.
+type LifecycleAge struct {
+	Expiration            time.Duration
+	AbortIncompleteUpload time.Duration
+	NoncurrentExpiration  time.Duration
+}
+
+// AgeFor combines all rules which match the object key. When multiple rules
+// match, the shortest age wins.
+func (rules LifecycleRules) AgeFor(key metabase.ObjectKey) LifecycleAge {
+	var age LifecycleAge
+	for _, rule := range rules {
+		if !rule.Matches(key) {
+			continue
+		}
+		age.Expiration = shortestAge(age.Expiration, rule.ExpirationDays)
+		age.AbortIncompleteUpload = shortestAge(age.AbortIncompleteUpload, rule.AbortIncompleteUploadDays)
+		age.NoncurrentExpiration = shortestAge(age.NoncurrentExpiration, rule.NoncurrentVersionExpirationDays)
+	}
+	return age
+}
+
+func shortestAge(current time.Duration, days int) time.Duration {
+	if days <= 0 {
+		return current
+	}
+	age := time.Duration(days) * 24 * time.Hour
+	if current == 0 || age < current {
+		return age
+	}
+	return current
+}
+
+// BucketLifecycle contains the lifecycle configuration of a bucket.
+type BucketLifecycle struct {
+	Location   metabase.BucketLocation
+	Versioning Versioning
+	Rules      LifecycleRules
+}
@@ -0,0 +1,59 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package buckets_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"storj.io/storj/satellite/buckets"
+)
+
+func TestLifecycleRules_Validate(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		rules buckets.LifecycleRules
+		valid bool
+	}{
+		{name: "empty", rules: nil, valid: true},
+		{name: "expiration", rules: buckets.LifecycleRules{{ID: "a", ExpirationDays: 1}}, valid: true},
+		{name: "all actions", rules: buckets.LifecycleRules{{ID: "a", Prefix: "p/", ExpirationDays: 1, AbortIncompleteUploadDays: 2, NoncurrentVersionExpirationDays: 3}}, valid: true},
+		{name: "missing id", rules: buckets.LifecycleRules{{ExpirationDays: 1}}},
+		{name: "duplicate id", rules: buckets.LifecycleRules{{ID: "a", ExpirationDays: 1}, {ID: "a", ExpirationDays: 2}}},
+		{name: "no action", rules: buckets.LifecycleRules{{ID: "a", Prefix: "p/"}}},
+		{name: "negative days", rules: buckets.LifecycleRules{{ID: "a", ExpirationDays: 1, AbortIncompleteUploadDays: -1}}},
+	} {
+		err := tc.rules.Validate()
+		if tc.valid {
+			require.NoError(t, err, tc.name)
+		} else {
+			require.True(t, buckets.ErrInvalidLifecycleRule.Has(err), tc.name)
+		}
+	}
+}
+
+func TestLifecycleRules_AgeFor(t *testing.T) {
+	const day = 24 * time.Hour
+
+	rules := buckets.LifecycleRules{
+		{ID: "all", ExpirationDays: 30, AbortIncompleteUploadDays: 7},
+		{ID: "logs", Prefix: "logs/", ExpirationDays: 10, NoncurrentVersionExpirationDays: 5},
+		{ID: "logs-uploads", Prefix: "logs/", AbortIncompleteUploadDays: 14},
+	}
+
+	require.Equal(t, buckets.LifecycleAge{
+		Expiration:            30 * day,
+		AbortIncompleteUpload: 7 * day,
+	}, rules.AgeFor("data/object"))
+
+	require.Equal(t, buckets.LifecycleAge{
+		Expiration:            10 * day,
+		AbortIncompleteUpload: 7 * day,
+		NoncurrentExpiration:  5 * day,
+	}, rules.AgeFor("logs/object"))
+
+	require.Equal(t, buckets.LifecycleAge{}, buckets.LifecycleRules{}.AgeFor("object"))
+}
@@ -9,12 +9,16 @@ import (
 	"github.com/zeebo/errs"
 
 	"storj.io/common/storj"
+	"storj.io/common/uuid"
 	"storj.io/storj/satellite/metabase"
 )
 
 var (
 	// ErrBucketNotEmpty is returned when a caller attempts to change placement constraints.
 	ErrBucketNotEmpty = errs.Class("bucket must be empty")
+
+	// ErrInvalidVersioningState is returned when a caller attempts an invalid versioning state transition.
+	ErrInvalidVersioningState = errs.Class("invalid versioning state")
 )
 
 // NewService converts the provided db and metabase calls into a single DB interface.
@@ -56,3 +60,34 @@ func (buckets *Service) UpdateBucket(ctx context.Context, bucket storj.Bucket) (
 
 	return buckets.DB.UpdateBucket(ctx, bucket)
 }
+
+// SetBucketVersioningState overrides the default SetBucketVersioningState behaviour by validating
+// the state transition. As in S3, a bucket cannot return to the unversioned state once versioning
+// has been enabled.
+func (buckets *Service) SetBucketVersioningState(ctx context.Context, bucketName []byte, projectID uuid.UUID, versioning Versioning) error {
+	current, err := buckets.DB.GetBucketVersioningState(ctx, bucketName, projectID)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case versioning == current:
+		return nil
+	case versioning != VersioningEnabled:
+		return ErrInvalidVersioningState.New("cannot change bucket versioning from %s to %s", current, versioning)
+	case !buckets.metabase.MultipleVersionsEnabled():
+		// without multiple versions, committing an object replaces all its versions.
+		return ErrInvalidVersioningState.New("versioning requires multiple object versions to be enabled")
+	}
+
+	return buckets.DB.SetBucketVersioningState(ctx, bucketName, projectID, versioning)
+}
+
+// SetBucketLifecycleRules overrides the default SetBucketLifecycleRules behaviour by validating
+// the rules before storing them.
+func (buckets *Service) SetBucketLifecycleRules(ctx context.Context, bucketName []byte, projectID uuid.UUID, rules LifecycleRules) error {
+	if err := rules.Validate(); err != nil {
+		return err
+	}
+	return buckets.DB.SetBucketLifecycleRules(ctx, bucketName, projectID, rules)
+}
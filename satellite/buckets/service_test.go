@@ -12,6 +12,7 @@ import (
 	"storj.io/common/storj"
 	"storj.io/common/testcontext"
 	"storj.io/storj/private/testplanet"
+	"storj.io/storj/satellite/buckets"
 )
 
 const TestBucket = "testbucket"
@@ -247,3 +248,44 @@ func TestBucketPlacement_PendingObject(t *testing.T) {
 		},
 	)
 }
+
+func TestBucketVersioning(t *testing.T) {
+	testplanet.Run(t,
+		testplanet.Config{
+			SatelliteCount: 1, StorageNodeCount: 0, UplinkCount: 1,
+		},
+		func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+			satellite := planet.Satellites[0]
+			service := satellite.API.Buckets.Service
+			uplink := planet.Uplinks[0]
+			projectID := uplink.Projects[0].ID
+
+			err := uplink.CreateBucket(ctx, satellite, TestBucket)
+			require.NoError(t, err)
+
+			// new buckets are unversioned
+			versioning, err := service.GetBucketVersioningState(ctx, []byte(TestBucket), projectID)
+			require.NoError(t, err)
+			require.Equal(t, buckets.Unversioned, versioning)
+
+			// versioning cannot be enabled without multiple versions support
+			satellite.Metabase.DB.TestingEnableMultipleVersions(false)
+			err = service.SetBucketVersioningState(ctx, []byte(TestBucket), projectID, buckets.VersioningEnabled)
+			require.True(t, buckets.ErrInvalidVersioningState.Has(err))
+			satellite.Metabase.DB.TestingEnableMultipleVersions(true)
+
+			err = service.SetBucketVersioningState(ctx, []byte(TestBucket), projectID, buckets.VersioningEnabled)
+			require.NoError(t, err)
+
+			versioning, err = service.GetBucketVersioningState(ctx, []byte(TestBucket), projectID)
+			require.NoError(t, err)
+			require.Equal(t, buckets.VersioningEnabled, versioning)
+
+			// versioning cannot be disabled once enabled
+			err = service.SetBucketVersioningState(ctx, []byte(TestBucket), projectID, buckets.Unversioned)
+			require.True(t, buckets.ErrInvalidVersioningState.Has(err))
+
+			_, err = service.GetBucketVersioningState(ctx, []byte("non-existing"), projectID)
+			require.True(t, storj.ErrBucketNotFound.Has(err))
+		})
+}
@@ -8,15 +8,22 @@ import (
 	"crypto/x509"
 	"net"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
 
 	"storj.io/common/pb"
 	"storj.io/common/rpc/rpcpeer"
 	"storj.io/common/rpc/rpcstatus"
+	"storj.io/common/signing"
 	"storj.io/common/storj"
 	"storj.io/common/testcontext"
+	"storj.io/storj/private/nodeextpb"
+	"storj.io/storj/private/nodetag"
 	"storj.io/storj/private/testplanet"
+	"storj.io/storj/satellite"
+	"storj.io/storj/satellite/overlay"
 	"storj.io/storj/storagenode"
 )
 
@@ -169,3 +176,139 @@ func TestSatellitePingMe_Failure(t *testing.T) {
 		require.Nil(t, resp)
 	})
 }
+
+func TestSatelliteContactEndpoint_Tags(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount: 1, StorageNodeCount: 2, UplinkCount: 0,
+		Reconfigure: testplanet.Reconfigure{
+			Satellite: func(log *zap.Logger, index int, config *satellite.Config) {
+				require.NoError(t, config.Overlay.Placement.Set(`10:tag("provider","storj")`))
+			},
+		},
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		sat := planet.Satellites[0]
+		node := planet.StorageNodes[0]
+		ident := node.Identity
+		nodeInfo := node.Contact.Service.Local()
+
+		peerCtx := rpcpeer.NewContext(ctx, &rpcpeer.Peer{
+			Addr: &net.TCPAddr{
+				IP:   net.ParseIP(nodeInfo.Address),
+				Port: 5,
+			},
+			State: tls.ConnectionState{
+				PeerCertificates: []*x509.Certificate{ident.Leaf, ident.CA},
+			},
+		})
+
+		checkIn := func(t *testing.T) *pb.CheckInResponse {
+			resp, err := sat.Contact.Endpoint.CheckIn(peerCtx, &pb.CheckInRequest{
+				Address:  nodeInfo.Address,
+				Version:  &nodeInfo.Version,
+				Capacity: &nodeInfo.Capacity,
+				Operator: &nodeInfo.Operator,
+			})
+			require.NoError(t, err)
+			return resp
+		}
+		setTags := func(signed ...*nodetag.SignedTagSet) error {
+			_, err := sat.Contact.Endpoint.SetNodeTags(peerCtx, &nodeextpb.SetNodeTagsRequest{
+				Tags: nodetag.ToProtobuf(signed),
+			})
+			return err
+		}
+
+		tagSet := &nodetag.TagSet{
+			NodeID:   node.ID(),
+			SignedAt: time.Now().UTC().Truncate(time.Second),
+			Tags: []nodetag.Tag{
+				{Name: "provider", Value: []byte("storj")},
+			},
+		}
+
+		t.Run("unknown signer", func(t *testing.T) {
+			signed, err := nodetag.Sign(ctx, tagSet, signing.SignerFromFullIdentity(planet.StorageNodes[1].Identity))
+			require.NoError(t, err)
+
+			err = setTags(signed)
+			require.Equal(t, rpcstatus.InvalidArgument, rpcstatus.Code(err))
+
+			tags, err := sat.Overlay.Service.GetNodeTags(ctx, node.ID())
+			require.NoError(t, err)
+			require.Empty(t, tags)
+		})
+
+		t.Run("different node", func(t *testing.T) {
+			other := *tagSet
+			other.NodeID = planet.StorageNodes[1].ID()
+			signed, err := nodetag.Sign(ctx, &other, signing.SignerFromFullIdentity(ident))
+			require.NoError(t, err)
+
+			err = setTags(signed)
+			require.Equal(t, rpcstatus.InvalidArgument, rpcstatus.Code(err))
+
+			tags, err := sat.Overlay.Service.GetNodeTags(ctx, node.ID())
+			require.NoError(t, err)
+			require.Empty(t, tags)
+		})
+
+		t.Run("self signed", func(t *testing.T) {
+			signed, err := nodetag.Sign(ctx, tagSet, signing.SignerFromFullIdentity(ident))
+			require.NoError(t, err)
+
+			require.NoError(t, setTags(signed))
+
+			tags, err := sat.Overlay.Service.GetNodeTags(ctx, node.ID())
+			require.NoError(t, err)
+			require.Len(t, tags, 1)
+			require.Equal(t, node.ID(), tags[0].Signer)
+			require.Equal(t, "provider", tags[0].Name)
+			require.Equal(t, []byte("storj"), tags[0].Value)
+			require.True(t, tagSet.SignedAt.Equal(tags[0].SignedAt))
+
+			require.NoError(t, sat.Overlay.Service.UploadSelectionCache.Refresh(ctx))
+			nodes, err := sat.Overlay.Service.FindStorageNodesForUpload(ctx, overlay.FindStorageNodesRequest{
+				RequestedCount: 2,
+				Placement:      10,
+			})
+			require.Error(t, err)
+			require.Len(t, nodes, 1)
+			require.Equal(t, node.ID(), nodes[0].ID)
+			require.Equal(t, tags, nodes[0].Tags)
+		})
+
+		t.Run("check-in without tags", func(t *testing.T) {
+			resp := checkIn(t)
+			require.Empty(t, resp.PingErrorMessage)
+
+			// checking in doesn't change the tags
+			tags, err := sat.Overlay.Service.GetNodeTags(ctx, node.ID())
+			require.NoError(t, err)
+			require.Len(t, tags, 1)
+		})
+	})
+}
+
+func TestSatelliteContactEndpoint_StorageNodeTags(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount: 1, StorageNodeCount: 1, UplinkCount: 0,
+		Reconfigure: testplanet.Reconfigure{
+			StorageNode: func(index int, config *storagenode.Config) {
+				config.Contact.Tags = "provider=storj,datacenter=dc1"
+			},
+		},
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		sat := planet.Satellites[0]
+		node := planet.StorageNodes[0]
+
+		require.NoError(t, node.Contact.Service.PingSatellites(ctx, time.Second))
+
+		tags, err := sat.Overlay.Service.GetNodeTags(ctx, node.ID())
+		require.NoError(t, err)
+		require.Len(t, tags, 2)
+		for _, tag := range tags {
+			require.Equal(t, node.ID(), tag.NodeID)
+			require.Equal(t, node.ID(), tag.Signer)
+		}
+	})
+}
@@ -0,0 +1,91 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package contact
+
+import (
+	"context"
+
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	"storj.io/common/identity"
+	"storj.io/common/rpc/rpcstatus"
+	"storj.io/common/signing"
+	"storj.io/storj/private/nodeextpb"
+	"storj.io/storj/private/nodetag"
+	"storj.io/storj/satellite/nodeselection/uploadselection"
+	"storj.io/storj/satellite/overlay"
+)
+
+var errNodeTags = errs.Class("node tags")
+
+// SetNodeTags replaces the tags of the calling node with the signed tags. The
+// node needs to check in before it can set its tags.
+func (endpoint *Endpoint) SetNodeTags(ctx context.Context, req *nodeextpb.SetNodeTagsRequest) (_ *nodeextpb.SetNodeTagsResponse, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	peerID, err := identity.PeerIdentityFromContext(ctx)
+	if err != nil {
+		endpoint.log.Info("failed to get node ID from context", zap.Error(err))
+		return nil, rpcstatus.Error(rpcstatus.Unauthenticated, errCheckInIdentity.New("failed to get ID from context: %v", err).Error())
+	}
+
+	_, err = endpoint.service.overlay.Get(ctx, peerID.ID)
+	if err != nil {
+		if overlay.ErrNodeNotFound.Has(err) {
+			return nil, rpcstatus.Error(rpcstatus.FailedPrecondition, errNodeTags.New("node should check in before setting its tags").Error())
+		}
+		endpoint.log.Info("failed to get node", zap.Stringer("Node ID", peerID.ID), zap.Error(err))
+		return nil, rpcstatus.Error(rpcstatus.Internal, Error.Wrap(err).Error())
+	}
+
+	err = endpoint.updateNodeTags(ctx, peerID, nodetag.FromProtobuf(req.Tags))
+	if err != nil {
+		endpoint.log.Info("failed to update node tags", zap.Stringer("Node ID", peerID.ID), zap.Error(err))
+		if errNodeTags.Has(err) {
+			return nil, rpcstatus.Error(rpcstatus.InvalidArgument, err.Error())
+		}
+		return nil, rpcstatus.Error(rpcstatus.Internal, err.Error())
+	}
+
+	return &nodeextpb.SetNodeTagsResponse{}, nil
+}
+
+// updateNodeTags replaces the tags of the node with the signed tags. A tag set
+// is accepted when it's signed by the node itself or by one of the trusted tag
+// authorities and it was issued for the calling node. When any of the tag sets
+// is invalid, the tags of the node are not changed.
+func (endpoint *Endpoint) updateNodeTags(ctx context.Context, peerID *identity.PeerIdentity, signedTags []*nodetag.SignedTagSet) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	nodeID := peerID.ID
+
+	var tags uploadselection.NodeTags
+	for _, signed := range signedTags {
+		var tagSet *nodetag.TagSet
+		if signed.SignerNodeID == nodeID {
+			tagSet, err = nodetag.Verify(ctx, signed, signing.SigneeFromPeerIdentity(peerID))
+		} else {
+			tagSet, err = endpoint.service.tagAuthority.Verify(ctx, signed)
+		}
+		if err != nil {
+			return errNodeTags.New("invalid tags signed by %s: %v", signed.SignerNodeID, err)
+		}
+		if tagSet.NodeID != nodeID {
+			return errNodeTags.New("tags are issued for a different node %s", tagSet.NodeID)
+		}
+
+		for _, tag := range tagSet.Tags {
+			tags = append(tags, uploadselection.NodeTag{
+				NodeID:   nodeID,
+				SignedAt: tagSet.SignedAt,
+				Signer:   signed.SignerNodeID,
+				Name:     tag.Name,
+				Value:    tag.Value,
+			})
+		}
+	}
+
+	return Error.Wrap(endpoint.service.overlay.UpdateNodeTags(ctx, nodeID, tags))
+}
@@ -17,6 +17,7 @@ import (
 	"storj.io/common/rpc/quic"
 	"storj.io/common/rpc/rpcstatus"
 	"storj.io/common/storj"
+	"storj.io/storj/private/nodetag"
 	"storj.io/storj/satellite/overlay"
 )
 
@@ -29,6 +30,8 @@ type Config struct {
 	RateLimitInterval  time.Duration `help:"the amount of time that should happen between contact attempts usually" releaseDefault:"10m0s" devDefault:"1ns"`
 	RateLimitBurst     int           `help:"the maximum burst size for the contact rate limit token bucket" releaseDefault:"2" devDefault:"1000"`
 	RateLimitCacheSize int           `help:"the number of nodes or addresses to keep token buckets for" default:"1000"`
+
+	TagAuthorities string `help:"comma-separated paths of the certificates of the authorities which are trusted to sign node tags" default:""`
 }
 
 // Service is the contact service between storage nodes and satellites.
@@ -49,10 +52,11 @@ type Service struct {
 	timeout        time.Duration
 	idLimiter      *RateLimiter
 	allowPrivateIP bool
+	tagAuthority   nodetag.Authority
 }
 
 // NewService creates a new contact service.
-func NewService(log *zap.Logger, self *overlay.NodeDossier, overlay *overlay.Service, peerIDs overlay.PeerIdentities, dialer rpc.Dialer, config Config) *Service {
+func NewService(log *zap.Logger, self *overlay.NodeDossier, overlay *overlay.Service, peerIDs overlay.PeerIdentities, dialer rpc.Dialer, tagAuthority nodetag.Authority, config Config) *Service {
 	return &Service{
 		log:            log,
 		self:           self,
@@ -62,6 +66,7 @@ func NewService(log *zap.Logger, self *overlay.NodeDossier, overlay *overlay.Ser
 		timeout:        config.Timeout,
 		idLimiter:      NewRateLimiter(config.RateLimitInterval, config.RateLimitBurst, config.RateLimitCacheSize),
 		allowPrivateIP: config.AllowPrivateIP,
+		tagAuthority:   tagAuthority,
 	}
 }
 
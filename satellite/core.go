@@ -38,6 +38,7 @@ import (
 	"storj.io/storj/satellite/metabase"
 	"storj.io/storj/satellite/metabase/segmentloop"
 	"storj.io/storj/satellite/metabase/zombiedeletion"
+	"storj.io/storj/satellite/metainfo/bucketlifecycle"
 	"storj.io/storj/satellite/metainfo/expireddeletion"
 	"storj.io/storj/satellite/metrics"
 	"storj.io/storj/satellite/nodeevents"
@@ -121,12 +122,17 @@ type Core struct {
 		Chore       *audit.Chore
 		Verifier    *audit.Verifier
 		Reporter    audit.Reporter
+		StatWorker  *audit.StatWorker
 	}
 
 	ExpiredDeletion struct {
 		Chore *expireddeletion.Chore
 	}
 
+	BucketLifecycle struct {
+		Chore *bucketlifecycle.Chore
+	}
+
 	ZombieDeletion struct {
 		Chore *zombiedeletion.Chore
 	}
@@ -355,13 +361,15 @@ func New(log *zap.Logger, full *identity.FullIdentity, db DB,
 			peer.Metainfo.SegmentLoop,
 			peer.Overlay.Service,
 			config.Checker)
-		peer.Services.Add(lifecycle.Item{
-			Name:  "repair:checker",
-			Run:   peer.Repair.Checker.Run,
-			Close: peer.Repair.Checker.Close,
-		})
-		peer.Debug.Server.Panel.Add(
-			debug.Cycle("Repair Checker", peer.Repair.Checker.Loop))
+		if !config.Checker.UseRangedLoop {
+			peer.Services.Add(lifecycle.Item{
+				Name:  "repair:checker",
+				Run:   peer.Repair.Checker.Run,
+				Close: peer.Repair.Checker.Close,
+			})
+			peer.Debug.Server.Panel.Add(
+				debug.Cycle("Repair Checker", peer.Repair.Checker.Loop))
+		}
 	}
 
 	{ // setup reputation
@@ -436,15 +444,34 @@ func New(log *zap.Logger, full *identity.FullIdentity, db DB,
 		peer.Audit.Chore = audit.NewChore(peer.Log.Named("audit:chore"),
 			peer.Audit.VerifyQueue,
 			peer.Metainfo.SegmentLoop,
+			peer.DB.Reputation(),
 			config,
 		)
-		peer.Services.Add(lifecycle.Item{
-			Name:  "audit:chore",
-			Run:   peer.Audit.Chore.Run,
-			Close: peer.Audit.Chore.Close,
-		})
-		peer.Debug.Server.Panel.Add(
-			debug.Cycle("Audit Chore", peer.Audit.Chore.Loop))
+		if !config.UseRangedLoop {
+			peer.Services.Add(lifecycle.Item{
+				Name:  "audit:chore",
+				Run:   peer.Audit.Chore.Run,
+				Close: peer.Audit.Chore.Close,
+			})
+			peer.Debug.Server.Panel.Add(
+				debug.Cycle("Audit Chore", peer.Audit.Chore.Loop))
+		}
+
+		if config.Stat.Enabled {
+			peer.Audit.StatWorker = audit.NewStatWorker(peer.Log.Named("audit:stat-worker"),
+				peer.Metainfo.Metabase,
+				peer.Audit.Verifier,
+				peer.Audit.Reporter,
+				config.Stat,
+			)
+			peer.Services.Add(lifecycle.Item{
+				Name:  "audit:stat-worker",
+				Run:   peer.Audit.StatWorker.Run,
+				Close: peer.Audit.StatWorker.Close,
+			})
+			peer.Debug.Server.Panel.Add(
+				debug.Cycle("Audit Stat Worker", peer.Audit.StatWorker.Loop))
+		}
 	}
 
 	{ // setup expired segment cleanup
@@ -462,6 +489,22 @@ func New(log *zap.Logger, full *identity.FullIdentity, db DB,
 			debug.Cycle("Expired Segments Chore", peer.ExpiredDeletion.Chore.Loop))
 	}
 
+	{ // setup bucket lifecycle rules
+		peer.BucketLifecycle.Chore = bucketlifecycle.NewChore(
+			peer.Log.Named("core-bucket-lifecycle"),
+			config.BucketLifecycle,
+			peer.DB.Buckets(),
+			peer.Metainfo.Metabase,
+		)
+		peer.Services.Add(lifecycle.Item{
+			Name:  "bucketlifecycle:chore",
+			Run:   peer.BucketLifecycle.Chore.Run,
+			Close: peer.BucketLifecycle.Chore.Close,
+		})
+		peer.Debug.Server.Panel.Add(
+			debug.Cycle("Bucket Lifecycle Chore", peer.BucketLifecycle.Chore.Loop))
+	}
+
 	{ // setup zombie objects cleanup
 		peer.ZombieDeletion.Chore = zombiedeletion.NewChore(
 			peer.Log.Named("core-zombie-deletion"),
@@ -136,7 +136,7 @@ func NewGarbageCollectionBF(log *zap.Logger, full *identity.FullIdentity, db DB,
 			peer.Metainfo.SegmentLoop,
 		)
 
-		if !config.GarbageCollectionBF.RunOnce {
+		if !config.GarbageCollectionBF.RunOnce && !config.GarbageCollectionBF.UseRangedLoop {
 			peer.Services.Add(lifecycle.Item{
 				Name: "garbage-collection-bf",
 				Run:  peer.GarbageCollection.Service.Run,
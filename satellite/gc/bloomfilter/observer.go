@@ -0,0 +1,196 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package bloomfilter
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	"storj.io/common/bloomfilter"
+	"storj.io/common/memory"
+	"storj.io/common/storj"
+	"storj.io/storj/satellite/metabase"
+	"storj.io/storj/satellite/metabase/rangedloop"
+	"storj.io/storj/satellite/overlay"
+)
+
+var _ rangedloop.Observer = (*Observer)(nil)
+
+// Observer implements the ranged loop Observer interface for collecting bloom filters.
+//
+// architecture: Observer
+type Observer struct {
+	log     *zap.Logger
+	config  Config
+	overlay overlay.DB
+	upload  *Upload
+
+	mu              sync.Mutex
+	startTime       time.Time
+	lastPieceCounts map[storj.NodeID]int64
+	// templates contains an empty filter for every node, so that the filters
+	// created by different partials have the same parameters and can be merged.
+	templates map[storj.NodeID]*bloomfilter.Filter
+
+	retainInfos        map[storj.NodeID]*RetainInfo
+	latestCreationTime time.Time
+}
+
+// NewObserver creates a new instance of the gc ranged loop observer.
+func NewObserver(log *zap.Logger, config Config, overlay overlay.DB) *Observer {
+	return &Observer{
+		log:     log,
+		config:  config,
+		overlay: overlay,
+		upload:  NewUpload(log, config),
+	}
+}
+
+// Start loads the last piece counts and prepares the observer for a new iteration.
+func (obs *Observer) Start(ctx context.Context, startTime time.Time, aliasMap metabase.NodeAliasMap) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	// the filters couldn't be uploaded, so don't collect them.
+	if err := obs.config.Validate(); err != nil {
+		return err
+	}
+
+	obs.log.Debug("collecting bloom filters started")
+
+	// load last piece counts from overlay db
+	lastPieceCounts, err := obs.overlay.AllPieceCounts(ctx)
+	if err != nil {
+		obs.log.Error("error getting last piece counts", zap.Error(err))
+		err = nil
+	}
+	if lastPieceCounts == nil {
+		lastPieceCounts = make(map[storj.NodeID]int64)
+	}
+
+	obs.mu.Lock()
+	defer obs.mu.Unlock()
+
+	obs.startTime = startTime
+	obs.lastPieceCounts = lastPieceCounts
+	obs.templates = make(map[storj.NodeID]*bloomfilter.Filter)
+	obs.retainInfos = make(map[storj.NodeID]*RetainInfo, len(lastPieceCounts))
+	obs.latestCreationTime = time.Time{}
+
+	return nil
+}
+
+// Fork creates a PieceTracker to process a chunk of all the segments.
+func (obs *Observer) Fork(ctx context.Context) (_ rangedloop.Partial, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	pieceTracker := NewPieceTracker(obs.log.Named("gc observer"), obs.config, obs.lastPieceCounts)
+	pieceTracker.startTime = obs.startTime
+	pieceTracker.newFilter = obs.newFilter
+
+	return pieceTracker, nil
+}
+
+// Join merges the bloom filters of the PieceTracker.
+func (obs *Observer) Join(ctx context.Context, partial rangedloop.Partial) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	pieceTracker, ok := partial.(*PieceTracker)
+	if !ok {
+		return errs.New("expected partial type %T but got %T", pieceTracker, partial)
+	}
+
+	obs.mu.Lock()
+	defer obs.mu.Unlock()
+
+	if obs.latestCreationTime.Before(pieceTracker.LatestCreationTime) {
+		obs.latestCreationTime = pieceTracker.LatestCreationTime
+	}
+
+	for nodeID, info := range pieceTracker.RetainInfos {
+		existing, ok := obs.retainInfos[nodeID]
+		if !ok {
+			obs.retainInfos[nodeID] = info
+			continue
+		}
+
+		merged, err := mergeFilters(existing.Filter, info.Filter)
+		if err != nil {
+			return err
+		}
+		existing.Filter = merged
+		existing.Count += info.Count
+	}
+
+	return nil
+}
+
+// Finish uploads the bloom filters.
+func (obs *Observer) Finish(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	obs.mu.Lock()
+	defer obs.mu.Unlock()
+
+	retainInfos, err := checkRetainInfos(obs.log, obs.config, obs.lastPieceCounts, obs.retainInfos)
+	if err != nil {
+		obs.log.Error("bloom filters not uploaded", zap.Error(err))
+		return nil
+	}
+
+	err = obs.upload.UploadBloomFilters(ctx, obs.latestCreationTime, retainInfos)
+	if err != nil {
+		return err
+	}
+
+	updatePieceCounts(ctx, obs.log, obs.config, obs.overlay, obs.retainInfos, retainInfos)
+
+	obs.log.Debug("collecting bloom filters finished")
+
+	return nil
+}
+
+// newFilter returns an empty filter with the same parameters for all partials.
+func (obs *Observer) newFilter(nodeID storj.NodeID, numPieces int64) *bloomfilter.Filter {
+	obs.mu.Lock()
+	defer obs.mu.Unlock()
+
+	template, ok := obs.templates[nodeID]
+	if !ok {
+		// limit size of bloom filter to ensure we are under the limit for RPC
+		template = bloomfilter.NewOptimalMaxSize(numPieces, obs.config.FalsePositiveRate, 2*memory.MiB)
+		obs.templates[nodeID] = template
+	}
+
+	// Bytes returns a copy, hence the new filter doesn't share the table.
+	filter, err := bloomfilter.NewFromBytes(template.Bytes())
+	if err != nil {
+		// the template is always a valid filter.
+		panic(err)
+	}
+	return filter
+}
+
+// mergeFilters combines two filters with the same parameters.
+func mergeFilters(a, b *bloomfilter.Filter) (*bloomfilter.Filter, error) {
+	bytesA, bytesB := a.Bytes(), b.Bytes()
+	if len(bytesA) != len(bytesB) {
+		return nil, errs.New("bloom filters have different sizes: %d != %d", len(bytesA), len(bytesB))
+	}
+
+	// the first three bytes contain the version, seed and hash count.
+	for i := 0; i < 3; i++ {
+		if bytesA[i] != bytesB[i] {
+			return nil, errs.New("bloom filters have different parameters")
+		}
+	}
+	for i := 3; i < len(bytesA); i++ {
+		bytesA[i] |= bytesB[i]
+	}
+
+	return bloomfilter.NewFromBytes(bytesA)
+}
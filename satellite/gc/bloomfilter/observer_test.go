@@ -0,0 +1,44 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package bloomfilter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"storj.io/common/bloomfilter"
+	"storj.io/common/storj"
+	"storj.io/common/testrand"
+)
+
+func TestObserverMergeFilters(t *testing.T) {
+	obs := NewObserver(zaptest.NewLogger(t), Config{FalsePositiveRate: 0.1}, nil)
+	obs.templates = make(map[storj.NodeID]*bloomfilter.Filter)
+
+	nodeID := testrand.NodeID()
+	a := obs.newFilter(nodeID, 100)
+	b := obs.newFilter(nodeID, 100)
+
+	var pieces []storj.PieceID
+	for i := 0; i < 50; i++ {
+		pieceID := testrand.PieceID()
+		pieces = append(pieces, pieceID)
+		if i%2 == 0 {
+			a.Add(pieceID)
+		} else {
+			b.Add(pieceID)
+		}
+	}
+
+	merged, err := mergeFilters(a, b)
+	require.NoError(t, err)
+	for _, pieceID := range pieces {
+		require.True(t, merged.Contains(pieceID))
+	}
+
+	_, err = mergeFilters(a, obs.newFilter(testrand.NodeID(), 100000))
+	require.Error(t, err)
+}
@@ -22,6 +22,8 @@ var _ segmentloop.Observer = (*PieceTracker)(nil)
 type RetainInfo struct {
 	Filter *bloomfilter.Filter
 	Count  int
+	// LastCount is the last piece count of the node, 0 when it's unknown.
+	LastCount int64
 }
 
 // PieceTracker implements the segments loop observer interface for garbage collection.
@@ -33,6 +35,8 @@ type PieceTracker struct {
 	// TODO: should we use int or int64 consistently for piece count (db type is int64)?
 	pieceCounts map[storj.NodeID]int64
 	startTime   time.Time
+	// newFilter creates an empty bloom filter for the node.
+	newFilter func(nodeID storj.NodeID, numPieces int64) *bloomfilter.Filter
 
 	RetainInfos map[storj.NodeID]*RetainInfo
 	// LatestCreationTime will be used to set bloom filter CreationDate.
@@ -47,6 +51,10 @@ func NewPieceTracker(log *zap.Logger, config Config, pieceCounts map[storj.NodeI
 		log:         log,
 		config:      config,
 		pieceCounts: pieceCounts,
+		newFilter: func(nodeID storj.NodeID, numPieces int64) *bloomfilter.Filter {
+			// limit size of bloom filter to ensure we are under the limit for RPC
+			return bloomfilter.NewOptimalMaxSize(numPieces, config.FalsePositiveRate, 2*memory.MiB)
+		},
 
 		RetainInfos: make(map[storj.NodeID]*RetainInfo, len(pieceCounts)),
 	}
@@ -92,10 +100,9 @@ func (pieceTracker *PieceTracker) add(nodeID storj.NodeID, pieceID storj.PieceID
 		if pieceTracker.pieceCounts[nodeID] > 0 {
 			numPieces = pieceTracker.pieceCounts[nodeID]
 		}
-		// limit size of bloom filter to ensure we are under the limit for RPC
-		filter := bloomfilter.NewOptimalMaxSize(numPieces, pieceTracker.config.FalsePositiveRate, 2*memory.MiB)
 		info = &RetainInfo{
-			Filter: filter,
+			Filter:    pieceTracker.newFilter(nodeID, numPieces),
+			LastCount: pieceTracker.pieceCounts[nodeID],
 		}
 		pieceTracker.RetainInfos[nodeID] = info
 	}
@@ -104,6 +111,19 @@ func (pieceTracker *PieceTracker) add(nodeID storj.NodeID, pieceID storj.PieceID
 	info.Count++
 }
 
+// Process is called repeatedly with batches of segments by the ranged loop.
+func (pieceTracker *PieceTracker) Process(ctx context.Context, segments []segmentloop.Segment) error {
+	for i := range segments {
+		if segments[i].Inline() {
+			continue
+		}
+		if err := pieceTracker.RemoteSegment(ctx, &segments[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // InlineSegment returns nil because we're only doing gc for storage nodes for now.
 func (pieceTracker *PieceTracker) InlineSegment(ctx context.Context, segment *segmentloop.Segment) (err error) {
 	return nil
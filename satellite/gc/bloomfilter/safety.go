@@ -0,0 +1,138 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package bloomfilter
+
+import (
+	"context"
+
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	"storj.io/common/storj"
+	"storj.io/storj/satellite/overlay"
+)
+
+// ErrIncompleteCollection is returned when the bloom filters together would let the nodes
+// delete too many pieces, which means that the collection of the pieces was incomplete.
+var ErrIncompleteCollection = errs.Class("incomplete bloom filter collection")
+
+// DeletionRatio returns the part of the last piece count of a node, which a bloom filter
+// with pieceCount pieces implies to be garbage. It's 0 when the last piece count isn't known.
+func DeletionRatio(pieceCount, lastPieceCount int64) float64 {
+	if lastPieceCount <= 0 || pieceCount >= lastPieceCount {
+		return 0
+	}
+	return float64(lastPieceCount-pieceCount) / float64(lastPieceCount)
+}
+
+// checkRetainInfos compares the piece counts of the bloom filters with the last piece counts
+// of the nodes. It returns the filters which are safe to send, refusing those which would
+// let a node delete a larger part of its pieces than allowed. It returns an error when the
+// filters together would let the nodes delete a larger part of their pieces than allowed.
+//
+// Only the nodes with at least the minimum last piece count are checked, because the
+// ratio is meaningless for nodes with only a few pieces.
+func checkRetainInfos(log *zap.Logger, config Config, lastPieceCounts map[storj.NodeID]int64, retainInfos map[storj.NodeID]*RetainInfo) (_ map[storj.NodeID]*RetainInfo, err error) {
+	var totalCount, totalLastCount int64
+	for nodeID, info := range retainInfos {
+		lastCount := lastPieceCounts[nodeID]
+		if lastCount < config.MinPieceCount || lastCount <= 0 {
+			continue
+		}
+		totalCount += int64(info.Count)
+		totalLastCount += lastCount
+	}
+
+	totalRatio := DeletionRatio(totalCount, totalLastCount)
+	mon.FloatVal("bloomfilter_total_deletion_ratio").Observe(totalRatio)
+	if config.MaxTotalDeletionRatio > 0 && totalRatio > config.MaxTotalDeletionRatio {
+		log.Error("bloom filters refused, they would delete too many pieces",
+			zap.Int64("Piece Count", totalCount),
+			zap.Int64("Last Piece Count", totalLastCount),
+			zap.Float64("Deletion Ratio", totalRatio))
+		return nil, ErrIncompleteCollection.New("deletion ratio %.4f exceeds %.4f", totalRatio, config.MaxTotalDeletionRatio)
+	}
+
+	if config.MaxDeletionRatio <= 0 {
+		return retainInfos, nil
+	}
+
+	accepted := make(map[storj.NodeID]*RetainInfo, len(retainInfos))
+	var refused int64
+	for nodeID, info := range retainInfos {
+		lastCount := lastPieceCounts[nodeID]
+		if lastCount >= config.MinPieceCount {
+			ratio := DeletionRatio(int64(info.Count), lastCount)
+			if ratio > config.MaxDeletionRatio {
+				log.Error("bloom filter refused, it would delete too many pieces",
+					zap.Stringer("Node ID", nodeID),
+					zap.Int("Piece Count", info.Count),
+					zap.Int64("Last Piece Count", lastCount),
+					zap.Float64("Deletion Ratio", ratio))
+				refused++
+				continue
+			}
+		}
+		accepted[nodeID] = info
+	}
+	mon.IntVal("bloomfilter_refused_filters").Observe(refused)
+
+	return accepted, nil
+}
+
+// updatePieceCounts stores the piece counts of the sent bloom filters as the last piece
+// counts of the nodes, which the next bloom filters are compared to.
+//
+// The refused filters are counted per node. A node may have lost its pieces or a large part
+// of its pieces may have been deleted, so after the configured number of consecutive refused
+// filters, the piece count of the refused filter is stored as the last piece count, which lets
+// the next filter of the node be accepted.
+func updatePieceCounts(ctx context.Context, log *zap.Logger, config Config, db overlay.DB, collected, accepted map[storj.NodeID]*RetainInfo) {
+	if len(collected) == 0 {
+		return
+	}
+
+	pieceCounts := make(map[storj.NodeID]int64, len(collected))
+	var refused, reset []storj.NodeID
+	for nodeID, info := range collected {
+		if _, ok := accepted[nodeID]; ok {
+			pieceCounts[nodeID] = int64(info.Count)
+			reset = append(reset, nodeID)
+		} else {
+			refused = append(refused, nodeID)
+		}
+	}
+
+	refusals, err := db.UpdateBloomFilterRefusals(ctx, refused, reset)
+	if err != nil {
+		log.Error("error updating bloom filter refusals", zap.Error(err))
+	}
+
+	var updated []storj.NodeID
+	for nodeID, count := range refusals {
+		if config.RefusalsBeforeUpdate <= 0 || count < config.RefusalsBeforeUpdate {
+			continue
+		}
+		info := collected[nodeID]
+		log.Warn("last piece count updated after consecutive refused bloom filters",
+			zap.Stringer("Node ID", nodeID),
+			zap.Int("Refusals", count),
+			zap.Int("Piece Count", info.Count),
+			zap.Int64("Last Piece Count", info.LastCount))
+		pieceCounts[nodeID] = int64(info.Count)
+		updated = append(updated, nodeID)
+	}
+	mon.IntVal("bloomfilter_refusal_updated_piece_counts").Observe(int64(len(updated)))
+
+	if err := db.UpdatePieceCounts(ctx, pieceCounts); err != nil {
+		log.Error("error updating piece counts", zap.Error(err))
+		return
+	}
+
+	if len(updated) > 0 {
+		if _, err := db.UpdateBloomFilterRefusals(ctx, nil, updated); err != nil {
+			log.Error("error resetting bloom filter refusals", zap.Error(err))
+		}
+	}
+}
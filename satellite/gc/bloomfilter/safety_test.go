@@ -0,0 +1,140 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package bloomfilter
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"storj.io/common/storj"
+	"storj.io/common/testcontext"
+	"storj.io/common/testrand"
+	"storj.io/storj/satellite/overlay"
+)
+
+func TestDeletionRatio(t *testing.T) {
+	require.Equal(t, 0.0, DeletionRatio(10, 0))
+	require.Equal(t, 0.0, DeletionRatio(120, 100))
+	require.Equal(t, 0.0, DeletionRatio(100, 100))
+	require.Equal(t, 0.25, DeletionRatio(75, 100))
+	require.Equal(t, 1.0, DeletionRatio(0, 100))
+}
+
+func TestCheckRetainInfos(t *testing.T) {
+	config := Config{
+		MaxDeletionRatio:      0.5,
+		MaxTotalDeletionRatio: 0.25,
+		MinPieceCount:         1000,
+	}
+
+	healthy, shrunk, small, unknown := testrand.NodeID(), testrand.NodeID(), testrand.NodeID(), testrand.NodeID()
+	lastPieceCounts := map[storj.NodeID]int64{
+		healthy: 10000,
+		shrunk:  2000,
+		small:   100,
+	}
+	retainInfos := map[storj.NodeID]*RetainInfo{
+		healthy: {Count: 9500},
+		shrunk:  {Count: 500},
+		small:   {Count: 1},
+		unknown: {Count: 10},
+	}
+
+	t.Run("refuse node filters", func(t *testing.T) {
+		accepted, err := checkRetainInfos(zaptest.NewLogger(t), config, lastPieceCounts, retainInfos)
+		require.NoError(t, err)
+		require.Len(t, accepted, 3)
+		require.NotContains(t, accepted, shrunk)
+		// the nodes with few or unknown pieces aren't checked.
+		require.Contains(t, accepted, small)
+		require.Contains(t, accepted, unknown)
+	})
+
+	t.Run("refuse incomplete collection", func(t *testing.T) {
+		incomplete := map[storj.NodeID]*RetainInfo{
+			healthy: {Count: 6000},
+			shrunk:  {Count: 2000},
+		}
+		_, err := checkRetainInfos(zaptest.NewLogger(t), config, lastPieceCounts, incomplete)
+		require.True(t, ErrIncompleteCollection.Has(err))
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		accepted, err := checkRetainInfos(zaptest.NewLogger(t), Config{}, lastPieceCounts, retainInfos)
+		require.NoError(t, err)
+		require.Len(t, accepted, 4)
+	})
+}
+
+// pieceCountDB stores the piece counts and the bloom filter refusals in memory.
+type pieceCountDB struct {
+	overlay.DB
+	pieceCounts map[storj.NodeID]int64
+	refusals    map[storj.NodeID]int
+}
+
+func (db *pieceCountDB) UpdatePieceCounts(ctx context.Context, pieceCounts map[storj.NodeID]int64) error {
+	for nodeID, count := range pieceCounts {
+		db.pieceCounts[nodeID] = count
+	}
+	return nil
+}
+
+func (db *pieceCountDB) UpdateBloomFilterRefusals(ctx context.Context, refused, reset []storj.NodeID) (map[storj.NodeID]int, error) {
+	for _, nodeID := range reset {
+		delete(db.refusals, nodeID)
+	}
+	refusals := make(map[storj.NodeID]int, len(refused))
+	for _, nodeID := range refused {
+		db.refusals[nodeID]++
+		refusals[nodeID] = db.refusals[nodeID]
+	}
+	return refusals, nil
+}
+
+func TestUpdatePieceCounts(t *testing.T) {
+	ctx := testcontext.New(t)
+
+	config := Config{RefusalsBeforeUpdate: 2}
+	healthy, shrunk := testrand.NodeID(), testrand.NodeID()
+	db := &pieceCountDB{
+		pieceCounts: map[storj.NodeID]int64{healthy: 10000, shrunk: 2000},
+		refusals:    map[storj.NodeID]int{},
+	}
+
+	collected := map[storj.NodeID]*RetainInfo{
+		healthy: {Count: 9500, LastCount: 10000},
+		shrunk:  {Count: 500, LastCount: 2000},
+	}
+	accepted := map[storj.NodeID]*RetainInfo{healthy: collected[healthy]}
+
+	// the first refusal keeps the last piece count.
+	updatePieceCounts(ctx, zaptest.NewLogger(t), config, db, collected, accepted)
+	require.Equal(t, int64(9500), db.pieceCounts[healthy])
+	require.Equal(t, int64(2000), db.pieceCounts[shrunk])
+	require.Equal(t, 1, db.refusals[shrunk])
+	require.NotContains(t, db.refusals, healthy)
+
+	// the consecutive refusal updates the last piece count and resets the refusals.
+	updatePieceCounts(ctx, zaptest.NewLogger(t), config, db, collected, accepted)
+	require.Equal(t, int64(500), db.pieceCounts[shrunk])
+	require.NotContains(t, db.refusals, shrunk)
+
+	// an accepted filter resets the refusals.
+	updatePieceCounts(ctx, zaptest.NewLogger(t), config, db, collected, accepted)
+	require.Equal(t, 1, db.refusals[shrunk])
+	updatePieceCounts(ctx, zaptest.NewLogger(t), config, db, collected, collected)
+	require.NotContains(t, db.refusals, shrunk)
+
+	// without the update, the last piece count is kept.
+	config.RefusalsBeforeUpdate = 0
+	for i := 0; i < 3; i++ {
+		updatePieceCounts(ctx, zaptest.NewLogger(t), config, db, collected, accepted)
+	}
+	require.Equal(t, int64(500), db.pieceCounts[shrunk])
+	require.Equal(t, 3, db.refusals[shrunk])
+}
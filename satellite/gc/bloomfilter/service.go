@@ -4,22 +4,17 @@
 package bloomfilter
 
 import (
-	"archive/zip"
 	"context"
-	"strconv"
 	"time"
 
 	"github.com/spacemonkeygo/monkit/v3"
 	"github.com/zeebo/errs"
 	"go.uber.org/zap"
 
-	"storj.io/common/pb"
 	"storj.io/common/storj"
 	"storj.io/common/sync2"
-	"storj.io/storj/satellite/internalpb"
 	"storj.io/storj/satellite/metabase/segmentloop"
 	"storj.io/storj/satellite/overlay"
-	"storj.io/uplink"
 )
 
 // LATEST is the name of the file that contains the most recently completed bloomfilter generation prefix.
@@ -43,6 +38,24 @@ type Config struct {
 	Bucket       string        `help:"Bucket which will be used to upload bloom filters" default:"" testDefault:"gc-queue"` // TODO do we need full location?
 	ZipBatchSize int           `help:"how many bloom filters will be packed in a single zip" default:"500" testDefault:"2"`
 	ExpireIn     time.Duration `help:"how quickly uploaded bloom filters will be automatically deleted" default:"336h"`
+
+	UseRangedLoop bool `help:"whether to use the ranged loop instead of the segment loop for collecting bloom filters" default:"false"`
+
+	MaxDeletionRatio      float64 `help:"the maximum part of the last piece count of a node, which its bloom filter may imply to be garbage; larger filters are refused (0 disables the check)" default:"0.5"`
+	MaxTotalDeletionRatio float64 `help:"the maximum part of the last piece counts of all nodes, which the bloom filters may imply to be garbage; otherwise the collection is considered incomplete and no filters are uploaded (0 disables the check)" default:"0.25"`
+	MinPieceCount         int64   `help:"the minimum last piece count of a node for checking the deletion ratio of its bloom filter" default:"1000"`
+	RefusalsBeforeUpdate  int     `help:"the number of consecutive refused bloom filters of a node, after which its last piece count is updated to the piece count of the refused filter, so that the next filter is accepted if the node really lost the pieces (0 disables the update)" default:"3"`
+}
+
+// Validate checks that the bloom filters can be uploaded.
+func (config Config) Validate() error {
+	switch {
+	case config.AccessGrant == "":
+		return errs.New("Access Grant is not set")
+	case config.Bucket == "":
+		return errs.New("Bucket is not set")
+	}
+	return nil
 }
 
 // Service implements service to collect bloom filters for the garbage collection.
@@ -55,6 +68,7 @@ type Service struct {
 
 	overlay     overlay.DB
 	segmentLoop *segmentloop.Service
+	upload      *Upload
 }
 
 // NewService creates a new instance of the gc service.
@@ -65,6 +79,7 @@ func NewService(log *zap.Logger, config Config, overlay overlay.DB, loop *segmen
 		Loop:        sync2.NewCycle(config.Interval),
 		overlay:     overlay,
 		segmentLoop: loop,
+		upload:      NewUpload(log, config),
 	}
 }
 
@@ -76,11 +91,8 @@ func (service *Service) Run(ctx context.Context) (err error) {
 		return nil
 	}
 
-	switch {
-	case service.config.AccessGrant == "":
-		return errs.New("Access Grant is not set")
-	case service.config.Bucket == "":
-		return errs.New("Bucket is not set")
+	if err := service.config.Validate(); err != nil {
+		return err
 	}
 
 	return service.Loop.Run(ctx, service.RunOnce)
@@ -111,176 +123,20 @@ func (service *Service) RunOnce(ctx context.Context) (err error) {
 		return nil
 	}
 
-	err = service.uploadBloomFilters(ctx, pieceTracker.LatestCreationTime, pieceTracker.RetainInfos)
-	if err != nil {
-		return err
-	}
-
-	service.log.Debug("collecting bloom filters finished")
-
-	return nil
-}
-
-// uploadBloomFilters stores a zipfile with multiple bloom filters in a bucket.
-func (service *Service) uploadBloomFilters(ctx context.Context, latestCreationDate time.Time, retainInfos map[storj.NodeID]*RetainInfo) (err error) {
-	defer mon.Task()(&ctx)(&err)
-
-	if len(retainInfos) == 0 {
-		return nil
-	}
-
-	prefix := time.Now().Format(time.RFC3339)
-
-	expirationTime := time.Now().Add(service.config.ExpireIn)
-
-	accessGrant, err := uplink.ParseAccess(service.config.AccessGrant)
-	if err != nil {
-		return err
-	}
-
-	project, err := uplink.OpenProject(ctx, accessGrant)
-	if err != nil {
-		return err
-	}
-	defer func() {
-		// do cleanup in case of any error while uploading bloom filters
-		if err != nil {
-			// TODO should we drop whole bucket if cleanup will fail
-			err = errs.Combine(err, service.cleanup(ctx, project, prefix))
-		}
-		err = errs.Combine(err, project.Close())
-	}()
-
-	_, err = project.EnsureBucket(ctx, service.config.Bucket)
-	if err != nil {
-		return err
-	}
-
-	// TODO move it before segment loop is started
-	o := uplink.ListObjectsOptions{
-		Prefix: prefix + "/",
-	}
-	iterator := project.ListObjects(ctx, service.config.Bucket, &o)
-	for iterator.Next() {
-		if iterator.Item().IsPrefix {
-			continue
-		}
-
-		service.log.Warn("target bucket was not empty, stop operation and wait for next execution", zap.String("bucket", service.config.Bucket))
-		return nil
-	}
-
-	infos := make([]internalpb.RetainInfo, 0, service.config.ZipBatchSize)
-	batchNumber := 0
-	for nodeID, info := range retainInfos {
-		infos = append(infos, internalpb.RetainInfo{
-			Filter: info.Filter.Bytes(),
-			// because bloom filters should be created from immutable database
-			// snapshot we are using latest segment creation date
-			CreationDate:  latestCreationDate,
-			PieceCount:    int64(info.Count),
-			StorageNodeId: nodeID,
-		})
-
-		if len(infos) == service.config.ZipBatchSize {
-			err = service.uploadPack(ctx, project, prefix, batchNumber, expirationTime, infos)
-			if err != nil {
-				return err
-			}
-
-			infos = infos[:0]
-			batchNumber++
-		}
-	}
-
-	// upload rest of infos if any
-	if err := service.uploadPack(ctx, project, prefix, batchNumber, expirationTime, infos); err != nil {
-		return err
-	}
-
-	// update LATEST file
-	upload, err := project.UploadObject(ctx, service.config.Bucket, LATEST, nil)
+	retainInfos, err := checkRetainInfos(service.log, service.config, lastPieceCounts, pieceTracker.RetainInfos)
 	if err != nil {
-		return err
-	}
-	_, err = upload.Write([]byte(prefix))
-	if err != nil {
-		return err
-	}
-
-	return upload.Commit()
-}
-
-// uploadPack uploads single zip pack with multiple bloom filters.
-func (service *Service) uploadPack(ctx context.Context, project *uplink.Project, prefix string, batchNumber int, expirationTime time.Time, infos []internalpb.RetainInfo) (err error) {
-	defer mon.Task()(&ctx)(&err)
-
-	if len(infos) == 0 {
+		service.log.Error("bloom filters not uploaded", zap.Error(err))
 		return nil
 	}
 
-	upload, err := project.UploadObject(ctx, service.config.Bucket, prefix+"/bloomfilters-"+strconv.Itoa(batchNumber)+".zip", &uplink.UploadOptions{
-		Expires: expirationTime,
-	})
+	err = service.upload.UploadBloomFilters(ctx, pieceTracker.LatestCreationTime, retainInfos)
 	if err != nil {
 		return err
 	}
 
-	zipWriter := zip.NewWriter(upload)
-	defer func() {
-		err = errs.Combine(err, zipWriter.Close())
-		if err != nil {
-			err = errs.Combine(err, upload.Abort())
-		} else {
-			err = upload.Commit()
-		}
-	}()
-
-	for _, info := range infos {
-		retainInfoBytes, err := pb.Marshal(&info)
-		if err != nil {
-			return err
-		}
-
-		writer, err := zipWriter.Create(info.StorageNodeId.String())
-		if err != nil {
-			return err
-		}
+	updatePieceCounts(ctx, service.log, service.config, service.overlay, pieceTracker.RetainInfos, retainInfos)
 
-		write, err := writer.Write(retainInfoBytes)
-		if err != nil {
-			return err
-		}
-		if len(retainInfoBytes) != write {
-			return errs.New("not whole bloom filter was written")
-		}
-	}
+	service.log.Debug("collecting bloom filters finished")
 
 	return nil
 }
-
-// cleanup moves all objects from root location to unique prefix. Objects will be deleted
-// automatically when expires.
-func (service *Service) cleanup(ctx context.Context, project *uplink.Project, prefix string) (err error) {
-	defer mon.Task()(&ctx)(&err)
-
-	errPrefix := "upload-error-" + time.Now().Format(time.RFC3339)
-	o := uplink.ListObjectsOptions{
-		Prefix: prefix + "/",
-	}
-	iterator := project.ListObjects(ctx, service.config.Bucket, &o)
-
-	for iterator.Next() {
-		item := iterator.Item()
-		if item.IsPrefix {
-			continue
-		}
-
-		err := project.MoveObject(ctx, service.config.Bucket, item.Key, service.config.Bucket, prefix+"/"+errPrefix+"/"+item.Key, nil)
-		if err != nil {
-			return err
-		}
-	}
-
-	return iterator.Err()
-}
@@ -23,6 +23,7 @@ import (
 	"storj.io/storj/satellite"
 	"storj.io/storj/satellite/gc/bloomfilter"
 	"storj.io/storj/satellite/internalpb"
+	"storj.io/storj/satellite/metabase/rangedloop"
 	"storj.io/uplink"
 )
 
@@ -191,3 +192,85 @@ func TestGarbageCollectionBloomFilters_AllowNotEmptyBucket(t *testing.T) {
 		require.Contains(t, keys, bloomfilter.LATEST)
 	})
 }
+
+func TestGarbageCollectionBloomFiltersRangedLoop(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount:   1,
+		StorageNodeCount: 4,
+		UplinkCount:      1,
+		Reconfigure: testplanet.Reconfigure{
+			Satellite: testplanet.ReconfigureRS(2, 2, 4, 4),
+		},
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		for i := 0; i < 5; i++ {
+			err := planet.Uplinks[0].Upload(ctx, planet.Satellites[0], "testbucket", "object-"+strconv.Itoa(i), testrand.Bytes(10*memory.KiB))
+			require.NoError(t, err)
+		}
+
+		access := planet.Uplinks[0].Access[planet.Satellites[0].ID()]
+		accessString, err := access.Serialize()
+		require.NoError(t, err)
+
+		project, err := planet.Uplinks[0].OpenProject(ctx, planet.Satellites[0])
+		require.NoError(t, err)
+		defer ctx.Check(project.Close)
+
+		runObserver := func(config bloomfilter.Config) error {
+			observer := bloomfilter.NewObserver(zaptest.NewLogger(t), config, planet.Satellites[0].Overlay.DB)
+			service := rangedloop.NewService(zaptest.NewLogger(t), rangedloop.Config{
+				Parallelism: 3,
+				BatchSize:   2,
+			}, planet.Satellites[0].Metabase.DB, []rangedloop.Observer{observer})
+			return service.RunOnce(ctx)
+		}
+
+		// the observer fails without a bucket to upload the filters to
+		config := planet.Satellites[0].Config.GarbageCollectionBF
+		config.Enabled = true
+		config.AccessGrant = accessString
+		config.Bucket = ""
+		require.Error(t, runObserver(config))
+
+		config.Bucket = "bloomfilters-rangedloop"
+		require.NoError(t, runObserver(config))
+
+		download, err := project.DownloadObject(ctx, config.Bucket, bloomfilter.LATEST, nil)
+		require.NoError(t, err)
+		prefix, err := io.ReadAll(download)
+		require.NoError(t, err)
+		require.NoError(t, download.Close())
+
+		// the filters merged from all ranges contain the pieces of every node
+		nodePieces := map[string]int64{}
+		segments, err := planet.Satellites[0].Metabase.DB.TestingAllSegments(ctx)
+		require.NoError(t, err)
+		for _, segment := range segments {
+			for _, piece := range segment.Pieces {
+				nodePieces[piece.StorageNode.String()]++
+			}
+		}
+
+		iterator := project.ListObjects(ctx, config.Bucket, &uplink.ListObjectsOptions{Prefix: string(prefix) + "/"})
+		filters := map[string]int64{}
+		for iterator.Next() {
+			data, err := planet.Uplinks[0].Download(ctx, planet.Satellites[0], config.Bucket, iterator.Item().Key)
+			require.NoError(t, err)
+
+			zipReader, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+			require.NoError(t, err)
+			for _, file := range zipReader.File {
+				bfReader, err := file.Open()
+				require.NoError(t, err)
+				data, err := io.ReadAll(bfReader)
+				require.NoError(t, err)
+				require.NoError(t, bfReader.Close())
+
+				var retainInfo internalpb.RetainInfo
+				require.NoError(t, pb.Unmarshal(data, &retainInfo))
+				filters[retainInfo.StorageNodeId.String()] = retainInfo.PieceCount
+			}
+		}
+		require.NoError(t, iterator.Err())
+		require.Equal(t, nodePieces, filters)
+	})
+}
@@ -43,6 +43,9 @@ type Config struct {
 	AccessGrant string        `help:"Access to download the bloom filters. Needs read and write permission."`
 	Bucket      string        `help:"bucket where retain info is stored" default:"" testDefault:"gc-queue"`
 	ExpireIn    time.Duration `help:"Expiration of newly created objects. These objects store error messages." default:"336h"`
+
+	MaxDeletionRatio float64 `help:"the maximum part of the previous piece count of a node, which a retain filter may imply to be garbage; larger filters are not sent (0 disables the check)" default:"0.5"`
+	MinPieceCount    int64   `help:"the minimum previous piece count of a node for checking the deletion ratio of its retain filter" default:"1000"`
 }
 
 // NewService creates a new instance of the gc sender service.
@@ -169,6 +172,19 @@ func (service *Service) sendRetainRequest(ctx context.Context, retainInfo *inter
 		return nil
 	}
 
+	// avoid sending bloom filters which would delete too many pieces of the node. The
+	// filter is compared to the piece count of the previous filter, because the piece
+	// count of the node is already updated to the piece count of this filter.
+	lastCount := retainInfo.LastPieceCount
+	if service.Config.MaxDeletionRatio > 0 && lastCount > 0 && lastCount >= service.Config.MinPieceCount {
+		ratio := bloomfilter.DeletionRatio(retainInfo.PieceCount, lastCount)
+		if ratio > service.Config.MaxDeletionRatio {
+			mon.Counter("retain_filters_refused").Inc(1)
+			return Error.New("retain filter refused for node %s: it implies %.4f of %d pieces to be garbage",
+				retainInfo.StorageNodeId, ratio, lastCount)
+		}
+	}
+
 	if service.Config.RetainSendTimeout > 0 {
 		var cancel func()
 		ctx, cancel = context.WithTimeout(ctx, service.Config.RetainSendTimeout)
@@ -31,8 +31,10 @@ type RetainInfo struct {
 	CreationDate time.Time `protobuf:"bytes,1,opt,name=creation_date,json=creationDate,proto3,stdtime" json:"creation_date"`
 	Filter       []byte    `protobuf:"bytes,2,opt,name=filter,proto3" json:"filter,omitempty"`
 	// number of pieces to retain on the node through the above bloom filter.
-	PieceCount           int64    `protobuf:"varint,3,opt,name=piece_count,json=pieceCount,proto3" json:"piece_count,omitempty"`
-	StorageNodeId        NodeID   `protobuf:"bytes,4,opt,name=storage_node_id,json=storageNodeId,proto3,customtype=NodeID" json:"storage_node_id"`
+	PieceCount    int64  `protobuf:"varint,3,opt,name=piece_count,json=pieceCount,proto3" json:"piece_count,omitempty"`
+	StorageNodeId NodeID `protobuf:"bytes,4,opt,name=storage_node_id,json=storageNodeId,proto3,customtype=NodeID" json:"storage_node_id"`
+	// number of pieces on the node according to the previous bloom filter, 0 when it's unknown.
+	LastPieceCount       int64    `protobuf:"varint,5,opt,name=last_piece_count,json=lastPieceCount,proto3" json:"last_piece_count,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -83,6 +85,13 @@ func (m *RetainInfo) GetPieceCount() int64 {
 	return 0
 }
 
+func (m *RetainInfo) GetLastPieceCount() int64 {
+	if m != nil {
+		return m.LastPieceCount
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterType((*RetainInfo)(nil), "satellite.gc.RetainInfo")
 }
@@ -90,22 +99,23 @@ func init() {
 func init() { proto.RegisterFile("gc.proto", fileDescriptor_5502b0b1493f7734) }
 
 var fileDescriptor_5502b0b1493f7734 = []byte{
-	// 262 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x3c, 0x90, 0xc1, 0x4a, 0xc3, 0x40,
-	0x10, 0x86, 0x5d, 0x2b, 0xa5, 0x6c, 0x53, 0x85, 0x1c, 0x24, 0xe4, 0x92, 0xa0, 0x08, 0x39, 0x6d,
-	0x40, 0xc1, 0x07, 0x88, 0xbd, 0xe4, 0xe2, 0x21, 0x78, 0xf2, 0x12, 0x36, 0xc9, 0x64, 0x59, 0xd9,
-	0xee, 0x84, 0xcd, 0xf4, 0x3d, 0x7c, 0xac, 0x3e, 0x83, 0x87, 0xfa, 0x2a, 0x92, 0x4d, 0xe3, 0x6d,
-	0xfe, 0x7f, 0xfe, 0xf9, 0x86, 0x19, 0xbe, 0x51, 0xad, 0x18, 0x1c, 0x12, 0x86, 0xc1, 0x28, 0x09,
-	0x8c, 0xd1, 0x04, 0x42, 0xb5, 0x31, 0x57, 0xa8, 0x70, 0xee, 0xc4, 0x89, 0x42, 0x54, 0x06, 0x72,
-	0xaf, 0x9a, 0x63, 0x9f, 0x93, 0x3e, 0xc0, 0x48, 0xf2, 0x30, 0xcc, 0x81, 0x87, 0x13, 0xe3, 0xbc,
-	0x02, 0x92, 0xda, 0x96, 0xb6, 0xc7, 0xb0, 0xe4, 0xbb, 0xd6, 0x81, 0x24, 0x8d, 0xb6, 0xee, 0x24,
-	0x41, 0xc4, 0x52, 0x96, 0x6d, 0x9f, 0x63, 0x31, 0x73, 0xc4, 0xc2, 0x11, 0x1f, 0x0b, 0xa7, 0xd8,
-	0x9c, 0xce, 0xc9, 0xd5, 0xf7, 0x6f, 0xc2, 0xaa, 0x60, 0x19, 0xdd, 0x4b, 0x82, 0xf0, 0x9e, 0xaf,
-	0x7b, 0x6d, 0x08, 0x5c, 0x74, 0x9d, 0xb2, 0x2c, 0xa8, 0x2e, 0x2a, 0x4c, 0xf8, 0x76, 0xd0, 0xd0,
-	0x42, 0xdd, 0xe2, 0xd1, 0x52, 0xb4, 0x4a, 0x59, 0xb6, 0xaa, 0xb8, 0xb7, 0xde, 0x26, 0x27, 0x7c,
-	0xe5, 0x77, 0x23, 0xa1, 0x93, 0x0a, 0x6a, 0x8b, 0x1d, 0xd4, 0xba, 0x8b, 0x6e, 0x26, 0x42, 0x71,
-	0x3b, 0x6d, 0xfa, 0x39, 0x27, 0xeb, 0x77, 0xec, 0xa0, 0xdc, 0x57, 0xbb, 0x4b, 0xcc, 0xcb, 0xae,
-	0x78, 0xfa, 0x7c, 0x9c, 0x8c, 0x2f, 0xa1, 0x31, 0xf7, 0x45, 0xfe, 0xff, 0x96, 0x5c, 0x5b, 0x02,
-	0x67, 0xa5, 0x19, 0x9a, 0x66, 0xed, 0x6f, 0x78, 0xf9, 0x0b, 0x00, 0x00, 0xff, 0xff, 0x33, 0xcf,
-	0x65, 0xaf, 0x3f, 0x01, 0x00, 0x00,
+	// 282 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x4c, 0x90, 0x4f, 0x4a, 0xc4, 0x30,
+	0x18, 0xc5, 0x8d, 0xa3, 0xc3, 0x90, 0xf9, 0xa3, 0x64, 0x21, 0x65, 0x36, 0x1d, 0x14, 0xa1, 0xab,
+	0x14, 0x14, 0x3c, 0x40, 0x9d, 0x4d, 0x37, 0x22, 0xc5, 0x95, 0x9b, 0x92, 0xb6, 0x5f, 0x43, 0x24,
+	0x93, 0xaf, 0xb4, 0xdf, 0xdc, 0xc3, 0x63, 0x79, 0x06, 0x17, 0xe3, 0x1d, 0x3c, 0x81, 0x34, 0x9d,
+	0x0e, 0xee, 0xf2, 0x5e, 0xde, 0xfb, 0x3d, 0x12, 0x3e, 0xd3, 0xa5, 0x6c, 0x5a, 0x24, 0x14, 0x8b,
+	0x4e, 0x11, 0x58, 0x6b, 0x08, 0xa4, 0x2e, 0xd7, 0x5c, 0xa3, 0xc6, 0xe1, 0x66, 0x1d, 0x6a, 0x44,
+	0x6d, 0x21, 0xf6, 0xaa, 0xd8, 0xd7, 0x31, 0x99, 0x1d, 0x74, 0xa4, 0x76, 0xcd, 0x10, 0xb8, 0xfd,
+	0x65, 0x9c, 0x67, 0x40, 0xca, 0xb8, 0xd4, 0xd5, 0x28, 0x52, 0xbe, 0x2c, 0x5b, 0x50, 0x64, 0xd0,
+	0xe5, 0x95, 0x22, 0x08, 0xd8, 0x86, 0x45, 0xf3, 0x87, 0xb5, 0x1c, 0x38, 0x72, 0xe4, 0xc8, 0xb7,
+	0x91, 0x93, 0xcc, 0xbe, 0x0e, 0xe1, 0xd9, 0xe7, 0x4f, 0xc8, 0xb2, 0xc5, 0x58, 0xdd, 0x2a, 0x02,
+	0x71, 0xc3, 0xa7, 0xb5, 0xb1, 0x04, 0x6d, 0x70, 0xbe, 0x61, 0xd1, 0x22, 0x3b, 0x2a, 0x11, 0xf2,
+	0x79, 0x63, 0xa0, 0x84, 0xbc, 0xc4, 0xbd, 0xa3, 0x60, 0xb2, 0x61, 0xd1, 0x24, 0xe3, 0xde, 0x7a,
+	0xee, 0x1d, 0xf1, 0xc4, 0xaf, 0x3a, 0xc2, 0x56, 0x69, 0xc8, 0x1d, 0x56, 0x90, 0x9b, 0x2a, 0xb8,
+	0xe8, 0x09, 0xc9, 0xaa, 0x5f, 0xfa, 0x3e, 0x84, 0xd3, 0x17, 0xac, 0x20, 0xdd, 0x66, 0xcb, 0x63,
+	0xcc, 0xcb, 0x4a, 0x44, 0xfc, 0xda, 0xaa, 0x8e, 0xf2, 0xff, 0xf4, 0x4b, 0x4f, 0x5f, 0xf5, 0xfe,
+	0xeb, 0x69, 0x21, 0xb9, 0x7f, 0xbf, 0xeb, 0xab, 0x1f, 0xd2, 0x60, 0xec, 0x0f, 0xf1, 0xe9, 0x03,
+	0x63, 0xe3, 0x08, 0x5a, 0xa7, 0x6c, 0x53, 0x14, 0x53, 0xff, 0xda, 0xc7, 0xbf, 0x00, 0x00, 0x00,
+	0xff, 0xff, 0xfd, 0x5e, 0xa0, 0x47, 0x69, 0x01, 0x00, 0x00,
 }
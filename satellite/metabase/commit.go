@@ -485,6 +485,9 @@ type CommitObject struct {
 	EncryptedMetadataEncryptedKey []byte // optional
 
 	DisallowDelete bool
+	// Versioned keeps the previous versions of the object (as in a bucket with
+	// versioning enabled) instead of deleting them on commit.
+	Versioned bool
 	// OnDelete will be triggered when/if existing object will be overwritten on commit.
 	// Wil be only executed after succesfull commit + delete DB operation.
 	// Error on this function won't revert back committed object.
@@ -512,7 +515,7 @@ func (c *CommitObject) Verify() error {
 }
 
 // CommitObject adds a pending object to the database. If another committed object is under target location
-// it will be deleted.
+// it will be deleted, unless the commit is versioned.
 func (db *DB) CommitObject(ctx context.Context, opts CommitObject) (object Object, err error) {
 	defer mon.Task()(&ctx)(&err)
 
@@ -584,7 +587,7 @@ func (db *DB) CommitObject(ctx context.Context, opts CommitObject) (object Objec
 		}
 
 		versionsToDelete := []Version{}
-		if db.config.MultipleVersions {
+		if db.config.MultipleVersions && !opts.Versioned {
 			if err := withRows(tx.QueryContext(ctx, `
 				SELECT version
 				FROM objects
@@ -592,7 +595,7 @@ func (db *DB) CommitObject(ctx context.Context, opts CommitObject) (object Objec
 					project_id   = $1 AND
 					bucket_name  = $2 AND
 					object_key   = $3 AND
-					status       = `+committedStatus,
+					status       IN (`+committedStatus+`, `+deleteMarkerStatus+`)`,
 				opts.ProjectID, []byte(opts.BucketName), opts.ObjectKey))(func(rows tagsql.Rows) error {
 				for rows.Next() {
 					var version Version
@@ -2811,6 +2811,48 @@ func TestCommitObject_MultipleVersions(t *testing.T) {
 
 			require.Equal(t, expectedDeletedSegments, deletedSegments)
 		})
+
+		t.Run("Versioned", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			obj := metabasetest.RandObjectStream()
+			previous := metabasetest.CreateObject(ctx, t, db, obj, 0)
+
+			obj.Version++
+			obj.StreamID = testrand.UUID()
+			metabasetest.BeginObjectExactVersion{
+				Opts: metabase.BeginObjectExactVersion{
+					ObjectStream: obj,
+					Encryption:   metabasetest.DefaultEncryption,
+				},
+				Version: obj.Version,
+			}.Check(ctx, t, db)
+
+			latest := metabasetest.CommitObject{
+				Opts: metabase.CommitObject{
+					ObjectStream: obj,
+					Encryption:   metabasetest.DefaultEncryption,
+					Versioned:    true,
+					OnDelete: func(segments []metabase.DeletedSegmentInfo) {
+						require.Fail(t, "versioned commit should not delete previous versions")
+					},
+				},
+			}.Check(ctx, t, db)
+
+			metabasetest.GetObjectLastCommitted{
+				Opts: metabase.GetObjectLastCommitted{
+					ObjectLocation: obj.Location(),
+				},
+				Result: latest,
+			}.Check(ctx, t, db)
+
+			metabasetest.Verify{
+				Objects: []metabase.RawObject{
+					metabase.RawObject(previous),
+					metabase.RawObject(latest),
+				},
+			}.Check(ctx, t, db)
+		})
 	})
 }
 
@@ -319,6 +319,10 @@ const NextVersion = Version(0)
 // DefaultVersion represents default version 1.
 const DefaultVersion = Version(1)
 
+// MaxVersion represents maximum version.
+// Used in listing to skip all the versions of an object.
+const MaxVersion = Version(math.MaxInt64)
+
 // ObjectStatus defines the statuses that the object might be in.
 type ObjectStatus byte
 
@@ -328,9 +332,14 @@ const (
 	Pending = ObjectStatus(1)
 	// Committed means that the object is finished and should be visible for general listing.
 	Committed = ObjectStatus(3)
-
-	pendingStatus   = "1"
-	committedStatus = "3"
+	// DeleteMarker means that the object was deleted in a versioned bucket.
+	// The delete marker doesn't have any segments and hides all previous versions
+	// of the object from general listing.
+	DeleteMarker = ObjectStatus(5)
+
+	pendingStatus      = "1"
+	committedStatus    = "3"
+	deleteMarkerStatus = "5"
 )
 
 // Pieces defines information for pieces.
@@ -95,6 +95,9 @@ func (finishCopy FinishCopyObject) Verify() error {
 
 // FinishCopyObject accepts new encryption keys for copied object and insert the corresponding new object ObjectKey and segments EncryptedKey.
 // It returns the object at the destination location.
+//
+// ErrObjectLocked is returned when the object at the destination is under
+// retention or legal hold.
 func (db *DB) FinishCopyObject(ctx context.Context, opts FinishCopyObject) (object Object, err error) {
 	defer mon.Task()(&ctx)(&err)
 
@@ -216,6 +219,12 @@ func (db *DB) FinishCopyObject(ctx context.Context, opts FinishCopyObject) (obje
 			if db.config.MultipleVersions {
 				version = objectAtDestination.Version
 			}
+
+			err := db.checkObjectVersionLocked(ctx, tx, objectAtDestination.Location(), version)
+			if err != nil {
+				return err
+			}
+
 			deletedObjects, err := db.deleteObjectExactVersionServerSideCopy(
 				ctx, DeleteObjectExactVersion{
 					Version: version,
@@ -249,13 +258,15 @@ func (db *DB) FinishCopyObject(ctx context.Context, opts FinishCopyObject) (obje
 				encryption,
 				encrypted_metadata, encrypted_metadata_nonce, encrypted_metadata_encrypted_key,
 				total_plain_size, total_encrypted_size, fixed_segment_size,
+				tags,
 				zombie_deletion_deadline
 			) VALUES (
 				$1, $2, $3, $4, $5,
 				$6,`+committedStatus+`, $7,
 				$8,
 				$9, $10, $11,
-				$12, $13, $14, null
+				$12, $13, $14,
+				$15, null
 			)
 			RETURNING
 				created_at`,
@@ -264,6 +275,7 @@ func (db *DB) FinishCopyObject(ctx context.Context, opts FinishCopyObject) (obje
 			encryptionParameters{&sourceObject.Encryption},
 			copyMetadata, opts.NewEncryptedMetadataKeyNonce, opts.NewEncryptedMetadataKey,
 			sourceObject.TotalPlainSize, sourceObject.TotalEncryptedSize, sourceObject.FixedSegmentSize,
+			sourceObject.Tags,
 		)
 
 		newObject = sourceObject
@@ -373,6 +385,7 @@ func getObjectAtCopySourceAndDestination(
 			encrypted_metadata,
 			total_plain_size, total_encrypted_size, fixed_segment_size,
 			encryption,
+			tags,
 			segment_copies.ancestor_stream_id,
 			0,
 			coalesce((SELECT max(version) FROM destination_current_versions),0) AS highest_version
@@ -393,6 +406,7 @@ func getObjectAtCopySourceAndDestination(
 			total_plain_size, total_encrypted_size, fixed_segment_size,
 			encryption,
 			NULL,
+			NULL,
 			version,
 			(SELECT max(version) FROM destination_current_versions) AS highest_version
 		FROM objects
@@ -423,6 +437,7 @@ func getObjectAtCopySourceAndDestination(
 		&sourceObject.EncryptedMetadata,
 		&sourceObject.TotalPlainSize, &sourceObject.TotalEncryptedSize, &sourceObject.FixedSegmentSize,
 		encryptionParameters{&sourceObject.Encryption},
+		&sourceObject.Tags,
 		&ancestorStreamIDBytes,
 		&highestVersion,
 		&highestVersion,
@@ -460,6 +475,7 @@ func getObjectAtCopySourceAndDestination(
 			&destinationObject.EncryptedMetadata,
 			&destinationObject.TotalPlainSize, &destinationObject.TotalEncryptedSize, &destinationObject.FixedSegmentSize,
 			encryptionParameters{&destinationObject.Encryption},
+			&destinationObject.Tags,
 			&_bogusBytes,
 			&destinationObject.Version,
 			&highestVersion,
@@ -171,7 +171,7 @@ func (db *DB) TestMigrateToLatest(ctx context.Context) error {
 			{
 				DB:          &db.db,
 				Description: "Test snapshot",
-				Version:     15,
+				Version:     17,
 				Action: migrate.SQL{
 
 					`CREATE TABLE objects (
@@ -199,6 +199,11 @@ func (db *DB) TestMigrateToLatest(ctx context.Context) error {
 
 						zombie_deletion_deadline TIMESTAMPTZ default now() + '1 day',
 
+						retain_until TIMESTAMPTZ default NULL,
+						legal_hold   BOOLEAN NOT NULL default false,
+
+						tags JSONB default NULL,
+
 						PRIMARY KEY (project_id, bucket_name, object_key, version)
 					);
 					CREATE TABLE segments (
@@ -472,6 +477,23 @@ func (db *DB) PostgresMigration() *migrate.Migration {
 					`CREATE INDEX ON segment_copies (ancestor_stream_id)`,
 				},
 			},
+			{
+				DB:          &db.db,
+				Description: "add object lock columns to objects",
+				Version:     16,
+				Action: migrate.SQL{
+					`ALTER TABLE objects ADD COLUMN retain_until TIMESTAMPTZ default NULL`,
+					`ALTER TABLE objects ADD COLUMN legal_hold BOOLEAN NOT NULL default false`,
+				},
+			},
+			{
+				DB:          &db.db,
+				Description: "add tags column to objects",
+				Version:     17,
+				Action: migrate.SQL{
+					`ALTER TABLE objects ADD COLUMN tags JSONB default NULL`,
+				},
+			},
 		},
 	}
 }
@@ -559,6 +581,11 @@ func limitedAsOfSystemTime(impl dbutil.Implementation, now, baseline time.Time,
 	return impl.AsOfSystemTime(baseline)
 }
 
+// MultipleVersionsEnabled returns whether objects can have multiple versions.
+func (db *DB) MultipleVersionsEnabled() bool {
+	return db.config.MultipleVersions
+}
+
 // TestingEnableMultipleVersions enables or disables the use of multiple versions (for tests).
 // Will be removed when multiple versions is enabled in production.
 func (db *DB) TestingEnableMultipleVersions(enabled bool) {
@@ -6,6 +6,8 @@ package metabase
 import (
 	"bytes"
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
 	"sort"
 	"time"
@@ -349,6 +351,8 @@ var updateSegmentsWithAncestor = `
 // Result will contain only those segments which needs to be deleted
 // from storage nodes. If object is an ancestor for copied object its
 // segments pieces cannot be deleted because copy still needs it.
+//
+// ErrObjectLocked is returned when the version is under retention or legal hold.
 func (db *DB) DeleteObjectExactVersion(
 	ctx context.Context, opts DeleteObjectExactVersion,
 ) (result DeleteObjectResult, err error) {
@@ -371,6 +375,10 @@ func (db *DB) deleteObjectExactVersion(ctx context.Context, opts DeleteObjectExa
 		return DeleteObjectResult{}, err
 	}
 
+	if err := db.checkObjectVersionLocked(ctx, tx, opts.ObjectLocation, opts.Version); err != nil {
+		return DeleteObjectResult{}, err
+	}
+
 	if db.config.ServerSideCopy {
 		objects, err := db.deleteObjectExactVersionServerSideCopy(ctx, opts, tx)
 		if err != nil {
@@ -567,6 +575,7 @@ func (db *DB) DeletePendingObject(ctx context.Context, opts DeletePendingObject)
 }
 
 // DeleteObjectAnyStatusAllVersions deletes all object versions.
+// Versions under retention or legal hold are kept.
 func (db *DB) DeleteObjectAnyStatusAllVersions(ctx context.Context, opts DeleteObjectAnyStatusAllVersions) (result DeleteObjectResult, err error) {
 	defer mon.Task()(&ctx)(&err)
 
@@ -584,7 +593,8 @@ func (db *DB) DeleteObjectAnyStatusAllVersions(ctx context.Context, opts DeleteO
 				WHERE
 				project_id   = $1 AND
 				bucket_name  = $2 AND
-				object_key   = $3
+				object_key   = $3 AND
+				NOT `+objectLockedCondition+`
 				RETURNING
 					version, stream_id,
 					created_at, expires_at,
@@ -627,6 +637,7 @@ func (db *DB) DeleteObjectAnyStatusAllVersions(ctx context.Context, opts DeleteO
 }
 
 // DeleteObjectsAllVersions deletes all versions of multiple objects from the same bucket.
+// ErrObjectLocked is returned when any of the versions is under retention or legal hold.
 func (db *DB) DeleteObjectsAllVersions(ctx context.Context, opts DeleteObjectsAllVersions) (result DeleteObjectResult, err error) {
 	defer mon.Task()(&ctx)(&err)
 
@@ -657,6 +668,11 @@ func (db *DB) DeleteObjectsAllVersions(ctx context.Context, opts DeleteObjectsAl
 	sort.Slice(objectKeys, func(i, j int) bool {
 		return bytes.Compare(objectKeys[i], objectKeys[j]) < 0
 	})
+
+	if err := db.checkObjectsLocked(ctx, projectID, bucketName, objectKeys); err != nil {
+		return DeleteObjectResult{}, err
+	}
+
 	err = withRows(db.db.QueryContext(ctx, `
 				WITH deleted_objects AS (
 					DELETE FROM objects
@@ -664,7 +680,8 @@ func (db *DB) DeleteObjectsAllVersions(ctx context.Context, opts DeleteObjectsAl
 					project_id   = $1 AND
 					bucket_name  = $2 AND
 					object_key   = ANY ($3) AND
-					status       = `+committedStatus+`
+					status       = `+committedStatus+` AND
+					NOT `+objectLockedCondition+`
 					RETURNING
 						project_id, bucket_name,
 						object_key, version, stream_id,
@@ -877,6 +894,10 @@ func (db *DB) scanMultipleObjectsDeletion(ctx context.Context, rows tagsql.Rows)
 // DeleteObjectLastCommitted contains arguments necessary for deleting last committed version of object.
 type DeleteObjectLastCommitted struct {
 	ObjectLocation
+
+	// Versioned inserts a delete marker instead of deleting the object,
+	// as in a bucket with versioning enabled.
+	Versioned bool
 }
 
 // Verify delete object last committed fields.
@@ -889,6 +910,13 @@ func (obj *DeleteObjectLastCommitted) Verify() error {
 // Result will contain only those segments which needs to be deleted
 // from storage nodes. If object is an ancestor for copied object its
 // segments pieces cannot be deleted because copy still needs it.
+//
+// For versioned deletes nothing is removed and the result contains
+// only the inserted delete marker. No delete marker is inserted when
+// the object is missing or already deleted.
+//
+// ErrObjectLocked is returned for unversioned deletes when any committed
+// version is under retention or legal hold.
 func (db *DB) DeleteObjectLastCommitted(
 	ctx context.Context, opts DeleteObjectLastCommitted,
 ) (result DeleteObjectResult, err error) {
@@ -910,6 +938,14 @@ func (db *DB) deleteObjectLastCommitted(ctx context.Context, opts DeleteObjectLa
 		return DeleteObjectResult{}, err
 	}
 
+	if opts.Versioned {
+		return db.insertDeleteMarker(ctx, opts.ObjectLocation, tx)
+	}
+
+	if err := db.checkObjectLocked(ctx, tx, opts.ObjectLocation); err != nil {
+		return DeleteObjectResult{}, err
+	}
+
 	if db.config.ServerSideCopy {
 		objects, err := db.deleteObjectLastCommittedServerSideCopy(ctx, opts, tx)
 		if err != nil {
@@ -951,6 +987,74 @@ func (db *DB) deleteObjectLastCommitted(ctx context.Context, opts DeleteObjectLa
 	return result, nil
 }
 
+// insertDeleteMarker hides the last committed version of the object behind a delete marker.
+func (db *DB) insertDeleteMarker(ctx context.Context, location ObjectLocation, tx tagsql.Tx) (result DeleteObjectResult, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	var latestStatus ObjectStatus
+	err = tx.QueryRowContext(ctx, `
+		SELECT status
+		FROM objects
+		WHERE
+			project_id   = $1 AND
+			bucket_name  = $2 AND
+			object_key   = $3 AND
+			status       IN (`+committedStatus+`, `+deleteMarkerStatus+`) AND
+			(expires_at IS NULL OR expires_at > now())
+		ORDER BY version DESC
+		LIMIT 1
+	`, location.ProjectID, []byte(location.BucketName), location.ObjectKey).Scan(&latestStatus)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return DeleteObjectResult{}, nil
+		}
+		return DeleteObjectResult{}, Error.New("unable to query object status: %w", err)
+	}
+	if latestStatus == DeleteMarker {
+		return DeleteObjectResult{}, nil
+	}
+
+	streamID, err := uuid.New()
+	if err != nil {
+		return DeleteObjectResult{}, Error.Wrap(err)
+	}
+
+	marker := Object{
+		ObjectStream: ObjectStream{
+			ProjectID:  location.ProjectID,
+			BucketName: location.BucketName,
+			ObjectKey:  location.ObjectKey,
+			StreamID:   streamID,
+		},
+		Status: DeleteMarker,
+	}
+
+	err = tx.QueryRowContext(ctx, `
+		INSERT INTO objects (
+			project_id, bucket_name, object_key, version, stream_id,
+			status, zombie_deletion_deadline
+		) VALUES (
+			$1, $2, $3,
+				coalesce((
+					SELECT version + 1
+					FROM objects
+					WHERE project_id = $1 AND bucket_name = $2 AND object_key = $3
+					ORDER BY version DESC
+					LIMIT 1
+				), 1),
+			$4, `+deleteMarkerStatus+`, NULL)
+		RETURNING version, created_at
+	`, location.ProjectID, []byte(location.BucketName), location.ObjectKey, streamID,
+	).Scan(&marker.Version, &marker.CreatedAt)
+	if err != nil {
+		return DeleteObjectResult{}, Error.New("unable to insert delete marker: %w", err)
+	}
+
+	mon.Meter("object_delete_marker").Mark(1)
+
+	return DeleteObjectResult{Objects: []Object{marker}}, nil
+}
+
 func (db *DB) deleteObjectLastCommittedServerSideCopy(ctx context.Context, opts DeleteObjectLastCommitted, tx tagsql.Tx) (objects []deletedObjectInfo, err error) {
 	defer mon.Task()(&ctx)(&err)
 
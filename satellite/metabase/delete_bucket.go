@@ -34,7 +34,7 @@ type DeleteBucketObjects struct {
 
 var deleteObjectsCockroachSubSQL = `
 DELETE FROM objects
-WHERE project_id = $1 AND bucket_name = $2
+WHERE project_id = $1 AND bucket_name = $2 AND NOT ` + objectLockedCondition + `
 LIMIT $3
 `
 
@@ -45,7 +45,7 @@ WHERE (objects.project_id, objects.bucket_name) IN (
 	SELECT project_id, bucket_name FROM objects
 	WHERE project_id = $1 AND bucket_name = $2
 	LIMIT $3
-)`
+) AND NOT ` + objectLockedCondition
 
 var deleteBucketObjectsWithCopyFeaturePostgresSQL = fmt.Sprintf(
 	deleteBucketObjectsWithCopyFeatureSQL,
@@ -73,6 +73,9 @@ func getDeleteBucketObjectsSQLWithCopyFeature(impl dbutil.Implementation) (strin
 // Deletion performs in batches, so in case of error while processing,
 // this method will return the number of objects deleted to the moment
 // when an error occurs.
+//
+// ErrObjectLocked is returned without deleting anything when the bucket
+// contains objects under retention or legal hold.
 func (db *DB) DeleteBucketObjects(ctx context.Context, opts DeleteBucketObjects) (deletedObjectCount int64, err error) {
 	defer mon.Task()(&ctx)(&err)
 
@@ -80,6 +83,10 @@ func (db *DB) DeleteBucketObjects(ctx context.Context, opts DeleteBucketObjects)
 		return 0, err
 	}
 
+	if err := db.checkBucketLocked(ctx, opts.Bucket); err != nil {
+		return 0, err
+	}
+
 	deleteBatchSizeLimit.Ensure(&opts.BatchSize)
 
 	if db.config.ServerSideCopy {
@@ -217,7 +224,7 @@ func (db *DB) deleteBucketObjectsWithCopyFeatureDisabled(ctx context.Context, op
 		query = `
 		WITH deleted_objects AS (
 			DELETE FROM objects
-			WHERE project_id = $1 AND bucket_name = $2 LIMIT $3
+			WHERE project_id = $1 AND bucket_name = $2 AND NOT ` + objectLockedCondition + ` LIMIT $3
 			RETURNING objects.stream_id
 		)
 		DELETE FROM segments
@@ -230,7 +237,7 @@ func (db *DB) deleteBucketObjectsWithCopyFeatureDisabled(ctx context.Context, op
 			DELETE FROM objects
 			WHERE stream_id IN (
 				SELECT stream_id FROM objects
-				WHERE project_id = $1 AND bucket_name = $2
+				WHERE project_id = $1 AND bucket_name = $2 AND NOT ` + objectLockedCondition + `
 				LIMIT $3
 			)
 			RETURNING objects.stream_id
@@ -41,6 +41,7 @@ func (db *DB) DeleteExpiredObjects(ctx context.Context, opts DeleteExpiredObject
 			WHERE
 				(project_id, bucket_name, object_key, version) > ($1, $2, $3, $4)
 				AND expires_at < $5
+				AND NOT ` + objectLockedCondition + `
 				ORDER BY project_id, bucket_name, object_key, version
 			LIMIT $6;`
 
@@ -1400,5 +1400,67 @@ func TestDeleteObjectLastCommitted(t *testing.T) {
 				},
 			}.Check(ctx, t, db)
 		})
+
+		t.Run("Versioned delete inserts delete marker", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			object := metabasetest.CreateObject(ctx, t, db, obj, 0)
+
+			result, err := db.DeleteObjectLastCommitted(ctx, metabase.DeleteObjectLastCommitted{
+				ObjectLocation: location,
+				Versioned:      true,
+			})
+			require.NoError(t, err)
+			require.Empty(t, result.Segments)
+			require.Len(t, result.Objects, 1)
+
+			marker := result.Objects[0]
+			require.Equal(t, metabase.DeleteMarker, marker.Status)
+			require.Equal(t, object.Version+1, marker.Version)
+
+			metabasetest.GetObjectLastCommitted{
+				Opts: metabase.GetObjectLastCommitted{
+					ObjectLocation: location,
+				},
+				ErrClass: &storj.ErrObjectNotFound,
+			}.Check(ctx, t, db)
+
+			// deleting an already deleted object doesn't insert another marker
+			metabasetest.DeleteObjectLastCommitted{
+				Opts: metabase.DeleteObjectLastCommitted{
+					ObjectLocation: location,
+					Versioned:      true,
+				},
+			}.Check(ctx, t, db)
+
+			metabasetest.Verify{
+				Objects: []metabase.RawObject{
+					metabase.RawObject(object),
+					{
+						ObjectStream: marker.ObjectStream,
+						CreatedAt:    marker.CreatedAt,
+						Status:       metabase.DeleteMarker,
+					},
+				},
+			}.Check(ctx, t, db)
+
+			// removing the delete marker restores the previous version
+			metabasetest.DeleteObjectExactVersion{
+				Opts: metabase.DeleteObjectExactVersion{
+					ObjectLocation: location,
+					Version:        marker.Version,
+				},
+				Result: metabase.DeleteObjectResult{
+					Objects: []metabase.Object{marker},
+				},
+			}.Check(ctx, t, db)
+
+			metabasetest.GetObjectLastCommitted{
+				Opts: metabase.GetObjectLastCommitted{
+					ObjectLocation: location,
+				},
+				Result: object,
+			}.Check(ctx, t, db)
+		})
 	})
 }
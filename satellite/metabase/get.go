@@ -10,11 +10,9 @@ import (
 	"time"
 
 	"github.com/zeebo/errs"
-	"go.uber.org/zap"
 
 	"storj.io/common/storj"
 	"storj.io/common/uuid"
-	"storj.io/private/tagsql"
 )
 
 // ErrSegmentNotFound is an error class for non-existing segment.
@@ -86,7 +84,7 @@ func (db *DB) GetObjectExactVersion(ctx context.Context, opts GetObjectExactVers
 			segment_count,
 			encrypted_metadata_nonce, encrypted_metadata, encrypted_metadata_encrypted_key,
 			total_plain_size, total_encrypted_size, fixed_segment_size,
-			encryption
+			encryption, tags
 		FROM objects
 		WHERE
 			project_id   = $1 AND
@@ -102,7 +100,7 @@ func (db *DB) GetObjectExactVersion(ctx context.Context, opts GetObjectExactVers
 			&object.SegmentCount,
 			&object.EncryptedMetadataNonce, &object.EncryptedMetadata, &object.EncryptedMetadataEncryptedKey,
 			&object.TotalPlainSize, &object.TotalEncryptedSize, &object.FixedSegmentSize,
-			encryptionParameters{&object.Encryption},
+			encryptionParameters{&object.Encryption}, &object.Tags,
 		)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -128,6 +126,7 @@ type GetObjectLastCommitted struct {
 }
 
 // GetObjectLastCommitted returns object information for last committed version.
+// When the latest version of the object is a delete marker the object is not found.
 func (db *DB) GetObjectLastCommitted(ctx context.Context, opts GetObjectLastCommitted) (_ Object, err error) {
 	defer mon.Task()(&ctx)(&err)
 
@@ -136,57 +135,34 @@ func (db *DB) GetObjectLastCommitted(ctx context.Context, opts GetObjectLastComm
 	}
 
 	var object Object
-
-	err = withRows(db.db.QueryContext(ctx, `
+	err = db.db.QueryRowContext(ctx, `
 		SELECT
-			stream_id, version,
+			stream_id, version, status,
 			created_at, expires_at,
 			segment_count,
 			encrypted_metadata_nonce, encrypted_metadata, encrypted_metadata_encrypted_key,
 			total_plain_size, total_encrypted_size, fixed_segment_size,
-			encryption
+			encryption, tags
 		FROM objects
 		WHERE
 			project_id   = $1 AND
 			bucket_name  = $2 AND
 			object_key   = $3 AND
-			status       = `+committedStatus+` AND
+			status       IN (`+committedStatus+`, `+deleteMarkerStatus+`) AND
 			(expires_at IS NULL OR expires_at > now())
-		ORDER BY version desc
-	`, opts.ProjectID, []byte(opts.BucketName), opts.ObjectKey))(func(rows tagsql.Rows) error {
-		objectFound := false
-		for rows.Next() {
-			var scannedObject Object
-			if err = rows.Scan(
-				&scannedObject.StreamID, &scannedObject.Version,
-				&scannedObject.CreatedAt, &scannedObject.ExpiresAt,
-				&scannedObject.SegmentCount,
-				&scannedObject.EncryptedMetadataNonce, &scannedObject.EncryptedMetadata, &scannedObject.EncryptedMetadataEncryptedKey,
-				&scannedObject.TotalPlainSize, &scannedObject.TotalEncryptedSize, &scannedObject.FixedSegmentSize,
-				encryptionParameters{&scannedObject.Encryption},
-			); err != nil {
-				return Error.New("unable to query object status: %w", err)
-			}
-
-			if objectFound {
-				db.log.Warn("object with multiple committed versions were found!",
-					zap.Stringer("Project ID", scannedObject.ProjectID), zap.String("Bucket Name", scannedObject.BucketName),
-					zap.String("Object Key", string(scannedObject.ObjectKey)), zap.Int("Version", int(scannedObject.Version)),
-					zap.Stringer("Stream ID", scannedObject.StreamID))
-				mon.Meter("multiple_committed_versions").Mark(1)
-				continue
-			}
-			object = scannedObject
-
-			objectFound = true
-		}
-
-		if !objectFound {
-			return sql.ErrNoRows
-		}
-
-		return nil
-	})
+		ORDER BY version DESC
+		LIMIT 1
+	`, opts.ProjectID, []byte(opts.BucketName), opts.ObjectKey).Scan(
+		&object.StreamID, &object.Version, &object.Status,
+		&object.CreatedAt, &object.ExpiresAt,
+		&object.SegmentCount,
+		&object.EncryptedMetadataNonce, &object.EncryptedMetadata, &object.EncryptedMetadataEncryptedKey,
+		&object.TotalPlainSize, &object.TotalEncryptedSize, &object.FixedSegmentSize,
+		encryptionParameters{&object.Encryption}, &object.Tags,
+	)
+	if err == nil && object.Status == DeleteMarker {
+		err = sql.ErrNoRows
+	}
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return Object{}, storj.ErrObjectNotFound.Wrap(Error.Wrap(err))
@@ -198,7 +174,6 @@ func (db *DB) GetObjectLastCommitted(ctx context.Context, opts GetObjectLastComm
 	object.ProjectID = opts.ProjectID
 	object.BucketName = opts.BucketName
 	object.ObjectKey = opts.ObjectKey
-	object.Status = Committed
 
 	return object, nil
 }
@@ -298,7 +298,8 @@ func querySelectorFields(objectKeyColumn string, it *objectsIterator) string {
 		querySelectFields += `
 			,encrypted_metadata_nonce
 			,encrypted_metadata
-			,encrypted_metadata_encrypted_key`
+			,encrypted_metadata_encrypted_key
+			,tags`
 	}
 
 	return querySelectFields
@@ -321,7 +322,8 @@ func doNextQueryStreamsByKey(ctx context.Context, it *objectsIterator) (_ tagsql
 				created_at, expires_at,
 				segment_count,
 				total_plain_size, total_encrypted_size, fixed_segment_size,
-				encrypted_metadata_nonce, encrypted_metadata, encrypted_metadata_encrypted_key
+				encrypted_metadata_nonce, encrypted_metadata, encrypted_metadata_encrypted_key,
+				tags
 			FROM objects
 			WHERE
 				project_id = $1 AND bucket_name = $2
@@ -365,6 +367,7 @@ func (it *objectsIterator) scanItem(item *ObjectEntry) (err error) {
 			&item.EncryptedMetadataNonce,
 			&item.EncryptedMetadata,
 			&item.EncryptedMetadataEncryptedKey,
+			&item.Tags,
 		)
 	}
 
@@ -1557,6 +1557,7 @@ func objectEntryFromRaw(m metabase.RawObject) metabase.ObjectEntry {
 		TotalPlainSize:                m.TotalPlainSize,
 		FixedSegmentSize:              m.FixedSegmentSize,
 		Encryption:                    m.Encryption,
+		Tags:                          m.Tags,
 	}
 }
 
@@ -34,6 +34,8 @@ type ObjectEntry struct {
 	FixedSegmentSize   int32
 
 	Encryption storj.EncryptionParameters
+
+	Tags ObjectTags
 }
 
 // ObjectsIterator iterates over a sequence of ObjectEntry items.
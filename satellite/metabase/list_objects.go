@@ -30,6 +30,17 @@ type ListObjects struct {
 	Status                ObjectStatus
 	IncludeCustomMetadata bool
 	IncludeSystemMetadata bool
+
+	// AllVersions lists all committed versions and delete markers of the objects
+	// instead of only the latest committed version.
+	AllVersions bool
+	// Versioned should be set for versioned buckets, whose objects may have
+	// several committed versions and delete markers. Otherwise every committed
+	// object is listed, without looking up the latest version of its key.
+	Versioned bool
+
+	// Tags lists only objects which have all the specified tags.
+	Tags ObjectTags
 }
 
 // Verify verifies get object request fields.
@@ -43,8 +54,12 @@ func (opts *ListObjects) Verify() error {
 		return ErrInvalidRequest.New("Invalid limit: %d", opts.Limit)
 	case !(opts.Status == Pending || opts.Status == Committed):
 		return ErrInvalidRequest.New("Status is invalid")
+	case opts.AllVersions && opts.Status != Committed:
+		return ErrInvalidRequest.New("AllVersions is only supported for committed objects")
+	case len(opts.Tags) > 0 && opts.Status != Committed:
+		return ErrInvalidRequest.New("Tags filter is only supported for committed objects")
 	}
-	return nil
+	return opts.Tags.Verify()
 }
 
 // ListObjectsResult result of listing objects.
@@ -63,11 +78,22 @@ func (db *DB) ListObjects(ctx context.Context, opts ListObjects) (result ListObj
 
 	ListLimit.Ensure(&opts.Limit)
 
-	var entries []ObjectEntry
-	err = withRows(db.db.QueryContext(ctx, opts.getSQLQuery(),
+	if opts.AllVersions {
+		// status is needed to tell delete markers apart from objects.
+		opts.IncludeSystemMetadata = true
+	}
+
+	args := []interface{}{
 		opts.ProjectID, opts.BucketName, opts.startKey(), opts.Cursor.Version,
 		opts.stopKey(), opts.Status,
-		opts.Limit+1, len(opts.Prefix)+1))(func(rows tagsql.Rows) error {
+		opts.Limit + 1, len(opts.Prefix) + 1,
+	}
+	if len(opts.Tags) > 0 {
+		args = append(args, opts.Tags)
+	}
+
+	var entries []ObjectEntry
+	err = withRows(db.db.QueryContext(ctx, opts.getSQLQuery(), args...))(func(rows tagsql.Rows) error {
 		entries, err = scanListObjectsResult(rows, opts)
 		return err
 	})
@@ -96,13 +122,46 @@ func (opts *ListObjects) getSQLQuery() string {
 	WHERE
 		(project_id, bucket_name, object_key, version) > ($1, $2, $3, $4)
 		AND ` + opts.stopCondition() + `
-		AND status = $6
+		AND ` + opts.statusCondition() + `
+		AND ` + opts.tagsCondition() + `
 		AND (expires_at IS NULL OR expires_at > now())
 	ORDER BY ` + opts.orderBy() + `
 	LIMIT $7
 	`
 }
 
+// statusCondition determines which object versions are listed.
+func (opts *ListObjects) statusCondition() string {
+	switch {
+	case opts.Status != Committed, !opts.Versioned && !opts.AllVersions:
+		return "status = $6"
+	case opts.AllVersions:
+		return "status IN ($6, " + deleteMarkerStatus + ")"
+	default:
+		// only the latest version of an object is listed and
+		// it's hidden when the latest version is a delete marker.
+		return `status = $6
+		AND version = (
+			SELECT MAX(latest.version)
+			FROM objects AS latest
+			WHERE
+				latest.project_id  = objects.project_id AND
+				latest.bucket_name = objects.bucket_name AND
+				latest.object_key  = objects.object_key AND
+				latest.status IN (` + committedStatus + `, ` + deleteMarkerStatus + `) AND
+				(latest.expires_at IS NULL OR latest.expires_at > now())
+		)`
+	}
+}
+
+// tagsCondition filters the objects by their tags.
+func (opts *ListObjects) tagsCondition() string {
+	if len(opts.Tags) == 0 {
+		return "TRUE"
+	}
+	return "tags @> $9::JSONB"
+}
+
 func (opts *ListObjects) stopKey() []byte {
 	if opts.Prefix != "" {
 		return []byte(prefixLimit(opts.Prefix))
@@ -119,20 +178,30 @@ func (opts *ListObjects) stopCondition() string {
 
 func (opts *ListObjects) orderBy() string {
 	if !opts.Recursive {
+		if opts.AllVersions {
+			return "entry_key ASC, " + entryVersion + " ASC"
+		}
 		return "entry_key ASC"
 	}
 
 	return "(object_key, version) ASC"
 }
 
+// entryVersion is used to list all versions of objects while listing each prefix only once.
+const entryVersion = `(CASE WHEN position('/' IN substring(object_key from $8)) <> 0 THEN 0 ELSE version END)`
+
 func (opts ListObjects) selectedFields() (selectedFields string) {
 
 	if opts.Recursive {
 		selectedFields = `
 			substring(object_key from $8), FALSE as is_prefix`
 	} else {
+		distinctOn := "entry_key"
+		if opts.AllVersions {
+			distinctOn += ", " + entryVersion
+		}
 		selectedFields = `
-			DISTINCT ON (entry_key)
+			DISTINCT ON (` + distinctOn + `)
 			CASE
 				WHEN position('/' IN substring(object_key from $8)) <> 0
 				THEN substring(substring(object_key from $8) from 0 for (position('/' IN substring(object_key from $8)) +1))
@@ -162,7 +231,8 @@ func (opts ListObjects) selectedFields() (selectedFields string) {
 		selectedFields += `
 		,encrypted_metadata_nonce
 		,encrypted_metadata
-		,encrypted_metadata_encrypted_key`
+		,encrypted_metadata_encrypted_key
+		,tags`
 	}
 	return selectedFields
 }
@@ -228,6 +298,7 @@ func scanListObjectsResult(rows tagsql.Rows, opts ListObjects) (entries []Object
 				&item.EncryptedMetadataNonce,
 				&item.EncryptedMetadata,
 				&item.EncryptedMetadataEncryptedKey,
+				&item.Tags,
 			)
 		}
 
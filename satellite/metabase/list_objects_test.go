@@ -11,6 +11,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"storj.io/common/testcontext"
+	"storj.io/common/testrand"
 	"storj.io/common/uuid"
 	"storj.io/storj/satellite/metabase"
 	"storj.io/storj/satellite/metabase/metabasetest"
@@ -796,6 +797,64 @@ func TestListObjectsSkipCursor(t *testing.T) {
 					}},
 			}.Check(ctx, t, db)
 		})
+
+		t.Run("versions", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			projectID, bucketName := uuid.UUID{1}, "bucky"
+
+			objA := metabasetest.RandObjectStream()
+			objA.ProjectID, objA.BucketName, objA.ObjectKey = projectID, bucketName, "a"
+			metabasetest.CreateObject(ctx, t, db, objA, 0)
+
+			objA.Version++
+			objA.StreamID = testrand.UUID()
+			metabasetest.CreateObjectVersioned(ctx, t, db, objA, 0)
+
+			objB := metabasetest.RandObjectStream()
+			objB.ProjectID, objB.BucketName, objB.ObjectKey = projectID, bucketName, "b"
+			metabasetest.CreateObject(ctx, t, db, objB, 0)
+
+			_, err := db.DeleteObjectLastCommitted(ctx, metabase.DeleteObjectLastCommitted{
+				ObjectLocation: objB.Location(),
+				Versioned:      true,
+			})
+			require.NoError(t, err)
+
+			type entry struct {
+				Key     metabase.ObjectKey
+				Version metabase.Version
+				Status  metabase.ObjectStatus
+			}
+			list := func(allVersions bool) (entries []entry) {
+				result, err := db.ListObjects(ctx, metabase.ListObjects{
+					ProjectID:             projectID,
+					BucketName:            bucketName,
+					Recursive:             true,
+					Status:                metabase.Committed,
+					IncludeSystemMetadata: true,
+					AllVersions:           allVersions,
+					Versioned:             true,
+				})
+				require.NoError(t, err)
+				for _, object := range result.Objects {
+					entries = append(entries, entry{object.ObjectKey, object.Version, object.Status})
+				}
+				return entries
+			}
+
+			// only the latest version is listed and deleted objects are hidden
+			require.Equal(t, []entry{
+				{"a", objA.Version, metabase.Committed},
+			}, list(false))
+
+			require.Equal(t, []entry{
+				{"a", objA.Version - 1, metabase.Committed},
+				{"a", objA.Version, metabase.Committed},
+				{"b", objB.Version, metabase.Committed},
+				{"b", objB.Version + 1, metabase.DeleteMarker},
+			}, list(true))
+		})
 	})
 }
 
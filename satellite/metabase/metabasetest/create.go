@@ -86,6 +86,16 @@ func CreatePendingObject(ctx *testcontext.Context, t *testing.T, db *metabase.DB
 
 // CreateObject creates a new committed object with the specified number of segments.
 func CreateObject(ctx *testcontext.Context, t require.TestingT, db *metabase.DB, obj metabase.ObjectStream, numberOfSegments byte) metabase.Object {
+	return createObject(ctx, t, db, obj, numberOfSegments, false)
+}
+
+// CreateObjectVersioned creates a new committed object with the specified number of segments,
+// keeping the previous versions of the object.
+func CreateObjectVersioned(ctx *testcontext.Context, t require.TestingT, db *metabase.DB, obj metabase.ObjectStream, numberOfSegments byte) metabase.Object {
+	return createObject(ctx, t, db, obj, numberOfSegments, true)
+}
+
+func createObject(ctx *testcontext.Context, t require.TestingT, db *metabase.DB, obj metabase.ObjectStream, numberOfSegments byte, versioned bool) metabase.Object {
 	BeginObjectExactVersion{
 		Opts: metabase.BeginObjectExactVersion{
 			ObjectStream: obj,
@@ -129,6 +139,7 @@ func CreateObject(ctx *testcontext.Context, t require.TestingT, db *metabase.DB,
 	return CommitObject{
 		Opts: metabase.CommitObject{
 			ObjectStream: obj,
+			Versioned:    versioned,
 		},
 	}.Check(ctx, t, db)
 }
@@ -146,6 +146,9 @@ func (finishMove FinishMoveObject) Verify() error {
 }
 
 // FinishMoveObject accepts new encryption keys for moved object and updates the corresponding object ObjectKey and segments EncryptedKey.
+//
+// ErrObjectLocked is returned when the moved version or the object at the
+// destination is under retention or legal hold.
 func (db *DB) FinishMoveObject(ctx context.Context, opts FinishMoveObject) (err error) {
 	defer mon.Task()(&ctx)(&err)
 
@@ -154,6 +157,19 @@ func (db *DB) FinishMoveObject(ctx context.Context, opts FinishMoveObject) (err
 	}
 
 	err = txutil.WithTx(ctx, db.db, nil, func(ctx context.Context, tx tagsql.Tx) (err error) {
+		if err := db.checkObjectVersionLocked(ctx, tx, opts.Location(), opts.Version); err != nil {
+			return err
+		}
+
+		err = db.checkObjectLocked(ctx, tx, ObjectLocation{
+			ProjectID:  opts.ProjectID,
+			BucketName: opts.NewBucket,
+			ObjectKey:  ObjectKey(opts.NewEncryptedObjectKey),
+		})
+		if err != nil {
+			return err
+		}
+
 		updateObjectsQuery := `
 			UPDATE objects SET
 				bucket_name = $1,
@@ -0,0 +1,309 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package metabase
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/zeebo/errs"
+
+	"storj.io/common/storj"
+	"storj.io/common/uuid"
+	"storj.io/private/dbutil/pgutil"
+	"storj.io/private/dbutil/txutil"
+	"storj.io/private/tagsql"
+)
+
+// ErrObjectLocked is used to indicate that an object is protected by a retention period or a legal hold.
+var ErrObjectLocked = errs.Class("object locked")
+
+// objectLockedCondition matches objects which cannot be removed or overwritten.
+const objectLockedCondition = `(legal_hold OR COALESCE(retain_until > now(), false))`
+
+// ObjectLock contains the write-once-read-many protection of an object version.
+type ObjectLock struct {
+	// RetainUntil is the time until the object cannot be removed. It can only be extended.
+	RetainUntil time.Time
+	// LegalHold prevents removing the object until it is released, regardless of RetainUntil.
+	LegalHold bool
+}
+
+// Locked returns whether the object cannot be removed at the specified time.
+func (lock ObjectLock) Locked(now time.Time) bool {
+	return lock.LegalHold || lock.RetainUntil.After(now)
+}
+
+// GetObjectLock contains arguments necessary for fetching the lock of an object version.
+type GetObjectLock struct {
+	ObjectLocation
+	Version Version
+}
+
+// Verify verifies get object lock fields.
+func (opts *GetObjectLock) Verify() error {
+	if err := opts.ObjectLocation.Verify(); err != nil {
+		return err
+	}
+	if opts.Version <= 0 {
+		return ErrInvalidRequest.New("Version invalid: %v", opts.Version)
+	}
+	return nil
+}
+
+// GetObjectLock returns the lock of a committed object version.
+func (db *DB) GetObjectLock(ctx context.Context, opts GetObjectLock) (lock ObjectLock, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if err := opts.Verify(); err != nil {
+		return ObjectLock{}, err
+	}
+
+	var retainUntil *time.Time
+	err = db.db.QueryRowContext(ctx, `
+		SELECT retain_until, legal_hold
+		FROM objects
+		WHERE
+			project_id   = $1 AND
+			bucket_name  = $2 AND
+			object_key   = $3 AND
+			version      = $4 AND
+			status       = `+committedStatus,
+		opts.ProjectID, []byte(opts.BucketName), opts.ObjectKey, opts.Version,
+	).Scan(&retainUntil, &lock.LegalHold)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ObjectLock{}, storj.ErrObjectNotFound.Wrap(Error.Wrap(err))
+		}
+		return ObjectLock{}, Error.New("unable to query object lock: %w", err)
+	}
+	if retainUntil != nil {
+		lock.RetainUntil = *retainUntil
+	}
+
+	return lock, nil
+}
+
+// SetObjectRetention contains arguments necessary for setting the retention of an object version.
+type SetObjectRetention struct {
+	ObjectLocation
+	Version     Version
+	RetainUntil time.Time
+}
+
+// Verify verifies set object retention fields.
+func (opts *SetObjectRetention) Verify() error {
+	if err := opts.ObjectLocation.Verify(); err != nil {
+		return err
+	}
+	switch {
+	case opts.Version <= 0:
+		return ErrInvalidRequest.New("Version invalid: %v", opts.Version)
+	case opts.RetainUntil.IsZero():
+		return ErrInvalidRequest.New("RetainUntil missing")
+	}
+	return nil
+}
+
+// SetObjectRetention sets the time until a committed object version cannot be removed.
+// The retention can only be extended, even the project owner cannot shorten or remove it.
+func (db *DB) SetObjectRetention(ctx context.Context, opts SetObjectRetention) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if err := opts.Verify(); err != nil {
+		return err
+	}
+
+	return txutil.WithTx(ctx, db.db, nil, func(ctx context.Context, tx tagsql.Tx) error {
+		var retainUntil *time.Time
+		err := tx.QueryRowContext(ctx, `
+			SELECT retain_until
+			FROM objects
+			WHERE
+				project_id   = $1 AND
+				bucket_name  = $2 AND
+				object_key   = $3 AND
+				version      = $4 AND
+				status       = `+committedStatus+`
+			FOR UPDATE`,
+			opts.ProjectID, []byte(opts.BucketName), opts.ObjectKey, opts.Version,
+		).Scan(&retainUntil)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return storj.ErrObjectNotFound.Wrap(Error.Wrap(err))
+			}
+			return Error.New("unable to query object retention: %w", err)
+		}
+
+		if retainUntil != nil && opts.RetainUntil.Before(*retainUntil) {
+			return ErrObjectLocked.New("retention period can only be extended")
+		}
+
+		_, err = tx.ExecContext(ctx, `
+			UPDATE objects SET retain_until = $5
+			WHERE
+				project_id   = $1 AND
+				bucket_name  = $2 AND
+				object_key   = $3 AND
+				version      = $4`,
+			opts.ProjectID, []byte(opts.BucketName), opts.ObjectKey, opts.Version, opts.RetainUntil)
+		if err != nil {
+			return Error.New("unable to update object retention: %w", err)
+		}
+
+		mon.Meter("object_retention_set").Mark(1)
+		return nil
+	})
+}
+
+// SetObjectLegalHold contains arguments necessary for placing or releasing a legal hold.
+type SetObjectLegalHold struct {
+	ObjectLocation
+	Version Version
+	Enabled bool
+}
+
+// Verify verifies set object legal hold fields.
+func (opts *SetObjectLegalHold) Verify() error {
+	if err := opts.ObjectLocation.Verify(); err != nil {
+		return err
+	}
+	if opts.Version <= 0 {
+		return ErrInvalidRequest.New("Version invalid: %v", opts.Version)
+	}
+	return nil
+}
+
+// SetObjectLegalHold places or releases a legal hold on a committed object version.
+func (db *DB) SetObjectLegalHold(ctx context.Context, opts SetObjectLegalHold) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if err := opts.Verify(); err != nil {
+		return err
+	}
+
+	result, err := db.db.ExecContext(ctx, `
+		UPDATE objects SET legal_hold = $5
+		WHERE
+			project_id   = $1 AND
+			bucket_name  = $2 AND
+			object_key   = $3 AND
+			version      = $4 AND
+			status       = `+committedStatus,
+		opts.ProjectID, []byte(opts.BucketName), opts.ObjectKey, opts.Version, opts.Enabled)
+	if err != nil {
+		return Error.New("unable to update object legal hold: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return Error.New("failed to get rows affected: %w", err)
+	}
+	if affected == 0 {
+		return storj.ErrObjectNotFound.Wrap(Error.New("object with specified version is missing"))
+	}
+
+	mon.Meter("object_legal_hold_set").Mark(1)
+	return nil
+}
+
+// checkObjectVersionLocked returns ErrObjectLocked when the object version cannot be removed.
+func (db *DB) checkObjectVersionLocked(ctx context.Context, tx tagsql.Tx, location ObjectLocation, version Version) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	var locked bool
+	err = tx.QueryRowContext(ctx, `
+		SELECT EXISTS (
+			SELECT 1 FROM objects
+			WHERE
+				project_id   = $1 AND
+				bucket_name  = $2 AND
+				object_key   = $3 AND
+				version      = $4 AND
+				`+objectLockedCondition+`
+		)`, location.ProjectID, []byte(location.BucketName), location.ObjectKey, version,
+	).Scan(&locked)
+	if err != nil {
+		return Error.New("unable to check object lock: %w", err)
+	}
+	if locked {
+		return ErrObjectLocked.New("object version %d is locked", version)
+	}
+	return nil
+}
+
+// checkObjectLocked returns ErrObjectLocked when any committed version of the object cannot be removed.
+func (db *DB) checkObjectLocked(ctx context.Context, tx tagsql.Tx, location ObjectLocation) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	var locked bool
+	err = tx.QueryRowContext(ctx, `
+		SELECT EXISTS (
+			SELECT 1 FROM objects
+			WHERE
+				project_id   = $1 AND
+				bucket_name  = $2 AND
+				object_key   = $3 AND
+				status       = `+committedStatus+` AND
+				`+objectLockedCondition+`
+		)`, location.ProjectID, []byte(location.BucketName), location.ObjectKey,
+	).Scan(&locked)
+	if err != nil {
+		return Error.New("unable to check object lock: %w", err)
+	}
+	if locked {
+		return ErrObjectLocked.New("object is locked")
+	}
+	return nil
+}
+
+// checkBucketLocked returns ErrObjectLocked when the bucket contains objects which cannot be removed.
+func (db *DB) checkBucketLocked(ctx context.Context, bucket BucketLocation) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	var locked bool
+	err = db.db.QueryRowContext(ctx, `
+		SELECT EXISTS (
+			SELECT 1 FROM objects
+			WHERE
+				project_id   = $1 AND
+				bucket_name  = $2 AND
+				`+objectLockedCondition+`
+		)`, bucket.ProjectID, []byte(bucket.BucketName),
+	).Scan(&locked)
+	if err != nil {
+		return Error.New("unable to check object lock: %w", err)
+	}
+	if locked {
+		return ErrObjectLocked.New("bucket contains locked objects")
+	}
+	return nil
+}
+
+// checkObjectsLocked returns ErrObjectLocked when any committed version of the objects cannot be removed.
+func (db *DB) checkObjectsLocked(ctx context.Context, projectID uuid.UUID, bucketName string, objectKeys [][]byte) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	var locked bool
+	err = db.db.QueryRowContext(ctx, `
+		SELECT EXISTS (
+			SELECT 1 FROM objects
+			WHERE
+				project_id   = $1 AND
+				bucket_name  = $2 AND
+				object_key   = ANY ($3) AND
+				status       = `+committedStatus+` AND
+				`+objectLockedCondition+`
+		)`, projectID, []byte(bucketName), pgutil.ByteaArray(objectKeys),
+	).Scan(&locked)
+	if err != nil {
+		return Error.New("unable to check object lock: %w", err)
+	}
+	if locked {
+		return ErrObjectLocked.New("object is locked")
+	}
+	return nil
+}
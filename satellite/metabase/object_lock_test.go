@@ -0,0 +1,212 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package metabase_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"storj.io/common/storj"
+	"storj.io/common/testcontext"
+	"storj.io/common/testrand"
+	"storj.io/storj/satellite/metabase"
+	"storj.io/storj/satellite/metabase/metabasetest"
+)
+
+func TestObjectLock(t *testing.T) {
+	metabasetest.Run(t, func(ctx *testcontext.Context, t *testing.T, db *metabase.DB) {
+		obj := metabasetest.RandObjectStream()
+		location := obj.Location()
+
+		t.Run("object missing", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			_, err := db.GetObjectLock(ctx, metabase.GetObjectLock{ObjectLocation: location, Version: 1})
+			require.True(t, storj.ErrObjectNotFound.Has(err))
+
+			err = db.SetObjectRetention(ctx, metabase.SetObjectRetention{
+				ObjectLocation: location,
+				Version:        1,
+				RetainUntil:    time.Now().Add(time.Hour),
+			})
+			require.True(t, storj.ErrObjectNotFound.Has(err))
+
+			err = db.SetObjectLegalHold(ctx, metabase.SetObjectLegalHold{ObjectLocation: location, Version: 1, Enabled: true})
+			require.True(t, storj.ErrObjectNotFound.Has(err))
+		})
+
+		t.Run("retention", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			object := metabasetest.CreateObject(ctx, t, db, obj, 0)
+
+			lock, err := db.GetObjectLock(ctx, metabase.GetObjectLock{ObjectLocation: location, Version: 1})
+			require.NoError(t, err)
+			require.False(t, lock.Locked(time.Now()))
+
+			retainUntil := time.Now().Add(time.Hour)
+			err = db.SetObjectRetention(ctx, metabase.SetObjectRetention{
+				ObjectLocation: location,
+				Version:        1,
+				RetainUntil:    retainUntil,
+			})
+			require.NoError(t, err)
+
+			lock, err = db.GetObjectLock(ctx, metabase.GetObjectLock{ObjectLocation: location, Version: 1})
+			require.NoError(t, err)
+			require.WithinDuration(t, retainUntil, lock.RetainUntil, time.Millisecond)
+			require.True(t, lock.Locked(time.Now()))
+
+			// the retention cannot be shortened
+			err = db.SetObjectRetention(ctx, metabase.SetObjectRetention{
+				ObjectLocation: location,
+				Version:        1,
+				RetainUntil:    retainUntil.Add(-time.Minute),
+			})
+			require.True(t, metabase.ErrObjectLocked.Has(err))
+
+			metabasetest.DeleteObjectExactVersion{
+				Opts: metabase.DeleteObjectExactVersion{
+					ObjectLocation: location,
+					Version:        1,
+				},
+				ErrClass: &metabase.ErrObjectLocked,
+				ErrText:  "object version 1 is locked",
+			}.Check(ctx, t, db)
+
+			metabasetest.DeleteBucketObjects{
+				Opts: metabase.DeleteBucketObjects{
+					Bucket: location.Bucket(),
+				},
+				ErrClass: &metabase.ErrObjectLocked,
+				ErrText:  "bucket contains locked objects",
+			}.Check(ctx, t, db)
+
+			metabasetest.Verify{
+				Objects: []metabase.RawObject{
+					metabase.RawObject(object),
+				},
+			}.Check(ctx, t, db)
+		})
+
+		t.Run("legal hold", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			object := metabasetest.CreateObject(ctx, t, db, obj, 0)
+
+			err := db.SetObjectLegalHold(ctx, metabase.SetObjectLegalHold{ObjectLocation: location, Version: 1, Enabled: true})
+			require.NoError(t, err)
+
+			metabasetest.DeleteObjectExactVersion{
+				Opts: metabase.DeleteObjectExactVersion{
+					ObjectLocation: location,
+					Version:        1,
+				},
+				ErrClass: &metabase.ErrObjectLocked,
+				ErrText:  "object version 1 is locked",
+			}.Check(ctx, t, db)
+
+			metabasetest.FinishMoveObject{
+				Opts: metabase.FinishMoveObject{
+					ObjectStream:          obj,
+					NewBucket:             obj.BucketName,
+					NewEncryptedObjectKey: []byte("new key"),
+				},
+				ErrClass: &metabase.ErrObjectLocked,
+				ErrText:  "object version 1 is locked",
+			}.Check(ctx, t, db)
+
+			metabasetest.Verify{
+				Objects: []metabase.RawObject{
+					metabase.RawObject(object),
+				},
+			}.Check(ctx, t, db)
+
+			// releasing the hold allows removing the object
+			err = db.SetObjectLegalHold(ctx, metabase.SetObjectLegalHold{ObjectLocation: location, Version: 1, Enabled: false})
+			require.NoError(t, err)
+
+			metabasetest.DeleteObjectExactVersion{
+				Opts: metabase.DeleteObjectExactVersion{
+					ObjectLocation: location,
+					Version:        1,
+				},
+				Result: metabase.DeleteObjectResult{
+					Objects: []metabase.Object{object},
+				},
+			}.Check(ctx, t, db)
+
+			metabasetest.Verify{}.Check(ctx, t, db)
+		})
+
+		t.Run("move over locked object", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			target := metabasetest.CreateObject(ctx, t, db, obj, 0)
+
+			source := metabasetest.RandObjectStream()
+			source.ProjectID, source.BucketName = obj.ProjectID, obj.BucketName
+			sourceObject := metabasetest.CreateObject(ctx, t, db, source, 0)
+
+			err := db.SetObjectRetention(ctx, metabase.SetObjectRetention{
+				ObjectLocation: location,
+				Version:        1,
+				RetainUntil:    time.Now().Add(time.Hour),
+			})
+			require.NoError(t, err)
+
+			metabasetest.FinishMoveObject{
+				Opts: metabase.FinishMoveObject{
+					ObjectStream:                 source,
+					NewBucket:                    obj.BucketName,
+					NewEncryptedObjectKey:        []byte(obj.ObjectKey),
+					NewEncryptedMetadataKeyNonce: testrand.Nonce(),
+					NewEncryptedMetadataKey:      testrand.Bytes(32),
+				},
+				ErrClass: &metabase.ErrObjectLocked,
+				ErrText:  "object is locked",
+			}.Check(ctx, t, db)
+
+			metabasetest.Verify{
+				Objects: []metabase.RawObject{
+					metabase.RawObject(target),
+					metabase.RawObject(sourceObject),
+				},
+			}.Check(ctx, t, db)
+		})
+
+		t.Run("copy over locked object", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			target := metabasetest.CreateObject(ctx, t, db, obj, 0)
+
+			source := metabasetest.RandObjectStream()
+			source.ProjectID, source.BucketName = obj.ProjectID, obj.BucketName
+			sourceObject := metabasetest.CreateObject(ctx, t, db, source, 0)
+
+			err := db.SetObjectLegalHold(ctx, metabase.SetObjectLegalHold{ObjectLocation: location, Version: 1, Enabled: true})
+			require.NoError(t, err)
+
+			metabasetest.FinishCopyObject{
+				Opts: metabase.FinishCopyObject{
+					ObjectStream:          source,
+					NewBucket:             obj.BucketName,
+					NewEncryptedObjectKey: obj.ObjectKey,
+					NewStreamID:           testrand.UUID(),
+				},
+				ErrClass: &metabase.ErrObjectLocked,
+				ErrText:  "object version 1 is locked",
+			}.Check(ctx, t, db)
+
+			metabasetest.Verify{
+				Objects: []metabase.RawObject{
+					metabase.RawObject(target),
+					metabase.RawObject(sourceObject),
+				},
+			}.Check(ctx, t, db)
+		})
+	})
+}
@@ -0,0 +1,79 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package rangedloop
+
+import (
+	"context"
+	"time"
+
+	"storj.io/storj/satellite/metabase"
+	"storj.io/storj/satellite/metabase/segmentloop"
+)
+
+var _ Observer = (*IntervalObserver)(nil)
+
+// IntervalObserver wraps an observer, so that it only takes part in the loop
+// iterations, which start at least its interval after the start of its last
+// successful iteration. The other iterations are skipped without calling
+// the wrapped observer.
+type IntervalObserver struct {
+	observer Observer
+	interval time.Duration
+
+	skip    bool
+	started time.Time
+	lastRun time.Time
+}
+
+// NewIntervalObserver creates an observer, which runs the observer at most once per interval.
+func NewIntervalObserver(observer Observer, interval time.Duration) *IntervalObserver {
+	return &IntervalObserver{
+		observer: observer,
+		interval: interval,
+	}
+}
+
+// Start starts the wrapped observer, when its interval has passed.
+func (obs *IntervalObserver) Start(ctx context.Context, startTime time.Time, aliasMap metabase.NodeAliasMap) error {
+	obs.skip = !obs.lastRun.IsZero() && startTime.Before(obs.lastRun.Add(obs.interval))
+	if obs.skip {
+		return nil
+	}
+	obs.started = startTime
+	return obs.observer.Start(ctx, startTime, aliasMap)
+}
+
+// Fork forks the wrapped observer, unless the iteration is skipped.
+func (obs *IntervalObserver) Fork(ctx context.Context) (Partial, error) {
+	if obs.skip {
+		return skippedPartial{}, nil
+	}
+	return obs.observer.Fork(ctx)
+}
+
+// Join joins the partial of the wrapped observer, unless the iteration is skipped.
+func (obs *IntervalObserver) Join(ctx context.Context, partial Partial) error {
+	if obs.skip {
+		return nil
+	}
+	return obs.observer.Join(ctx, partial)
+}
+
+// Finish finishes the wrapped observer, unless the iteration is skipped.
+func (obs *IntervalObserver) Finish(ctx context.Context) error {
+	if obs.skip {
+		return nil
+	}
+	if err := obs.observer.Finish(ctx); err != nil {
+		return err
+	}
+	obs.lastRun = obs.started
+	return nil
+}
+
+// skippedPartial ignores the segments of a skipped iteration.
+type skippedPartial struct{}
+
+// Process implements Partial.
+func (skippedPartial) Process(context.Context, []segmentloop.Segment) error { return nil }
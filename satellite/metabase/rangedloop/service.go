@@ -5,21 +5,43 @@ package rangedloop
 
 import (
 	"context"
+	"fmt"
+	"sync"
+	"time"
 
 	"github.com/spacemonkeygo/monkit/v3"
 	"github.com/zeebo/errs"
 	"go.uber.org/zap"
+	"golang.org/x/sync/errgroup"
 
 	"storj.io/common/errs2"
+	"storj.io/common/sync2"
+	"storj.io/storj/satellite/metabase"
 	"storj.io/storj/satellite/metabase/segmentloop"
 )
 
 var (
 	mon = monkit.Package()
+
+	// Error is a standard error class for this component.
+	Error = errs.Class("ranged loop")
 )
 
 // Config contains configurable values for the shared loop.
-type Config struct{}
+type Config struct {
+	Interval           time.Duration `help:"how often to run the loop" releaseDefault:"2h" devDefault:"10s" testDefault:"0"`
+	Parallelism        int           `help:"how many chunks of segments to process in parallel" default:"2"`
+	BatchSize          int           `help:"how many items to query in a batch" default:"2500"`
+	AsOfSystemInterval time.Duration `help:"as of system interval" releaseDefault:"-5m" devDefault:"-1us" testDefault:"-1us"`
+}
+
+// MetabaseDB contains iterators for the metabase data.
+type MetabaseDB interface {
+	segmentloop.MetabaseDB
+
+	// LatestNodesAliasMap returns the latest mapping between alias and NodeID.
+	LatestNodesAliasMap(ctx context.Context) (*metabase.NodeAliasMap, error)
+}
 
 // Service iterates through all segments and calls the attached observers for every segment
 //
@@ -27,17 +49,41 @@ type Config struct{}
 type Service struct {
 	log        *zap.Logger
 	config     Config
-	metabaseDB segmentloop.MetabaseDB
+	metabaseDB MetabaseDB
 	observers  []Observer
+
+	Loop *sync2.Cycle
 }
 
 // NewService creates a new instance of the ranged loop service.
-func NewService(log *zap.Logger, config Config, metabaseDB segmentloop.MetabaseDB, observers []Observer) *Service {
+func NewService(log *zap.Logger, config Config, metabaseDB MetabaseDB, observers []Observer) *Service {
 	return &Service{
 		log:        log,
 		config:     config,
 		metabaseDB: metabaseDB,
 		observers:  observers,
+		Loop:       sync2.NewCycle(config.Interval),
+	}
+}
+
+// observerState contains information to manage an observer during a loop iteration.
+type observerState struct {
+	observer Observer
+	partials []Partial
+	// err is set when the observer failed during the iteration.
+	// Failed observers don't receive any further calls.
+	err error
+
+	// durations contains the processing time of every range. Each range only
+	// touches its own entry, so they are reported after all ranges finished.
+	durations []time.Duration
+}
+
+func newObserverState(observer Observer, nRanges int) *observerState {
+	return &observerState{
+		observer:  observer,
+		partials:  make([]Partial, nRanges),
+		durations: make([]time.Duration, nRanges),
 	}
 }
 
@@ -45,7 +91,7 @@ func NewService(log *zap.Logger, config Config, metabaseDB segmentloop.MetabaseD
 func (service *Service) Run(ctx context.Context) (err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	for {
+	return service.Loop.Run(ctx, func(ctx context.Context) error {
 		if err := service.RunOnce(ctx); err != nil {
 			service.log.Error("ranged loop failure", zap.Error(err))
 
@@ -58,14 +104,167 @@ func (service *Service) Run(ctx context.Context) (err error) {
 
 			mon.Event("rangedloop_error") //mon:locked
 		}
-	}
+		return nil
+	})
+}
+
+// Close stops the looping service.
+func (service *Service) Close() error {
+	service.Loop.Close()
+	return nil
 }
 
 // RunOnce goes through one time and sends information to observers.
 func (service *Service) RunOnce(ctx context.Context) (err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	// TODO
+	ranges, err := CreateUUIDRanges(uint32(service.config.Parallelism))
+	if err != nil {
+		return Error.Wrap(err)
+	}
 
-	return nil
+	startTime, err := service.metabaseDB.Now(ctx)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	aliasMap, err := service.metabaseDB.LatestNodesAliasMap(ctx)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	states := make([]*observerState, 0, len(service.observers))
+	for _, observer := range service.observers {
+		state := newObserverState(observer, len(ranges))
+		state.err = observer.Start(ctx, startTime, *aliasMap)
+		states = append(states, state)
+	}
+
+	for _, state := range states {
+		for i := range ranges {
+			if state.err != nil {
+				break
+			}
+			state.partials[i], state.err = state.observer.Fork(ctx)
+		}
+	}
+
+	// each range has its own set of partials and only touches the error
+	// of an observer through the mutex.
+	var mu sync.Mutex
+	failObserver := func(state *observerState, err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		if state.err == nil {
+			state.err = err
+		}
+	}
+	isFailed := func(state *observerState) bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return state.err != nil
+	}
+
+	group, groupCtx := errgroup.WithContext(ctx)
+	for i, uuidRange := range ranges {
+		i, uuidRange := i, uuidRange
+		group.Go(func() error {
+			return service.iterateRange(groupCtx, uuidRange, startTime, func(ctx context.Context, segments []segmentloop.Segment) {
+				for _, state := range states {
+					if isFailed(state) {
+						continue
+					}
+
+					start := time.Now()
+					err := state.partials[i].Process(ctx, segments)
+					state.durations[i] += time.Since(start)
+
+					if err != nil {
+						failObserver(state, err)
+					}
+				}
+			})
+		})
+	}
+	if err := group.Wait(); err != nil {
+		return Error.Wrap(err)
+	}
+
+	for _, state := range states {
+		observerTag := monkit.NewSeriesTag("observer", fmt.Sprintf("%T", state.observer))
+		for _, duration := range state.durations {
+			mon.DurationVal("rangedloop_observer_range_duration", observerTag).Observe(duration)
+		}
+	}
+
+	for _, state := range states {
+		for _, partial := range state.partials {
+			if state.err != nil {
+				break
+			}
+			state.err = state.observer.Join(ctx, partial)
+		}
+		if state.err == nil {
+			state.err = state.observer.Finish(ctx)
+		}
+	}
+
+	var failures errs.Group
+	for _, state := range states {
+		if state.err != nil {
+			service.log.Error("observer failed",
+				zap.String("observer", fmt.Sprintf("%T", state.observer)),
+				zap.Error(state.err))
+			failures.Add(state.err)
+		}
+	}
+
+	return Error.Wrap(failures.Err())
+}
+
+// iterateRange iterates through all segments in the range and sends them in batches to process.
+func (service *Service) iterateRange(ctx context.Context, uuidRange UUIDRange, startTime time.Time, process func(context.Context, []segmentloop.Segment)) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	batchSize := service.config.BatchSize
+	if batchSize <= 0 {
+		batchSize = 2500
+	}
+
+	opts := metabase.IterateLoopSegments{
+		BatchSize:          batchSize,
+		AsOfSystemTime:     startTime,
+		AsOfSystemInterval: service.config.AsOfSystemInterval,
+	}
+	if uuidRange.Start != nil {
+		opts.StartStreamID = *uuidRange.Start
+	}
+	if uuidRange.End != nil {
+		opts.EndStreamID = *uuidRange.End
+	}
+
+	return service.metabaseDB.IterateLoopSegments(ctx, opts, func(ctx context.Context, iterator metabase.LoopSegmentsIterator) error {
+		batch := make([]segmentloop.Segment, 0, batchSize)
+
+		var entry metabase.LoopSegmentEntry
+		for iterator.Next(ctx, &entry) {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+
+			batch = append(batch, segmentloop.Segment(entry))
+			if len(batch) >= batchSize {
+				process(ctx, batch)
+				mon.IntVal("rangedloop_segments_processed").Observe(int64(len(batch)))
+				// observers may keep the batch, so we need a new one.
+				batch = make([]segmentloop.Segment, 0, batchSize)
+			}
+		}
+
+		if len(batch) > 0 {
+			process(ctx, batch)
+			mon.IntVal("rangedloop_segments_processed").Observe(int64(len(batch)))
+		}
+		return ctx.Err()
+	})
 }
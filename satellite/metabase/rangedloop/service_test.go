@@ -0,0 +1,222 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package rangedloop_test
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"storj.io/common/testcontext"
+	"storj.io/common/testrand"
+	"storj.io/common/uuid"
+	"storj.io/storj/satellite/metabase"
+	"storj.io/storj/satellite/metabase/rangedloop"
+	"storj.io/storj/satellite/metabase/segmentloop"
+)
+
+func TestRunOnce(t *testing.T) {
+	ctx := testcontext.New(t)
+
+	segments := make([]metabase.LoopSegmentEntry, 1000)
+	for i := range segments {
+		segments[i] = metabase.LoopSegmentEntry{
+			StreamID: testrand.UUID(),
+			Position: metabase.SegmentPosition{Index: uint32(i % 3)},
+		}
+	}
+
+	for _, parallelism := range []int{1, 2, 5, 16} {
+		for _, batchSize := range []int{1, 7, 1000, 2000} {
+			observer := &countObserver{}
+			service := rangedloop.NewService(zaptest.NewLogger(t), rangedloop.Config{
+				Parallelism: parallelism,
+				BatchSize:   batchSize,
+			}, newFakeMetabase(segments), []rangedloop.Observer{observer})
+
+			require.NoError(t, service.RunOnce(ctx))
+			require.Equal(t, len(segments), observer.total)
+			require.Equal(t, parallelism, observer.forks)
+			require.Equal(t, parallelism, observer.joins)
+			require.True(t, observer.finished)
+
+			seen := map[uuid.UUID]int{}
+			for _, streamID := range observer.streamIDs {
+				seen[streamID]++
+			}
+			for _, segment := range segments {
+				require.Equal(t, 1, seen[segment.StreamID])
+			}
+		}
+	}
+}
+
+func TestRunOnce_FailingObserver(t *testing.T) {
+	ctx := testcontext.New(t)
+
+	segments := make([]metabase.LoopSegmentEntry, 100)
+	for i := range segments {
+		segments[i] = metabase.LoopSegmentEntry{StreamID: testrand.UUID()}
+	}
+
+	failing := &countObserver{processErr: errors.New("process failed")}
+	healthy := &countObserver{}
+	service := rangedloop.NewService(zaptest.NewLogger(t), rangedloop.Config{
+		Parallelism: 4,
+		BatchSize:   10,
+	}, newFakeMetabase(segments), []rangedloop.Observer{failing, healthy})
+
+	require.Error(t, service.RunOnce(ctx))
+	require.False(t, failing.finished)
+	require.True(t, healthy.finished)
+	require.Equal(t, len(segments), healthy.total)
+}
+
+func TestIntervalObserver(t *testing.T) {
+	ctx := testcontext.New(t)
+
+	segments := []metabase.LoopSegmentEntry{{StreamID: testrand.UUID()}}
+	observer := &countObserver{}
+	service := rangedloop.NewService(zaptest.NewLogger(t), rangedloop.Config{
+		Parallelism: 2,
+		BatchSize:   10,
+	}, newFakeMetabase(segments), []rangedloop.Observer{rangedloop.NewIntervalObserver(observer, time.Hour)})
+
+	// the first iteration always runs the observer
+	require.NoError(t, service.RunOnce(ctx))
+	require.True(t, observer.finished)
+	require.Equal(t, 1, observer.total)
+
+	// the next iterations within the interval skip it
+	*observer = countObserver{}
+	require.NoError(t, service.RunOnce(ctx))
+	require.False(t, observer.finished)
+	require.Zero(t, observer.forks)
+	require.Zero(t, observer.total)
+
+	// an observer with a zero interval runs in every iteration
+	observer = &countObserver{}
+	service = rangedloop.NewService(zaptest.NewLogger(t), rangedloop.Config{
+		Parallelism: 2,
+		BatchSize:   10,
+	}, newFakeMetabase(segments), []rangedloop.Observer{rangedloop.NewIntervalObserver(observer, 0)})
+	for i := 1; i <= 2; i++ {
+		require.NoError(t, service.RunOnce(ctx))
+		require.Equal(t, i, observer.total)
+	}
+}
+
+type countObserver struct {
+	processErr error
+
+	forks     int
+	joins     int
+	finished  bool
+	total     int
+	streamIDs []uuid.UUID
+}
+
+type countPartial struct {
+	processErr error
+	streamIDs  []uuid.UUID
+}
+
+func (observer *countObserver) Start(context.Context, time.Time, metabase.NodeAliasMap) error {
+	return nil
+}
+
+func (observer *countObserver) Fork(context.Context) (rangedloop.Partial, error) {
+	observer.forks++
+	return &countPartial{processErr: observer.processErr}, nil
+}
+
+func (observer *countObserver) Join(ctx context.Context, partial rangedloop.Partial) error {
+	observer.joins++
+	streamIDs := partial.(*countPartial).streamIDs
+	observer.total += len(streamIDs)
+	observer.streamIDs = append(observer.streamIDs, streamIDs...)
+	return nil
+}
+
+func (observer *countObserver) Finish(context.Context) error {
+	observer.finished = true
+	return nil
+}
+
+func (partial *countPartial) Process(ctx context.Context, segments []segmentloop.Segment) error {
+	if partial.processErr != nil {
+		return partial.processErr
+	}
+	for _, segment := range segments {
+		partial.streamIDs = append(partial.streamIDs, segment.StreamID)
+	}
+	return nil
+}
+
+// fakeMetabase mimics the range semantics of metabase.DB.IterateLoopSegments.
+type fakeMetabase struct {
+	mu       sync.Mutex
+	segments []metabase.LoopSegmentEntry
+}
+
+func newFakeMetabase(segments []metabase.LoopSegmentEntry) *fakeMetabase {
+	sorted := append([]metabase.LoopSegmentEntry{}, segments...)
+	sort.Slice(sorted, func(i, k int) bool {
+		if sorted[i].StreamID == sorted[k].StreamID {
+			return sorted[i].Position.Less(sorted[k].Position)
+		}
+		return sorted[i].StreamID.Less(sorted[k].StreamID)
+	})
+	return &fakeMetabase{segments: sorted}
+}
+
+func (db *fakeMetabase) Now(ctx context.Context) (time.Time, error) {
+	return time.Now(), nil
+}
+
+func (db *fakeMetabase) LatestNodesAliasMap(ctx context.Context) (*metabase.NodeAliasMap, error) {
+	return metabase.NewNodeAliasMap(nil), nil
+}
+
+func (db *fakeMetabase) GetTableStats(context.Context, metabase.GetTableStats) (metabase.TableStats, error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	return metabase.TableStats{SegmentCount: int64(len(db.segments))}, nil
+}
+
+func (db *fakeMetabase) IterateLoopSegments(ctx context.Context, opts metabase.IterateLoopSegments, fn func(context.Context, metabase.LoopSegmentsIterator) error) error {
+	db.mu.Lock()
+	var selected []metabase.LoopSegmentEntry
+	for _, segment := range db.segments {
+		if !opts.StartStreamID.IsZero() && !opts.StartStreamID.Less(segment.StreamID) {
+			continue
+		}
+		if !opts.EndStreamID.IsZero() && opts.EndStreamID.Less(segment.StreamID) {
+			continue
+		}
+		selected = append(selected, segment)
+	}
+	db.mu.Unlock()
+
+	return fn(ctx, &fakeIterator{segments: selected})
+}
+
+type fakeIterator struct {
+	segments []metabase.LoopSegmentEntry
+}
+
+func (it *fakeIterator) Next(ctx context.Context, item *metabase.LoopSegmentEntry) bool {
+	if len(it.segments) == 0 {
+		return false
+	}
+	*item = it.segments[0]
+	it.segments = it.segments[1:]
+	return true
+}
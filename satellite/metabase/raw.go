@@ -35,6 +35,9 @@ type RawObject struct {
 
 	Encryption storj.EncryptionParameters
 
+	// Tags are unencrypted key/value pairs visible to the satellite.
+	Tags ObjectTags
+
 	// ZombieDeletionDeadline defines when the pending raw object should be deleted from the database.
 	// This is as a safeguard against objects that failed to upload and the client has not indicated
 	// whether they want to continue uploading or delete the already uploaded data.
@@ -129,6 +132,7 @@ func (db *DB) testingGetAllObjects(ctx context.Context) (_ []RawObject, err erro
 			encrypted_metadata_nonce, encrypted_metadata, encrypted_metadata_encrypted_key,
 			total_plain_size, total_encrypted_size, fixed_segment_size,
 			encryption,
+			tags,
 			zombie_deletion_deadline
 		FROM objects
 		ORDER BY project_id ASC, bucket_name ASC, object_key ASC, version ASC
@@ -161,6 +165,7 @@ func (db *DB) testingGetAllObjects(ctx context.Context) (_ []RawObject, err erro
 			&obj.FixedSegmentSize,
 
 			encryptionParameters{&obj.Encryption},
+			&obj.Tags,
 			&obj.ZombieDeletionDeadline,
 		)
 		if err != nil {
@@ -0,0 +1,137 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package metabase
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"unicode/utf8"
+
+	"storj.io/common/storj"
+)
+
+const (
+	// MaxObjectTags is the maximum number of tags on an object.
+	MaxObjectTags = 10
+	// MaxObjectTagKeyLength is the maximum length of a tag key in bytes.
+	MaxObjectTagKeyLength = 128
+	// MaxObjectTagValueLength is the maximum length of a tag value in bytes.
+	MaxObjectTagValueLength = 256
+)
+
+// ObjectTags is a set of unencrypted key/value pairs attached to an object.
+// Unlike the encrypted metadata, tags are visible to the satellite.
+type ObjectTags map[string]string
+
+// Verify verifies the object tags.
+func (tags ObjectTags) Verify() error {
+	if len(tags) > MaxObjectTags {
+		return ErrInvalidRequest.New("too many tags: %d, maximum is %d", len(tags), MaxObjectTags)
+	}
+	for key, value := range tags {
+		switch {
+		case key == "":
+			return ErrInvalidRequest.New("tag key missing")
+		case len(key) > MaxObjectTagKeyLength:
+			return ErrInvalidRequest.New("tag key too long: %d, maximum is %d", len(key), MaxObjectTagKeyLength)
+		case len(value) > MaxObjectTagValueLength:
+			return ErrInvalidRequest.New("tag value too long: %d, maximum is %d", len(value), MaxObjectTagValueLength)
+		case !utf8.ValidString(key) || !utf8.ValidString(value):
+			return ErrInvalidRequest.New("tags must be valid UTF-8")
+		}
+	}
+	return nil
+}
+
+// Value implements sql/driver.Valuer interface.
+func (tags ObjectTags) Value() (driver.Value, error) {
+	if len(tags) == 0 {
+		return nil, nil
+	}
+	data, err := json.Marshal(map[string]string(tags))
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	return string(data), nil
+}
+
+// Scan implements sql.Scanner interface.
+func (tags *ObjectTags) Scan(value interface{}) error {
+	var data []byte
+	switch value := value.(type) {
+	case nil:
+		*tags = nil
+		return nil
+	case []byte:
+		data = value
+	case string:
+		data = []byte(value)
+	default:
+		return Error.New("unable to scan %T into ObjectTags", value)
+	}
+
+	var decoded map[string]string
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return Error.New("unable to decode ObjectTags: %w", err)
+	}
+	if len(decoded) == 0 {
+		decoded = nil
+	}
+	*tags = decoded
+	return nil
+}
+
+// SetObjectTags contains arguments necessary for replacing the tags of an object version.
+type SetObjectTags struct {
+	ObjectLocation
+	Version Version
+	Tags    ObjectTags
+}
+
+// Verify verifies set object tags fields.
+func (opts *SetObjectTags) Verify() error {
+	if err := opts.ObjectLocation.Verify(); err != nil {
+		return err
+	}
+	if opts.Version <= 0 {
+		return ErrInvalidRequest.New("Version invalid: %v", opts.Version)
+	}
+	return opts.Tags.Verify()
+}
+
+// SetObjectTags replaces the tags of a committed object version.
+// Setting an empty tag set removes all the tags.
+func (db *DB) SetObjectTags(ctx context.Context, opts SetObjectTags) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if err := opts.Verify(); err != nil {
+		return err
+	}
+
+	result, err := db.db.ExecContext(ctx, `
+		UPDATE objects SET tags = $5
+		WHERE
+			project_id   = $1 AND
+			bucket_name  = $2 AND
+			object_key   = $3 AND
+			version      = $4 AND
+			status       = `+committedStatus+` AND
+			(expires_at IS NULL OR expires_at > now())`,
+		opts.ProjectID, []byte(opts.BucketName), opts.ObjectKey, opts.Version, opts.Tags)
+	if err != nil {
+		return Error.New("unable to update object tags: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return Error.New("failed to get rows affected: %w", err)
+	}
+	if affected == 0 {
+		return storj.ErrObjectNotFound.New("object with specified version and committed status is missing")
+	}
+
+	mon.Meter("object_set_tags").Mark(1)
+	return nil
+}
@@ -0,0 +1,210 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package metabase_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"storj.io/common/storj"
+	"storj.io/common/testcontext"
+	"storj.io/storj/satellite/metabase"
+	"storj.io/storj/satellite/metabase/metabasetest"
+)
+
+func TestObjectTags_Verify(t *testing.T) {
+	tooMany := metabase.ObjectTags{}
+	for i := 0; i <= metabase.MaxObjectTags; i++ {
+		tooMany[strings.Repeat("k", i+1)] = "v"
+	}
+
+	for _, tc := range []struct {
+		name  string
+		tags  metabase.ObjectTags
+		valid bool
+	}{
+		{name: "empty", tags: nil, valid: true},
+		{name: "valid", tags: metabase.ObjectTags{"team": "storage", "empty": ""}, valid: true},
+		{name: "too many", tags: tooMany},
+		{name: "empty key", tags: metabase.ObjectTags{"": "value"}},
+		{name: "long key", tags: metabase.ObjectTags{strings.Repeat("k", metabase.MaxObjectTagKeyLength+1): "value"}},
+		{name: "long value", tags: metabase.ObjectTags{"key": strings.Repeat("v", metabase.MaxObjectTagValueLength+1)}},
+		{name: "invalid utf-8", tags: metabase.ObjectTags{"key": "\xff"}},
+	} {
+		err := tc.tags.Verify()
+		if tc.valid {
+			require.NoError(t, err, tc.name)
+		} else {
+			require.True(t, metabase.ErrInvalidRequest.Has(err), tc.name)
+		}
+	}
+}
+
+func TestObjectTags_Encoding(t *testing.T) {
+	value, err := metabase.ObjectTags(nil).Value()
+	require.NoError(t, err)
+	require.Nil(t, value)
+
+	tags := metabase.ObjectTags{"team": "storage", "env": "prod"}
+	value, err = tags.Value()
+	require.NoError(t, err)
+
+	var decoded metabase.ObjectTags
+	require.NoError(t, decoded.Scan(value))
+	require.Equal(t, tags, decoded)
+
+	require.NoError(t, decoded.Scan([]byte(`{}`)))
+	require.Nil(t, decoded)
+
+	require.NoError(t, decoded.Scan(nil))
+	require.Nil(t, decoded)
+
+	require.Error(t, decoded.Scan(int64(1)))
+}
+
+func TestSetObjectTags(t *testing.T) {
+	metabasetest.Run(t, func(ctx *testcontext.Context, t *testing.T, db *metabase.DB) {
+		obj := metabasetest.RandObjectStream()
+		location := obj.Location()
+
+		t.Run("invalid tags", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			err := db.SetObjectTags(ctx, metabase.SetObjectTags{
+				ObjectLocation: location,
+				Version:        1,
+				Tags:           metabase.ObjectTags{"": "value"},
+			})
+			require.True(t, metabase.ErrInvalidRequest.Has(err))
+		})
+
+		t.Run("object missing", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			err := db.SetObjectTags(ctx, metabase.SetObjectTags{
+				ObjectLocation: location,
+				Version:        1,
+				Tags:           metabase.ObjectTags{"key": "value"},
+			})
+			require.True(t, storj.ErrObjectNotFound.Has(err))
+		})
+
+		t.Run("set and remove", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			object := metabasetest.CreateObject(ctx, t, db, obj, 0)
+
+			tags := metabase.ObjectTags{"team": "storage"}
+			err := db.SetObjectTags(ctx, metabase.SetObjectTags{
+				ObjectLocation: location,
+				Version:        1,
+				Tags:           tags,
+			})
+			require.NoError(t, err)
+
+			object.Tags = tags
+			metabasetest.GetObjectExactVersion{
+				Opts: metabase.GetObjectExactVersion{
+					ObjectLocation: location,
+					Version:        1,
+				},
+				Result: object,
+			}.Check(ctx, t, db)
+
+			metabasetest.Verify{
+				Objects: []metabase.RawObject{
+					metabase.RawObject(object),
+				},
+			}.Check(ctx, t, db)
+
+			err = db.SetObjectTags(ctx, metabase.SetObjectTags{
+				ObjectLocation: location,
+				Version:        1,
+			})
+			require.NoError(t, err)
+
+			object.Tags = nil
+			metabasetest.Verify{
+				Objects: []metabase.RawObject{
+					metabase.RawObject(object),
+				},
+			}.Check(ctx, t, db)
+		})
+
+		t.Run("list by tags", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			objects := map[string]metabase.Object{}
+			for key, tags := range map[string]metabase.ObjectTags{
+				"a":   {"team": "storage", "env": "prod"},
+				"b":   {"team": "storage"},
+				"c":   {"team": "billing", "env": "prod"},
+				"d/e": {"team": "storage", "env": "prod"},
+				"f":   nil,
+			} {
+				stream := obj
+				stream.ObjectKey = metabase.ObjectKey(key)
+				object := metabasetest.CreateObject(ctx, t, db, stream, 0)
+
+				err := db.SetObjectTags(ctx, metabase.SetObjectTags{
+					ObjectLocation: object.Location(),
+					Version:        object.Version,
+					Tags:           tags,
+				})
+				require.NoError(t, err)
+
+				object.Tags = tags
+				objects[key] = object
+			}
+
+			entry := func(key string) metabase.ObjectEntry {
+				return objectEntryFromRaw(metabase.RawObject(objects[key]))
+			}
+
+			metabasetest.ListObjects{
+				Opts: metabase.ListObjects{
+					ProjectID:             obj.ProjectID,
+					BucketName:            obj.BucketName,
+					Recursive:             true,
+					Status:                metabase.Committed,
+					IncludeCustomMetadata: true,
+					IncludeSystemMetadata: true,
+					Tags:                  metabase.ObjectTags{"team": "storage", "env": "prod"},
+				},
+				Result: metabase.ListObjectsResult{
+					Objects: []metabase.ObjectEntry{entry("a"), entry("d/e")},
+				},
+			}.Check(ctx, t, db)
+
+			metabasetest.ListObjects{
+				Opts: metabase.ListObjects{
+					ProjectID:             obj.ProjectID,
+					BucketName:            obj.BucketName,
+					Recursive:             true,
+					Status:                metabase.Committed,
+					IncludeCustomMetadata: true,
+					IncludeSystemMetadata: true,
+					Tags:                  metabase.ObjectTags{"team": "storage"},
+				},
+				Result: metabase.ListObjectsResult{
+					Objects: []metabase.ObjectEntry{entry("a"), entry("b"), entry("d/e")},
+				},
+			}.Check(ctx, t, db)
+
+			metabasetest.ListObjects{
+				Opts: metabase.ListObjects{
+					ProjectID:  obj.ProjectID,
+					BucketName: obj.BucketName,
+					Recursive:  true,
+					Status:     metabase.Pending,
+					Tags:       metabase.ObjectTags{"team": "storage"},
+				},
+				ErrClass: &metabase.ErrInvalidRequest,
+				ErrText:  "Tags filter is only supported for committed objects",
+			}.Check(ctx, t, db)
+		})
+	})
+}
@@ -0,0 +1,91 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package bucketlifecycle_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"storj.io/common/memory"
+	"storj.io/common/testcontext"
+	"storj.io/common/testrand"
+	"storj.io/storj/private/testplanet"
+	"storj.io/storj/satellite"
+	"storj.io/storj/satellite/buckets"
+)
+
+func TestBucketLifecycle(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount: 1, StorageNodeCount: 1, UplinkCount: 1,
+		Reconfigure: testplanet.Reconfigure{
+			Satellite: func(log *zap.Logger, index int, config *satellite.Config) {
+				config.BucketLifecycle.Interval = 500 * time.Millisecond
+			},
+		},
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		satellite := planet.Satellites[0]
+		upl := planet.Uplinks[0]
+		projectID := upl.Projects[0].ID
+		chore := satellite.Core.BucketLifecycle.Chore
+
+		chore.Loop.Pause()
+
+		require.NoError(t, upl.Upload(ctx, satellite, "expiring", "object", testrand.Bytes(1*memory.KiB)))
+		require.NoError(t, upl.Upload(ctx, satellite, "keeping", "object", testrand.Bytes(1*memory.KiB)))
+
+		project, err := upl.OpenProject(ctx, satellite)
+		require.NoError(t, err)
+		defer ctx.Check(project.Close)
+
+		_, err = project.BeginUpload(ctx, "keeping", "pending", nil)
+		require.NoError(t, err)
+
+		err = satellite.API.Buckets.Service.SetBucketLifecycleRules(ctx, []byte("expiring"), projectID, buckets.LifecycleRules{
+			{ID: "expire", ExpirationDays: 10},
+		})
+		require.NoError(t, err)
+		err = satellite.API.Buckets.Service.SetBucketLifecycleRules(ctx, []byte("keeping"), projectID, buckets.LifecycleRules{
+			{ID: "uploads", AbortIncompleteUploadDays: 1},
+		})
+		require.NoError(t, err)
+
+		rules, err := satellite.API.Buckets.Service.GetBucketLifecycleRules(ctx, []byte("expiring"), projectID)
+		require.NoError(t, err)
+		require.Equal(t, buckets.LifecycleRules{{ID: "expire", ExpirationDays: 10}}, rules)
+
+		objects, err := satellite.Metabase.DB.TestingAllObjects(ctx)
+		require.NoError(t, err)
+		require.Len(t, objects, 3)
+
+		// nothing is old enough yet
+		chore.Loop.TriggerWait()
+
+		objects, err = satellite.Metabase.DB.TestingAllObjects(ctx)
+		require.NoError(t, err)
+		require.Len(t, objects, 3)
+
+		chore.SetNow(func() time.Time {
+			return time.Now().Add(11 * 24 * time.Hour)
+		})
+		chore.Loop.TriggerWait()
+
+		// only the committed object in the bucket without expiration is left
+		objects, err = satellite.Metabase.DB.TestingAllObjects(ctx)
+		require.NoError(t, err)
+		require.Len(t, objects, 1)
+		require.Equal(t, "keeping", objects[0].BucketName)
+
+		// removing the rules stops applying them
+		err = satellite.API.Buckets.Service.SetBucketLifecycleRules(ctx, []byte("keeping"), projectID, nil)
+		require.NoError(t, err)
+
+		lifecycles, err := satellite.API.Buckets.Service.ListBucketLifecycles(ctx)
+		require.NoError(t, err)
+		require.Len(t, lifecycles, 1)
+		require.Equal(t, "expiring", lifecycles[0].Location.BucketName)
+	})
+}
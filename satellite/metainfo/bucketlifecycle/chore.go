@@ -0,0 +1,183 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package bucketlifecycle
+
+import (
+	"context"
+	"time"
+
+	"github.com/spacemonkeygo/monkit/v3"
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	"storj.io/common/storj"
+	"storj.io/common/sync2"
+	"storj.io/storj/satellite/buckets"
+	"storj.io/storj/satellite/metabase"
+)
+
+var (
+	// Error defines the bucketlifecycle chore errors class.
+	Error = errs.Class("bucket lifecycle")
+	mon   = monkit.Package()
+)
+
+// Config contains configurable values for applying bucket lifecycle rules.
+type Config struct {
+	Interval           time.Duration `help:"the time between each attempt to apply bucket lifecycle rules" releaseDefault:"24h" devDefault:"10s" testDefault:"$TESTINTERVAL"`
+	Enabled            bool          `help:"set if bucket lifecycle rules are applied or not" releaseDefault:"true" devDefault:"true"`
+	ListLimit          int           `help:"how many objects to query in a batch" default:"2500"`
+	AsOfSystemInterval time.Duration `help:"as of system interval" releaseDefault:"-5m" devDefault:"-1us" testDefault:"-1us"`
+}
+
+// Chore applies bucket lifecycle rules to the objects in the metabase.
+//
+// architecture: Chore
+type Chore struct {
+	log      *zap.Logger
+	config   Config
+	buckets  buckets.DB
+	metabase *metabase.DB
+
+	nowFn func() time.Time
+	Loop  *sync2.Cycle
+}
+
+// NewChore creates a new instance of the bucketlifecycle chore.
+func NewChore(log *zap.Logger, config Config, buckets buckets.DB, metabase *metabase.DB) *Chore {
+	return &Chore{
+		log:      log,
+		config:   config,
+		buckets:  buckets,
+		metabase: metabase,
+
+		nowFn: time.Now,
+		Loop:  sync2.NewCycle(config.Interval),
+	}
+}
+
+// Run starts the bucketlifecycle loop service.
+func (chore *Chore) Run(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if !chore.config.Enabled {
+		return nil
+	}
+
+	return chore.Loop.Run(ctx, func(ctx context.Context) error {
+		if err := chore.RunOnce(ctx); err != nil {
+			chore.log.Error("applying bucket lifecycle rules failed", zap.Error(err))
+		}
+		return nil
+	})
+}
+
+// Close stops the bucketlifecycle chore.
+func (chore *Chore) Close() error {
+	chore.Loop.Close()
+	return nil
+}
+
+// SetNow allows tests to have the server act as if the current time is whatever they want.
+func (chore *Chore) SetNow(nowFn func() time.Time) {
+	chore.nowFn = nowFn
+}
+
+// RunOnce goes through all objects once and applies the lifecycle rules of their buckets.
+func (chore *Chore) RunOnce(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	lifecycles, err := chore.buckets.ListBucketLifecycles(ctx)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+	if len(lifecycles) == 0 {
+		return nil
+	}
+
+	observer := newObserver(chore.nowFn(), lifecycles)
+
+	err = chore.metabase.IterateLoopObjects(ctx, metabase.IterateLoopObjects{
+		BatchSize:          chore.config.ListLimit,
+		AsOfSystemInterval: chore.config.AsOfSystemInterval,
+	}, func(ctx context.Context, it metabase.LoopObjectsIterator) error {
+		var entry metabase.LoopObjectEntry
+		for it.Next(ctx, &entry) {
+			observer.Object(entry)
+
+			if len(observer.actions) >= chore.config.ListLimit {
+				if err := chore.apply(ctx, observer.TakeActions()); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	observer.Finish()
+	return Error.Wrap(chore.apply(ctx, observer.TakeActions()))
+}
+
+// apply removes the objects selected by the lifecycle rules.
+func (chore *Chore) apply(ctx context.Context, actions []action) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	for _, action := range actions {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		switch action.kind {
+		case abortUpload:
+			_, err = chore.metabase.DeletePendingObject(ctx, metabase.DeletePendingObject{
+				ObjectStream: action.object,
+			})
+			mon.Meter("bucket_lifecycle_aborted_uploads").Mark(1)
+		case deleteVersion:
+			_, err = chore.metabase.DeleteObjectExactVersion(ctx, metabase.DeleteObjectExactVersion{
+				ObjectLocation: action.object.Location(),
+				Version:        action.object.Version,
+			})
+			mon.Meter("bucket_lifecycle_deleted_versions").Mark(1)
+		case insertDeleteMarker:
+			err = chore.insertDeleteMarker(ctx, action.object)
+			mon.Meter("bucket_lifecycle_delete_markers").Mark(1)
+		}
+
+		// the object may have been removed since the loop has seen it.
+		if err != nil && !storj.ErrObjectNotFound.Has(err) {
+			chore.log.Error("failed to apply lifecycle rule",
+				zap.Stringer("project", action.object.ProjectID),
+				zap.String("bucket", action.object.BucketName),
+				zap.Binary("object", []byte(action.object.ObjectKey)),
+				zap.Error(err))
+		}
+	}
+	return nil
+}
+
+// insertDeleteMarker hides the object with a delete marker when it's still the
+// latest version, so that objects uploaded after the loop has started are kept.
+func (chore *Chore) insertDeleteMarker(ctx context.Context, object metabase.ObjectStream) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	latest, err := chore.metabase.GetObjectLastCommitted(ctx, metabase.GetObjectLastCommitted{
+		ObjectLocation: object.Location(),
+	})
+	if err != nil {
+		return err
+	}
+	if latest.Version != object.Version {
+		return nil
+	}
+
+	_, err = chore.metabase.DeleteObjectLastCommitted(ctx, metabase.DeleteObjectLastCommitted{
+		ObjectLocation: object.Location(),
+		Versioned:      true,
+	})
+	return err
+}
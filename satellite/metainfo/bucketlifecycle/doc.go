@@ -0,0 +1,16 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+/*
+Package bucketlifecycle contains the functions needed to apply bucket lifecycle rules.
+
+The bucketlifecycle.observer receives every object from the metabase objects loop
+and evaluates the lifecycle rules of its bucket, collecting the objects which should
+be removed: expired current versions, expired noncurrent versions and incomplete
+uploads which were started too long ago.
+
+The bucketlifecycle chore runs the loop periodically and applies the collected
+actions to the metabase. Pieces of the removed objects are cleaned up by garbage
+collection.
+*/
+package bucketlifecycle
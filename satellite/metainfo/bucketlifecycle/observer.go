@@ -0,0 +1,170 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package bucketlifecycle
+
+import (
+	"time"
+
+	"storj.io/storj/satellite/buckets"
+	"storj.io/storj/satellite/metabase"
+)
+
+// actionKind defines how an object is removed.
+type actionKind int
+
+const (
+	// abortUpload removes a pending object.
+	abortUpload actionKind = iota
+	// deleteVersion removes an exact version of a committed object or a delete marker.
+	deleteVersion
+	// insertDeleteMarker hides the current version of an object in a versioned bucket.
+	insertDeleteMarker
+)
+
+// action is a single change the lifecycle rules require for an object.
+type action struct {
+	kind   actionKind
+	object metabase.ObjectStream
+}
+
+// observer evaluates lifecycle rules for the objects received from the objects loop.
+//
+// The loop returns objects ordered by key and version, so all versions of
+// an object key are collected before evaluating them together.
+type observer struct {
+	now        time.Time
+	lifecycles map[metabase.BucketLocation]buckets.BucketLifecycle
+
+	lifecycle buckets.BucketLifecycle
+	versions  []metabase.LoopObjectEntry
+
+	actions []action
+}
+
+func newObserver(now time.Time, lifecycles []buckets.BucketLifecycle) *observer {
+	byLocation := make(map[metabase.BucketLocation]buckets.BucketLifecycle, len(lifecycles))
+	for _, lifecycle := range lifecycles {
+		byLocation[lifecycle.Location] = lifecycle
+	}
+
+	return &observer{
+		now:        now,
+		lifecycles: byLocation,
+	}
+}
+
+// Object is called for every object from the objects loop.
+func (observer *observer) Object(object metabase.LoopObjectEntry) {
+	if len(observer.versions) > 0 && observer.versions[0].Location() != object.Location() {
+		observer.evaluate()
+	}
+
+	if len(observer.versions) == 0 {
+		lifecycle, ok := observer.lifecycles[object.Location().Bucket()]
+		if !ok {
+			return
+		}
+		observer.lifecycle = lifecycle
+	}
+
+	observer.versions = append(observer.versions, object)
+}
+
+// Finish evaluates the last object key.
+func (observer *observer) Finish() {
+	observer.evaluate()
+}
+
+// TakeActions returns the collected actions and resets them.
+func (observer *observer) TakeActions() []action {
+	actions := observer.actions
+	observer.actions = nil
+	return actions
+}
+
+// evaluate applies the rules of the bucket to all versions of the current object key.
+func (observer *observer) evaluate() {
+	versions := observer.versions
+	observer.versions = observer.versions[:0]
+	if len(versions) == 0 {
+		return
+	}
+
+	age := observer.lifecycle.Rules.AgeFor(versions[0].ObjectKey)
+
+	// versions are ordered from oldest to newest, so the visible version
+	// following a noncurrent version is the one which replaced it.
+	var replacedAt *time.Time
+	remaining := 0
+	latest := true
+	for i := len(versions) - 1; i >= 0; i-- {
+		version := versions[i]
+
+		switch version.Status {
+		case metabase.Pending:
+			if observer.expired(version.CreatedAt, age.AbortIncompleteUpload) {
+				observer.add(abortUpload, version)
+			}
+			continue
+		case metabase.Committed, metabase.DeleteMarker:
+		default:
+			continue
+		}
+
+		switch {
+		case latest:
+			latest = false
+			if version.Status == metabase.DeleteMarker {
+				// handled after all noncurrent versions are known.
+				remaining++
+			} else if observer.expired(version.CreatedAt, age.Expiration) {
+				if observer.lifecycle.Versioning.IsVersioned() {
+					observer.add(insertDeleteMarker, version)
+					remaining++
+				} else {
+					observer.add(deleteVersion, version)
+				}
+			} else {
+				remaining++
+			}
+		case replacedAt != nil && observer.expired(*replacedAt, age.NoncurrentExpiration):
+			observer.add(deleteVersion, version)
+		default:
+			remaining++
+		}
+
+		createdAt := version.CreatedAt
+		replacedAt = &createdAt
+	}
+
+	// a delete marker without any previous versions doesn't hide anything anymore.
+	current := lastVisible(versions)
+	if current != nil && current.Status == metabase.DeleteMarker && remaining == 1 &&
+		(age.Expiration > 0 || age.NoncurrentExpiration > 0) {
+		observer.add(deleteVersion, *current)
+	}
+}
+
+// expired checks whether the age has passed since the specified time.
+func (observer *observer) expired(since time.Time, age time.Duration) bool {
+	return age > 0 && !since.Add(age).After(observer.now)
+}
+
+func (observer *observer) add(kind actionKind, object metabase.LoopObjectEntry) {
+	observer.actions = append(observer.actions, action{
+		kind:   kind,
+		object: object.ObjectStream,
+	})
+}
+
+// lastVisible returns the newest committed version or delete marker.
+func lastVisible(versions []metabase.LoopObjectEntry) *metabase.LoopObjectEntry {
+	for i := len(versions) - 1; i >= 0; i-- {
+		switch versions[i].Status {
+		case metabase.Committed, metabase.DeleteMarker:
+			return &versions[i]
+		}
+	}
+	return nil
+}
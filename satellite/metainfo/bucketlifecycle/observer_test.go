@@ -0,0 +1,143 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package bucketlifecycle
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"storj.io/common/testrand"
+	"storj.io/storj/satellite/buckets"
+	"storj.io/storj/satellite/metabase"
+)
+
+func TestObserver(t *testing.T) {
+	const day = 24 * time.Hour
+	now := time.Now()
+	projectID := testrand.UUID()
+
+	bucket := func(name string) metabase.BucketLocation {
+		return metabase.BucketLocation{ProjectID: projectID, BucketName: name}
+	}
+	object := func(bucket, key string, version metabase.Version, status metabase.ObjectStatus, age time.Duration) metabase.LoopObjectEntry {
+		return metabase.LoopObjectEntry{
+			ObjectStream: metabase.ObjectStream{
+				ProjectID:  projectID,
+				BucketName: bucket,
+				ObjectKey:  metabase.ObjectKey(key),
+				Version:    version,
+				StreamID:   testrand.UUID(),
+			},
+			Status:    status,
+			CreatedAt: now.Add(-age),
+		}
+	}
+
+	rules := buckets.LifecycleRules{
+		{ID: "expire", Prefix: "expire/", ExpirationDays: 10},
+		{ID: "uploads", AbortIncompleteUploadDays: 2},
+		{ID: "noncurrent", Prefix: "versions/", NoncurrentVersionExpirationDays: 5},
+	}
+
+	type expected struct {
+		kind    actionKind
+		key     string
+		version metabase.Version
+	}
+
+	for _, tc := range []struct {
+		name       string
+		versioning buckets.Versioning
+		objects    []metabase.LoopObjectEntry
+		actions    []expected
+	}{
+		{
+			name: "bucket without rules",
+			objects: []metabase.LoopObjectEntry{
+				object("other", "expire/a", 1, metabase.Committed, 20*day),
+			},
+		},
+		{
+			name: "expiration",
+			objects: []metabase.LoopObjectEntry{
+				object("bucket", "data/a", 1, metabase.Committed, 20*day),
+				object("bucket", "expire/a", 1, metabase.Committed, 20*day),
+				object("bucket", "expire/b", 1, metabase.Committed, 5*day),
+			},
+			actions: []expected{
+				{deleteVersion, "expire/a", 1},
+			},
+		},
+		{
+			name:       "expiration in versioned bucket",
+			versioning: buckets.VersioningEnabled,
+			objects: []metabase.LoopObjectEntry{
+				object("bucket", "expire/a", 1, metabase.Committed, 20*day),
+				object("bucket", "expire/b", 1, metabase.Committed, 30*day),
+				object("bucket", "expire/b", 2, metabase.DeleteMarker, 20*day),
+			},
+			actions: []expected{
+				{insertDeleteMarker, "expire/a", 1},
+			},
+		},
+		{
+			name: "abort incomplete uploads",
+			objects: []metabase.LoopObjectEntry{
+				object("bucket", "data/a", 1, metabase.Committed, 20*day),
+				object("bucket", "data/a", 2, metabase.Pending, 3*day),
+				object("bucket", "data/a", 3, metabase.Pending, 1*day),
+			},
+			actions: []expected{
+				{abortUpload, "data/a", 2},
+			},
+		},
+		{
+			name:       "noncurrent versions",
+			versioning: buckets.VersioningEnabled,
+			objects: []metabase.LoopObjectEntry{
+				object("bucket", "versions/a", 1, metabase.Committed, 30*day),
+				object("bucket", "versions/a", 2, metabase.Committed, 10*day),
+				object("bucket", "versions/a", 3, metabase.Committed, 3*day),
+				object("bucket", "versions/a", 4, metabase.Committed, 1*day),
+			},
+			actions: []expected{
+				// version 2 became noncurrent only 3 days ago.
+				{deleteVersion, "versions/a", 1},
+			},
+		},
+		{
+			name:       "delete marker without previous versions",
+			versioning: buckets.VersioningEnabled,
+			objects: []metabase.LoopObjectEntry{
+				object("bucket", "versions/a", 1, metabase.Committed, 30*day),
+				object("bucket", "versions/a", 2, metabase.DeleteMarker, 10*day),
+				object("bucket", "versions/b", 1, metabase.Committed, 30*day),
+				object("bucket", "versions/b", 2, metabase.DeleteMarker, 1*day),
+			},
+			actions: []expected{
+				{deleteVersion, "versions/a", 1},
+				{deleteVersion, "versions/a", 2},
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			observer := newObserver(now, []buckets.BucketLifecycle{
+				{Location: bucket("bucket"), Versioning: tc.versioning, Rules: rules},
+			})
+			for _, object := range tc.objects {
+				observer.Object(object)
+			}
+			observer.Finish()
+
+			var actions []expected
+			for _, action := range observer.TakeActions() {
+				actions = append(actions, expected{action.kind, string(action.object.ObjectKey), action.object.Version})
+			}
+			require.Equal(t, tc.actions, actions)
+			require.Empty(t, observer.TakeActions())
+		})
+	}
+}
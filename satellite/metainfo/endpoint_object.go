@@ -19,6 +19,8 @@ import (
 	"storj.io/common/rpc/rpcstatus"
 	"storj.io/common/storj"
 	"storj.io/common/uuid"
+	"storj.io/storj/private/metainfoextpb"
+	"storj.io/storj/satellite/buckets"
 	"storj.io/storj/satellite/internalpb"
 	"storj.io/storj/satellite/metabase"
 	"storj.io/storj/satellite/metainfo/piecedeletion"
@@ -89,6 +91,16 @@ func (endpoint *Endpoint) BeginObject(ctx context.Context, req *pb.ObjectBeginRe
 	}
 
 	if !endpoint.config.MultipleVersions {
+		// a new object at the default version would replace all versions of a
+		// versioned bucket, which must be kept.
+		versioning, err := endpoint.getBucketVersioning(ctx, req.Bucket, keyInfo.ProjectID)
+		if err != nil {
+			return nil, err
+		}
+		if versioning.IsVersioned() {
+			return nil, rpcstatus.Error(rpcstatus.FailedPrecondition, "uploads to versioned buckets require multiple object versions")
+		}
+
 		if canDelete {
 			_, err = endpoint.DeleteObjectAnyStatus(ctx, metabase.ObjectLocation{
 				ProjectID:  keyInfo.ProjectID,
@@ -250,6 +262,11 @@ func (endpoint *Endpoint) CommitObject(ctx context.Context, req *pb.ObjectCommit
 		return nil, rpcstatus.Error(rpcstatus.Internal, err.Error())
 	}
 
+	versioning, err := endpoint.getBucketVersioning(ctx, streamID.Bucket, keyInfo.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+
 	// for old uplinks get Encryption from StreamMeta
 	streamMeta := &pb.StreamMeta{}
 	encryption := storj.EncryptionParameters{}
@@ -270,6 +287,7 @@ func (endpoint *Endpoint) CommitObject(ctx context.Context, req *pb.ObjectCommit
 		Encryption: encryption,
 
 		DisallowDelete: !allowDelete,
+		Versioned:      versioning.IsVersioned(),
 		OnDelete: func(segments []metabase.DeletedSegmentInfo) {
 			endpoint.deleteSegmentPieces(ctx, segments)
 		},
@@ -334,13 +352,23 @@ func (endpoint *Endpoint) GetObject(ctx context.Context, req *pb.ObjectGetReques
 		return nil, rpcstatus.Error(rpcstatus.InvalidArgument, err.Error())
 	}
 
-	mbObject, err := endpoint.metabase.GetObjectLastCommitted(ctx, metabase.GetObjectLastCommitted{
-		ObjectLocation: metabase.ObjectLocation{
-			ProjectID:  keyInfo.ProjectID,
-			BucketName: string(req.Bucket),
-			ObjectKey:  metabase.ObjectKey(req.EncryptedObjectKey),
-		},
-	})
+	location := metabase.ObjectLocation{
+		ProjectID:  keyInfo.ProjectID,
+		BucketName: string(req.Bucket),
+		ObjectKey:  metabase.ObjectKey(req.EncryptedObjectKey),
+	}
+
+	var mbObject metabase.Object
+	if req.Version != 0 {
+		mbObject, err = endpoint.metabase.GetObjectExactVersion(ctx, metabase.GetObjectExactVersion{
+			ObjectLocation: location,
+			Version:        metabase.Version(req.Version),
+		})
+	} else {
+		mbObject, err = endpoint.metabase.GetObjectLastCommitted(ctx, metabase.GetObjectLastCommitted{
+			ObjectLocation: location,
+		})
+	}
 	if err != nil {
 		return nil, endpoint.convertMetabaseErr(err)
 	}
@@ -753,6 +781,45 @@ func (endpoint *Endpoint) ListObjects(ctx context.Context, req *pb.ObjectListReq
 
 	endpoint.versionCollector.collect(req.Header.UserAgent, mon.Func().ShortName())
 
+	return endpoint.listObjects(ctx, req, listObjectsOptions{})
+}
+
+// ListObjectsWithOptions lists objects like ListObjects, with the listing
+// options which aren't part of pb.ObjectListRequest.
+func (endpoint *Endpoint) ListObjectsWithOptions(ctx context.Context, req *metainfoextpb.ListObjectsRequest) (resp *pb.ObjectListResponse, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if req.List == nil {
+		return nil, rpcstatus.Error(rpcstatus.InvalidArgument, "list request missing")
+	}
+
+	endpoint.versionCollector.collect(req.List.Header.GetUserAgent(), mon.Func().ShortName())
+
+	tags, err := objectTagsFromProto(req.Tags)
+	if err != nil {
+		return nil, err
+	}
+
+	return endpoint.listObjects(ctx, req.List, listObjectsOptions{
+		allVersions:   req.AllVersions,
+		cursorVersion: metabase.Version(req.CursorVersion),
+		tags:          tags,
+	})
+}
+
+// listObjectsOptions contains the listing options, which aren't part of pb.ObjectListRequest.
+type listObjectsOptions struct {
+	// allVersions lists all committed versions and delete markers of the objects.
+	allVersions bool
+	// cursorVersion is the version of the cursor object when listing all versions.
+	cursorVersion metabase.Version
+	// tags lists only the committed objects, which have all the tags.
+	tags metabase.ObjectTags
+}
+
+func (endpoint *Endpoint) listObjects(ctx context.Context, req *pb.ObjectListRequest, options listObjectsOptions) (resp *pb.ObjectListResponse, err error) {
+	defer mon.Task()(&ctx)(&err)
+
 	keyInfo, err := endpoint.validateAuth(ctx, req.Header, macaroon.Action{
 		Op:            macaroon.ActionList,
 		Bucket:        req.Bucket,
@@ -778,6 +845,11 @@ func (endpoint *Endpoint) ListObjects(ctx context.Context, req *pb.ObjectListReq
 		return nil, rpcstatus.Error(rpcstatus.Internal, err.Error())
 	}
 
+	versioning, err := endpoint.getBucketVersioning(ctx, req.Bucket, keyInfo.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+
 	limit := int(req.Limit)
 	if limit < 0 {
 		return nil, rpcstatus.Error(rpcstatus.InvalidArgument, "limit is negative")
@@ -811,7 +883,17 @@ func (endpoint *Endpoint) ListObjects(ctx context.Context, req *pb.ObjectListReq
 	}
 
 	resp = &pb.ObjectListResponse{}
-	if endpoint.config.TestListingQuery {
+	// versioned buckets need the listing query to return only the latest versions.
+	if endpoint.config.TestListingQuery || options.allVersions || len(options.tags) > 0 || (versioning.IsVersioned() && status == metabase.Committed) {
+		cursorVersion := metabase.DefaultVersion
+		switch {
+		case options.allVersions:
+			cursorVersion = options.cursorVersion
+		case status == metabase.Committed:
+			// only the latest version is listed, so all versions of the cursor object are skipped.
+			cursorVersion = metabase.MaxVersion
+		}
+
 		result, err := endpoint.metabase.ListObjects(ctx,
 			metabase.ListObjects{
 				ProjectID:  keyInfo.ProjectID,
@@ -819,11 +901,14 @@ func (endpoint *Endpoint) ListObjects(ctx context.Context, req *pb.ObjectListReq
 				Prefix:     prefix,
 				Cursor: metabase.ListObjectsCursor{
 					Key:     metabase.ObjectKey(cursor),
-					Version: metabase.DefaultVersion, // TODO: set to a the version from the protobuf request when it supports this
+					Version: cursorVersion,
 				},
 				Recursive:             req.Recursive,
 				Limit:                 limit,
 				Status:                status,
+				AllVersions:           options.allVersions,
+				Versioned:             versioning.IsVersioned(),
+				Tags:                  options.tags,
 				IncludeCustomMetadata: includeCustomMetadata,
 				// because multipart upload UploadID depends on some System metadata fields we need
 				// to force reading it for listing pending object when its not included in options.
@@ -1036,6 +1121,12 @@ func (endpoint *Endpoint) BeginDeleteObject(ctx context.Context, req *pb.ObjectB
 					})
 			}
 		}
+	} else if req.Version != 0 {
+		deletedObjects, err = endpoint.DeleteObjectVersion(ctx, metabase.ObjectLocation{
+			ProjectID:  keyInfo.ProjectID,
+			BucketName: string(req.Bucket),
+			ObjectKey:  metabase.ObjectKey(req.EncryptedObjectKey),
+		}, metabase.Version(req.Version))
 	} else {
 		deletedObjects, err = endpoint.DeleteCommittedObject(ctx, keyInfo.ProjectID, string(req.Bucket), metabase.ObjectKey(req.EncryptedObjectKey))
 	}
@@ -1404,6 +1495,21 @@ func (endpoint *Endpoint) DeleteCommittedObject(
 		ObjectKey:  object,
 	}
 
+	versioning, err := endpoint.buckets.GetBucketVersioningState(ctx, []byte(bucket), projectID)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	if versioning.IsVersioned() {
+		// the object is only hidden behind a delete marker, so there is
+		// nothing to remove from the storage nodes.
+		_, err = endpoint.metabase.DeleteObjectLastCommitted(ctx, metabase.DeleteObjectLastCommitted{
+			ObjectLocation: req,
+			Versioned:      true,
+		})
+		return nil, Error.Wrap(err)
+	}
+
 	var result metabase.DeleteObjectResult
 	if endpoint.config.ServerSideCopy {
 		result, err = endpoint.metabase.DeleteObjectLastCommitted(ctx, metabase.DeleteObjectLastCommitted{
@@ -1430,6 +1536,38 @@ func (endpoint *Endpoint) DeleteCommittedObject(
 	return deletedObjects, nil
 }
 
+// DeleteObjectVersion deletes an exact version of the object, which can be
+// also a delete marker, and all the pieces of the storage nodes that belongs
+// to it.
+//
+// NOTE: this method is exported for being able to individually test it without
+// having import cycles.
+func (endpoint *Endpoint) DeleteObjectVersion(ctx context.Context, location metabase.ObjectLocation, version metabase.Version,
+) (deletedObjects []*pb.Object, err error) {
+	defer mon.Task()(&ctx, location.ProjectID.String(), location.BucketName, location.ObjectKey)(&err)
+
+	result, err := endpoint.metabase.DeleteObjectExactVersion(ctx, metabase.DeleteObjectExactVersion{
+		ObjectLocation: location,
+		Version:        version,
+	})
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	deletedObjects, err = endpoint.deleteObjectsPieces(ctx, result)
+	if err != nil {
+		endpoint.log.Error("failed to delete pointers",
+			zap.Stringer("project", location.ProjectID),
+			zap.String("bucket", location.BucketName),
+			zap.Binary("object", []byte(location.ObjectKey)),
+			zap.Error(err),
+		)
+		return deletedObjects, Error.Wrap(err)
+	}
+
+	return deletedObjects, nil
+}
+
 // DeleteObjectAnyStatus deletes all the pieces of the storage nodes that belongs
 // to the specified object.
 //
@@ -1489,13 +1627,17 @@ func (endpoint *Endpoint) deleteObjectsPieces(ctx context.Context, result metaba
 	defer mon.Task()(&ctx)(&err)
 	// We should ignore client cancelling and always try to delete segments.
 	ctx = context2.WithoutCancellation(ctx)
-	deletedObjects = make([]*pb.Object, len(result.Objects))
-	for i, object := range result.Objects {
+	deletedObjects = make([]*pb.Object, 0, len(result.Objects))
+	for _, object := range result.Objects {
+		// delete markers are not objects from the uplink point of view.
+		if object.Status == metabase.DeleteMarker {
+			continue
+		}
 		deletedObject, err := endpoint.objectToProto(ctx, object, endpoint.defaultRS)
 		if err != nil {
 			return nil, err
 		}
-		deletedObjects[i] = deletedObject
+		deletedObjects = append(deletedObjects, deletedObject)
 	}
 
 	endpoint.deleteSegmentPieces(ctx, result.Segments)
@@ -2004,3 +2146,19 @@ func protobufkeysToMetabase(protoKeys []*pb.EncryptedKeyAndNonce) []metabase.Enc
 
 	return keys
 }
+
+// getBucketVersioning returns the versioning state of the bucket.
+func (endpoint *Endpoint) getBucketVersioning(ctx context.Context, bucket []byte, projectID uuid.UUID) (_ buckets.Versioning, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	// TODO this needs to be optimized to avoid DB call on each request
+	versioning, err := endpoint.buckets.GetBucketVersioningState(ctx, bucket, projectID)
+	if err != nil {
+		if storj.ErrBucketNotFound.Has(err) {
+			return buckets.Unversioned, rpcstatus.Errorf(rpcstatus.NotFound, "bucket not found: %s", bucket)
+		}
+		endpoint.log.Error("unable to check bucket", zap.Error(err))
+		return buckets.Unversioned, rpcstatus.Error(rpcstatus.Internal, err.Error())
+	}
+	return versioning, nil
+}
@@ -0,0 +1,113 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package metainfo
+
+import (
+	"context"
+	"time"
+
+	"storj.io/common/macaroon"
+	"storj.io/common/rpc/rpcstatus"
+	"storj.io/storj/private/metainfoextpb"
+	"storj.io/storj/satellite/metabase"
+)
+
+// GetObjectLock returns the retention and legal hold of an object version.
+func (endpoint *Endpoint) GetObjectLock(ctx context.Context, req *metainfoextpb.GetObjectLockRequest) (resp *metainfoextpb.GetObjectLockResponse, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	endpoint.versionCollector.collect(req.Header.GetUserAgent(), mon.Func().ShortName())
+
+	location, err := endpoint.validateObjectAuth(ctx, req.Header, macaroon.ActionRead, req.Bucket, req.EncryptedObjectKey)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := endpoint.checkObjectLockAllowed(ctx, location); err != nil {
+		return nil, err
+	}
+
+	lock, err := endpoint.metabase.GetObjectLock(ctx, metabase.GetObjectLock{
+		ObjectLocation: location,
+		Version:        metabase.Version(req.Version),
+	})
+	if err != nil {
+		return nil, endpoint.convertMetabaseErr(err)
+	}
+
+	return &metainfoextpb.GetObjectLockResponse{
+		RetainUntil: lock.RetainUntil,
+		LegalHold:   lock.LegalHold,
+	}, nil
+}
+
+// SetObjectRetention protects an object version from being removed until the
+// specified time. The retention can only be extended afterwards.
+func (endpoint *Endpoint) SetObjectRetention(ctx context.Context, req *metainfoextpb.SetObjectRetentionRequest) (resp *metainfoextpb.SetObjectRetentionResponse, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	endpoint.versionCollector.collect(req.Header.GetUserAgent(), mon.Func().ShortName())
+
+	location, err := endpoint.validateObjectAuth(ctx, req.Header, macaroon.ActionWrite, req.Bucket, req.EncryptedObjectKey)
+	if err != nil {
+		return nil, err
+	}
+
+	if !req.RetainUntil.After(time.Now()) {
+		return nil, rpcstatus.Error(rpcstatus.InvalidArgument, "retention date must be in the future")
+	}
+
+	if err := endpoint.checkObjectLockAllowed(ctx, location); err != nil {
+		return nil, err
+	}
+
+	err = endpoint.metabase.SetObjectRetention(ctx, metabase.SetObjectRetention{
+		ObjectLocation: location,
+		Version:        metabase.Version(req.Version),
+		RetainUntil:    req.RetainUntil,
+	})
+	if err != nil {
+		return nil, endpoint.convertMetabaseErr(err)
+	}
+	return &metainfoextpb.SetObjectRetentionResponse{}, nil
+}
+
+// SetObjectLegalHold places or releases a legal hold on an object version.
+func (endpoint *Endpoint) SetObjectLegalHold(ctx context.Context, req *metainfoextpb.SetObjectLegalHoldRequest) (resp *metainfoextpb.SetObjectLegalHoldResponse, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	endpoint.versionCollector.collect(req.Header.GetUserAgent(), mon.Func().ShortName())
+
+	location, err := endpoint.validateObjectAuth(ctx, req.Header, macaroon.ActionWrite, req.Bucket, req.EncryptedObjectKey)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := endpoint.checkObjectLockAllowed(ctx, location); err != nil {
+		return nil, err
+	}
+
+	err = endpoint.metabase.SetObjectLegalHold(ctx, metabase.SetObjectLegalHold{
+		ObjectLocation: location,
+		Version:        metabase.Version(req.Version),
+		Enabled:        req.Enabled,
+	})
+	if err != nil {
+		return nil, endpoint.convertMetabaseErr(err)
+	}
+	return &metainfoextpb.SetObjectLegalHoldResponse{}, nil
+}
+
+// checkObjectLockAllowed verifies that the bucket keeps all versions of the
+// objects, otherwise an upload would be able to overwrite a locked object.
+func (endpoint *Endpoint) checkObjectLockAllowed(ctx context.Context, location metabase.ObjectLocation) error {
+	versioning, err := endpoint.getBucketVersioning(ctx, []byte(location.BucketName), location.ProjectID)
+	if err != nil {
+		return err
+	}
+	if !versioning.IsVersioned() {
+		return rpcstatus.Error(rpcstatus.FailedPrecondition, "object lock requires bucket versioning")
+	}
+	return nil
+}
@@ -0,0 +1,95 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package metainfo
+
+import (
+	"context"
+	"sort"
+
+	"storj.io/common/macaroon"
+	"storj.io/common/rpc/rpcstatus"
+	"storj.io/storj/private/metainfoextpb"
+	"storj.io/storj/satellite/metabase"
+)
+
+// GetObjectTags returns the tags of an object version.
+func (endpoint *Endpoint) GetObjectTags(ctx context.Context, req *metainfoextpb.GetObjectTagsRequest) (resp *metainfoextpb.GetObjectTagsResponse, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	endpoint.versionCollector.collect(req.Header.GetUserAgent(), mon.Func().ShortName())
+
+	location, err := endpoint.validateObjectAuth(ctx, req.Header, macaroon.ActionRead, req.Bucket, req.EncryptedObjectKey)
+	if err != nil {
+		return nil, err
+	}
+
+	object, err := endpoint.metabase.GetObjectExactVersion(ctx, metabase.GetObjectExactVersion{
+		ObjectLocation: location,
+		Version:        metabase.Version(req.Version),
+	})
+	if err != nil {
+		return nil, endpoint.convertMetabaseErr(err)
+	}
+
+	return &metainfoextpb.GetObjectTagsResponse{
+		Tags: objectTagsToProto(object.Tags),
+	}, nil
+}
+
+// SetObjectTags replaces the tags of an object version.
+func (endpoint *Endpoint) SetObjectTags(ctx context.Context, req *metainfoextpb.SetObjectTagsRequest) (resp *metainfoextpb.SetObjectTagsResponse, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	endpoint.versionCollector.collect(req.Header.GetUserAgent(), mon.Func().ShortName())
+
+	location, err := endpoint.validateObjectAuth(ctx, req.Header, macaroon.ActionWrite, req.Bucket, req.EncryptedObjectKey)
+	if err != nil {
+		return nil, err
+	}
+
+	tags, err := objectTagsFromProto(req.Tags)
+	if err != nil {
+		return nil, err
+	}
+
+	err = endpoint.metabase.SetObjectTags(ctx, metabase.SetObjectTags{
+		ObjectLocation: location,
+		Version:        metabase.Version(req.Version),
+		Tags:           tags,
+	})
+	if err != nil {
+		return nil, endpoint.convertMetabaseErr(err)
+	}
+
+	mon.Meter("req_set_object_tags").Mark(1)
+	return &metainfoextpb.SetObjectTagsResponse{}, nil
+}
+
+// objectTagsFromProto converts the protobuf tags, which mustn't repeat a key.
+func objectTagsFromProto(tags []*metainfoextpb.ObjectTag) (metabase.ObjectTags, error) {
+	if len(tags) == 0 {
+		return nil, nil
+	}
+
+	result := make(metabase.ObjectTags, len(tags))
+	for _, tag := range tags {
+		if _, ok := result[tag.Key]; ok {
+			return nil, rpcstatus.Errorf(rpcstatus.InvalidArgument, "duplicate tag key: %q", tag.Key)
+		}
+		result[tag.Key] = tag.Value
+	}
+	return result, nil
+}
+
+// objectTagsToProto converts the tags to protobuf, sorted by their keys.
+func objectTagsToProto(tags metabase.ObjectTags) []*metainfoextpb.ObjectTag {
+	result := make([]*metainfoextpb.ObjectTag, 0, len(tags))
+	for key, value := range tags {
+		result = append(result, &metainfoextpb.ObjectTag{Key: key, Value: value})
+	}
+	sort.Slice(result, func(i, k int) bool {
+		return result[i].Key < result[k].Key
+	})
+	return result
+}
@@ -21,6 +21,7 @@ import (
 
 	"storj.io/common/errs2"
 	"storj.io/common/identity"
+	"storj.io/common/macaroon"
 	"storj.io/common/memory"
 	"storj.io/common/pb"
 	"storj.io/common/rpc/rpcstatus"
@@ -29,6 +30,7 @@ import (
 	"storj.io/common/testcontext"
 	"storj.io/common/testrand"
 	"storj.io/common/uuid"
+	"storj.io/storj/private/metainfoextpb"
 	"storj.io/storj/private/testplanet"
 	"storj.io/storj/satellite"
 	"storj.io/storj/satellite/buckets"
@@ -2249,3 +2251,365 @@ func TestEndpoint_Object_CopyObject_MultipleVersions(t *testing.T) {
 		}, items)
 	})
 }
+
+func TestEndpoint_Object_Versioning(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount: 1, UplinkCount: 1,
+		Reconfigure: testplanet.Reconfigure{
+			Satellite: func(log *zap.Logger, index int, config *satellite.Config) {
+				config.Metainfo.MultipleVersions = true
+			},
+		},
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		satellite := planet.Satellites[0]
+		projectID := planet.Uplinks[0].Projects[0].ID
+
+		project, err := planet.Uplinks[0].OpenProject(ctx, satellite)
+		require.NoError(t, err)
+		defer ctx.Check(project.Close)
+
+		_, err = project.CreateBucket(ctx, "versioned")
+		require.NoError(t, err)
+
+		err = satellite.API.Buckets.Service.SetBucketVersioningState(ctx, []byte("versioned"), projectID, buckets.VersioningEnabled)
+		require.NoError(t, err)
+
+		firstData := testrand.Bytes(memory.KiB)
+		secondData := testrand.Bytes(memory.KiB)
+		require.NoError(t, planet.Uplinks[0].Upload(ctx, satellite, "versioned", "object", firstData))
+		require.NoError(t, planet.Uplinks[0].Upload(ctx, satellite, "versioned", "object", secondData))
+
+		// both versions are kept, only the latest one is visible
+		objects, err := satellite.Metabase.DB.TestingAllObjects(ctx)
+		require.NoError(t, err)
+		require.Len(t, objects, 2)
+
+		data, err := planet.Uplinks[0].Download(ctx, satellite, "versioned", "object")
+		require.NoError(t, err)
+		require.Equal(t, secondData, data)
+
+		iterator := project.ListObjects(ctx, "versioned", nil)
+		require.True(t, iterator.Next())
+		require.Equal(t, "object", iterator.Item().Key)
+		require.False(t, iterator.Next())
+		require.NoError(t, iterator.Err())
+
+		// deleting without a version inserts a delete marker
+		_, err = project.DeleteObject(ctx, "versioned", "object")
+		require.NoError(t, err)
+
+		_, err = project.StatObject(ctx, "versioned", "object")
+		require.ErrorIs(t, err, uplink.ErrObjectNotFound)
+
+		objects, err = satellite.Metabase.DB.TestingAllObjects(ctx)
+		require.NoError(t, err)
+		require.Len(t, objects, 3)
+
+		var marker metabase.Object
+		for _, object := range objects {
+			if object.Status == metabase.DeleteMarker {
+				marker = object
+			}
+		}
+		require.EqualValues(t, 3, marker.Version)
+
+		// all the versions and the delete marker are listed page by page
+		conn, err := planet.Uplinks[0].Dialer.DialNodeURL(ctx, satellite.NodeURL())
+		require.NoError(t, err)
+		defer ctx.Check(conn.Close)
+
+		client := metainfoextpb.NewDRPCMetainfoClient(conn)
+		apiKey := planet.Uplinks[0].APIKey[satellite.ID()]
+
+		var listed []*pb.ObjectListItem
+		var cursor []byte
+		var cursorVersion int32
+		for {
+			response, err := client.ListObjectsWithOptions(ctx, &metainfoextpb.ListObjectsRequest{
+				List: &pb.ObjectListRequest{
+					Header:          &pb.RequestHeader{ApiKey: apiKey.SerializeRaw()},
+					Bucket:          []byte("versioned"),
+					EncryptedCursor: cursor,
+					Recursive:       true,
+					Limit:           1,
+				},
+				AllVersions:   true,
+				CursorVersion: cursorVersion,
+			})
+			require.NoError(t, err)
+			require.Len(t, response.Items, 1)
+
+			item := response.Items[0]
+			require.Equal(t, []byte(marker.ObjectKey), item.EncryptedObjectKey)
+			listed = append(listed, item)
+			cursor, cursorVersion = item.EncryptedObjectKey, item.Version
+
+			if !response.More {
+				break
+			}
+		}
+		require.Len(t, listed, 3)
+		for i, item := range listed {
+			require.EqualValues(t, i+1, item.Version)
+		}
+		require.Equal(t, pb.Object_Status(metabase.DeleteMarker), listed[2].Status)
+
+		// removing the delete marker restores the previous version
+		_, err = satellite.Metainfo.Endpoint.DeleteObjectVersion(ctx, marker.Location(), marker.Version)
+		require.NoError(t, err)
+
+		data, err = planet.Uplinks[0].Download(ctx, satellite, "versioned", "object")
+		require.NoError(t, err)
+		require.Equal(t, secondData, data)
+	})
+}
+
+func TestEndpoint_Object_Lock(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount: 1, StorageNodeCount: 1, UplinkCount: 1,
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		satellite := planet.Satellites[0]
+		endpoint := satellite.API.Metainfo.Endpoint
+		projectID := planet.Uplinks[0].Projects[0].ID
+
+		require.NoError(t, planet.Uplinks[0].Upload(ctx, satellite, "unversioned", "object", testrand.Bytes(memory.KiB)))
+		require.NoError(t, planet.Uplinks[0].CreateBucket(ctx, satellite, "locked"))
+
+		err := satellite.API.Buckets.Service.SetBucketVersioningState(ctx, []byte("locked"), projectID, buckets.VersioningEnabled)
+		require.NoError(t, err)
+		require.NoError(t, planet.Uplinks[0].Upload(ctx, satellite, "locked", "object", testrand.Bytes(memory.KiB)))
+
+		objects, err := satellite.Metabase.DB.TestingAllObjects(ctx)
+		require.NoError(t, err)
+		require.Len(t, objects, 2)
+
+		var unversioned, locked metabase.Object
+		for _, object := range objects {
+			if object.BucketName == "locked" {
+				locked = object
+			} else {
+				unversioned = object
+			}
+		}
+
+		apiKey := planet.Uplinks[0].APIKey[satellite.ID()]
+		header := &pb.RequestHeader{ApiKey: apiKey.SerializeRaw()}
+
+		readOnlyKey, err := apiKey.Restrict(macaroon.Caveat{DisallowWrites: true})
+		require.NoError(t, err)
+		readOnlyHeader := &pb.RequestHeader{ApiKey: readOnlyKey.SerializeRaw()}
+
+		conn, err := planet.Uplinks[0].Dialer.DialNodeURL(ctx, satellite.NodeURL())
+		require.NoError(t, err)
+		defer ctx.Check(conn.Close)
+
+		client := metainfoextpb.NewDRPCMetainfoClient(conn)
+
+		bucket, key, version := []byte(locked.BucketName), []byte(locked.ObjectKey), int32(locked.Version)
+		setRetention := func(header *pb.RequestHeader, retainUntil time.Time) error {
+			_, err := client.SetObjectRetention(ctx, &metainfoextpb.SetObjectRetentionRequest{
+				Header:             header,
+				Bucket:             bucket,
+				EncryptedObjectKey: key,
+				Version:            version,
+				RetainUntil:        retainUntil,
+			})
+			return err
+		}
+		setLegalHold := func(header *pb.RequestHeader, bucket, key []byte, version int32) error {
+			_, err := client.SetObjectLegalHold(ctx, &metainfoextpb.SetObjectLegalHoldRequest{
+				Header:             header,
+				Bucket:             bucket,
+				EncryptedObjectKey: key,
+				Version:            version,
+				Enabled:            true,
+			})
+			return err
+		}
+
+		// object lock requires versioning
+		err = setLegalHold(header, []byte(unversioned.BucketName), []byte(unversioned.ObjectKey), int32(unversioned.Version))
+		require.Equal(t, rpcstatus.FailedPrecondition, rpcstatus.Code(err))
+
+		err = setRetention(header, time.Now().Add(-time.Hour))
+		require.Equal(t, rpcstatus.InvalidArgument, rpcstatus.Code(err))
+
+		// changing the lock requires write permission
+		retainUntil := time.Now().Add(time.Hour)
+		err = setRetention(readOnlyHeader, retainUntil)
+		require.Equal(t, rpcstatus.PermissionDenied, rpcstatus.Code(err))
+		err = setLegalHold(readOnlyHeader, bucket, key, version)
+		require.Equal(t, rpcstatus.PermissionDenied, rpcstatus.Code(err))
+
+		require.NoError(t, setRetention(header, retainUntil))
+		require.NoError(t, setLegalHold(header, bucket, key, version))
+
+		lock, err := client.GetObjectLock(ctx, &metainfoextpb.GetObjectLockRequest{
+			Header:             readOnlyHeader,
+			Bucket:             bucket,
+			EncryptedObjectKey: key,
+			Version:            version,
+		})
+		require.NoError(t, err)
+		require.True(t, lock.LegalHold)
+		require.WithinDuration(t, retainUntil, lock.RetainUntil, time.Millisecond)
+
+		err = setRetention(header, retainUntil.Add(-time.Minute))
+		require.Equal(t, rpcstatus.PermissionDenied, rpcstatus.Code(err))
+
+		_, err = endpoint.DeleteObjectVersion(ctx, locked.Location(), locked.Version)
+		require.True(t, metabase.ErrObjectLocked.Has(err))
+
+		// even the project owner cannot remove the bucket
+		project, err := planet.Uplinks[0].OpenProject(ctx, satellite)
+		require.NoError(t, err)
+		defer ctx.Check(project.Close)
+
+		_, err = project.DeleteBucketWithObjects(ctx, "locked")
+		require.Error(t, err)
+
+		// nor overwrite the object with a copy
+		_, err = project.CopyObject(ctx, "unversioned", "object", "locked", "object", nil)
+		require.Error(t, err)
+
+		data, err := planet.Uplinks[0].Download(ctx, satellite, "locked", "object")
+		require.NoError(t, err)
+		require.Len(t, data, memory.KiB.Int())
+	})
+}
+
+func TestEndpoint_Object_Tags(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount: 1, StorageNodeCount: 1, UplinkCount: 1,
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		satellite := planet.Satellites[0]
+
+		require.NoError(t, planet.Uplinks[0].Upload(ctx, satellite, "bucket", "tagged", testrand.Bytes(memory.KiB)))
+		require.NoError(t, planet.Uplinks[0].Upload(ctx, satellite, "bucket", "untagged", testrand.Bytes(memory.KiB)))
+
+		objects, err := satellite.Metabase.DB.TestingAllObjects(ctx)
+		require.NoError(t, err)
+		require.Len(t, objects, 2)
+
+		apiKey := planet.Uplinks[0].APIKey[satellite.ID()]
+		header := &pb.RequestHeader{ApiKey: apiKey.SerializeRaw()}
+
+		readOnlyKey, err := apiKey.Restrict(macaroon.Caveat{DisallowWrites: true})
+		require.NoError(t, err)
+		readOnlyHeader := &pb.RequestHeader{ApiKey: readOnlyKey.SerializeRaw()}
+
+		noListKey, err := apiKey.Restrict(macaroon.Caveat{DisallowLists: true})
+		require.NoError(t, err)
+		noListHeader := &pb.RequestHeader{ApiKey: noListKey.SerializeRaw()}
+
+		conn, err := planet.Uplinks[0].Dialer.DialNodeURL(ctx, satellite.NodeURL())
+		require.NoError(t, err)
+		defer ctx.Check(conn.Close)
+
+		client := metainfoextpb.NewDRPCMetainfoClient(conn)
+
+		// object keys are encrypted, so it doesn't matter which one is tagged.
+		tagged := objects[0]
+		bucket, key := []byte(tagged.BucketName), []byte(tagged.ObjectKey)
+
+		setTags := func(header *pb.RequestHeader, version metabase.Version, tags ...*metainfoextpb.ObjectTag) error {
+			_, err := client.SetObjectTags(ctx, &metainfoextpb.SetObjectTagsRequest{
+				Header:             header,
+				Bucket:             bucket,
+				EncryptedObjectKey: key,
+				Version:            int32(version),
+				Tags:               tags,
+			})
+			return err
+		}
+		listByTags := func(header *pb.RequestHeader, cursor []byte, tags ...*metainfoextpb.ObjectTag) (*pb.ObjectListResponse, error) {
+			return client.ListObjectsWithOptions(ctx, &metainfoextpb.ListObjectsRequest{
+				List: &pb.ObjectListRequest{
+					Header:          header,
+					Bucket:          bucket,
+					EncryptedCursor: cursor,
+					Recursive:       true,
+				},
+				Tags: tags,
+			})
+		}
+
+		team := &metainfoextpb.ObjectTag{Key: "team", Value: "storage"}
+		env := &metainfoextpb.ObjectTag{Key: "env", Value: "prod"}
+
+		err = setTags(header, tagged.Version, &metainfoextpb.ObjectTag{Key: "", Value: "invalid"})
+		require.Equal(t, rpcstatus.InvalidArgument, rpcstatus.Code(err))
+
+		err = setTags(header, tagged.Version, team, &metainfoextpb.ObjectTag{Key: "team", Value: "other"})
+		require.Equal(t, rpcstatus.InvalidArgument, rpcstatus.Code(err))
+
+		err = setTags(header, tagged.Version+1, team)
+		require.Equal(t, rpcstatus.NotFound, rpcstatus.Code(err))
+
+		// changing the tags requires write permission
+		err = setTags(readOnlyHeader, tagged.Version, team, env)
+		require.Equal(t, rpcstatus.PermissionDenied, rpcstatus.Code(err))
+
+		require.NoError(t, setTags(header, tagged.Version, team, env))
+
+		actual, err := client.GetObjectTags(ctx, &metainfoextpb.GetObjectTagsRequest{
+			Header:             readOnlyHeader,
+			Bucket:             bucket,
+			EncryptedObjectKey: key,
+			Version:            int32(tagged.Version),
+		})
+		require.NoError(t, err)
+		require.Equal(t, []*metainfoextpb.ObjectTag{env, team}, actual.Tags)
+
+		// listing requires list permission
+		_, err = listByTags(noListHeader, nil, team)
+		require.Equal(t, rpcstatus.PermissionDenied, rpcstatus.Code(err))
+
+		result, err := listByTags(header, nil, team)
+		require.NoError(t, err)
+		require.False(t, result.More)
+		require.Len(t, result.Items, 1)
+		require.Equal(t, key, result.Items[0].EncryptedObjectKey)
+
+		// the cursor skips the object
+		result, err = listByTags(header, key, team)
+		require.NoError(t, err)
+		require.Empty(t, result.Items)
+
+		result, err = listByTags(header, nil, team, &metainfoextpb.ObjectTag{Key: "env", Value: "dev"})
+		require.NoError(t, err)
+		require.Empty(t, result.Items)
+	})
+}
+
+func TestEndpoint_Object_Versioning_MultipleVersionsDisabled(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount: 1, UplinkCount: 1,
+		Reconfigure: testplanet.Reconfigure{
+			Satellite: func(log *zap.Logger, index int, config *satellite.Config) {
+				config.Metainfo.MultipleVersions = false
+			},
+		},
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		satellite := planet.Satellites[0]
+		projectID := planet.Uplinks[0].Projects[0].ID
+
+		data := testrand.Bytes(memory.KiB)
+		require.NoError(t, planet.Uplinks[0].Upload(ctx, satellite, "versioned", "object", data))
+
+		// versioning can't be enabled without multiple versions
+		err := satellite.API.Buckets.Service.SetBucketVersioningState(ctx, []byte("versioned"), projectID, buckets.VersioningEnabled)
+		require.True(t, buckets.ErrInvalidVersioningState.Has(err))
+
+		// buckets, which were versioned before multiple versions were disabled, don't lose versions
+		err = satellite.DB.Buckets().SetBucketVersioningState(ctx, []byte("versioned"), projectID, buckets.VersioningEnabled)
+		require.NoError(t, err)
+
+		err = planet.Uplinks[0].Upload(ctx, satellite, "versioned", "object", testrand.Bytes(memory.KiB))
+		require.Error(t, err)
+
+		downloaded, err := planet.Uplinks[0].Download(ctx, satellite, "versioned", "object")
+		require.NoError(t, err)
+		require.Equal(t, data, downloaded)
+	})
+}
@@ -300,6 +300,8 @@ func (endpoint *Endpoint) CommitSegment(ctx context.Context, req *pb.SegmentComm
 		return nil, endpoint.convertMetabaseErr(err)
 	}
 
+	endpoint.trackUploadSuccess(originalLimits, pieces)
+
 	if err := endpoint.addSegmentToUploadLimits(ctx, keyInfo.ProjectID, segmentSize); err != nil {
 		return nil, err
 	}
@@ -313,6 +315,34 @@ func (endpoint *Endpoint) CommitSegment(ctx context.Context, req *pb.SegmentComm
 	}, nil
 }
 
+// trackUploadSuccess records which of the nodes selected for the upload got
+// their pieces committed.
+func (endpoint *Endpoint) trackUploadSuccess(originalLimits []*pb.OrderLimit, pieces metabase.Pieces) {
+	tracker := endpoint.overlay.UploadSuccessTracker
+	if tracker == nil {
+		return
+	}
+
+	committed := make(map[storj.NodeID]struct{}, len(pieces))
+	successful := make([]storj.NodeID, 0, len(pieces))
+	for _, piece := range pieces {
+		committed[piece.StorageNode] = struct{}{}
+		successful = append(successful, piece.StorageNode)
+	}
+
+	var failed []storj.NodeID
+	for _, limit := range originalLimits {
+		if limit == nil {
+			continue
+		}
+		if _, ok := committed[limit.StorageNodeId]; !ok {
+			failed = append(failed, limit.StorageNodeId)
+		}
+	}
+
+	tracker.Record(time.Now(), successful, failed)
+}
+
 // MakeInlineSegment makes inline segment on satellite.
 func (endpoint *Endpoint) MakeInlineSegment(ctx context.Context, req *pb.SegmentMakeInlineRequest) (resp *pb.SegmentMakeInlineResponse, err error) {
 	defer mon.Task()(&ctx)(&err)
@@ -10,6 +10,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
 
 	"storj.io/common/errs2"
 	"storj.io/common/memory"
@@ -20,6 +21,7 @@ import (
 	"storj.io/common/testcontext"
 	"storj.io/common/testrand"
 	"storj.io/storj/private/testplanet"
+	"storj.io/storj/satellite"
 	"storj.io/storj/satellite/metabase"
 	"storj.io/uplink/private/metaclient"
 )
@@ -581,3 +583,31 @@ func TestCommitSegment_Validation(t *testing.T) {
 		require.True(t, errs2.IsRPC(err, rpcstatus.InvalidArgument))
 	})
 }
+
+func TestCommitSegment_UploadSuccessTracker(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount: 1, StorageNodeCount: 4, UplinkCount: 1,
+		Reconfigure: testplanet.Reconfigure{
+			Satellite: func(log *zap.Logger, index int, config *satellite.Config) {
+				config.Overlay.UploadSuccessTracker.Enabled = true
+				config.Overlay.UploadSuccessTracker.MinUploads = 1
+			},
+		},
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		sat := planet.Satellites[0]
+		require.NotNil(t, sat.Overlay.Service.UploadSuccessTracker)
+
+		err := planet.Uplinks[0].Upload(ctx, sat, "testbucket", "object", testrand.Bytes(50*memory.KiB))
+		require.NoError(t, err)
+
+		segments, err := sat.Metabase.DB.TestingAllSegments(ctx)
+		require.NoError(t, err)
+		require.Len(t, segments, 1)
+
+		for _, piece := range segments[0].Pieces {
+			rate, ok := sat.Overlay.Service.UploadSuccessTracker.SuccessRate(time.Now(), piece.StorageNode)
+			require.True(t, ok)
+			require.Equal(t, 1.0, rate)
+		}
+	})
+}
@@ -70,6 +70,33 @@ func (endpoint *Endpoint) validateAuth(ctx context.Context, header *pb.RequestHe
 	return keyInfo, nil
 }
 
+// validateObjectAuth validates the API key for the action on the object and
+// returns the location of the object in the project of the API key.
+func (endpoint *Endpoint) validateObjectAuth(ctx context.Context, header *pb.RequestHeader, op macaroon.ActionType, bucket, encryptedObjectKey []byte) (_ metabase.ObjectLocation, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	keyInfo, err := endpoint.validateAuth(ctx, header, macaroon.Action{
+		Op:            op,
+		Bucket:        bucket,
+		EncryptedPath: encryptedObjectKey,
+		Time:          time.Now(),
+	})
+	if err != nil {
+		return metabase.ObjectLocation{}, err
+	}
+
+	err = endpoint.validateBucket(ctx, bucket)
+	if err != nil {
+		return metabase.ObjectLocation{}, rpcstatus.Error(rpcstatus.InvalidArgument, err.Error())
+	}
+
+	return metabase.ObjectLocation{
+		ProjectID:  keyInfo.ProjectID,
+		BucketName: string(bucket),
+		ObjectKey:  metabase.ObjectKey(encryptedObjectKey),
+	}, nil
+}
+
 type verifyPermission struct {
 	action          macaroon.Action
 	actionPermitted *bool
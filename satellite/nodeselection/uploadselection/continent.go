@@ -0,0 +1,58 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package uploadselection
+
+import (
+	"strings"
+
+	"storj.io/common/storj/location"
+)
+
+// Continent is the two letter code of a continent, as used by geonames.
+type Continent string
+
+// Continents which can be used in placement rules.
+const (
+	Africa       = Continent("AF")
+	Antarctica   = Continent("AN")
+	Asia         = Continent("AS")
+	Europe       = Continent("EU")
+	NorthAmerica = Continent("NA")
+	Oceania      = Continent("OC")
+	SouthAmerica = Continent("SA")
+)
+
+// continentCountries lists the country codes of each continent.
+// original source: https://download.geonames.org/export/dump/countryInfo.txt
+var continentCountries = map[Continent]string{
+	Africa:       "AO BF BI BJ BW CD CF CG CI CM CV DJ DZ EG EH ER ET GA GH GM GN GQ GW KE KM LR LS LY MA MG ML MR MU MW MZ NA NE NG RE RW SC SD SH SL SN SO SS ST SZ TD TG TN TZ UG YT ZA ZM ZW",
+	Antarctica:   "AQ BV GS HM TF",
+	Asia:         "AE AF AM AZ BD BH BN BT CC CN CX GE HK ID IL IN IO IQ IR JO JP KG KH KP KR KW KZ LA LB LK MM MN MO MV MY NP OM PH PK PS QA SA SG SY TH TJ TM TR TW UZ VN YE",
+	Europe:       "AD AL AT AX BA BE BG BY CH CS CY CZ DE DK EE ES FI FO FR GB GG GI GR HR HU IE IM IS IT JE LI LT LU LV MC MD ME MK MT NL NO PL PT RO RS RU SE SI SJ SK SM UA VA XK",
+	NorthAmerica: "AG AI AN AW BB BL BM BQ BS BZ CA CR CU CW DM DO GD GL GP GT HN HT JM KN KY LC MF MQ MS MX NI PA PM PR SV SX TC TT US VC VG VI",
+	Oceania:      "AS AU CK FJ FM GU KI MH MP NC NF NR NU NZ PF PG PN PW SB TK TL TO TV UM VU WF WS",
+	SouthAmerica: "AR BO BR CL CO EC FK GF GY PE PY SR UY VE",
+}
+
+var countryContinent = func() map[location.CountryCode]Continent {
+	result := map[location.CountryCode]Continent{}
+	for continent, countries := range continentCountries {
+		for _, country := range strings.Fields(countries) {
+			result[location.ToCountryCode(country)] = continent
+		}
+	}
+	return result
+}()
+
+// ContinentOf returns the continent of the country, or an empty string when it's unknown.
+func ContinentOf(country location.CountryCode) Continent {
+	return countryContinent[country]
+}
+
+// ParseContinent parses the two letter code of a continent.
+func ParseContinent(s string) (Continent, bool) {
+	continent := Continent(strings.ToUpper(s))
+	_, ok := continentCountries[continent]
+	return continent, ok
+}
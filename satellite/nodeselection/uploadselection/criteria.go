@@ -12,8 +12,13 @@ import (
 type Criteria struct {
 	ExcludeNodeIDs       []storj.NodeID
 	AutoExcludeSubnets   map[string]struct{} // initialize it with empty map to keep only one node per subnet.
-	Placement            storj.PlacementConstraint
+	Placement            NodeFilter          // nil includes all the nodes.
 	ExcludedCountryCodes []location.CountryCode
+
+	// DistinctTag is the name of the tag, which values must be distinct among the
+	// selected nodes. Nodes without the tag are not restricted.
+	DistinctTag          string
+	AutoExcludeTagValues map[string]struct{} // initialize it with empty map to keep only one node per tag value.
 }
 
 // MatchInclude returns with true if node is selected.
@@ -22,7 +27,7 @@ func (c *Criteria) MatchInclude(node *Node) bool {
 		return false
 	}
 
-	if !c.Placement.AllowedCountry(node.CountryCode) {
+	if c.Placement != nil && !c.Placement.MatchInclude(node) {
 		return false
 	}
 
@@ -42,6 +47,15 @@ func (c *Criteria) MatchInclude(node *Node) bool {
 		}
 	}
 
+	if c.DistinctTag != "" && c.AutoExcludeTagValues != nil {
+		if value, ok := node.Tags.Value(c.DistinctTag); ok {
+			if _, excluded := c.AutoExcludeTagValues[string(value)]; excluded {
+				return false
+			}
+			c.AutoExcludeTagValues[string(value)] = struct{}{}
+		}
+	}
+
 	return true
 }
 
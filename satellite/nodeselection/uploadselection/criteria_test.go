@@ -32,6 +32,25 @@ func TestCriteria_AutoExcludeSubnet(t *testing.T) {
 	}))
 }
 
+func TestCriteria_DistinctTag(t *testing.T) {
+	criteria := Criteria{
+		DistinctTag:          "provider",
+		AutoExcludeTagValues: map[string]struct{}{},
+	}
+
+	tagged := func(value string) *Node {
+		return &Node{Tags: NodeTags{{Name: "provider", Value: []byte(value)}}}
+	}
+
+	assert.True(t, criteria.MatchInclude(tagged("a")))
+	assert.False(t, criteria.MatchInclude(tagged("a")))
+	assert.True(t, criteria.MatchInclude(tagged("b")))
+
+	// nodes without the tag are not restricted
+	assert.True(t, criteria.MatchInclude(&Node{}))
+	assert.True(t, criteria.MatchInclude(&Node{}))
+}
+
 func TestCriteria_ExcludeNodeID(t *testing.T) {
 	included := testrand.NodeID()
 	excluded := testrand.NodeID()
@@ -85,11 +104,11 @@ func TestCriteria_NodeIDAndSubnet(t *testing.T) {
 
 func TestCriteria_Geofencing(t *testing.T) {
 	eu := Criteria{
-		Placement: storj.EU,
+		Placement: PlacementFilter(storj.EU),
 	}
 
 	us := Criteria{
-		Placement: storj.US,
+		Placement: PlacementFilter(storj.US),
 	}
 
 	cases := []struct {
@@ -0,0 +1,112 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package uploadselection
+
+import (
+	"net"
+	"strings"
+
+	"storj.io/common/storj"
+	"storj.io/common/storj/location"
+)
+
+// NodeFilter can decide if a Node should be part of the selection or not.
+type NodeFilter interface {
+	MatchInclude(node *Node) bool
+}
+
+// NodeFilters is a collection of multiple node filters, all of them should include the node.
+type NodeFilters []NodeFilter
+
+// MatchInclude implements NodeFilter interface.
+func (filters NodeFilters) MatchInclude(node *Node) bool {
+	for _, filter := range filters {
+		if !filter.MatchInclude(node) {
+			return false
+		}
+	}
+	return true
+}
+
+// PlacementFilter includes the nodes allowed by one of the built-in placement constraints.
+type PlacementFilter storj.PlacementConstraint
+
+// MatchInclude implements NodeFilter interface.
+func (p PlacementFilter) MatchInclude(node *Node) bool {
+	return storj.PlacementConstraint(p).AllowedCountry(node.CountryCode)
+}
+
+// CountryFilter includes the nodes from the specified countries.
+type CountryFilter []location.CountryCode
+
+// MatchInclude implements NodeFilter interface.
+func (countries CountryFilter) MatchInclude(node *Node) bool {
+	for _, country := range countries {
+		if node.CountryCode == country {
+			return true
+		}
+	}
+	return false
+}
+
+// ContinentFilter includes the nodes from the specified continents.
+type ContinentFilter []Continent
+
+// MatchInclude implements NodeFilter interface.
+func (continents ContinentFilter) MatchInclude(node *Node) bool {
+	continent := ContinentOf(node.CountryCode)
+	for _, c := range continents {
+		if continent == c {
+			return true
+		}
+	}
+	return false
+}
+
+// SubnetFilter includes the nodes whose last IP address is in one of the networks.
+type SubnetFilter []*net.IPNet
+
+// MatchInclude implements NodeFilter interface.
+func (subnets SubnetFilter) MatchInclude(node *Node) bool {
+	host, _, err := net.SplitHostPort(node.LastIPPort)
+	if err != nil {
+		return false
+	}
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return false
+	}
+	for _, subnet := range subnets {
+		if subnet.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// WalletFilter includes the nodes of the operators with the specified wallets.
+type WalletFilter []string
+
+// MatchInclude implements NodeFilter interface.
+func (wallets WalletFilter) MatchInclude(node *Node) bool {
+	for _, wallet := range wallets {
+		if strings.EqualFold(node.Wallet, wallet) {
+			return true
+		}
+	}
+	return false
+}
+
+// TagFilter includes the nodes which have the tag. A zero signer accepts
+// the tag from any signer.
+type TagFilter struct {
+	Signer storj.NodeID
+	Name   string
+	Value  []byte
+}
+
+// MatchInclude implements NodeFilter interface.
+func (filter TagFilter) MatchInclude(node *Node) bool {
+	return node.Tags.Has(filter.Signer, filter.Name, filter.Value)
+}
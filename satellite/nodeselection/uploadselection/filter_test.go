@@ -0,0 +1,77 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package uploadselection
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"storj.io/common/storj"
+	"storj.io/common/storj/location"
+	"storj.io/common/testrand"
+)
+
+func TestContinent(t *testing.T) {
+	assert.Equal(t, Europe, ContinentOf(location.Germany))
+	assert.Equal(t, NorthAmerica, ContinentOf(location.UnitedStates))
+	assert.Equal(t, Asia, ContinentOf(location.Japan))
+	assert.Equal(t, Continent(""), ContinentOf(location.None))
+
+	continent, ok := ParseContinent("eu")
+	assert.True(t, ok)
+	assert.Equal(t, Europe, continent)
+
+	_, ok = ParseContinent("XX")
+	assert.False(t, ok)
+}
+
+func TestNodeFilters(t *testing.T) {
+	_, subnet, err := net.ParseCIDR("10.0.0.0/8")
+	require.NoError(t, err)
+
+	node := &Node{
+		LastIPPort:  "10.1.2.3:28967",
+		CountryCode: location.Germany,
+		Wallet:      "0xABCD",
+	}
+
+	assert.True(t, PlacementFilter(storj.EU).MatchInclude(node))
+	assert.False(t, PlacementFilter(storj.US).MatchInclude(node))
+
+	assert.True(t, CountryFilter{location.France, location.Germany}.MatchInclude(node))
+	assert.False(t, CountryFilter{location.France}.MatchInclude(node))
+
+	assert.True(t, ContinentFilter{Europe}.MatchInclude(node))
+	assert.False(t, ContinentFilter{Asia, NorthAmerica}.MatchInclude(node))
+
+	assert.True(t, SubnetFilter{subnet}.MatchInclude(node))
+	assert.False(t, SubnetFilter{subnet}.MatchInclude(&Node{LastIPPort: "192.168.1.1:28967"}))
+	assert.False(t, SubnetFilter{subnet}.MatchInclude(&Node{}))
+
+	assert.True(t, WalletFilter{"0xabcd"}.MatchInclude(node))
+	assert.False(t, WalletFilter{"0x1234"}.MatchInclude(node))
+
+	assert.True(t, NodeFilters{}.MatchInclude(node))
+	assert.True(t, NodeFilters{ContinentFilter{Europe}, WalletFilter{"0xabcd"}}.MatchInclude(node))
+	assert.False(t, NodeFilters{ContinentFilter{Europe}, WalletFilter{"0x1234"}}.MatchInclude(node))
+}
+
+func TestTagFilter(t *testing.T) {
+	signer := testrand.NodeID()
+	node := &Node{
+		Tags: NodeTags{
+			{Signer: signer, Name: "provider", Value: []byte("storj")},
+		},
+	}
+
+	assert.True(t, TagFilter{Name: "provider", Value: []byte("storj")}.MatchInclude(node))
+	assert.True(t, TagFilter{Signer: signer, Name: "provider", Value: []byte("storj")}.MatchInclude(node))
+	assert.False(t, TagFilter{Signer: testrand.NodeID(), Name: "provider", Value: []byte("storj")}.MatchInclude(node))
+	assert.False(t, TagFilter{Name: "provider", Value: []byte("other")}.MatchInclude(node))
+	assert.False(t, TagFilter{Name: "datacenter", Value: []byte("storj")}.MatchInclude(node))
+	assert.False(t, TagFilter{Name: "provider", Value: []byte("storj")}.MatchInclude(&Node{}))
+}
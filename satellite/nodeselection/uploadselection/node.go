@@ -4,16 +4,58 @@
 package uploadselection
 
 import (
+	"bytes"
+	"time"
+
 	"storj.io/common/storj"
 	"storj.io/common/storj/location"
 )
 
+// NodeTag is a tag of a node, which is approved by the signer.
+type NodeTag struct {
+	NodeID   storj.NodeID
+	SignedAt time.Time
+	Signer   storj.NodeID
+	Name     string
+	Value    []byte
+}
+
+// NodeTags is the list of the tags of a node.
+type NodeTags []NodeTag
+
+// Has returns whether the node has a tag with the name and value. A zero signer
+// matches tags from any signer.
+func (tags NodeTags) Has(signer storj.NodeID, name string, value []byte) bool {
+	for _, tag := range tags {
+		if !signer.IsZero() && tag.Signer != signer {
+			continue
+		}
+		if tag.Name == name && bytes.Equal(tag.Value, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// Value returns the value of the first tag with the name from any signer.
+func (tags NodeTags) Value(name string) (value []byte, ok bool) {
+	for _, tag := range tags {
+		if tag.Name == name {
+			return tag.Value, true
+		}
+	}
+	return nil, false
+}
+
 // Node defines necessary information for node-selection.
 type Node struct {
 	storj.NodeURL
 	LastNet     string
 	LastIPPort  string
 	CountryCode location.CountryCode
+	Wallet      string
+	FreeDisk    int64
+	Tags        NodeTags
 }
 
 // Clone returns a deep clone of the selected node.
@@ -23,5 +65,8 @@ func (node *Node) Clone() *Node {
 		LastNet:     node.LastNet,
 		LastIPPort:  node.LastIPPort,
 		CountryCode: node.CountryCode,
+		Wallet:      node.Wallet,
+		FreeDisk:    node.FreeDisk,
+		Tags:        append(NodeTags(nil), node.Tags...),
 	}
 }
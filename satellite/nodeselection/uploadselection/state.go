@@ -23,15 +23,17 @@ type State struct {
 	stats Stats
 	// netByID returns subnet based on storj.NodeID
 	netByID map[storj.NodeID]string
+	// tagsByID returns the tags of the nodes, which have any.
+	tagsByID map[storj.NodeID]NodeTags
 	// nonDistinct contains selectors for non-distinct selection.
 	nonDistinct struct {
-		Reputable SelectByID
-		New       SelectByID
+		Reputable Selector
+		New       Selector
 	}
 	// distinct contains selectors for distinct slection.
 	distinct struct {
-		Reputable SelectBySubnet
-		New       SelectBySubnet
+		Reputable Selector
+		New       Selector
 	}
 }
 
@@ -53,23 +55,41 @@ type Selector interface {
 	Select(n int, criteria Criteria) []*Node
 }
 
-// NewState returns a state based on the input.
+// NewState returns a state based on the input, where every node has equal probability.
 func NewState(reputableNodes, newNodes []*Node) *State {
+	return NewWeightedState(reputableNodes, newNodes, nil)
+}
+
+// NewWeightedState returns a state based on the input, where the nodes are selected
+// with a probability proportional to their weight. A nil weighter gives every node
+// equal probability.
+func NewWeightedState(reputableNodes, newNodes []*Node, weighter NodeWeighter) *State {
 	state := &State{}
 
 	state.netByID = map[storj.NodeID]string{}
-	for _, node := range reputableNodes {
-		state.netByID[node.ID] = node.LastNet
-	}
-	for _, node := range newNodes {
-		state.netByID[node.ID] = node.LastNet
+	state.tagsByID = map[storj.NodeID]NodeTags{}
+	for _, nodes := range [][]*Node{reputableNodes, newNodes} {
+		for _, node := range nodes {
+			state.netByID[node.ID] = node.LastNet
+			if len(node.Tags) > 0 {
+				state.tagsByID[node.ID] = node.Tags
+			}
+		}
 	}
 
-	state.nonDistinct.Reputable = SelectByID(reputableNodes)
-	state.nonDistinct.New = SelectByID(newNodes)
+	if weighter == nil {
+		state.nonDistinct.Reputable = SelectByID(reputableNodes)
+		state.nonDistinct.New = SelectByID(newNodes)
 
-	state.distinct.Reputable = SelectBySubnetFromNodes(reputableNodes)
-	state.distinct.New = SelectBySubnetFromNodes(newNodes)
+		state.distinct.Reputable = SelectBySubnetFromNodes(reputableNodes)
+		state.distinct.New = SelectBySubnetFromNodes(newNodes)
+	} else {
+		state.nonDistinct.Reputable = SelectByWeightFromNodes(reputableNodes, weighter)
+		state.nonDistinct.New = SelectByWeightFromNodes(newNodes, weighter)
+
+		state.distinct.Reputable = SelectBySubnetAndWeightFromNodes(reputableNodes, weighter)
+		state.distinct.New = SelectBySubnetAndWeightFromNodes(newNodes, weighter)
+	}
 
 	state.stats = Stats{
 		New:       state.nonDistinct.New.Count(),
@@ -88,8 +108,11 @@ type Request struct {
 	NewFraction          float64
 	Distinct             bool
 	ExcludedIDs          []storj.NodeID
-	Placement            storj.PlacementConstraint
+	Placement            NodeFilter
 	ExcludedCountryCodes []string
+	// DistinctTag selects at most one node per value of the tag, e.g. one node
+	// per provider. Empty doesn't restrict the selection.
+	DistinctTag string
 }
 
 // Select selects requestedCount nodes where there will be newFraction nodes.
@@ -119,6 +142,16 @@ func (state *State) Select(ctx context.Context, request Request) (_ []*Node, err
 
 	criteria.Placement = request.Placement
 
+	if request.DistinctTag != "" {
+		criteria.DistinctTag = request.DistinctTag
+		criteria.AutoExcludeTagValues = make(map[string]struct{})
+		for _, id := range request.ExcludedIDs {
+			if value, ok := state.tagsByID[id].Value(request.DistinctTag); ok {
+				criteria.AutoExcludeTagValues[string(value)] = struct{}{}
+			}
+		}
+	}
+
 	if request.Distinct {
 		criteria.AutoExcludeSubnets = make(map[string]struct{})
 		for _, id := range request.ExcludedIDs {
@@ -119,6 +119,66 @@ func TestState_Select(t *testing.T) {
 	}
 }
 
+func TestState_Select_DistinctTag(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	// 3 nodes of provider a, 3 nodes of provider b and 2 nodes without provider
+	nodes := createRandomNodes(8, "1.0.1")
+	providers := map[storj.NodeID]string{}
+	for i, node := range nodes[:6] {
+		providers[node.ID] = []string{"a", "b"}[i%2]
+		node.Tags = uploadselection.NodeTags{{
+			NodeID: node.ID,
+			Name:   "provider",
+			Value:  []byte(providers[node.ID]),
+		}}
+	}
+
+	state := uploadselection.NewState(nodes, nil)
+
+	for i := 0; i < 10; i++ {
+		selected, err := state.Select(ctx, uploadselection.Request{
+			Count:       4,
+			DistinctTag: "provider",
+		})
+		require.NoError(t, err)
+		require.Len(t, selected, 4)
+
+		seen := map[string]bool{}
+		for _, node := range selected {
+			provider, ok := providers[node.ID]
+			if !ok {
+				continue
+			}
+			require.False(t, seen[provider], "provider %q is selected twice", provider)
+			seen[provider] = true
+		}
+	}
+
+	{ // only one node per provider and the nodes without provider are available
+		selected, err := state.Select(ctx, uploadselection.Request{
+			Count:       5,
+			DistinctTag: "provider",
+		})
+		require.Error(t, err)
+		require.Len(t, selected, 4)
+	}
+
+	{ // the providers of the excluded nodes are excluded too
+		selected, err := state.Select(ctx, uploadselection.Request{
+			Count:       3,
+			DistinctTag: "provider",
+			ExcludedIDs: []storj.NodeID{nodes[0].ID},
+		})
+		require.NoError(t, err)
+		require.Len(t, selected, 3)
+		for _, node := range selected {
+			require.NotEqual(t, "a", providers[node.ID])
+		}
+	}
+}
+
 func TestState_Select_Concurrent(t *testing.T) {
 	ctx := testcontext.New(t)
 	defer ctx.Cleanup()
@@ -0,0 +1,179 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package uploadselection
+
+import (
+	"math"
+	mathrand "math/rand" // Using mathrand here because crypto-graphic randomness is not required and simplifies code.
+	"sort"
+)
+
+// NodeWeighter returns the relative weight of a node for the selection.
+// Nodes with higher weight are selected more often, nodes with non-positive
+// weight are never selected.
+type NodeWeighter interface {
+	Weight(node *Node) float64
+}
+
+// NodeWeighterFunc implements NodeWeighter with a function.
+type NodeWeighterFunc func(node *Node) float64
+
+// Weight implements NodeWeighter.
+func (fn NodeWeighterFunc) Weight(node *Node) float64 { return fn(node) }
+
+// FreeDiskWeight weights nodes by their free disk space.
+var FreeDiskWeight = NodeWeighterFunc(func(node *Node) float64 {
+	return float64(node.FreeDisk)
+})
+
+// weightedNode is a node with its selection weight.
+type weightedNode struct {
+	node   *Node
+	weight float64
+}
+
+// SelectByWeight implements selection from nodes with every node having a
+// probability proportional to its weight.
+type SelectByWeight []weightedNode
+
+var _ Selector = (SelectByWeight)(nil)
+
+// SelectByWeightFromNodes creates SelectByWeight selector from nodes.
+func SelectByWeightFromNodes(nodes []*Node, weighter NodeWeighter) SelectByWeight {
+	selector := make(SelectByWeight, 0, len(nodes))
+	for _, node := range nodes {
+		weight := weighter.Weight(node)
+		if weight <= 0 || math.IsNaN(weight) || math.IsInf(weight, 0) {
+			continue
+		}
+		selector = append(selector, weightedNode{node: node, weight: weight})
+	}
+	return selector
+}
+
+// Count returns the number of maximum number of nodes that it can return.
+func (nodes SelectByWeight) Count() int { return len(nodes) }
+
+// Select selects upto n nodes.
+func (nodes SelectByWeight) Select(n int, criteria Criteria) []*Node {
+	if n <= 0 {
+		return nil
+	}
+
+	selected := []*Node{}
+	for _, idx := range weightedPerm(len(nodes), func(i int) float64 { return nodes[i].weight }) {
+		node := nodes[idx].node
+
+		if !criteria.MatchInclude(node) {
+			continue
+		}
+
+		selected = append(selected, node.Clone())
+		if len(selected) >= n {
+			break
+		}
+	}
+
+	return selected
+}
+
+// pick returns a random node with a probability proportional to its weight.
+func (nodes SelectByWeight) pick() *Node {
+	var total float64
+	for _, node := range nodes {
+		total += node.weight
+	}
+	target := mathrand.Float64() * total
+	for _, node := range nodes {
+		target -= node.weight
+		if target < 0 {
+			return node.node
+		}
+	}
+	// rounding errors can leave a small remainder.
+	return nodes[len(nodes)-1].node
+}
+
+// weightedSubnet groups together weighted nodes with the same subnet.
+type weightedSubnet struct {
+	Net    string
+	Nodes  SelectByWeight
+	weight float64
+}
+
+// SelectBySubnetAndWeight implements selection from nodes with every subnet
+// having a probability proportional to the average weight of its nodes and
+// the nodes of the subnet having a probability proportional to their weight.
+type SelectBySubnetAndWeight []weightedSubnet
+
+var _ Selector = (SelectBySubnetAndWeight)(nil)
+
+// SelectBySubnetAndWeightFromNodes creates SelectBySubnetAndWeight selector from nodes.
+func SelectBySubnetAndWeightFromNodes(nodes []*Node, weighter NodeWeighter) SelectBySubnetAndWeight {
+	bynet := map[string]SelectByWeight{}
+	for _, node := range SelectByWeightFromNodes(nodes, weighter) {
+		bynet[node.node.LastNet] = append(bynet[node.node.LastNet], node)
+	}
+
+	var subnets SelectBySubnetAndWeight
+	for net, nodes := range bynet {
+		var total float64
+		for _, node := range nodes {
+			total += node.weight
+		}
+		subnets = append(subnets, weightedSubnet{
+			Net:    net,
+			Nodes:  nodes,
+			weight: total / float64(len(nodes)),
+		})
+	}
+
+	return subnets
+}
+
+// Count returns the number of maximum number of nodes that it can return.
+func (subnets SelectBySubnetAndWeight) Count() int { return len(subnets) }
+
+// Select selects upto n nodes.
+func (subnets SelectBySubnetAndWeight) Select(n int, criteria Criteria) []*Node {
+	if n <= 0 {
+		return nil
+	}
+
+	selected := []*Node{}
+	for _, idx := range weightedPerm(len(subnets), func(i int) float64 { return subnets[i].weight }) {
+		subnet := subnets[idx]
+		node := subnet.Nodes.pick()
+
+		if !criteria.MatchInclude(node) {
+			continue
+		}
+
+		selected = append(selected, node.Clone())
+		if len(selected) >= n {
+			break
+		}
+	}
+
+	return selected
+}
+
+// weightedPerm returns a random permutation of [0, n), where the items with
+// higher weight tend to be in front. The weights must be positive.
+//
+// Taking the first k items of the permutation is equivalent to weighted random
+// sampling without replacement (Efraimidis-Spirakis).
+func weightedPerm(n int, weight func(i int) float64) []int {
+	perm := make([]int, n)
+	keys := make([]float64, n)
+	for i := range perm {
+		perm[i] = i
+		// log(u^(1/w)) keeps the same order as u^(1/w), without underflow.
+		keys[i] = math.Log(1-mathrand.Float64()) / weight(i)
+	}
+	sort.Slice(perm, func(a, b int) bool {
+		return keys[perm[a]] > keys[perm[b]]
+	})
+	return perm
+}
@@ -0,0 +1,147 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package uploadselection_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"storj.io/common/memory"
+	"storj.io/common/storj"
+	"storj.io/common/testcontext"
+	"storj.io/storj/satellite/nodeselection/uploadselection"
+)
+
+func TestSelectByWeight(t *testing.T) {
+	nodes := createRandomNodes(4, "1.0.1")
+	nodes[0].FreeDisk = 1 * memory.TB.Int64()
+	nodes[1].FreeDisk = 1 * memory.TB.Int64()
+	nodes[2].FreeDisk = 2 * memory.TB.Int64()
+	nodes[3].FreeDisk = 0
+
+	selector := uploadselection.SelectByWeightFromNodes(nodes, uploadselection.FreeDiskWeight)
+	require.Equal(t, 3, selector.Count())
+
+	const executionCount = 10000
+
+	var selectedNodeCount = map[storj.NodeID]int{}
+	for i := 0; i < executionCount; i++ {
+		selectedNodes := selector.Select(1, uploadselection.Criteria{})
+		require.Len(t, selectedNodes, 1)
+		selectedNodeCount[selectedNodes[0].ID]++
+	}
+
+	const selectionEpsilon = 0.05
+	assert.InDelta(t, 0.25, float64(selectedNodeCount[nodes[0].ID])/executionCount, selectionEpsilon)
+	assert.InDelta(t, 0.25, float64(selectedNodeCount[nodes[1].ID])/executionCount, selectionEpsilon)
+	assert.InDelta(t, 0.5, float64(selectedNodeCount[nodes[2].ID])/executionCount, selectionEpsilon)
+	assert.Zero(t, selectedNodeCount[nodes[3].ID])
+
+	// all the nodes with positive weight are returned when requesting more
+	selectedNodes := selector.Select(4, uploadselection.Criteria{})
+	require.Len(t, selectedNodes, 3)
+	require.Empty(t, intersectLists(selectedNodes, nodes[3:]))
+
+	// criteria is respected
+	selectedNodes = selector.Select(3, uploadselection.Criteria{
+		ExcludeNodeIDs: []storj.NodeID{nodes[2].ID},
+	})
+	require.Len(t, selectedNodes, 2)
+	require.Empty(t, intersectLists(selectedNodes, nodes[2:]))
+}
+
+func TestSelectBySubnetAndWeight(t *testing.T) {
+	subnetA := createRandomNodes(2, "1.0.1")
+	subnetA[0].FreeDisk = 1 * memory.TB.Int64()
+	subnetA[1].FreeDisk = 3 * memory.TB.Int64()
+	subnetB := createRandomNodes(1, "1.0.2")
+	subnetB[0].FreeDisk = 2 * memory.TB.Int64()
+
+	selector := uploadselection.SelectBySubnetAndWeightFromNodes(joinNodes(subnetA, subnetB), uploadselection.FreeDiskWeight)
+	require.Equal(t, 2, selector.Count())
+
+	const executionCount = 10000
+
+	var selectedNodeCount = map[storj.NodeID]int{}
+	for i := 0; i < executionCount; i++ {
+		selectedNodes := selector.Select(2, uploadselection.Criteria{})
+		require.Len(t, selectedNodes, 2)
+		require.NotEqual(t, selectedNodes[0].LastNet, selectedNodes[1].LastNet)
+		selectedNodeCount[selectedNodes[0].ID]++
+	}
+
+	// both subnets have the same average weight, within the subnet the nodes
+	// are selected proportional to their weight.
+	const selectionEpsilon = 0.05
+	assert.InDelta(t, 0.125, float64(selectedNodeCount[subnetA[0].ID])/executionCount, selectionEpsilon)
+	assert.InDelta(t, 0.375, float64(selectedNodeCount[subnetA[1].ID])/executionCount, selectionEpsilon)
+	assert.InDelta(t, 0.5, float64(selectedNodeCount[subnetB[0].ID])/executionCount, selectionEpsilon)
+}
+
+func TestState_SelectWeighted(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	reputableNodes := joinNodes(
+		createRandomNodes(2, "1.0.1"),
+		createRandomNodes(3, "1.0.2"),
+	)
+	newNodes := joinNodes(
+		createRandomNodes(2, "1.0.3"),
+		createRandomNodes(3, "1.0.4"),
+	)
+	for _, node := range joinNodes(reputableNodes, newNodes) {
+		node.FreeDisk = memory.TB.Int64()
+	}
+	// full nodes are never selected.
+	reputableNodes[0].FreeDisk = 0
+	newNodes[0].FreeDisk = 0
+
+	state := uploadselection.NewWeightedState(reputableNodes, newNodes, uploadselection.FreeDiskWeight)
+	require.Equal(t, uploadselection.Stats{
+		New:               4,
+		Reputable:         4,
+		NewDistinct:       2,
+		ReputableDistinct: 2,
+	}, state.Stats())
+
+	{ // select 6 non-distinct subnet reputable and new nodes (50%)
+		const selectCount = 6
+		const newFraction = 0.5
+		selected, err := state.Select(ctx, uploadselection.Request{
+			Count:       selectCount,
+			NewFraction: newFraction,
+		})
+		require.NoError(t, err)
+		require.Len(t, selected, selectCount)
+		require.Len(t, intersectLists(selected, reputableNodes), selectCount*(1-newFraction))
+		require.Len(t, intersectLists(selected, newNodes), selectCount*newFraction)
+	}
+
+	{ // select 4 distinct subnet reputable and new nodes (50%)
+		const selectCount = 4
+		const newFraction = 0.5
+		selected, err := state.Select(ctx, uploadselection.Request{
+			Count:       selectCount,
+			NewFraction: newFraction,
+			Distinct:    true,
+		})
+		require.NoError(t, err)
+		require.Len(t, selected, selectCount)
+		require.Len(t, intersectLists(selected, reputableNodes), selectCount*(1-newFraction))
+		require.Len(t, intersectLists(selected, newNodes), selectCount*newFraction)
+	}
+
+	{ // try to select all the nodes, only the ones with free disk are selected
+		selected, err := state.Select(ctx, uploadselection.Request{
+			Count:       10,
+			NewFraction: 0.5,
+		})
+		require.Error(t, err)
+		require.Len(t, selected, 8)
+		require.Empty(t, intersectLists(selected, []*uploadselection.Node{reputableNodes[0], newNodes[0]}))
+	}
+}
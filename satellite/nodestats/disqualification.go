@@ -0,0 +1,43 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package nodestats
+
+import (
+	"context"
+	"encoding/json"
+
+	"go.uber.org/zap"
+
+	"storj.io/common/identity"
+	"storj.io/common/rpc/rpcstatus"
+	"storj.io/storj/private/nodeextpb"
+	"storj.io/storj/satellite/overlay"
+)
+
+// GetDisqualificationExplanation returns the JSON encoded explanation of the latest
+// disqualification of the calling node, which is stored when the node is disqualified.
+func (e *Endpoint) GetDisqualificationExplanation(ctx context.Context, req *nodeextpb.GetDisqualificationExplanationRequest) (_ *nodeextpb.GetDisqualificationExplanationResponse, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	peer, err := identity.PeerIdentityFromContext(ctx)
+	if err != nil {
+		return nil, rpcstatus.Error(rpcstatus.Unauthenticated, err.Error())
+	}
+
+	explanation, err := e.overlay.GetDisqualificationExplanation(ctx, peer.ID)
+	if err != nil {
+		if overlay.ErrExplanationNotFound.Has(err) {
+			return nil, rpcstatus.Error(rpcstatus.NotFound, err.Error())
+		}
+		e.log.Error("overlay.GetDisqualificationExplanation failed", zap.Error(err))
+		return nil, rpcstatus.Error(rpcstatus.Internal, err.Error())
+	}
+
+	data, err := json.Marshal(explanation)
+	if err != nil {
+		e.log.Error("failed to encode disqualification explanation", zap.Error(err))
+		return nil, rpcstatus.Error(rpcstatus.Internal, err.Error())
+	}
+	return &nodeextpb.GetDisqualificationExplanationResponse{Explanation: data}, nil
+}
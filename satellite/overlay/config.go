@@ -10,6 +10,7 @@ import (
 	"github.com/zeebo/errs"
 
 	"storj.io/common/memory"
+	"storj.io/storj/satellite/nodeselection/uploadselection"
 )
 
 var (
@@ -23,11 +24,13 @@ type Config struct {
 	Node                            NodeSelectionConfig
 	NodeSelectionCache              UploadSelectionCacheConfig
 	GeoIP                           GeoIPConfig
-	UpdateStatsBatchSize            int           `help:"number of update requests to process per transaction" default:"100"`
-	NodeCheckInWaitPeriod           time.Duration `help:"the amount of time to wait before accepting a redundant check-in from a node (unmodified info since last check-in)" default:"2h" testDefault:"30s"`
-	NodeSoftwareUpdateEmailCooldown time.Duration `help:"the amount of time to wait between sending Node Software Update emails" default:"168h"`
-	RepairExcludedCountryCodes      []string      `help:"list of country codes to exclude nodes from target repair selection" default:"" testDefault:"FR,BE"`
-	SendNodeEmails                  bool          `help:"whether to send emails to nodes" default:"false"`
+	UploadSuccessTracker            UploadSuccessTrackerConfig
+	UpdateStatsBatchSize            int                       `help:"number of update requests to process per transaction" default:"100"`
+	NodeCheckInWaitPeriod           time.Duration             `help:"the amount of time to wait before accepting a redundant check-in from a node (unmodified info since last check-in)" default:"2h" testDefault:"30s"`
+	NodeSoftwareUpdateEmailCooldown time.Duration             `help:"the amount of time to wait between sending Node Software Update emails" default:"168h"`
+	RepairExcludedCountryCodes      []string                  `help:"list of country codes to exclude nodes from target repair selection" default:"" testDefault:"FR,BE"`
+	SendNodeEmails                  bool                      `help:"whether to send emails to nodes" default:"false"`
+	Placement                       ConfigurablePlacementRule `help:"definitions of the placement rules in the form of id:filter && filter;id:filter, where a filter is country(\"DE\",...), continent(\"EU\",...), subnet(\"10.0.0.0/8\",...), wallet(\"0x...\",...) or tag(\"signer\",\"name\",\"value\") where the signer is optional" default:""`
 }
 
 // AsOfSystemTimeConfig is a configuration struct to enable 'AS OF SYSTEM TIME' for CRDB queries.
@@ -48,6 +51,48 @@ type NodeSelectionConfig struct {
 	AsOfSystemTime AsOfSystemTimeConfig
 
 	UploadExcludedCountryCodes []string `help:"list of country codes to exclude from node selection for uploads" default:"" testDefault:"FR,BE"`
+
+	DistinctTag string `help:"the name of a node tag (e.g. provider), which values must be distinct among the nodes selected for a segment, nodes without the tag are not restricted" default:""`
+
+	Weighting NodeWeighting `help:"how to weight the nodes in upload node selection: uniform or free-disk" default:"uniform"`
+}
+
+// NodeWeighting defines how the nodes are weighted in upload node selection.
+type NodeWeighting string
+
+const (
+	// UniformWeighting gives every node equal probability.
+	UniformWeighting NodeWeighting = "uniform"
+	// FreeDiskWeighting selects the nodes with a probability proportional to their free disk space.
+	FreeDiskWeighting NodeWeighting = "free-disk"
+)
+
+// Type implements pflag.Value.
+func (NodeWeighting) Type() string { return "overlay.NodeWeighting" }
+
+// String implements pflag.Value.
+func (weighting *NodeWeighting) String() string { return string(*weighting) }
+
+// Set implements pflag.Value.
+func (weighting *NodeWeighting) Set(s string) error {
+	if _, err := NodeWeighting(s).Weighter(); err != nil {
+		return err
+	}
+	*weighting = NodeWeighting(s)
+	return nil
+}
+
+// Weighter returns the node weighter of the weighting, a nil weighter gives every node
+// equal probability.
+func (weighting NodeWeighting) Weighter() (uploadselection.NodeWeighter, error) {
+	switch weighting {
+	case "", UniformWeighting:
+		return nil, nil
+	case FreeDiskWeighting:
+		return uploadselection.FreeDiskWeight, nil
+	default:
+		return nil, errs.New("unknown node weighting %q", weighting)
+	}
 }
 
 // GeoIPConfig is a configuration struct that helps configure the GeoIP lookup features on the satellite.
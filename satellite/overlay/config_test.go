@@ -0,0 +1,28 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package overlay_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"storj.io/storj/satellite/nodeselection/uploadselection"
+	"storj.io/storj/satellite/overlay"
+)
+
+func TestNodeWeighting(t *testing.T) {
+	var weighting overlay.NodeWeighting
+	require.Error(t, weighting.Set("unknown"))
+
+	require.NoError(t, weighting.Set("uniform"))
+	weighter, err := weighting.Weighter()
+	require.NoError(t, err)
+	require.Nil(t, weighter)
+
+	require.NoError(t, weighting.Set("free-disk"))
+	weighter, err = weighting.Weighter()
+	require.NoError(t, err)
+	require.Equal(t, float64(100), weighter.Weight(&uploadselection.Node{FreeDisk: 100}))
+}
@@ -18,6 +18,7 @@ import (
 	"storj.io/storj/private/testplanet"
 	"storj.io/storj/private/teststorj"
 	"storj.io/storj/satellite"
+	"storj.io/storj/satellite/nodeselection/uploadselection"
 	"storj.io/storj/satellite/overlay"
 	"storj.io/storj/satellite/satellitedb/satellitedbtest"
 	"storj.io/storj/storagenode"
@@ -238,3 +239,96 @@ func TestDBDisqualifyNode(t *testing.T) {
 		}
 	})
 }
+
+func TestNodeTags(t *testing.T) {
+	satellitedbtest.Run(t, func(ctx *testcontext.Context, t *testing.T, db satellite.DB) {
+		cache := db.OverlayCache()
+
+		nodeID := testrand.NodeID()
+		signer := testrand.NodeID()
+		signedAt := time.Now().UTC().Truncate(time.Second)
+
+		tags, err := cache.GetNodeTags(ctx, nodeID)
+		require.NoError(t, err)
+		require.Empty(t, tags)
+
+		err = cache.UpdateNodeTags(ctx, nodeID, uploadselection.NodeTags{
+			{NodeID: nodeID, SignedAt: signedAt, Signer: signer, Name: "provider", Value: []byte("storj")},
+			{NodeID: nodeID, SignedAt: signedAt, Signer: signer, Name: "datacenter", Value: []byte("dc1")},
+		})
+		require.NoError(t, err)
+
+		// tags of other nodes are refused
+		err = cache.UpdateNodeTags(ctx, nodeID, uploadselection.NodeTags{
+			{NodeID: testrand.NodeID(), SignedAt: signedAt, Signer: signer, Name: "provider", Value: []byte("other")},
+		})
+		require.Error(t, err)
+
+		// the tags are replaced, tags which are not reported anymore are removed
+		err = cache.UpdateNodeTags(ctx, nodeID, uploadselection.NodeTags{
+			{NodeID: nodeID, SignedAt: signedAt.Add(time.Hour), Signer: signer, Name: "datacenter", Value: []byte("dc2")},
+		})
+		require.NoError(t, err)
+
+		tags, err = cache.GetNodeTags(ctx, nodeID)
+		require.NoError(t, err)
+		require.Len(t, tags, 1)
+		require.Equal(t, "datacenter", tags[0].Name)
+		require.Equal(t, []byte("dc2"), tags[0].Value)
+		require.Equal(t, signer, tags[0].Signer)
+		require.True(t, signedAt.Add(time.Hour).Equal(tags[0].SignedAt))
+
+		// the tags of the node can be removed
+		require.NoError(t, cache.UpdateNodeTags(ctx, nodeID, nil))
+		tags, err = cache.GetNodeTags(ctx, nodeID)
+		require.NoError(t, err)
+		require.Empty(t, tags)
+	})
+}
+
+func TestDisqualificationExplanationDB(t *testing.T) {
+	satellitedbtest.Run(t, func(ctx *testcontext.Context, t *testing.T, db satellite.DB) {
+		cache := db.OverlayCache()
+		nodeID := testrand.NodeID()
+		now := time.Now().UTC().Truncate(time.Second)
+
+		_, err := cache.GetDisqualificationExplanation(ctx, nodeID)
+		require.True(t, overlay.ErrExplanationNotFound.Has(err))
+
+		explanation := overlay.DisqualificationExplanation{
+			NodeID:         nodeID,
+			DisqualifiedAt: now,
+			Reason:         overlay.DisqualificationReasonAuditFailure,
+			Scores: &overlay.DisqualificationScores{
+				AuditScore: 0.5,
+				AuditDQ:    0.96,
+			},
+			RecentAudits: []overlay.DisqualificationAudit{{
+				AuditedAt:  now,
+				Outcome:    "AuditFailure",
+				Segment:    &overlay.AuditedSegment{StreamID: testrand.UUID(), Position: 5},
+				AuditScore: 0.5,
+			}},
+			FailingWindows: []overlay.DisqualificationWindow{
+				{WindowStart: now.Add(-time.Hour), OnlineCount: 1, TotalCount: 3},
+			},
+		}
+		require.NoError(t, cache.SetDisqualificationExplanation(ctx, explanation))
+
+		stored, err := cache.GetDisqualificationExplanation(ctx, nodeID)
+		require.NoError(t, err)
+		require.Equal(t, explanation, *stored)
+
+		// a later disqualification replaces the explanation.
+		explanation = overlay.DisqualificationExplanation{
+			NodeID:         nodeID,
+			DisqualifiedAt: now.Add(time.Hour),
+			Reason:         overlay.DisqualificationReasonNodeOffline,
+		}
+		require.NoError(t, cache.SetDisqualificationExplanation(ctx, explanation))
+
+		stored, err = cache.GetDisqualificationExplanation(ctx, nodeID)
+		require.NoError(t, err)
+		require.Equal(t, explanation, *stored)
+	})
+}
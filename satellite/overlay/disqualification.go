@@ -0,0 +1,115 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package overlay
+
+import (
+	"context"
+	"time"
+
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	"storj.io/common/storj"
+	"storj.io/common/uuid"
+)
+
+// ErrExplanationNotFound is returned when a node has no disqualification explanation.
+var ErrExplanationNotFound = errs.Class("disqualification explanation not found")
+
+// DisqualificationExplanation explains why a node was disqualified. It's stored when
+// the node is disqualified, so that it's still available after its reputation changed.
+type DisqualificationExplanation struct {
+	NodeID         storj.NodeID           `json:"nodeId"`
+	DisqualifiedAt time.Time              `json:"disqualifiedAt"`
+	Reason         DisqualificationReason `json:"reason"`
+
+	LastContactSuccess time.Time `json:"lastContactSuccess"`
+	LastContactFailure time.Time `json:"lastContactFailure"`
+
+	// Scores are the scores of the node when it was disqualified. They are nil, when
+	// the node wasn't disqualified by the reputation service.
+	Scores *DisqualificationScores `json:"scores,omitempty"`
+	// RecentAudits are the most recent audits of the node before it was disqualified,
+	// the oldest first, as far as they are known.
+	RecentAudits []DisqualificationAudit `json:"recentAudits"`
+	// FailingWindows are the audit history windows of the node, in which its online
+	// score was below the offline threshold.
+	FailingWindows []DisqualificationWindow `json:"failingWindows"`
+}
+
+// DisqualificationScores contains the reputation of a node when it was disqualified and
+// the thresholds it was compared to.
+type DisqualificationScores struct {
+	AuditScore        float64 `json:"auditScore"`
+	UnknownAuditScore float64 `json:"unknownAuditScore"`
+	OnlineScore       float64 `json:"onlineScore"`
+	AuditSuccessCount int64   `json:"auditSuccessCount"`
+	TotalAuditCount   int64   `json:"totalAuditCount"`
+
+	AuditDQ          float64 `json:"auditDQ"`
+	UnknownAuditDQ   float64 `json:"unknownAuditDQ"`
+	OfflineThreshold float64 `json:"offlineThreshold"`
+
+	UnknownAuditSuspended *time.Time `json:"unknownAuditSuspended,omitempty"`
+	OfflineSuspended      *time.Time `json:"offlineSuspended,omitempty"`
+}
+
+// DisqualificationAudit is an audit of a node and the scores of the node after it.
+type DisqualificationAudit struct {
+	AuditedAt time.Time       `json:"auditedAt"`
+	Outcome   string          `json:"outcome"`
+	Segment   *AuditedSegment `json:"segment,omitempty"`
+
+	AuditScore        float64 `json:"auditScore"`
+	UnknownAuditScore float64 `json:"unknownAuditScore"`
+	OnlineScore       float64 `json:"onlineScore"`
+}
+
+// AuditedSegment identifies the segment of an audit.
+type AuditedSegment struct {
+	StreamID uuid.UUID `json:"streamId"`
+	Position uint64    `json:"position"`
+}
+
+// DisqualificationWindow is an audit history window of a node.
+type DisqualificationWindow struct {
+	WindowStart time.Time `json:"windowStart"`
+	OnlineCount int32     `json:"onlineCount"`
+	TotalCount  int32     `json:"totalCount"`
+}
+
+// SetDisqualificationExplanation stores the explanation of the disqualification of a node.
+// The last contact of the node is filled in from the node dossier.
+func (service *Service) SetDisqualificationExplanation(ctx context.Context, explanation DisqualificationExplanation) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	node, err := service.db.Get(ctx, explanation.NodeID)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+	explanation.LastContactSuccess = node.Reputation.LastContactSuccess
+	explanation.LastContactFailure = node.Reputation.LastContactFailure
+
+	return service.db.SetDisqualificationExplanation(ctx, explanation)
+}
+
+// GetDisqualificationExplanation returns the explanation of the disqualification of a node.
+func (service *Service) GetDisqualificationExplanation(ctx context.Context, nodeID storj.NodeID) (_ *DisqualificationExplanation, err error) {
+	defer mon.Task()(&ctx)(&err)
+	return service.db.GetDisqualificationExplanation(ctx, nodeID)
+}
+
+// explainDisqualification stores the explanation of a disqualification, which isn't done
+// by the reputation service. It only logs the failures, because the node is disqualified
+// anyway.
+func (service *Service) explainDisqualification(ctx context.Context, nodeID storj.NodeID, disqualifiedAt time.Time, reason DisqualificationReason) {
+	err := service.SetDisqualificationExplanation(ctx, DisqualificationExplanation{
+		NodeID:         nodeID,
+		DisqualifiedAt: disqualifiedAt,
+		Reason:         reason,
+	})
+	if err != nil {
+		service.log.Error("could not store disqualification explanation", zap.Stringer("Node ID", nodeID), zap.Error(err))
+	}
+}
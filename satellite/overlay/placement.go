@@ -0,0 +1,264 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package overlay
+
+import (
+	"net"
+	"strconv"
+	"strings"
+
+	"storj.io/common/storj"
+	"storj.io/common/storj/location"
+	"storj.io/storj/satellite/nodeselection/uploadselection"
+)
+
+// PlacementRules returns the node filter of a placement constraint.
+// A nil filter includes all the nodes.
+type PlacementRules func(constraint storj.PlacementConstraint) uploadselection.NodeFilter
+
+// ConfigurablePlacementRule defines the node filters of placement constraints.
+// The built-in constraints (EU, EEA, US, DE) are always available, but they
+// can be redefined.
+//
+// Can be used as a flag.
+type ConfigurablePlacementRule struct {
+	definitions string
+	placements  map[storj.PlacementConstraint]uploadselection.NodeFilter
+}
+
+// NewPlacementRules creates placement rules with only the built-in constraints.
+func NewPlacementRules() *ConfigurablePlacementRule {
+	return &ConfigurablePlacementRule{}
+}
+
+// Type implements pflag.Value.
+func (d *ConfigurablePlacementRule) Type() string { return "overlay.ConfigurablePlacementRule" }
+
+// String implements pflag.Value.
+func (d *ConfigurablePlacementRule) String() string { return d.definitions }
+
+// Set implements pflag.Value.
+func (d *ConfigurablePlacementRule) Set(definitions string) error {
+	placements, err := parsePlacementDefinitions(definitions)
+	if err != nil {
+		return err
+	}
+
+	d.definitions = ""
+	d.placements = nil
+	d.addPlacements(definitions, placements)
+	return nil
+}
+
+// AddPlacementRule defines the filter of a placement constraint.
+func (d *ConfigurablePlacementRule) AddPlacementRule(id storj.PlacementConstraint, filter uploadselection.NodeFilter) {
+	if d.placements == nil {
+		d.placements = map[storj.PlacementConstraint]uploadselection.NodeFilter{}
+	}
+	d.placements[id] = filter
+}
+
+// AddPlacementFromString parses placement definitions in the form of
+// `id:filter && filter;id:filter`, where a filter is one of
+//
+//	country("DE","AT")
+//	continent("EU")
+//	subnet("10.0.0.0/8")
+//	wallet("0x...")
+//	tag("name","value")
+//	tag("signer node id","name","value")
+//
+// A node needs to match all the filters of a placement to be included.
+// The separators inside quoted arguments are part of the arguments.
+//
+// The placements are only added, when all the definitions are valid.
+func (d *ConfigurablePlacementRule) AddPlacementFromString(definitions string) error {
+	placements, err := parsePlacementDefinitions(definitions)
+	if err != nil {
+		return err
+	}
+	d.addPlacements(definitions, placements)
+	return nil
+}
+
+// addPlacements adds the parsed placements and their definitions.
+func (d *ConfigurablePlacementRule) addPlacements(definitions string, placements map[storj.PlacementConstraint]uploadselection.NodeFilter) {
+	for id, filter := range placements {
+		d.AddPlacementRule(id, filter)
+	}
+
+	if d.definitions == "" {
+		d.definitions = definitions
+	} else if definitions != "" {
+		d.definitions += ";" + definitions
+	}
+}
+
+// parsePlacementDefinitions parses the definitions without applying them.
+// When a placement is defined several times, the last definition wins.
+func parsePlacementDefinitions(definitions string) (map[storj.PlacementConstraint]uploadselection.NodeFilter, error) {
+	parts, err := splitUnquoted(definitions, ";")
+	if err != nil {
+		return nil, err
+	}
+
+	placements := map[storj.PlacementConstraint]uploadselection.NodeFilter{}
+	for _, definition := range parts {
+		definition = strings.TrimSpace(definition)
+		if definition == "" {
+			continue
+		}
+
+		// ids are numbers, so the first separator can't be inside a quoted argument.
+		separator := strings.Index(definition, ":")
+		if separator < 0 {
+			return nil, Error.New("placement definition should be in the form id:filter: %q", definition)
+		}
+		idString, expression := definition[:separator], definition[separator+1:]
+
+		id, err := strconv.ParseUint(strings.TrimSpace(idString), 10, 16)
+		if err != nil {
+			return nil, Error.New("invalid placement id %q: %w", idString, err)
+		}
+
+		expressions, err := splitUnquoted(expression, "&&")
+		if err != nil {
+			return nil, Error.New("invalid placement %d: %w", id, err)
+		}
+
+		var filters uploadselection.NodeFilters
+		for _, part := range expressions {
+			filter, err := parsePlacementFilter(strings.TrimSpace(part))
+			if err != nil {
+				return nil, Error.New("invalid placement %d: %w", id, err)
+			}
+			filters = append(filters, filter)
+		}
+
+		placements[storj.PlacementConstraint(id)] = filters
+	}
+	return placements, nil
+}
+
+// CreateFilters returns the node filter of a placement constraint.
+func (d *ConfigurablePlacementRule) CreateFilters(constraint storj.PlacementConstraint) uploadselection.NodeFilter {
+	if filter, ok := d.placements[constraint]; ok {
+		return filter
+	}
+	if constraint == storj.EveryCountry {
+		return nil
+	}
+	// built-in constraints, the unknown ones don't include any node.
+	return uploadselection.PlacementFilter(constraint)
+}
+
+// parsePlacementFilter parses a single filter in the form of name("arg","arg").
+func parsePlacementFilter(expression string) (uploadselection.NodeFilter, error) {
+	open := strings.Index(expression, "(")
+	if open < 0 || !strings.HasSuffix(expression, ")") {
+		return nil, Error.New("filter should be in the form name(\"value\",...): %q", expression)
+	}
+	name, rest := strings.TrimSpace(expression[:open]), expression[open+1:]
+
+	parts, err := splitUnquoted(strings.TrimSuffix(rest, ")"), ",")
+	if err != nil {
+		return nil, err
+	}
+
+	var args []string
+	for _, arg := range parts {
+		value, err := strconv.Unquote(strings.TrimSpace(arg))
+		if err != nil {
+			return nil, Error.New("filter argument should be a quoted string: %s", arg)
+		}
+		args = append(args, value)
+	}
+
+	switch name {
+	case "country":
+		var filter uploadselection.CountryFilter
+		for _, arg := range args {
+			country := location.ToCountryCode(arg)
+			if country == location.None || uploadselection.ContinentOf(country) == "" {
+				return nil, Error.New("invalid country code %q", arg)
+			}
+			filter = append(filter, country)
+		}
+		return filter, nil
+	case "continent":
+		var filter uploadselection.ContinentFilter
+		for _, arg := range args {
+			continent, ok := uploadselection.ParseContinent(arg)
+			if !ok {
+				return nil, Error.New("invalid continent %q", arg)
+			}
+			filter = append(filter, continent)
+		}
+		return filter, nil
+	case "subnet":
+		var filter uploadselection.SubnetFilter
+		for _, arg := range args {
+			_, subnet, err := net.ParseCIDR(arg)
+			if err != nil {
+				return nil, Error.New("invalid subnet %q: %w", arg, err)
+			}
+			filter = append(filter, subnet)
+		}
+		return filter, nil
+	case "wallet":
+		var filter uploadselection.WalletFilter
+		for _, arg := range args {
+			if arg == "" {
+				return nil, Error.New("wallet is empty")
+			}
+			filter = append(filter, arg)
+		}
+		return filter, nil
+	case "tag":
+		var filter uploadselection.TagFilter
+		switch len(args) {
+		case 2:
+			filter.Name, filter.Value = args[0], []byte(args[1])
+		case 3:
+			signer, err := storj.NodeIDFromString(args[0])
+			if err != nil {
+				return nil, Error.New("invalid tag signer %q: %w", args[0], err)
+			}
+			filter.Signer, filter.Name, filter.Value = signer, args[1], []byte(args[2])
+		default:
+			return nil, Error.New("tag filter should have a name and a value with an optional signer: %q", expression)
+		}
+		if filter.Name == "" {
+			return nil, Error.New("tag name is empty")
+		}
+		return filter, nil
+	default:
+		return nil, Error.New("unknown filter %q", name)
+	}
+}
+
+// splitUnquoted splits s around the separators, which are outside of the
+// double-quoted strings. The quoted strings may contain escaped quotes.
+func splitUnquoted(s, separator string) (parts []string, err error) {
+	quoted, escaped := false, false
+	start := 0
+	for i := 0; i < len(s); i++ {
+		switch {
+		case escaped:
+			escaped = false
+		case quoted && s[i] == '\\':
+			escaped = true
+		case s[i] == '"':
+			quoted = !quoted
+		case !quoted && strings.HasPrefix(s[i:], separator):
+			parts = append(parts, s[start:i])
+			start = i + len(separator)
+			i = start - 1
+		}
+	}
+	if quoted {
+		return nil, Error.New("unterminated quoted string: %s", s)
+	}
+	return append(parts, s[start:]), nil
+}
@@ -0,0 +1,132 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package overlay_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"storj.io/common/storj"
+	"storj.io/common/storj/location"
+	"storj.io/common/testrand"
+	"storj.io/storj/satellite/nodeselection/uploadselection"
+	"storj.io/storj/satellite/overlay"
+)
+
+func TestPlacementFromString(t *testing.T) {
+	t.Run("invalid", func(t *testing.T) {
+		for _, definition := range []string{
+			`10`,
+			`x:country("DE")`,
+			`10:country(DE)`,
+			`10:country("XX")`,
+			`10:continent("XX")`,
+			`10:subnet("10.0.0.0")`,
+			`10:wallet("")`,
+			`10:unknown("DE")`,
+			`10:country("DE"`,
+			`10:tag("provider")`,
+			`10:tag("","storj")`,
+			`10:tag("invalid","provider","storj")`,
+			`10:tag("provider","storj)`,
+		} {
+			err := overlay.NewPlacementRules().Set(definition)
+			require.Error(t, err, definition)
+		}
+	})
+
+	t.Run("filters", func(t *testing.T) {
+		rules := overlay.NewPlacementRules()
+		definitions := `10:country("DE","AT") && wallet("0xabcd"); 11:continent("NA"); 12:subnet("10.0.0.0/8")`
+		require.NoError(t, rules.Set(definitions))
+		require.Equal(t, definitions, rules.String())
+
+		german := &uploadselection.Node{
+			CountryCode: location.Germany,
+			LastIPPort:  "10.1.1.1:28967",
+			Wallet:      "0xABCD",
+		}
+		american := &uploadselection.Node{
+			CountryCode: location.UnitedStates,
+			LastIPPort:  "192.168.1.1:28967",
+			Wallet:      "0xabcd",
+		}
+
+		filter := rules.CreateFilters(10)
+		require.True(t, filter.MatchInclude(german))
+		require.False(t, filter.MatchInclude(american))
+		require.False(t, filter.MatchInclude(&uploadselection.Node{CountryCode: location.Germany}))
+
+		filter = rules.CreateFilters(11)
+		require.False(t, filter.MatchInclude(german))
+		require.True(t, filter.MatchInclude(american))
+
+		filter = rules.CreateFilters(12)
+		require.True(t, filter.MatchInclude(german))
+		require.False(t, filter.MatchInclude(american))
+	})
+
+	t.Run("built-in", func(t *testing.T) {
+		rules := overlay.NewPlacementRules()
+		require.NoError(t, rules.Set(""))
+
+		require.Nil(t, rules.CreateFilters(storj.EveryCountry))
+
+		german := &uploadselection.Node{CountryCode: location.Germany}
+		require.True(t, rules.CreateFilters(storj.EU).MatchInclude(german))
+		require.False(t, rules.CreateFilters(storj.US).MatchInclude(german))
+		require.False(t, rules.CreateFilters(42).MatchInclude(german))
+
+		// built-in constraints can be redefined.
+		require.NoError(t, rules.Set(`3:country("DE")`))
+		require.True(t, rules.CreateFilters(storj.US).MatchInclude(german))
+	})
+
+	t.Run("quoted separators", func(t *testing.T) {
+		rules := overlay.NewPlacementRules()
+		require.NoError(t, rules.Set(`10:tag("provider","a,b;c && \"d\"")`))
+
+		node := &uploadselection.Node{
+			Tags: uploadselection.NodeTags{
+				{Signer: testrand.NodeID(), Name: "provider", Value: []byte(`a,b;c && "d"`)},
+			},
+		}
+		require.True(t, rules.CreateFilters(10).MatchInclude(node))
+	})
+
+	t.Run("invalid definitions are not applied", func(t *testing.T) {
+		rules := overlay.NewPlacementRules()
+		require.NoError(t, rules.Set(`10:country("DE")`))
+
+		err := rules.AddPlacementFromString(`11:country("US"); 12:country("XX")`)
+		require.Error(t, err)
+		require.Equal(t, `10:country("DE")`, rules.String())
+
+		american := &uploadselection.Node{CountryCode: location.UnitedStates}
+		require.False(t, rules.CreateFilters(11).MatchInclude(american))
+
+		err = rules.Set(`10:country("US"); 12:country("XX")`)
+		require.Error(t, err)
+		require.Equal(t, `10:country("DE")`, rules.String())
+		require.False(t, rules.CreateFilters(10).MatchInclude(american))
+	})
+
+	t.Run("tags", func(t *testing.T) {
+		signer := testrand.NodeID()
+		rules := overlay.NewPlacementRules()
+		require.NoError(t, rules.Set(`10:tag("provider","storj"); 11:tag("`+signer.String()+`","provider","storj")`))
+
+		node := &uploadselection.Node{
+			Tags: uploadselection.NodeTags{
+				{Signer: testrand.NodeID(), Name: "provider", Value: []byte("storj")},
+			},
+		}
+		require.True(t, rules.CreateFilters(10).MatchInclude(node))
+		require.False(t, rules.CreateFilters(11).MatchInclude(node))
+
+		node.Tags[0].Signer = signer
+		require.True(t, rules.CreateFilters(11).MatchInclude(node))
+	})
+}
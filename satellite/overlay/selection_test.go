@@ -256,6 +256,128 @@ func TestEnsureMinimumRequested(t *testing.T) {
 	})
 }
 
+func TestFindStorageNodesWithPreferencesPlacement(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount: 1, StorageNodeCount: 4, UplinkCount: 0,
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		service := planet.Satellites[0].Overlay.Service
+
+		eu := map[storj.NodeID]bool{}
+		for i, node := range planet.StorageNodes {
+			countryCode := "US"
+			if i < 2 {
+				countryCode = "DE"
+				eu[node.ID()] = true
+			}
+			require.NoError(t, service.TestNodeCountryCode(ctx, node.ID(), countryCode))
+		}
+
+		preferences := testNodeSelectionConfig(0, false)
+
+		// only the nodes allowed by the placement are selected
+		for i := 0; i < 5; i++ {
+			nodes, err := service.FindStorageNodesWithPreferences(ctx, overlay.FindStorageNodesRequest{
+				RequestedCount: 2,
+				Placement:      storj.EU,
+			}, &preferences)
+			require.NoError(t, err)
+			require.Len(t, nodes, 2)
+			for _, node := range nodes {
+				require.True(t, eu[node.ID])
+			}
+		}
+
+		_, err := service.FindStorageNodesWithPreferences(ctx, overlay.FindStorageNodesRequest{
+			RequestedCount: 3,
+			Placement:      storj.EU,
+		}, &preferences)
+		require.True(t, overlay.ErrNotEnoughNodes.Has(err))
+
+		// excluded countries are honored as well
+		preferences.UploadExcludedCountryCodes = []string{"US"}
+		nodes, err := service.FindStorageNodesWithPreferences(ctx, overlay.FindStorageNodesRequest{
+			RequestedCount: 2,
+		}, &preferences)
+		require.NoError(t, err)
+		for _, node := range nodes {
+			require.True(t, eu[node.ID])
+		}
+	})
+}
+
+func TestFindStorageNodesForUploadPlacementCacheDisabled(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount: 1, StorageNodeCount: 4, UplinkCount: 0,
+		Reconfigure: testplanet.Reconfigure{
+			Satellite: func(log *zap.Logger, index int, config *satellite.Config) {
+				config.Overlay.NodeSelectionCache.Disabled = true
+			},
+		},
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		service := planet.Satellites[0].Overlay.Service
+
+		eu := map[storj.NodeID]bool{}
+		for i, node := range planet.StorageNodes {
+			countryCode := "US"
+			if i < 2 {
+				countryCode = "DE"
+				eu[node.ID()] = true
+			}
+			require.NoError(t, service.TestNodeCountryCode(ctx, node.ID(), countryCode))
+		}
+
+		// selections with a placement use the cache, which needs to see the country codes.
+		require.NoError(t, service.UploadSelectionCache.Refresh(ctx))
+
+		for i := 0; i < 5; i++ {
+			nodes, err := service.FindStorageNodesForUpload(ctx, overlay.FindStorageNodesRequest{
+				RequestedCount: 2,
+				Placement:      storj.EU,
+			})
+			require.NoError(t, err)
+			require.Len(t, nodes, 2)
+			for _, node := range nodes {
+				require.True(t, eu[node.ID])
+			}
+		}
+	})
+}
+
+func TestFindStorageNodesWithPreferencesUploadSuccessTracker(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount: 1, StorageNodeCount: 4, UplinkCount: 0,
+		Reconfigure: testplanet.Reconfigure{
+			Satellite: func(log *zap.Logger, index int, config *satellite.Config) {
+				config.Overlay.UploadSuccessTracker = overlay.UploadSuccessTrackerConfig{
+					Enabled:      true,
+					Window:       time.Hour,
+					MinUploads:   1,
+					ExcludeBelow: 0.5,
+				}
+			},
+		},
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		service := planet.Satellites[0].Overlay.Service
+		require.NotNil(t, service.UploadSuccessTracker)
+
+		slow := planet.StorageNodes[0].ID()
+		service.UploadSuccessTracker.Record(time.Now(), nil, []storj.NodeID{slow})
+
+		// the nodes excluded by the tracker are not selected without the cache either
+		preferences := testNodeSelectionConfig(0, false)
+		for i := 0; i < 5; i++ {
+			nodes, err := service.FindStorageNodesWithPreferences(ctx, overlay.FindStorageNodesRequest{
+				RequestedCount: 3,
+			}, &preferences)
+			require.NoError(t, err)
+			require.Len(t, nodes, 3)
+			for _, node := range nodes {
+				require.NotEqual(t, slow, node.ID)
+			}
+		}
+	})
+}
+
 func TestNodeSelection(t *testing.T) {
 	testplanet.Run(t, testplanet.Config{
 		SatelliteCount: 1, StorageNodeCount: 6, UplinkCount: 1,
@@ -21,6 +21,7 @@ import (
 	"storj.io/storj/satellite/mailservice"
 	"storj.io/storj/satellite/metabase"
 	"storj.io/storj/satellite/nodeevents"
+	"storj.io/storj/satellite/nodeselection/uploadselection"
 )
 
 // ErrEmptyNode is returned when the nodeID is empty.
@@ -66,10 +67,14 @@ type DB interface {
 	KnownUnreliableOrOffline(context.Context, *NodeCriteria, storj.NodeIDList) (storj.NodeIDList, error)
 	// KnownReliableInExcludedCountries filters healthy nodes that are in excluded countries.
 	KnownReliableInExcludedCountries(context.Context, *NodeCriteria, storj.NodeIDList) (storj.NodeIDList, error)
+	// KnownReliableSelectedNodes filters a set of nodes to reliable nodes, returning the information needed for node selection.
+	KnownReliableSelectedNodes(context.Context, *NodeCriteria, storj.NodeIDList) ([]*SelectedNode, error)
 	// KnownReliable filters a set of nodes to reliable (online and qualified) nodes.
 	KnownReliable(ctx context.Context, onlineWindow time.Duration, nodeIDs storj.NodeIDList) ([]*pb.Node, error)
 	// Reliable returns all nodes that are reliable
 	Reliable(context.Context, *NodeCriteria) (storj.NodeIDList, error)
+	// ReliableWithHistory returns all nodes that are reliable, with their audit and uptime history.
+	ReliableWithHistory(context.Context, *NodeCriteria) ([]*NodeHistory, error)
 	// UpdateReputation updates the DB columns for all reputation fields in ReputationStatus.
 	UpdateReputation(ctx context.Context, id storj.NodeID, request ReputationUpdate) error
 	// UpdateNodeInfo updates node dossier with info requested from the node itself like node type, email, wallet, capacity, and version.
@@ -77,10 +82,18 @@ type DB interface {
 	// UpdateCheckIn updates a single storagenode's check-in stats.
 	UpdateCheckIn(ctx context.Context, node NodeCheckInInfo, timestamp time.Time, config NodeSelectionConfig) (err error)
 
+	// UpdateNodeTags replaces all the tags of the node.
+	UpdateNodeTags(ctx context.Context, nodeID storj.NodeID, tags uploadselection.NodeTags) (err error)
+	// GetNodeTags returns the tags of a node.
+	GetNodeTags(ctx context.Context, nodeID storj.NodeID) (tags uploadselection.NodeTags, err error)
+
 	// AllPieceCounts returns a map of node IDs to piece counts from the db.
 	AllPieceCounts(ctx context.Context) (pieceCounts map[storj.NodeID]int64, err error)
 	// UpdatePieceCounts sets the piece count field for the given node IDs.
 	UpdatePieceCounts(ctx context.Context, pieceCounts map[storj.NodeID]int64) (err error)
+	// UpdateBloomFilterRefusals increments the numbers of consecutive refused bloom filters of the
+	// refused nodes and resets them for the reset nodes. It returns the numbers of the refused nodes.
+	UpdateBloomFilterRefusals(ctx context.Context, refused, reset []storj.NodeID) (refusals map[storj.NodeID]int, err error)
 
 	// UpdateExitStatus is used to update a node's graceful exit status.
 	UpdateExitStatus(ctx context.Context, request *ExitStatusRequest) (_ *NodeDossier, err error)
@@ -98,6 +111,10 @@ type DB interface {
 
 	// DisqualifyNode disqualifies a storage node.
 	DisqualifyNode(ctx context.Context, nodeID storj.NodeID, disqualifiedAt time.Time, reason DisqualificationReason) (email string, err error)
+	// SetDisqualificationExplanation stores the explanation of the disqualification of a node, replacing the previous one.
+	SetDisqualificationExplanation(ctx context.Context, explanation DisqualificationExplanation) (err error)
+	// GetDisqualificationExplanation returns the explanation of the latest disqualification of a node.
+	GetDisqualificationExplanation(ctx context.Context, nodeID storj.NodeID) (_ *DisqualificationExplanation, err error)
 
 	// GetOfflineNodesForEmail gets offline nodes in need of an email.
 	GetOfflineNodesForEmail(ctx context.Context, offlineWindow time.Duration, cutoff time.Duration, cooldown time.Duration, limit int) (nodes map[storj.NodeID]string, err error)
@@ -266,6 +283,25 @@ type NodeLastContact struct {
 	LastContactFailure time.Time
 }
 
+// NodeHistory contains the recorded audit and uptime history of a node, which
+// is used for estimating how likely the node is to go down.
+type NodeHistory struct {
+	ID storj.NodeID
+	// OnlineScore is the fraction of the audits, for which the node was online,
+	// over the windows of its audit history.
+	OnlineScore          float64
+	AuditReputationAlpha float64
+	AuditReputationBeta  float64
+}
+
+// AuditScore returns the audit reputation score of the node.
+func (history *NodeHistory) AuditScore() float64 {
+	if history.AuditReputationAlpha+history.AuditReputationBeta <= 0 {
+		return 1
+	}
+	return history.AuditReputationAlpha / (history.AuditReputationAlpha + history.AuditReputationBeta)
+}
+
 // SelectedNode is used as a result for creating orders limits.
 type SelectedNode struct {
 	ID          storj.NodeID
@@ -273,6 +309,9 @@ type SelectedNode struct {
 	LastNet     string
 	LastIPPort  string
 	CountryCode location.CountryCode
+	Wallet      string
+	FreeDisk    int64
+	Tags        uploadselection.NodeTags
 }
 
 // NodeReputation is used as a result for creating orders limits for audits.
@@ -308,8 +347,10 @@ type Service struct {
 	satelliteName    string
 	satelliteAddress string
 	config           Config
+	placementRules   PlacementRules
 
 	GeoIP                  geoip.IPToCountry
+	UploadSuccessTracker   *UploadSuccessTracker
 	UploadSelectionCache   *UploadSelectionCache
 	DownloadSelectionCache *DownloadSelectionCache
 }
@@ -329,8 +370,14 @@ func NewService(log *zap.Logger, db DB, nodeEvents nodeevents.DB, mailService *m
 		}
 	}
 
+	var uploadSuccessTracker *UploadSuccessTracker
+	if config.UploadSuccessTracker.Enabled {
+		uploadSuccessTracker = NewUploadSuccessTracker(config.UploadSuccessTracker)
+	}
+
 	uploadSelectionCache, err := NewUploadSelectionCache(log, db,
-		config.NodeSelectionCache.Staleness, config.Node,
+		config.NodeSelectionCache.Staleness, config.Node, config.Placement.CreateFilters,
+		uploadSuccessTracker,
 	)
 	if err != nil {
 		return nil, errs.Wrap(err)
@@ -352,9 +399,11 @@ func NewService(log *zap.Logger, db DB, nodeEvents nodeevents.DB, mailService *m
 		satelliteAddress: satelliteAddr,
 		satelliteName:    satelliteName,
 		config:           config,
+		placementRules:   config.Placement.CreateFilters,
 
 		GeoIP: geoIP,
 
+		UploadSuccessTracker:   uploadSuccessTracker,
 		UploadSelectionCache:   uploadSelectionCache,
 		DownloadSelectionCache: downloadSelectionCache,
 	}, nil
@@ -422,15 +471,15 @@ func (service *Service) FindStorageNodesForGracefulExit(ctx context.Context, req
 // FindStorageNodesForUpload searches the overlay network for nodes that meet the provided requirements for upload.
 //
 // When enabled it uses the cache to select nodes.
-// When the node selection from the cache fails, it falls back to the old implementation.
+// When the cache is disabled, the nodes are selected by a database query, unless
+// the selection depends on attributes of the nodes, which only the cache keeps.
 func (service *Service) FindStorageNodesForUpload(ctx context.Context, req FindStorageNodesRequest) (_ []*SelectedNode, err error) {
 	defer mon.Task()(&ctx)(&err)
 	if service.config.Node.AsOfSystemTime.Enabled && service.config.Node.AsOfSystemTime.DefaultInterval < 0 {
 		req.AsOfSystemInterval = service.config.Node.AsOfSystemTime.DefaultInterval
 	}
 
-	// TODO excluding country codes on upload if cache is disabled is not implemented
-	if service.config.NodeSelectionCache.Disabled {
+	if service.config.NodeSelectionCache.Disabled && !service.selectsByNodeAttributes(req, &service.config.Node) {
 		return service.FindStorageNodesWithPreferences(ctx, req, &service.config.Node)
 	}
 
@@ -465,6 +514,10 @@ func (service *Service) FindStorageNodesWithPreferences(ctx context.Context, req
 	// TODO: add sanity limits to excluded nodes
 	totalNeededNodes := req.RequestedCount
 
+	if service.selectsByNodeAttributes(req, preferences) {
+		return service.findStorageNodesWithFilter(ctx, req, preferences)
+	}
+
 	excludedIDs := req.ExcludedIDs
 	// if distinctIP is enabled, keep track of the network
 	// to make sure we only select nodes from different networks
@@ -502,6 +555,61 @@ func (service *Service) FindStorageNodesWithPreferences(ctx context.Context, req
 	return nodes, nil
 }
 
+// selectsByNodeAttributes returns whether the selection filters or weights the
+// nodes by their attributes (e.g. country code, tags, upload success rate),
+// which the node selection query doesn't load.
+func (service *Service) selectsByNodeAttributes(req FindStorageNodesRequest, preferences *NodeSelectionConfig) bool {
+	return service.placementRules(req.Placement) != nil ||
+		len(preferences.UploadExcludedCountryCodes) > 0 ||
+		preferences.DistinctTag != "" ||
+		service.UploadSuccessTracker != nil
+}
+
+// findStorageNodesWithFilter selects the nodes from all the nodes qualifying for
+// upload, like the upload selection cache does, without caching them.
+//
+// It reads all the qualifying nodes on every call, hence uploads use the upload
+// selection cache for such selections, even when the cache is disabled.
+func (service *Service) findStorageNodesWithFilter(ctx context.Context, req FindStorageNodesRequest, preferences *NodeSelectionConfig) (_ []*SelectedNode, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	filter := service.placementRules(req.Placement)
+
+	weighter, err := preferences.Weighting.Weighter()
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	if tracker := service.UploadSuccessTracker; tracker != nil {
+		weighter = tracker.Weighter(weighter)
+		if filter == nil {
+			filter = tracker
+		} else {
+			filter = uploadselection.NodeFilters{filter, tracker}
+		}
+	}
+
+	reputableNodes, newNodes, err := service.db.SelectAllStorageNodesUpload(ctx, *preferences)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	state := uploadselection.NewWeightedState(convSelectedNodesToNodes(reputableNodes), convSelectedNodesToNodes(newNodes), weighter)
+	selected, err := state.Select(ctx, uploadselection.Request{
+		Count:                req.RequestedCount,
+		NewFraction:          preferences.NewNodeFraction,
+		Distinct:             preferences.DistinctIP,
+		ExcludedIDs:          req.ExcludedIDs,
+		Placement:            filter,
+		ExcludedCountryCodes: preferences.UploadExcludedCountryCodes,
+		DistinctTag:          preferences.DistinctTag,
+	})
+	if uploadselection.ErrNotEnoughNodes.Has(err) {
+		err = ErrNotEnoughNodes.Wrap(err)
+	}
+
+	return convNodesToSelectedNodes(selected), err
+}
+
 // KnownOffline filters a set of nodes to offline nodes.
 func (service *Service) KnownOffline(ctx context.Context, nodeIds storj.NodeIDList) (offlineNodes storj.NodeIDList, err error) {
 	defer mon.Task()(&ctx)(&err)
@@ -581,6 +689,17 @@ func (service *Service) Reliable(ctx context.Context) (nodes storj.NodeIDList, e
 	return service.db.Reliable(ctx, criteria)
 }
 
+// ReliableWithHistory returns all nodes that are reliable, with their audit and uptime history.
+func (service *Service) ReliableWithHistory(ctx context.Context) (nodes []*NodeHistory, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	criteria := &NodeCriteria{
+		OnlineWindow: service.config.Node.OnlineWindow,
+	}
+	criteria.ExcludedCountries = service.config.RepairExcludedCountryCodes
+	return service.db.ReliableWithHistory(ctx, criteria)
+}
+
 // UpdateReputation updates the DB columns for any of the reputation fields.
 func (service *Service) UpdateReputation(ctx context.Context, id storj.NodeID, email string, request ReputationUpdate, reputationChanges []nodeevents.Type) (err error) {
 	defer mon.Task()(&ctx)(&err)
@@ -603,6 +722,18 @@ func (service *Service) UpdateNodeInfo(ctx context.Context, node storj.NodeID, n
 	return service.db.UpdateNodeInfo(ctx, node, nodeInfo)
 }
 
+// UpdateNodeTags replaces all the tags of the node.
+func (service *Service) UpdateNodeTags(ctx context.Context, nodeID storj.NodeID, tags uploadselection.NodeTags) (err error) {
+	defer mon.Task()(&ctx)(&err)
+	return service.db.UpdateNodeTags(ctx, nodeID, tags)
+}
+
+// GetNodeTags returns the tags of a node.
+func (service *Service) GetNodeTags(ctx context.Context, nodeID storj.NodeID) (_ uploadselection.NodeTags, err error) {
+	defer mon.Task()(&ctx)(&err)
+	return service.db.GetNodeTags(ctx, nodeID)
+}
+
 // UpdateCheckIn updates a single storagenode's check-in info if needed.
 /*
 The check-in info is updated in the database if:
@@ -745,8 +876,9 @@ func (service *Service) GetMissingPieces(ctx context.Context, pieces metabase.Pi
 	return missingPieces, nil
 }
 
-// GetReliablePiecesInExcludedCountries returns the list of pieces held by nodes located in excluded countries.
-func (service *Service) GetReliablePiecesInExcludedCountries(ctx context.Context, pieces metabase.Pieces) (piecesInExcluded []uint16, err error) {
+// GetReliablePiecesInExcludedCountries returns the list of pieces held by reliable nodes located in excluded
+// countries or not allowed by the placement rule of the segment.
+func (service *Service) GetReliablePiecesInExcludedCountries(ctx context.Context, pieces metabase.Pieces, placement storj.PlacementConstraint) (piecesInExcluded []uint16, err error) {
 	defer mon.Task()(&ctx)(&err)
 	var nodeIDs storj.NodeIDList
 	for _, p := range pieces {
@@ -757,13 +889,30 @@ func (service *Service) GetReliablePiecesInExcludedCountries(ctx context.Context
 		return nil, Error.New("error getting nodes %s", err)
 	}
 
-	for _, p := range pieces {
-		for _, nodeID := range inExcluded {
-			if nodeID == p.StorageNode {
-				piecesInExcluded = append(piecesInExcluded, p.Number)
+	excluded := map[storj.NodeID]bool{}
+	for _, nodeID := range inExcluded {
+		excluded[nodeID] = true
+	}
+
+	if filter := service.placementRules(placement); filter != nil && len(nodeIDs) > 0 {
+		reliable, err := service.db.KnownReliableSelectedNodes(ctx, &NodeCriteria{
+			OnlineWindow: service.config.Node.OnlineWindow,
+		}, nodeIDs)
+		if err != nil {
+			return nil, Error.New("error getting nodes %s", err)
+		}
+		for _, node := range convSelectedNodesToNodes(reliable) {
+			if !filter.MatchInclude(node) {
+				excluded[node.ID] = true
 			}
 		}
 	}
+
+	for _, p := range pieces {
+		if excluded[p.StorageNode] {
+			piecesInExcluded = append(piecesInExcluded, p.Number)
+		}
+	}
 	return piecesInExcluded, nil
 }
 
@@ -775,6 +924,10 @@ func (service *Service) DQNodesLastSeenBefore(ctx context.Context, cutoff time.T
 	if err != nil {
 		return 0, err
 	}
+	disqualifiedAt := time.Now().UTC()
+	for nodeID := range nodes {
+		service.explainDisqualification(ctx, nodeID, disqualifiedAt, DisqualificationReasonNodeOffline)
+	}
 	if service.config.SendNodeEmails {
 		for nodeID, email := range nodes {
 			_, err = service.nodeEvents.Insert(ctx, email, nodeID, nodeevents.Disqualified)
@@ -789,10 +942,12 @@ func (service *Service) DQNodesLastSeenBefore(ctx context.Context, cutoff time.T
 // DisqualifyNode disqualifies a storage node.
 func (service *Service) DisqualifyNode(ctx context.Context, nodeID storj.NodeID, reason DisqualificationReason) (err error) {
 	defer mon.Task()(&ctx)(&err)
-	email, err := service.db.DisqualifyNode(ctx, nodeID, time.Now().UTC(), reason)
+	disqualifiedAt := time.Now().UTC()
+	email, err := service.db.DisqualifyNode(ctx, nodeID, disqualifiedAt, reason)
 	if err != nil {
 		return err
 	}
+	service.explainDisqualification(ctx, nodeID, disqualifiedAt, reason)
 	if service.config.SendNodeEmails {
 		_, err = service.nodeEvents.Insert(ctx, email, nodeID, nodeevents.Disqualified)
 		if err != nil {
@@ -881,6 +881,35 @@ func TestReliable(t *testing.T) {
 	})
 }
 
+func TestReliableWithHistory(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount: 1, StorageNodeCount: 2, UplinkCount: 0,
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		service := planet.Satellites[0].Overlay.Service
+		node := planet.StorageNodes[0]
+
+		nodes, err := service.ReliableWithHistory(ctx)
+		require.NoError(t, err)
+		require.Len(t, nodes, 2)
+
+		for _, history := range nodes {
+			info, err := planet.Satellites[0].Reputation.Service.Get(ctx, history.ID)
+			require.NoError(t, err)
+			require.Equal(t, info.OnlineScore, history.OnlineScore)
+			require.Equal(t, info.AuditReputationAlpha/(info.AuditReputationAlpha+info.AuditReputationBeta), history.AuditScore())
+		}
+
+		err = service.TestNodeCountryCode(ctx, node.ID(), "FR")
+		require.NoError(t, err)
+
+		// first node should be excluded because of country code
+		nodes, err = service.ReliableWithHistory(ctx)
+		require.NoError(t, err)
+		require.Len(t, nodes, 1)
+		require.NotEqual(t, node.ID(), nodes[0].ID)
+	})
+}
+
 func TestKnownReliableInExcludedCountries(t *testing.T) {
 	testplanet.Run(t, testplanet.Config{
 		SatelliteCount: 1, StorageNodeCount: 2, UplinkCount: 0,
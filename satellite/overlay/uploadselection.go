@@ -25,7 +25,7 @@ type UploadSelectionDB interface {
 
 // UploadSelectionCacheConfig is a configuration for upload selection cache.
 type UploadSelectionCacheConfig struct {
-	Disabled  bool          `help:"disable node cache" default:"false"`
+	Disabled  bool          `help:"disable node cache, except for selections filtered by node attributes" default:"false"`
 	Staleness time.Duration `help:"how stale the node selection cache can be" releaseDefault:"3m" devDefault:"5m" testDefault:"3m"`
 }
 
@@ -36,16 +36,28 @@ type UploadSelectionCache struct {
 	log             *zap.Logger
 	db              UploadSelectionDB
 	selectionConfig NodeSelectionConfig
+	placementRules  PlacementRules
+	weighter        uploadselection.NodeWeighter
+	tracker         *UploadSuccessTracker
 
 	cache sync2.ReadCache
 }
 
 // NewUploadSelectionCache creates a new cache that keeps a list of all the storage nodes that are qualified to store data.
-func NewUploadSelectionCache(log *zap.Logger, db UploadSelectionDB, staleness time.Duration, config NodeSelectionConfig) (*UploadSelectionCache, error) {
+// The upload success tracker is optional, when it's set the nodes are weighted and excluded by their upload success rate.
+func NewUploadSelectionCache(log *zap.Logger, db UploadSelectionDB, staleness time.Duration, config NodeSelectionConfig, placementRules PlacementRules, tracker *UploadSuccessTracker) (*UploadSelectionCache, error) {
+	weighter, err := config.Weighting.Weighter()
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
 	cache := &UploadSelectionCache{
 		log:             log,
 		db:              db,
 		selectionConfig: config,
+		placementRules:  placementRules,
+		weighter:        weighter,
+		tracker:         tracker,
 	}
 	return cache, cache.cache.Init(staleness/2, staleness, cache.read)
 }
@@ -74,7 +86,12 @@ func (cache *UploadSelectionCache) read(ctx context.Context) (_ interface{}, err
 		return nil, Error.Wrap(err)
 	}
 
-	state := uploadselection.NewState(convSelectedNodesToNodes(reputableNodes), convSelectedNodesToNodes(newNodes))
+	weighter := cache.weighter
+	if cache.tracker != nil {
+		weighter = cache.tracker.Weighter(weighter)
+	}
+
+	state := uploadselection.NewWeightedState(convSelectedNodesToNodes(reputableNodes), convSelectedNodesToNodes(newNodes), weighter)
 
 	mon.IntVal("refresh_cache_size_reputable").Observe(int64(len(reputableNodes)))
 	mon.IntVal("refresh_cache_size_new").Observe(int64(len(newNodes)))
@@ -94,13 +111,23 @@ func (cache *UploadSelectionCache) GetNodes(ctx context.Context, req FindStorage
 	}
 	state := stateAny.(*uploadselection.State)
 
+	filter := cache.placementRules(req.Placement)
+	if cache.tracker != nil {
+		if filter == nil {
+			filter = cache.tracker
+		} else {
+			filter = uploadselection.NodeFilters{filter, cache.tracker}
+		}
+	}
+
 	selected, err := state.Select(ctx, uploadselection.Request{
 		Count:                req.RequestedCount,
 		NewFraction:          cache.selectionConfig.NewNodeFraction,
 		Distinct:             cache.selectionConfig.DistinctIP,
 		ExcludedIDs:          req.ExcludedIDs,
-		Placement:            req.Placement,
+		Placement:            filter,
 		ExcludedCountryCodes: cache.selectionConfig.UploadExcludedCountryCodes,
+		DistinctTag:          cache.selectionConfig.DistinctTag,
 	})
 	if uploadselection.ErrNotEnoughNodes.Has(err) {
 		err = ErrNotEnoughNodes.Wrap(err)
@@ -128,6 +155,9 @@ func convNodesToSelectedNodes(nodes []*uploadselection.Node) (xs []*SelectedNode
 			LastNet:     n.LastNet,
 			LastIPPort:  n.LastIPPort,
 			CountryCode: n.CountryCode,
+			Wallet:      n.Wallet,
+			FreeDisk:    n.FreeDisk,
+			Tags:        n.Tags,
 		})
 	}
 	return xs
@@ -143,6 +173,9 @@ func convSelectedNodesToNodes(nodes []*SelectedNode) (xs []*uploadselection.Node
 			LastNet:     n.LastNet,
 			LastIPPort:  n.LastIPPort,
 			CountryCode: n.CountryCode,
+			Wallet:      n.Wallet,
+			FreeDisk:    n.FreeDisk,
+			Tags:        n.Tags,
 		})
 	}
 	return xs
@@ -57,6 +57,8 @@ func TestRefresh(t *testing.T) {
 			db.OverlayCache(),
 			lowStaleness,
 			nodeSelectionConfig,
+			overlay.NewPlacementRules().CreateFilters,
+			nil,
 		)
 		require.NoError(t, err)
 
@@ -160,6 +162,8 @@ func TestRefreshConcurrent(t *testing.T) {
 		&mockDB,
 		highStaleness,
 		nodeSelectionConfig,
+		overlay.NewPlacementRules().CreateFilters,
+		nil,
 	)
 	require.NoError(t, err)
 
@@ -185,6 +189,8 @@ func TestRefreshConcurrent(t *testing.T) {
 		&mockDB,
 		lowStaleness,
 		nodeSelectionConfig,
+		overlay.NewPlacementRules().CreateFilters,
+		nil,
 	)
 	require.NoError(t, err)
 	ctx.Go(func() error { return cache.Run(cacheCtx) })
@@ -213,6 +219,8 @@ func TestGetNodes(t *testing.T) {
 			db.OverlayCache(),
 			lowStaleness,
 			nodeSelectionConfig,
+			overlay.NewPlacementRules().CreateFilters,
+			nil,
 		)
 		require.NoError(t, err)
 
@@ -299,6 +307,8 @@ func TestGetNodesConcurrent(t *testing.T) {
 		&mockDB,
 		highStaleness,
 		nodeSelectionConfig,
+		overlay.NewPlacementRules().CreateFilters,
+		nil,
 	)
 	require.NoError(t, err)
 
@@ -344,6 +354,8 @@ func TestGetNodesConcurrent(t *testing.T) {
 		&mockDB,
 		lowStaleness,
 		nodeSelectionConfig,
+		overlay.NewPlacementRules().CreateFilters,
+		nil,
 	)
 	require.NoError(t, err)
 
@@ -434,6 +446,8 @@ func TestGetNodesDistinct(t *testing.T) {
 			&mockDB,
 			highStaleness,
 			config,
+			overlay.NewPlacementRules().CreateFilters,
+			nil,
 		)
 		require.NoError(t, err)
 
@@ -467,6 +481,8 @@ func TestGetNodesDistinct(t *testing.T) {
 			&mockDB,
 			highStaleness,
 			config,
+			overlay.NewPlacementRules().CreateFilters,
+			nil,
 		)
 		require.NoError(t, err)
 
@@ -490,6 +506,8 @@ func TestGetNodesError(t *testing.T) {
 		&mockDB,
 		highStaleness,
 		nodeSelectionConfig,
+		overlay.NewPlacementRules().CreateFilters,
+		nil,
 	)
 	require.NoError(t, err)
 
@@ -523,6 +541,8 @@ func TestNewNodeFraction(t *testing.T) {
 			db.OverlayCache(),
 			lowStaleness,
 			nodeSelectionConfig,
+			overlay.NewPlacementRules().CreateFilters,
+			nil,
 		)
 		require.NoError(t, err)
 
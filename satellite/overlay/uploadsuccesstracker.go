@@ -0,0 +1,206 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package overlay
+
+import (
+	"sync"
+	"time"
+
+	"storj.io/common/storj"
+	"storj.io/storj/satellite/nodeselection/uploadselection"
+)
+
+// UploadSuccessTrackerConfig is a configuration for the upload success tracker.
+type UploadSuccessTrackerConfig struct {
+	Enabled      bool          `help:"track which selected nodes get their pieces committed and use it in upload node selection" default:"false"`
+	Window       time.Duration `help:"the duration of the rolling window of the tracked uploads" default:"1h"`
+	MinUploads   int           `help:"the minimum number of tracked uploads of a node before its success rate is used" default:"20"`
+	ExcludeBelow float64       `help:"nodes with a lower upload success rate are excluded from upload node selection" default:"0.1"`
+}
+
+// uploadSuccessTrackerShards is the number of the independently locked
+// shards of the tracked nodes, so the lookups of the node selection don't
+// contend on a single lock.
+const uploadSuccessTrackerShards = 64
+
+// uploadCounts contains the number of the tracked uploads of a node.
+type uploadCounts struct {
+	success int
+	failure int
+}
+
+// uploadSuccessShard contains the tracked uploads of a subset of the nodes.
+type uploadSuccessShard struct {
+	mu          sync.RWMutex
+	windowStart time.Time
+	current     map[storj.NodeID]*uploadCounts
+	previous    map[storj.NodeID]*uploadCounts
+}
+
+// UploadSuccessTracker tracks per node whether the pieces of the uploads
+// were committed with the segment, i.e. the node didn't lose the long tail
+// race. The tracked uploads are kept for a rolling window between one and
+// two window durations.
+//
+// Nodes with a lower success rate are down-weighted in upload node selection
+// and excluded when the success rate is below the configured limit.
+//
+// The nodes are split into shards by their ID. Recording takes the write lock
+// of the shards of the nodes, while the lookups of the node selection only
+// take a read lock of a single shard.
+//
+// architecture: Service
+type UploadSuccessTracker struct {
+	config UploadSuccessTrackerConfig
+
+	shards [uploadSuccessTrackerShards]uploadSuccessShard
+}
+
+var _ uploadselection.NodeFilter = (*UploadSuccessTracker)(nil)
+
+// NewUploadSuccessTracker creates a new upload success tracker.
+func NewUploadSuccessTracker(config UploadSuccessTrackerConfig) *UploadSuccessTracker {
+	tracker := &UploadSuccessTracker{
+		config: config,
+	}
+	for i := range tracker.shards {
+		tracker.shards[i].current = map[storj.NodeID]*uploadCounts{}
+		tracker.shards[i].previous = map[storj.NodeID]*uploadCounts{}
+	}
+	return tracker
+}
+
+// shard returns the shard of the node.
+func (tracker *UploadSuccessTracker) shard(nodeID storj.NodeID) *uploadSuccessShard {
+	return &tracker.shards[int(nodeID[0])%uploadSuccessTrackerShards]
+}
+
+// Record records the nodes which got their pieces committed and the ones
+// which were selected for the upload but did not.
+func (tracker *UploadSuccessTracker) Record(now time.Time, successful, failed []storj.NodeID) {
+	record := func(nodeID storj.NodeID, success bool) {
+		shard := tracker.shard(nodeID)
+		shard.mu.Lock()
+		defer shard.mu.Unlock()
+
+		shard.rotate(now, tracker.config.Window)
+		counts := shard.counts(nodeID)
+		if success {
+			counts.success++
+		} else {
+			counts.failure++
+		}
+	}
+
+	for _, nodeID := range successful {
+		record(nodeID, true)
+	}
+	for _, nodeID := range failed {
+		record(nodeID, false)
+	}
+
+	mon.IntVal("upload_success_tracker_success").Observe(int64(len(successful)))
+	mon.IntVal("upload_success_tracker_failure").Observe(int64(len(failed)))
+}
+
+// SuccessRate returns the rate of the tracked uploads of the node which
+// got committed. ok is false when there are not enough tracked uploads.
+func (tracker *UploadSuccessTracker) SuccessRate(now time.Time, nodeID storj.NodeID) (rate float64, ok bool) {
+	shard := tracker.shard(nodeID)
+	shard.mu.RLock()
+	defer shard.mu.RUnlock()
+
+	var success, total int
+	for _, window := range shard.windows(now, tracker.config.Window) {
+		if counts, found := window[nodeID]; found {
+			success += counts.success
+			total += counts.success + counts.failure
+		}
+	}
+
+	if total == 0 || total < tracker.config.MinUploads {
+		return 0, false
+	}
+	return float64(success) / float64(total), true
+}
+
+// Weight returns the success rate of the node, or 1 when there are not
+// enough tracked uploads of the node.
+func (tracker *UploadSuccessTracker) Weight(node *uploadselection.Node) float64 {
+	rate, ok := tracker.SuccessRate(time.Now(), node.ID)
+	if !ok {
+		return 1
+	}
+	return rate
+}
+
+// MatchInclude implements uploadselection.NodeFilter, it excludes the nodes
+// with a success rate below the configured limit.
+func (tracker *UploadSuccessTracker) MatchInclude(node *uploadselection.Node) bool {
+	rate, ok := tracker.SuccessRate(time.Now(), node.ID)
+	return !ok || rate >= tracker.config.ExcludeBelow
+}
+
+// Weighter returns a node weighter, which multiplies the weight of the base
+// weighter with the success rate. A nil base weighter gives every node
+// equal weight.
+func (tracker *UploadSuccessTracker) Weighter(base uploadselection.NodeWeighter) uploadselection.NodeWeighter {
+	return uploadselection.NodeWeighterFunc(func(node *uploadselection.Node) float64 {
+		weight := tracker.Weight(node)
+		if base != nil {
+			weight *= base.Weight(node)
+		}
+		return weight
+	})
+}
+
+// counts returns the counts of the node in the current window.
+func (shard *uploadSuccessShard) counts(nodeID storj.NodeID) *uploadCounts {
+	counts, ok := shard.current[nodeID]
+	if !ok {
+		counts = &uploadCounts{}
+		shard.current[nodeID] = counts
+	}
+	return counts
+}
+
+// windows returns the windows, which are not expired at now, without
+// rotating them.
+func (shard *uploadSuccessShard) windows(now time.Time, window time.Duration) []map[storj.NodeID]*uploadCounts {
+	if shard.windowStart.IsZero() {
+		return nil
+	}
+
+	elapsed := now.Sub(shard.windowStart)
+	switch {
+	case elapsed < window:
+		return []map[storj.NodeID]*uploadCounts{shard.current, shard.previous}
+	case elapsed < 2*window:
+		// the current window would become the previous one.
+		return []map[storj.NodeID]*uploadCounts{shard.current}
+	default:
+		return nil
+	}
+}
+
+// rotate starts a new window when the current one has expired.
+func (shard *uploadSuccessShard) rotate(now time.Time, window time.Duration) {
+	if shard.windowStart.IsZero() {
+		shard.windowStart = now
+		return
+	}
+
+	elapsed := now.Sub(shard.windowStart)
+	if elapsed < window {
+		return
+	}
+
+	if elapsed < 2*window {
+		shard.previous = shard.current
+	} else {
+		shard.previous = map[storj.NodeID]*uploadCounts{}
+	}
+	shard.current = map[storj.NodeID]*uploadCounts{}
+	shard.windowStart = now
+}
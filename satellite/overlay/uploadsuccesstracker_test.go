@@ -0,0 +1,130 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package overlay_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/sync/errgroup"
+
+	"storj.io/common/storj"
+	"storj.io/common/testrand"
+	"storj.io/storj/satellite/nodeselection/uploadselection"
+	"storj.io/storj/satellite/overlay"
+)
+
+func TestUploadSuccessTracker(t *testing.T) {
+	tracker := overlay.NewUploadSuccessTracker(overlay.UploadSuccessTrackerConfig{
+		Enabled:      true,
+		Window:       time.Hour,
+		MinUploads:   4,
+		ExcludeBelow: 0.3,
+	})
+
+	good, slow, unknown := testrand.NodeID(), testrand.NodeID(), testrand.NodeID()
+
+	now := time.Now()
+	for i := 0; i < 4; i++ {
+		failed := []storj.NodeID{slow}
+		if i == 0 {
+			failed = nil
+		}
+		successful := []storj.NodeID{good}
+		if i == 0 {
+			successful = append(successful, slow)
+		}
+		tracker.Record(now, successful, failed)
+	}
+
+	rate, ok := tracker.SuccessRate(now, good)
+	require.True(t, ok)
+	require.Equal(t, 1.0, rate)
+
+	rate, ok = tracker.SuccessRate(now, slow)
+	require.True(t, ok)
+	require.Equal(t, 0.25, rate)
+
+	_, ok = tracker.SuccessRate(now, unknown)
+	require.False(t, ok)
+
+	// the uploads are still used in the next window.
+	rate, ok = tracker.SuccessRate(now.Add(90*time.Minute), slow)
+	require.True(t, ok)
+	require.Equal(t, 0.25, rate)
+
+	// the uploads expire after two windows.
+	_, ok = tracker.SuccessRate(now.Add(4*time.Hour), slow)
+	require.False(t, ok)
+}
+
+func TestUploadSuccessTracker_Selection(t *testing.T) {
+	tracker := overlay.NewUploadSuccessTracker(overlay.UploadSuccessTrackerConfig{
+		Enabled:      true,
+		Window:       time.Hour,
+		MinUploads:   2,
+		ExcludeBelow: 0.3,
+	})
+
+	good := &uploadselection.Node{NodeURL: storj.NodeURL{ID: testrand.NodeID()}, FreeDisk: 100}
+	average := &uploadselection.Node{NodeURL: storj.NodeURL{ID: testrand.NodeID()}, FreeDisk: 100}
+	bad := &uploadselection.Node{NodeURL: storj.NodeURL{ID: testrand.NodeID()}, FreeDisk: 100}
+	unknown := &uploadselection.Node{NodeURL: storj.NodeURL{ID: testrand.NodeID()}, FreeDisk: 100}
+
+	tracker.Record(time.Now(), []storj.NodeID{good.ID, average.ID}, []storj.NodeID{bad.ID})
+	tracker.Record(time.Now(), []storj.NodeID{good.ID}, []storj.NodeID{average.ID, bad.ID})
+
+	require.True(t, tracker.MatchInclude(good))
+	require.True(t, tracker.MatchInclude(average))
+	require.False(t, tracker.MatchInclude(bad))
+	require.True(t, tracker.MatchInclude(unknown))
+
+	weighter := tracker.Weighter(nil)
+	require.Equal(t, 1.0, weighter.Weight(good))
+	require.Equal(t, 0.5, weighter.Weight(average))
+	require.Equal(t, 0.0, weighter.Weight(bad))
+	require.Equal(t, 1.0, weighter.Weight(unknown))
+
+	weighter = tracker.Weighter(uploadselection.FreeDiskWeight)
+	require.Equal(t, 100.0, weighter.Weight(good))
+	require.Equal(t, 50.0, weighter.Weight(average))
+}
+
+func TestUploadSuccessTracker_Concurrent(t *testing.T) {
+	tracker := overlay.NewUploadSuccessTracker(overlay.UploadSuccessTrackerConfig{
+		Enabled:      true,
+		Window:       time.Hour,
+		MinUploads:   1,
+		ExcludeBelow: 0.3,
+	})
+
+	nodes := make([]*uploadselection.Node, 100)
+	for i := range nodes {
+		nodes[i] = &uploadselection.Node{NodeURL: storj.NodeURL{ID: testrand.NodeID()}}
+	}
+
+	var group errgroup.Group
+	for i := 0; i < 4; i++ {
+		group.Go(func() error {
+			for _, node := range nodes {
+				tracker.Record(time.Now(), []storj.NodeID{node.ID}, nil)
+			}
+			return nil
+		})
+		group.Go(func() error {
+			for _, node := range nodes {
+				_ = tracker.MatchInclude(node)
+			}
+			return nil
+		})
+	}
+	require.NoError(t, group.Wait())
+
+	for _, node := range nodes {
+		rate, ok := tracker.SuccessRate(time.Now(), node.ID)
+		require.True(t, ok)
+		require.Equal(t, 1.0, rate)
+	}
+}
@@ -18,8 +18,13 @@ import (
 	"storj.io/common/storj"
 	"storj.io/private/debug"
 	"storj.io/storj/private/lifecycle"
+	"storj.io/storj/satellite/audit"
+	"storj.io/storj/satellite/gc/bloomfilter"
+	"storj.io/storj/satellite/mailservice"
 	"storj.io/storj/satellite/metabase"
 	"storj.io/storj/satellite/metabase/rangedloop"
+	"storj.io/storj/satellite/overlay"
+	"storj.io/storj/satellite/repair/checker"
 )
 
 // RangedLoop is the satellite ranged loop process.
@@ -38,6 +43,24 @@ type RangedLoop struct {
 		Server   *debug.Server
 	}
 
+	Mail *mailservice.Service
+
+	Overlay struct {
+		Service *overlay.Service
+	}
+
+	Repair struct {
+		Observer *checker.RangedLoopObserver
+	}
+
+	Audit struct {
+		Observer *audit.RangedLoopObserver
+	}
+
+	GarbageCollectionBF struct {
+		Observer *bloomfilter.Observer
+	}
+
 	RangedLoop struct {
 		Service *rangedloop.Service
 	}
@@ -73,13 +96,78 @@ func NewRangedLoop(log *zap.Logger, full *identity.FullIdentity, db DB, metabase
 		})
 	}
 
+	{ // setup mail
+		var err error
+		peer.Mail, err = setupMailService(peer.Log, *config)
+		if err != nil {
+			return nil, errs.Combine(err, peer.Close())
+		}
+
+		peer.Services.Add(lifecycle.Item{
+			Name:  "mail:service",
+			Close: peer.Mail.Close,
+		})
+	}
+
+	{ // setup overlay
+		var err error
+		peer.Overlay.Service, err = overlay.NewService(log.Named("overlay"), db.OverlayCache(), db.NodeEvents(), peer.Mail, config.Console.ExternalAddress, config.Console.SatelliteName, config.Overlay)
+		if err != nil {
+			return nil, errs.Combine(err, peer.Close())
+		}
+		peer.Services.Add(lifecycle.Item{
+			Name:  "overlay",
+			Run:   peer.Overlay.Service.Run,
+			Close: peer.Overlay.Service.Close,
+		})
+	}
+
+	var observers []rangedloop.Observer
+
+	if config.Checker.UseRangedLoop { // setup repair checker observer
+		peer.Repair.Observer = checker.NewRangedLoopObserver(
+			log.Named("repair:checker"),
+			db.RepairQueue(),
+			peer.Overlay.Service,
+			config.Checker,
+		)
+		observers = append(observers, rangedloop.NewIntervalObserver(peer.Repair.Observer, config.Checker.Interval))
+	}
+
+	if config.Audit.UseRangedLoop { // setup audit observer
+		peer.Audit.Observer = audit.NewRangedLoopObserver(
+			log.Named("audit:chore"),
+			db.VerifyQueue(),
+			db.Reputation(),
+			config.Audit,
+		)
+		observers = append(observers, rangedloop.NewIntervalObserver(peer.Audit.Observer, config.Audit.ChoreInterval))
+	}
+
+	if config.GarbageCollectionBF.UseRangedLoop && config.GarbageCollectionBF.Enabled { // setup garbage collection bloom filter observer
+		if err := config.GarbageCollectionBF.Validate(); err != nil {
+			return nil, errs.Combine(err, peer.Close())
+		}
+
+		peer.GarbageCollectionBF.Observer = bloomfilter.NewObserver(
+			log.Named("garbage-collection-bf"),
+			config.GarbageCollectionBF,
+			db.OverlayCache(),
+		)
+		observers = append(observers, rangedloop.NewIntervalObserver(peer.GarbageCollectionBF.Observer, config.GarbageCollectionBF.Interval))
+	}
+
 	{ // setup ranged loop
-		peer.RangedLoop.Service = rangedloop.NewService(log.Named("rangedloop"), config.RangedLoop, metabaseDB, nil)
+		peer.RangedLoop.Service = rangedloop.NewService(log.Named("rangedloop"), config.RangedLoop, metabaseDB, observers)
 
 		peer.Services.Add(lifecycle.Item{
-			Name: "rangeloop",
-			Run:  peer.RangedLoop.Service.Run,
+			Name:  "rangeloop",
+			Run:   peer.RangedLoop.Service.Run,
+			Close: peer.RangedLoop.Service.Close,
 		})
+
+		peer.Debug.Server.Panel.Add(
+			debug.Cycle("Ranged Loop", peer.RangedLoop.Service.Loop))
 	}
 
 	return peer, nil
@@ -54,7 +54,7 @@ func NewChecker(logger *zap.Logger, repairQueue queue.RepairQueue, metabase *met
 		repairQueue:          repairQueue,
 		metabase:             metabase,
 		segmentLoop:          segmentLoop,
-		nodestate:            NewReliabilityCache(overlay, config.ReliabilityCacheStaleness),
+		nodestate:            NewReliabilityCache(overlay, config.ReliabilityCacheStaleness, NewNodeFailureModel(config)),
 		statsCollector:       newStatsCollector(),
 		repairOverrides:      config.RepairOverrides.GetMap(),
 		nodeFailureRate:      config.NodeFailureRate,
@@ -77,8 +77,12 @@ func (checker *Checker) Run(ctx context.Context) (err error) {
 // We can't calculate this upon first starting a Checker, because there may not be any
 // nodes yet. We expect that there will be nodes before there are segments, though.
 func (checker *Checker) getNodesEstimate(ctx context.Context) (int, error) {
+	return getNodesEstimate(ctx, checker.nodestate)
+}
+
+func getNodesEstimate(ctx context.Context, nodestate *ReliabilityCache) (int, error) {
 	// this should be safe to call frequently; it is an efficient caching lookup.
-	totalNumNodes, err := checker.nodestate.NumNodes(ctx)
+	totalNumNodes, err := nodestate.NumNodes(ctx)
 	if err != nil {
 		// We could proceed here by returning the last good value, or by returning a fallback
 		// constant estimate, like "20000", and we'd probably be fine, but it would be better
@@ -263,7 +267,7 @@ func (obs *checkerObserver) RemoteSegment(ctx context.Context, segment *segmentl
 		return Error.New("could not get estimate of total number of nodes: %w", err)
 	}
 
-	missingPieces, err := obs.nodestate.MissingPieces(ctx, segment.CreatedAt, segment.Pieces)
+	missingPieces, failureRates, err := obs.nodestate.PieceFailureRates(ctx, segment.CreatedAt, segment.Pieces)
 	if err != nil {
 		obs.monStats.remoteSegmentsFailedToCheck++
 		stats.iterationAggregates.remoteSegmentsFailedToCheck++
@@ -283,6 +287,9 @@ func (obs *checkerObserver) RemoteSegment(ctx context.Context, segment *segmentl
 	required, repairThreshold, successThreshold, _ := obs.loadRedundancy(segment.Redundancy)
 
 	segmentHealth := repair.SegmentHealth(numHealthy, required, totalNumNodes, obs.nodeFailureRate)
+	if failureRates != nil {
+		segmentHealth = repair.AdjustSegmentHealth(segmentHealth, failureRates, required, obs.nodeFailureRate)
+	}
 	mon.FloatVal("checker_segment_health").Observe(segmentHealth) //mon:locked
 	stats.segmentHealth.Observe(segmentHealth)
 
@@ -370,6 +377,22 @@ func (obs *checkerObserver) RemoteSegment(ctx context.Context, segment *segmentl
 	return nil
 }
 
+// Process is called repeatedly with batches of segments by the ranged loop.
+func (obs *checkerObserver) Process(ctx context.Context, segments []segmentloop.Segment) (err error) {
+	for i := range segments {
+		segment := &segments[i]
+		if segment.Inline() {
+			err = obs.InlineSegment(ctx, segment)
+		} else {
+			err = obs.RemoteSegment(ctx, segment)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (obs *checkerObserver) InlineSegment(ctx context.Context, segment *segmentloop.Segment) (err error) {
 	// inline segments are not repaired but we would like to count as checked also
 	// objects that have only inline segments
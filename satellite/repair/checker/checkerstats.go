@@ -5,6 +5,7 @@ package checker
 
 import (
 	"fmt"
+	"sync"
 
 	"github.com/spacemonkeygo/monkit/v3"
 
@@ -15,7 +16,11 @@ import (
 // seen by the checker. These are chained into the monkit scope for
 // monitoring as they are initialized.
 type statsCollector struct {
+	mu    sync.Mutex
 	stats map[string]*stats
+
+	// parent is set for collectors created with fork.
+	parent *statsCollector
 }
 
 func newStatsCollector() *statsCollector {
@@ -25,19 +30,56 @@ func newStatsCollector() *statsCollector {
 }
 
 func (collector *statsCollector) getStatsByRS(rs string) *stats {
+	collector.mu.Lock()
+	defer collector.mu.Unlock()
+
 	stats, ok := collector.stats[rs]
 	if !ok {
-		stats = newStats(rs)
-		mon.Chain(stats)
+		if collector.parent != nil {
+			// share the monkit metrics with the parent, but tally the
+			// iteration aggregates separately.
+			shared := *collector.parent.getStatsByRS(rs)
+			shared.iterationAggregates = new(aggregateStats)
+			stats = &shared
+		} else {
+			stats = newStats(rs)
+			mon.Chain(stats)
+		}
 		collector.stats[rs] = stats
 	}
 	return stats
 }
 
+// fork creates a collector which can be used concurrently with other forks.
+// The iteration aggregates of the fork need to be merged back with join.
+func (collector *statsCollector) fork() *statsCollector {
+	return &statsCollector{
+		stats:  make(map[string]*stats),
+		parent: collector,
+	}
+}
+
+// join merges the iteration aggregates of a forked collector.
+func (collector *statsCollector) join(fork *statsCollector) {
+	fork.mu.Lock()
+	defer fork.mu.Unlock()
+
+	for rs, forkStats := range fork.stats {
+		stats := collector.getStatsByRS(rs)
+
+		collector.mu.Lock()
+		stats.iterationAggregates.combine(forkStats.iterationAggregates)
+		collector.mu.Unlock()
+	}
+}
+
 // collectAggregates transfers the iteration aggregates into the
 // respective stats monkit metrics at the end of each checker iteration.
 // iterationAggregates is then cleared.
 func (collector *statsCollector) collectAggregates() {
+	collector.mu.Lock()
+	defer collector.mu.Unlock()
+
 	for _, stats := range collector.stats {
 		stats.collectAggregates()
 		stats.iterationAggregates = new(aggregateStats)
@@ -90,6 +132,24 @@ type aggregateStats struct {
 	remoteSegmentsOverThreshold [5]int64
 }
 
+// combine adds the tallies of other to stats.
+func (stats *aggregateStats) combine(other *aggregateStats) {
+	stats.objectsChecked += other.objectsChecked
+	stats.remoteSegmentsChecked += other.remoteSegmentsChecked
+	stats.remoteSegmentsNeedingRepair += other.remoteSegmentsNeedingRepair
+	stats.newRemoteSegmentsNeedingRepair += other.newRemoteSegmentsNeedingRepair
+	stats.remoteSegmentsLost += other.remoteSegmentsLost
+	stats.remoteSegmentsFailedToCheck += other.remoteSegmentsFailedToCheck
+	for _, streamID := range other.objectsLost {
+		if !containsStreamID(stats.objectsLost, streamID) {
+			stats.objectsLost = append(stats.objectsLost, streamID)
+		}
+	}
+	for i := range stats.remoteSegmentsOverThreshold {
+		stats.remoteSegmentsOverThreshold[i] += other.remoteSegmentsOverThreshold[i]
+	}
+}
+
 func newStats(rs string) *stats {
 	return &stats{
 		iterationAggregates:             new(aggregateStats),
@@ -0,0 +1,71 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package checker
+
+import (
+	"storj.io/storj/satellite/overlay"
+)
+
+// TimeToLossConfig contains the configuration for ordering the repair queue
+// by the estimated time to loss of the segments.
+type TimeToLossConfig struct {
+	Enabled          bool    `help:"order the repair queue by the estimated time to loss, based on the audit and uptime history of the nodes holding the pieces, instead of only the number of healthy pieces" default:"false"`
+	AuditDQ          float64 `help:"the audit score at which nodes are disqualified, it should match reputation.audit-dq" default:"0.96"`
+	OfflineThreshold float64 `help:"the online score at which nodes are suspended, it should match reputation.audit-history.offline-threshold" default:"0.6"`
+}
+
+// NodeFailureModel estimates the probability of a node going down within the
+// next checker iteration from its recorded audit and uptime history.
+type NodeFailureModel struct {
+	config   TimeToLossConfig
+	baseRate float64
+}
+
+// NewNodeFailureModel creates a node failure model from the checker config.
+// It returns nil when the time to loss estimation is disabled.
+func NewNodeFailureModel(config Config) *NodeFailureModel {
+	if !config.TimeToLoss.Enabled {
+		return nil
+	}
+	return &NodeFailureModel{
+		config:   config.TimeToLoss,
+		baseRate: config.NodeFailureRate,
+	}
+}
+
+// FailureRate returns the estimated probability of the node going down
+// within the next checker iteration.
+//
+// Nodes with a perfect audit and online score fail with the configured node
+// failure rate. The rate grows with the inverse of the remaining margin of
+// the scores to disqualification and suspension, so nodes close to them are
+// expected to be lost soon.
+func (model *NodeFailureModel) FailureRate(node *overlay.NodeHistory) float64 {
+	margin := scoreMargin(node.AuditScore(), model.config.AuditDQ) *
+		scoreMargin(node.OnlineScore, model.config.OfflineThreshold)
+
+	if margin <= model.baseRate {
+		return 1
+	}
+	rate := model.baseRate / margin
+	if rate > 1 {
+		rate = 1
+	}
+	return rate
+}
+
+// scoreMargin returns the remaining fraction of the score above the threshold.
+func scoreMargin(score, threshold float64) float64 {
+	if threshold >= 1 {
+		return 1
+	}
+	margin := (score - threshold) / (1 - threshold)
+	switch {
+	case margin < 0:
+		return 0
+	case margin > 1:
+		return 1
+	}
+	return margin
+}
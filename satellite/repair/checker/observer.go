@@ -0,0 +1,147 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package checker
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"storj.io/storj/satellite/metabase"
+	"storj.io/storj/satellite/metabase/rangedloop"
+	"storj.io/storj/satellite/overlay"
+	"storj.io/storj/satellite/repair/queue"
+)
+
+var _ rangedloop.Observer = (*RangedLoopObserver)(nil)
+
+// RangedLoopObserver implements the ranged loop Observer interface. It
+// identifies injured segments and adds them to the repair queue.
+//
+// architecture: Observer
+type RangedLoopObserver struct {
+	logger               *zap.Logger
+	repairQueue          queue.RepairQueue
+	nodestate            *ReliabilityCache
+	repairOverrides      RepairOverridesMap
+	nodeFailureRate      float64
+	repairQueueBatchSize int
+
+	mu             sync.Mutex
+	startTime      time.Time
+	statsCollector *statsCollector
+	monStats       aggregateStats
+}
+
+// NewRangedLoopObserver creates a new checker observer for the ranged loop.
+func NewRangedLoopObserver(logger *zap.Logger, repairQueue queue.RepairQueue, overlay *overlay.Service, config Config) *RangedLoopObserver {
+	return &RangedLoopObserver{
+		logger:               logger,
+		repairQueue:          repairQueue,
+		nodestate:            NewReliabilityCache(overlay, config.ReliabilityCacheStaleness, NewNodeFailureModel(config)),
+		repairOverrides:      config.RepairOverrides.GetMap(),
+		nodeFailureRate:      config.NodeFailureRate,
+		repairQueueBatchSize: config.RepairQueueInsertBatchSize,
+		statsCollector:       newStatsCollector(),
+	}
+}
+
+// Start is called at the beginning of each ranged loop iteration.
+func (observer *RangedLoopObserver) Start(ctx context.Context, startTime time.Time, aliasMap metabase.NodeAliasMap) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	observer.mu.Lock()
+	defer observer.mu.Unlock()
+
+	// the repair queue uses the local time for updated_at.
+	observer.startTime = time.Now()
+	observer.monStats = aggregateStats{}
+
+	return nil
+}
+
+// Fork creates a Partial to process a chunk of all the segments.
+func (observer *RangedLoopObserver) Fork(ctx context.Context) (_ rangedloop.Partial, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	return &checkerObserver{
+		repairQueue:     queue.NewInsertBuffer(observer.repairQueue, observer.repairQueueBatchSize),
+		nodestate:       observer.nodestate,
+		statsCollector:  observer.statsCollector.fork(),
+		monStats:        aggregateStats{},
+		repairOverrides: observer.repairOverrides,
+		nodeFailureRate: observer.nodeFailureRate,
+		getNodesEstimate: func(ctx context.Context) (int, error) {
+			return getNodesEstimate(ctx, observer.nodestate)
+		},
+		log: observer.logger,
+	}, nil
+}
+
+// Join flushes the injured segments of the partial and merges its statistics.
+func (observer *RangedLoopObserver) Join(ctx context.Context, partial rangedloop.Partial) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	fork, ok := partial.(*checkerObserver)
+	if !ok {
+		return Error.New("expected partial type %T but got %T", fork, partial)
+	}
+
+	if err := fork.repairQueue.Flush(ctx); err != nil {
+		return Error.Wrap(err)
+	}
+
+	observer.mu.Lock()
+	defer observer.mu.Unlock()
+
+	observer.statsCollector.join(fork.statsCollector)
+	observer.monStats.combine(&fork.monStats)
+
+	return nil
+}
+
+// Finish removes healthy segments from the repair queue and reports the statistics.
+func (observer *RangedLoopObserver) Finish(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	observer.mu.Lock()
+	defer observer.mu.Unlock()
+
+	// remove all segments which were not seen as unhealthy by this checker iteration
+	healthyDeleted, err := observer.repairQueue.Clean(ctx, observer.startTime)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	observer.statsCollector.collectAggregates()
+
+	stats := &observer.monStats
+	mon.IntVal("remote_files_checked").Observe(stats.objectsChecked)
+	mon.IntVal("remote_segments_checked").Observe(stats.remoteSegmentsChecked)
+	mon.IntVal("remote_segments_failed_to_check").Observe(stats.remoteSegmentsFailedToCheck)
+	mon.IntVal("remote_segments_needing_repair").Observe(stats.remoteSegmentsNeedingRepair)
+	mon.IntVal("new_remote_segments_needing_repair").Observe(stats.newRemoteSegmentsNeedingRepair)
+	mon.IntVal("remote_segments_lost").Observe(stats.remoteSegmentsLost)
+	mon.IntVal("remote_files_lost").Observe(int64(len(stats.objectsLost)))
+	mon.IntVal("remote_segments_over_threshold_1").Observe(stats.remoteSegmentsOverThreshold[0])
+	mon.IntVal("remote_segments_over_threshold_2").Observe(stats.remoteSegmentsOverThreshold[1])
+	mon.IntVal("remote_segments_over_threshold_3").Observe(stats.remoteSegmentsOverThreshold[2])
+	mon.IntVal("remote_segments_over_threshold_4").Observe(stats.remoteSegmentsOverThreshold[3])
+	mon.IntVal("remote_segments_over_threshold_5").Observe(stats.remoteSegmentsOverThreshold[4])
+	mon.IntVal("healthy_segments_removed_from_queue").Observe(healthyDeleted)
+
+	allUnhealthy := stats.remoteSegmentsNeedingRepair + stats.remoteSegmentsFailedToCheck
+	allChecked := stats.remoteSegmentsChecked
+	allHealthy := allChecked - allUnhealthy
+	mon.FloatVal("remote_segments_healthy_percentage").Observe(100 * float64(allHealthy) / float64(allChecked))
+
+	return nil
+}
+
+// RefreshReliabilityCache forces refreshing node online status cache.
+func (observer *RangedLoopObserver) RefreshReliabilityCache(ctx context.Context) error {
+	return observer.nodestate.Refresh(ctx)
+}
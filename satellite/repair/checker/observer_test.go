@@ -0,0 +1,89 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package checker_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"storj.io/common/storj"
+	"storj.io/common/testcontext"
+	"storj.io/storj/private/testplanet"
+	"storj.io/storj/satellite/metabase"
+	"storj.io/storj/satellite/metabase/rangedloop"
+	"storj.io/storj/satellite/repair/checker"
+)
+
+func TestRangedLoopObserver(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount: 1, StorageNodeCount: 4, UplinkCount: 1,
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		satellite := planet.Satellites[0]
+		satellite.Repair.Checker.Loop.Pause()
+		satellite.Repair.Repairer.Loop.Pause()
+		repairQueue := satellite.DB.RepairQueue()
+
+		rs := storj.RedundancyScheme{
+			RequiredShares: 2,
+			RepairShares:   3,
+			OptimalShares:  4,
+			TotalShares:    5,
+			ShareSize:      256,
+		}
+
+		err := planet.Uplinks[0].CreateBucket(ctx, satellite, "test-bucket")
+		require.NoError(t, err)
+		location := metabase.SegmentLocation{
+			ProjectID:  planet.Uplinks[0].Projects[0].ID,
+			BucketName: "test-bucket",
+		}
+
+		for x := 0; x < 10; x++ {
+			location.ObjectKey = metabase.ObjectKey(fmt.Sprintf("a-%d", x))
+			insertSegment(ctx, t, planet, rs, location, createPieces(planet, rs), nil)
+		}
+
+		location.ObjectKey = "b-0"
+		injured := insertSegment(ctx, t, planet, rs, location, createLostPieces(planet, rs), nil)
+
+		location.ObjectKey = "b-1"
+		expiresAt := time.Now().Add(-time.Hour)
+		insertSegment(ctx, t, planet, rs, location, createLostPieces(planet, rs), &expiresAt)
+
+		observer := checker.NewRangedLoopObserver(zaptest.NewLogger(t), repairQueue, satellite.Overlay.Service, satellite.Config.Checker)
+		service := rangedloop.NewService(zaptest.NewLogger(t), rangedloop.Config{
+			Parallelism: 4,
+			BatchSize:   3,
+		}, satellite.Metabase.DB, []rangedloop.Observer{observer})
+
+		// the injured segment is queued by whichever range contains it
+		require.NoError(t, service.RunOnce(ctx))
+		count, err := repairQueue.Count(ctx)
+		require.NoError(t, err)
+		require.Equal(t, 1, count)
+
+		segment, err := repairQueue.Select(ctx)
+		require.NoError(t, err)
+		require.Equal(t, injured, segment.StreamID)
+
+		// segments, which became healthy, are removed from the queue by the next iteration
+		_, err = satellite.Metabase.DB.DeleteObjectsAllVersions(ctx, metabase.DeleteObjectsAllVersions{
+			Locations: []metabase.ObjectLocation{{
+				ProjectID:  location.ProjectID,
+				BucketName: location.BucketName,
+				ObjectKey:  "b-0",
+			}},
+		})
+		require.NoError(t, err)
+
+		require.NoError(t, service.RunOnce(ctx))
+		count, err = repairQueue.Count(ctx)
+		require.NoError(t, err)
+		require.Zero(t, count)
+	})
+}
@@ -19,23 +19,29 @@ import (
 //
 // architecture: Service
 type ReliabilityCache struct {
-	overlay   *overlay.Service
-	staleness time.Duration
-	mu        sync.Mutex
-	state     atomic.Value // contains immutable *reliabilityState
+	overlay      *overlay.Service
+	staleness    time.Duration
+	failureModel *NodeFailureModel
+	mu           sync.Mutex
+	state        atomic.Value // contains immutable *reliabilityState
 }
 
 // reliabilityState.
 type reliabilityState struct {
-	reliable map[storj.NodeID]struct{}
+	// reliable contains the estimated failure rates of the reliable nodes,
+	// the rates are zero when there is no node failure model.
+	reliable map[storj.NodeID]float64
 	created  time.Time
 }
 
-// NewReliabilityCache creates a new reliability checking cache.
-func NewReliabilityCache(overlay *overlay.Service, staleness time.Duration) *ReliabilityCache {
+// NewReliabilityCache creates a new reliability checking cache. The node
+// failure model is optional, when it's set the cache also estimates the
+// failure rates of the reliable nodes.
+func NewReliabilityCache(overlay *overlay.Service, staleness time.Duration, failureModel *NodeFailureModel) *ReliabilityCache {
 	return &ReliabilityCache{
-		overlay:   overlay,
-		staleness: staleness,
+		overlay:      overlay,
+		staleness:    staleness,
+		failureModel: failureModel,
 	}
 }
 
@@ -60,19 +66,28 @@ func (cache *ReliabilityCache) NumNodes(ctx context.Context) (numNodes int, err
 	return len(state.reliable), nil
 }
 
-// MissingPieces returns piece indices that are unreliable with the given staleness period.
-func (cache *ReliabilityCache) MissingPieces(ctx context.Context, created time.Time, pieces metabase.Pieces) (_ []metabase.Piece, err error) {
+// PieceFailureRates returns piece indices that are unreliable with the given
+// staleness period and the estimated failure rates of the nodes holding the
+// other pieces. The failure rates are nil when there is no node failure model.
+func (cache *ReliabilityCache) PieceFailureRates(ctx context.Context, created time.Time, pieces metabase.Pieces) (unreliable []metabase.Piece, failureRates []float64, err error) {
 	state, err := cache.loadFast(ctx, created)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+	if cache.failureModel != nil {
+		failureRates = make([]float64, 0, len(pieces))
 	}
-	var unreliable []metabase.Piece
 	for _, p := range pieces {
-		if _, ok := state.reliable[p.StorageNode]; !ok {
+		rate, ok := state.reliable[p.StorageNode]
+		if !ok {
 			unreliable = append(unreliable, p)
+			continue
+		}
+		if cache.failureModel != nil {
+			failureRates = append(failureRates, rate)
 		}
 	}
-	return unreliable, nil
+	return unreliable, failureRates, nil
 }
 
 func (cache *ReliabilityCache) loadFast(ctx context.Context, validUpTo time.Time) (_ *reliabilityState, err error) {
@@ -114,6 +129,10 @@ func (cache *ReliabilityCache) Refresh(ctx context.Context) (err error) {
 func (cache *ReliabilityCache) refreshLocked(ctx context.Context) (_ *reliabilityState, err error) {
 	defer mon.Task()(&ctx)(&err)
 
+	if cache.failureModel != nil {
+		return cache.refreshWithHistoryLocked(ctx)
+	}
+
 	nodes, err := cache.overlay.Reliable(ctx)
 	if err != nil {
 		return nil, Error.Wrap(err)
@@ -121,10 +140,32 @@ func (cache *ReliabilityCache) refreshLocked(ctx context.Context) (_ *reliabilit
 
 	state := &reliabilityState{
 		created:  time.Now(),
-		reliable: make(map[storj.NodeID]struct{}, len(nodes)),
+		reliable: make(map[storj.NodeID]float64, len(nodes)),
 	}
 	for _, id := range nodes {
-		state.reliable[id] = struct{}{}
+		state.reliable[id] = 0
+	}
+
+	cache.state.Store(state)
+	return state, nil
+}
+
+// refreshWithHistoryLocked does the refresh together with estimating the
+// failure rates of the nodes, assuming the write mutex is held.
+func (cache *ReliabilityCache) refreshWithHistoryLocked(ctx context.Context) (_ *reliabilityState, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	nodes, err := cache.overlay.ReliableWithHistory(ctx)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	state := &reliabilityState{
+		created:  time.Now(),
+		reliable: make(map[storj.NodeID]float64, len(nodes)),
+	}
+	for _, node := range nodes {
+		state.reliable[node.ID] = cache.failureModel.FailureRate(node)
 	}
 
 	cache.state.Store(state)
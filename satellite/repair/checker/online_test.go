@@ -35,13 +35,13 @@ func TestReliabilityCache_Concurrent(t *testing.T) {
 	ctx.Go(func() error { return overlayCache.Run(cacheCtx) })
 	defer ctx.Check(overlayCache.Close)
 
-	cache := NewReliabilityCache(overlayCache, time.Millisecond)
+	cache := NewReliabilityCache(overlayCache, time.Millisecond, nil)
 	var group errgroup.Group
 	for i := 0; i < 10; i++ {
 		group.Go(func() error {
 			for i := 0; i < 10000; i++ {
 				pieces := []metabase.Piece{{StorageNode: testrand.NodeID()}}
-				_, err := cache.MissingPieces(ctx, time.Now(), pieces)
+				_, _, err := cache.PieceFailureRates(ctx, time.Now(), pieces)
 				if err != nil {
 					return err
 				}
@@ -52,7 +52,81 @@ func TestReliabilityCache_Concurrent(t *testing.T) {
 	require.NoError(t, group.Wait())
 }
 
-type fakeOverlayDB struct{ overlay.DB }
+func TestReliabilityCache_FailureRates(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	stable := &overlay.NodeHistory{ID: testrand.NodeID(), OnlineScore: 1, AuditReputationAlpha: 1}
+	flaky := &overlay.NodeHistory{ID: testrand.NodeID(), OnlineScore: 0.8, AuditReputationAlpha: 1}
+
+	overlayCache, err := overlay.NewService(zap.NewNop(), fakeOverlayDB{history: []*overlay.NodeHistory{stable, flaky}}, fakeNodeEvents{}, nil, "", "", overlay.Config{
+		NodeSelectionCache: overlay.UploadSelectionCacheConfig{
+			Staleness: time.Minute,
+		},
+	})
+	require.NoError(t, err)
+	defer ctx.Check(overlayCache.Close)
+
+	config := Config{NodeFailureRate: 0.001}
+	config.TimeToLoss = TimeToLossConfig{
+		Enabled:          true,
+		AuditDQ:          0.96,
+		OfflineThreshold: 0.6,
+	}
+
+	cache := NewReliabilityCache(overlayCache, time.Minute, NewNodeFailureModel(config))
+	offline := metabase.Piece{Number: 2, StorageNode: testrand.NodeID()}
+	missing, rates, err := cache.PieceFailureRates(ctx, time.Time{}, metabase.Pieces{
+		{Number: 0, StorageNode: stable.ID},
+		{Number: 1, StorageNode: flaky.ID},
+		offline,
+	})
+	require.NoError(t, err)
+	require.Equal(t, []metabase.Piece{offline}, missing)
+	require.Len(t, rates, 2)
+	require.InDelta(t, 0.001, rates[0], 1e-9)
+	require.InDelta(t, 0.002, rates[1], 1e-9)
+
+	// without the model there are no failure rates.
+	require.Nil(t, NewNodeFailureModel(Config{NodeFailureRate: 0.001}))
+	cache = NewReliabilityCache(overlayCache, time.Minute, nil)
+	missing, rates, err = cache.PieceFailureRates(ctx, time.Time{}, metabase.Pieces{offline})
+	require.NoError(t, err)
+	require.Equal(t, []metabase.Piece{offline}, missing)
+	require.Nil(t, rates)
+}
+
+func TestNodeFailureModel(t *testing.T) {
+	model := NewNodeFailureModel(Config{
+		NodeFailureRate: 0.01,
+		TimeToLoss: TimeToLossConfig{
+			Enabled:          true,
+			AuditDQ:          0.96,
+			OfflineThreshold: 0.6,
+		},
+	})
+
+	for _, tc := range []struct {
+		name string
+		node overlay.NodeHistory
+		rate float64
+	}{
+		{name: "perfect", node: overlay.NodeHistory{OnlineScore: 1, AuditReputationAlpha: 1000}, rate: 0.01},
+		{name: "no audits", node: overlay.NodeHistory{OnlineScore: 1}, rate: 0.01},
+		{name: "offline", node: overlay.NodeHistory{OnlineScore: 0.8, AuditReputationAlpha: 1000}, rate: 0.02},
+		{name: "failing audits", node: overlay.NodeHistory{OnlineScore: 1, AuditReputationAlpha: 98, AuditReputationBeta: 2}, rate: 0.02},
+		{name: "both", node: overlay.NodeHistory{OnlineScore: 0.8, AuditReputationAlpha: 98, AuditReputationBeta: 2}, rate: 0.04},
+		{name: "suspended", node: overlay.NodeHistory{OnlineScore: 0.5, AuditReputationAlpha: 1000}, rate: 1},
+		{name: "disqualified", node: overlay.NodeHistory{OnlineScore: 1, AuditReputationAlpha: 90, AuditReputationBeta: 10}, rate: 1},
+	} {
+		require.InDelta(t, tc.rate, model.FailureRate(&tc.node), 1e-9, tc.name)
+	}
+}
+
+type fakeOverlayDB struct {
+	overlay.DB
+	history []*overlay.NodeHistory
+}
 type fakeNodeEvents struct{ nodeevents.DB }
 
 func (fakeOverlayDB) Reliable(context.Context, *overlay.NodeCriteria) (storj.NodeIDList, error) {
@@ -63,3 +137,7 @@ func (fakeOverlayDB) Reliable(context.Context, *overlay.NodeCriteria) (storj.Nod
 		testrand.NodeID(),
 	}, nil
 }
+
+func (db fakeOverlayDB) ReliableWithHistory(context.Context, *overlay.NodeCriteria) ([]*overlay.NodeHistory, error) {
+	return db.history, nil
+}
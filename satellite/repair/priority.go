@@ -3,7 +3,10 @@
 
 package repair
 
-import "math"
+import (
+	"math"
+	"sort"
+)
 
 // SegmentHealth returns a value corresponding to the health of a segment in the
 // repair queue. Lower health segments should be repaired first.
@@ -58,6 +61,79 @@ func SegmentHealth(numHealthy, minPieces, totalNodes int, failureRate float64) f
 	return mean1 / churnPerRound
 }
 
+// SegmentTimeToLoss returns a value corresponding to the health of a segment
+// in the repair queue, based on the failure rates of the individual nodes
+// holding the healthy pieces. Lower health segments should be repaired first.
+//
+// failureRates contains for every healthy piece the probability of its node
+// going down within one iteration. The segment is lost when
+// r = len(failureRates) - minPieces + 1 of the nodes have gone down.
+//
+// Modeling the lifetime of every node as exponentially distributed with its
+// failure rate, the expected time until the next node goes down is the
+// reciprocal of the sum of the rates of the remaining nodes. We assume that
+// the nodes go down in the order of their failure rates, which makes the
+// estimate slightly pessimistic, and sum the expected times of the r steps:
+//
+//	T = sum_{k=0}^{r-1} 1 / (sum of the rates without the k flakiest nodes)
+//
+// The result is the expected number of iterations until the segment is lost,
+// so segments whose pieces sit on flaky nodes get lower values than segments
+// with the same number of pieces on stable nodes.
+func SegmentTimeToLoss(failureRates []float64, minPieces int) float64 {
+	lossThreshold := len(failureRates) - minPieces + 1
+	if lossThreshold <= 0 {
+		return 0
+	}
+
+	rates := make([]float64, len(failureRates))
+	for i, rate := range failureRates {
+		switch {
+		case math.IsNaN(rate) || rate < minChurnPerRound:
+			// treat nodes without churn as very stable nodes, so that the
+			// health values do not approach the floating point maximum.
+			rate = minChurnPerRound
+		case rate > 1:
+			rate = 1
+		}
+		rates[i] = rate
+	}
+	sort.Sort(sort.Reverse(sort.Float64Slice(rates)))
+
+	// remaining[k] is the sum of the rates without the k flakiest nodes.
+	remaining := make([]float64, len(rates)+1)
+	for k := len(rates) - 1; k >= 0; k-- {
+		remaining[k] = remaining[k+1] + rates[k]
+	}
+
+	var iterations float64
+	for k := 0; k < lossThreshold; k++ {
+		iterations += 1 / remaining[k]
+	}
+	return iterations
+}
+
+// AdjustSegmentHealth adjusts the health of the segment, as returned by
+// SegmentHealth, by the failure rates of the nodes holding the healthy pieces.
+//
+// The health is scaled by the ratio of the time to loss with the failure rates
+// and the time to loss with every node failing with the base failure rate.
+// The adjusted health of segments on nodes with the base failure rate is
+// unchanged, so the adjusted and unadjusted health values in the repair queue
+// are on the same scale.
+func AdjustSegmentHealth(health float64, failureRates []float64, minPieces int, baseRate float64) float64 {
+	baseRates := make([]float64, len(failureRates))
+	for i := range baseRates {
+		baseRates[i] = baseRate
+	}
+
+	base := SegmentTimeToLoss(baseRates, minPieces)
+	if base <= 0 || math.IsInf(health, 0) {
+		return health
+	}
+	return health * SegmentTimeToLoss(failureRates, minPieces) / base
+}
+
 const (
 	minChurnPerRound = 1e-10
 	minTotalNodes    = 100
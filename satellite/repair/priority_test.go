@@ -54,3 +54,72 @@ func TestHighHealthAndLowFailureRate(t *testing.T) {
 		repair.SegmentHealth(11, 10, 10000, failureRate),
 		repair.SegmentHealth(39, 34, 10000, failureRate))
 }
+
+func TestSegmentTimeToLoss(t *testing.T) {
+	const failureRate = 0.00005435
+
+	rates := func(stable, flaky int) []float64 {
+		var rates []float64
+		for i := 0; i < stable; i++ {
+			rates = append(rates, failureRate)
+		}
+		for i := 0; i < flaky; i++ {
+			rates = append(rates, 10*failureRate)
+		}
+		return rates
+	}
+
+	// more healthy pieces survive longer.
+	assert.Less(t,
+		repair.SegmentTimeToLoss(rates(35, 0), 29),
+		repair.SegmentTimeToLoss(rates(36, 0), 29))
+	// pieces on flaky nodes are lost sooner.
+	assert.Less(t,
+		repair.SegmentTimeToLoss(rates(30, 5), 29),
+		repair.SegmentTimeToLoss(rates(35, 0), 29))
+	assert.Less(t,
+		repair.SegmentTimeToLoss(rates(25, 10), 29),
+		repair.SegmentTimeToLoss(rates(30, 5), 29))
+	// the order of the rates doesn't matter.
+	assert.Equal(t,
+		repair.SegmentTimeToLoss([]float64{failureRate, 2 * failureRate, 3 * failureRate}, 2),
+		repair.SegmentTimeToLoss([]float64{3 * failureRate, failureRate, 2 * failureRate}, 2))
+
+	// a single remaining node is lost after 1/rate iterations.
+	assert.InDelta(t, 1/failureRate, repair.SegmentTimeToLoss([]float64{failureRate}, 1), 1e-6)
+
+	// already lost segments.
+	assert.Equal(t, float64(0), repair.SegmentTimeToLoss(rates(9, 0), 10))
+	assert.Equal(t, float64(0), repair.SegmentTimeToLoss(nil, 1))
+
+	// nodes without churn don't result in infinite health.
+	assert.Less(t, repair.SegmentTimeToLoss([]float64{0, 0}, 1), math.Inf(1))
+}
+
+func TestAdjustSegmentHealth(t *testing.T) {
+	const failureRate = 0.00005435
+	health := repair.SegmentHealth(35, 29, 10000, failureRate)
+
+	rates := func(stable, flaky int) []float64 {
+		var rates []float64
+		for i := 0; i < stable; i++ {
+			rates = append(rates, failureRate)
+		}
+		for i := 0; i < flaky; i++ {
+			rates = append(rates, 10*failureRate)
+		}
+		return rates
+	}
+
+	// segments on nodes with the base failure rate keep their health.
+	assert.InDelta(t, health, repair.AdjustSegmentHealth(health, rates(35, 0), 29, failureRate), health*1e-9)
+
+	// pieces on flaky nodes lower the health.
+	assert.Less(t, repair.AdjustSegmentHealth(health, rates(30, 5), 29, failureRate), health)
+	assert.Less(t,
+		repair.AdjustSegmentHealth(health, rates(25, 10), 29, failureRate),
+		repair.AdjustSegmentHealth(health, rates(30, 5), 29, failureRate))
+
+	// lost segments are not adjusted.
+	assert.Equal(t, health, repair.AdjustSegmentHealth(health, rates(9, 0), 10, failureRate))
+}
@@ -9,3 +9,6 @@ import (
 
 // Error is a standard error class for this package.
 var Error = errs.Class("repair queue")
+
+// ErrSegmentNotFound is returned when the segment is not in the repair queue.
+var ErrSegmentNotFound = errs.Class("segment not found in repair queue")
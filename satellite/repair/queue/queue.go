@@ -21,8 +21,18 @@ type InjuredSegment struct {
 	AttemptedAt   *time.Time
 	UpdatedAt     time.Time
 	InsertedAt    time.Time
+
+	// Prioritized is set when the segment was moved to the front of the
+	// queue manually, its segment health isn't updated anymore.
+	Prioritized bool
+	// DoNotRepair is set when the segment was pinned to not be repaired.
+	DoNotRepair bool
 }
 
+// PrioritizedSegmentHealth is the segment health of the segments which were
+// moved to the front of the queue manually.
+const PrioritizedSegmentHealth = -1
+
 // RepairQueue implements queueing for segments that need repairing.
 // Implementation can be found at satellite/satellitedb/repairqueue.go.
 //
@@ -42,6 +52,18 @@ type RepairQueue interface {
 	SelectN(ctx context.Context, limit int) ([]InjuredSegment, error)
 	// Count counts the number of segments in the repair queue.
 	Count(ctx context.Context) (count int, err error)
+	// CountByHealth counts the number of segments in the repair queue for the
+	// health buckets separated by the ascending boundaries. counts[i] is the
+	// number of segments with boundaries[i-1] <= health < boundaries[i].
+	CountByHealth(ctx context.Context, boundaries []float64) (counts []int, err error)
+
+	// Get returns the injured segment with the stream id and position.
+	Get(ctx context.Context, streamID uuid.UUID, position metabase.SegmentPosition) (*InjuredSegment, error)
+	// Prioritize moves the segment to the front of the queue, it also removes
+	// the do not repair pin from the segment.
+	Prioritize(ctx context.Context, streamID uuid.UUID, position metabase.SegmentPosition) error
+	// SetDoNotRepair pins the segment to not be selected for repair, or unpins it.
+	SetDoNotRepair(ctx context.Context, streamID uuid.UUID, position metabase.SegmentPosition, doNotRepair bool) error
 
 	// TestingSetAttemptedTime sets attempted time for a segment.
 	TestingSetAttemptedTime(ctx context.Context, streamID uuid.UUID, position metabase.SegmentPosition, t time.Time) (rowsAffected int64, err error)
@@ -314,3 +314,75 @@ func TestClean(t *testing.T) {
 		require.Equal(t, 0, count)
 	})
 }
+
+func TestPrioritizeAndDoNotRepair(t *testing.T) {
+	satellitedbtest.Run(t, func(ctx *testcontext.Context, t *testing.T, db satellite.DB) {
+		q := db.RepairQueue()
+
+		healthy := createInjuredSegment()
+		healthy.SegmentHealth = 10
+		injured := createInjuredSegment()
+		injured.SegmentHealth = 1
+		for _, seg := range []*queue.InjuredSegment{healthy, injured} {
+			_, err := q.Insert(ctx, seg)
+			require.NoError(t, err)
+		}
+
+		// missing segments
+		missing := createInjuredSegment()
+		_, err := q.Get(ctx, missing.StreamID, missing.Position)
+		require.True(t, queue.ErrSegmentNotFound.Has(err))
+		require.True(t, queue.ErrSegmentNotFound.Has(q.Prioritize(ctx, missing.StreamID, missing.Position)))
+		require.True(t, queue.ErrSegmentNotFound.Has(q.SetDoNotRepair(ctx, missing.StreamID, missing.Position, true)))
+
+		// prioritized segment is selected first and keeps its health
+		require.NoError(t, q.Prioritize(ctx, healthy.StreamID, healthy.Position))
+		_, err = q.Insert(ctx, healthy)
+		require.NoError(t, err)
+
+		got, err := q.Get(ctx, healthy.StreamID, healthy.Position)
+		require.NoError(t, err)
+		require.True(t, got.Prioritized)
+		require.False(t, got.DoNotRepair)
+		require.Equal(t, float64(queue.PrioritizedSegmentHealth), got.SegmentHealth)
+
+		// pinned segment is not selected
+		require.NoError(t, q.SetDoNotRepair(ctx, healthy.StreamID, healthy.Position, true))
+		s, err := q.Select(ctx)
+		require.NoError(t, err)
+		require.Equal(t, injured.StreamID, s.StreamID)
+
+		_, err = q.Select(ctx)
+		require.True(t, storage.ErrEmptyQueue.Has(err))
+
+		// prioritizing removes the pin
+		require.NoError(t, q.Prioritize(ctx, healthy.StreamID, healthy.Position))
+		s, err = q.Select(ctx)
+		require.NoError(t, err)
+		require.Equal(t, healthy.StreamID, s.StreamID)
+	})
+}
+
+func TestCountByHealth(t *testing.T) {
+	satellitedbtest.Run(t, func(ctx *testcontext.Context, t *testing.T, db satellite.DB) {
+		q := db.RepairQueue()
+
+		for _, health := range []float64{0, 0.5, 1, 5, 10, 100, 1000} {
+			seg := createInjuredSegment()
+			seg.SegmentHealth = health
+			_, err := q.Insert(ctx, seg)
+			require.NoError(t, err)
+		}
+
+		counts, err := q.CountByHealth(ctx, []float64{1, 10})
+		require.NoError(t, err)
+		require.Equal(t, []int{2, 2, 3}, counts)
+
+		counts, err = q.CountByHealth(ctx, nil)
+		require.NoError(t, err)
+		require.Equal(t, []int{7}, counts)
+
+		_, err = q.CountByHealth(ctx, []float64{10, 1})
+		require.Error(t, err)
+	})
+}
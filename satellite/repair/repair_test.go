@@ -50,14 +50,18 @@ import (
 //     threshold
 //   - Downloads the data from those left nodes and check that it's the same than the uploaded one.
 func TestDataRepairInMemoryBlake(t *testing.T) {
-	testDataRepair(t, true, pb.PieceHashAlgorithm_BLAKE3)
+	testDataRepair(t, true, false, pb.PieceHashAlgorithm_BLAKE3)
 }
 
 func TestDataRepairToDiskSHA256(t *testing.T) {
-	testDataRepair(t, false, pb.PieceHashAlgorithm_SHA256)
+	testDataRepair(t, false, false, pb.PieceHashAlgorithm_SHA256)
 }
 
-func testDataRepair(t *testing.T, inMemoryRepair bool, hashAlgo pb.PieceHashAlgorithm) {
+func TestDataRepairStreaming(t *testing.T) {
+	testDataRepair(t, false, true, pb.PieceHashAlgorithm_SHA256)
+}
+
+func testDataRepair(t *testing.T, inMemoryRepair, streamingRepair bool, hashAlgo pb.PieceHashAlgorithm) {
 	const (
 		RepairMaxExcessRateOptimalThreshold = 0.05
 		minThreshold                        = 3
@@ -73,6 +77,7 @@ func testDataRepair(t *testing.T, inMemoryRepair bool, hashAlgo pb.PieceHashAlgo
 				func(log *zap.Logger, index int, config *satellite.Config) {
 					config.Repairer.MaxExcessRateOptimalThreshold = RepairMaxExcessRateOptimalThreshold
 					config.Repairer.InMemoryRepair = inMemoryRepair
+					config.Repairer.StreamingRepair = streamingRepair
 				},
 				testplanet.ReconfigureRS(minThreshold, 5, successThreshold, 9),
 			),
@@ -2445,6 +2450,7 @@ func ecRepairerWithMockConnector(t testing.TB, sat *testplanet.Satellite, mock *
 		signing.SigneeFromPeerIdentity(sat.Identity.PeerIdentity()),
 		sat.Config.Repairer.DownloadTimeout,
 		sat.Config.Repairer.InMemoryRepair,
+		sat.Config.Repairer.DownloadHedge,
 	)
 	return ec
 }
@@ -10,7 +10,6 @@ import (
 	"hash"
 	"io"
 	"sort"
-	"sync"
 	"sync/atomic"
 	"time"
 
@@ -25,7 +24,6 @@ import (
 	"storj.io/common/rpc/rpcpool"
 	"storj.io/common/signing"
 	"storj.io/common/storj"
-	"storj.io/common/sync2"
 	"storj.io/storj/satellite/audit"
 	"storj.io/storj/satellite/metabase"
 	"storj.io/storj/satellite/overlay"
@@ -48,30 +46,52 @@ type ECRepairer struct {
 	satelliteSignee signing.Signee
 	downloadTimeout time.Duration
 	inmemory        bool
+	hedge           DownloadHedgeConfig
+	latencies       *LatencyTracker
 }
 
 // NewECRepairer creates a new repairer for interfacing with storagenodes.
-func NewECRepairer(log *zap.Logger, dialer rpc.Dialer, satelliteSignee signing.Signee, downloadTimeout time.Duration, inmemory bool) *ECRepairer {
+func NewECRepairer(log *zap.Logger, dialer rpc.Dialer, satelliteSignee signing.Signee, downloadTimeout time.Duration, inmemory bool, hedge DownloadHedgeConfig) *ECRepairer {
 	return &ECRepairer{
 		log:             log,
 		dialer:          dialer,
 		satelliteSignee: satelliteSignee,
 		downloadTimeout: downloadTimeout,
 		inmemory:        inmemory,
+		hedge:           hedge,
+		latencies:       NewLatencyTracker(),
 	}
 }
 
+// Latencies returns the tracker of the piece download latencies of the nodes.
+func (ec *ECRepairer) Latencies() *LatencyTracker {
+	return ec.latencies
+}
+
 func (ec *ECRepairer) dialPiecestore(ctx context.Context, n storj.NodeURL) (*piecestore.Client, error) {
 	client, err := piecestore.Dial(rpcpool.WithForceDial(ctx), ec.dialer, n, piecestore.DefaultConfig)
 	return client, ErrDialFailed.Wrap(err)
 }
 
 // Get downloads pieces from storagenodes using the provided order limits, and decodes those pieces into a segment.
-// It attempts to download from the minimum required number based on the redundancy scheme.
+// See GetPieces for how the pieces are downloaded and verified.
+func (ec *ECRepairer) Get(ctx context.Context, limits []*pb.AddressedOrderLimit, cachedNodesInfo map[storj.NodeID]overlay.NodeReputation, privateKey storj.PiecePrivateKey, es eestream.ErasureScheme, dataSize int64) (_ io.ReadCloser, _ FetchResultReport, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	pieces, report, err := ec.GetPieces(ctx, limits, cachedNodesInfo, privateKey, es, dataSize)
+	if err != nil {
+		return nil, report, err
+	}
+	return pieces.Decode(ctx), report, nil
+}
+
+// GetPieces downloads pieces from storagenodes using the provided order limits.
+// It attempts to download from the minimum required number based on the redundancy scheme, plus the configured
+// number of hedged downloads. The slowest downloads are canceled once the minimum required number succeeded.
 // After downloading a piece, the ECRepairer will verify the hash and original order limit for that piece.
 // If verification fails, another piece will be downloaded until we reach the minimum required or run out of order limits.
 // If piece hash verification fails, it will return all failed node IDs.
-func (ec *ECRepairer) Get(ctx context.Context, limits []*pb.AddressedOrderLimit, cachedNodesInfo map[storj.NodeID]overlay.NodeReputation, privateKey storj.PiecePrivateKey, es eestream.ErasureScheme, dataSize int64) (_ io.ReadCloser, _ FetchResultReport, err error) {
+func (ec *ECRepairer) GetPieces(ctx context.Context, limits []*pb.AddressedOrderLimit, cachedNodesInfo map[storj.NodeID]overlay.NodeReputation, privateKey storj.PiecePrivateKey, es eestream.ErasureScheme, dataSize int64) (_ *VerifiedPieces, _ FetchResultReport, err error) {
 	defer mon.Task()(&ctx)(&err)
 
 	if len(limits) != es.TotalCount() {
@@ -86,130 +106,118 @@ func (ec *ECRepairer) Get(ctx context.Context, limits []*pb.AddressedOrderLimit,
 
 	pieceSize := eestream.CalcPieceSize(dataSize, es)
 
-	var successfulPieces, inProgress int
-	unusedLimits := nonNilLimits
+	var order []int
+	for index, limit := range limits {
+		if limit != nil {
+			order = append(order, index)
+		}
+	}
+
+	budget := func(index int) time.Duration {
+		if ec.hedge.LatencyQuantile <= 0 {
+			return 0
+		}
+		latency, ok := ec.latencies.Quantile(limits[index].GetLimit().StorageNodeId, ec.hedge.LatencyQuantile)
+		if !ok {
+			return 0
+		}
+		return latency
+	}
+
+	download := func(ctx context.Context, index int) (io.ReadCloser, error) {
+		limit := limits[index]
+
+		info := cachedNodesInfo[limit.GetLimit().StorageNodeId]
+		address := limit.GetStorageNodeAddress().GetAddress()
+		var triedLastIPPort bool
+		if info.LastIPPort != "" && info.LastIPPort != address {
+			address = info.LastIPPort
+			triedLastIPPort = true
+		}
+
+		pieceReadCloser, _, _, err := ec.downloadAndVerifyPiece(ctx, limit, address, privateKey, "", pieceSize)
+		// if piecestore dial with last ip:port failed try again with node address
+		if triedLastIPPort && ErrDialFailed.Has(err) {
+			if pieceReadCloser != nil {
+				_ = pieceReadCloser.Close()
+			}
+			pieceReadCloser, _, _, err = ec.downloadAndVerifyPiece(ctx, limit, limit.GetStorageNodeAddress().GetAddress(), privateKey, "", pieceSize)
+		}
+		return pieceReadCloser, err
+	}
+
+	var successfulPieces int
 	pieceReaders := make(map[int]io.ReadCloser)
 	var pieces FetchResultReport
 
-	limiter := sync2.NewLimiter(es.RequiredCount())
-	cond := sync.NewCond(&sync.Mutex{})
+	for _, result := range hedgedDownloads(ctx, order, es.RequiredCount(), ec.hedge.Count, budget, download) {
+		limit := limits[result.index]
+		piece := metabase.Piece{
+			Number:      uint16(result.index),
+			StorageNode: limit.GetLimit().StorageNodeId,
+		}
+
+		if result.err != nil {
+			if result.reader != nil {
+				_ = result.reader.Close()
+			}
+			err := result.err
+
+			// gather nodes where the calculated piece hash doesn't match the uplink signed piece hash
+			if ErrPieceHashVerifyFailed.Has(err) {
+				ec.log.Info("audit failed",
+					zap.Stringer("node ID", limit.GetLimit().StorageNodeId),
+					zap.Stringer("Piece ID", limit.Limit.PieceId),
+					zap.String("reason", err.Error()))
+				pieces.Failed = append(pieces.Failed, PieceFetchResult{Piece: piece, Err: err})
+				continue
+			}
 
-	for currentLimitIndex, limit := range limits {
-		if limit == nil {
+			pieceAudit := audit.PieceAuditFromErr(err)
+			switch pieceAudit {
+			case audit.PieceAuditFailure:
+				ec.log.Debug("Failed to download piece for repair: piece not found (audit failed)",
+					zap.Stringer("Node ID", limit.GetLimit().StorageNodeId),
+					zap.Stringer("Piece ID", limit.Limit.PieceId),
+					zap.Error(err))
+				pieces.Failed = append(pieces.Failed, PieceFetchResult{Piece: piece, Err: err})
+
+			case audit.PieceAuditOffline:
+				ec.log.Debug("Failed to download piece for repair: dial timeout (offline)",
+					zap.Stringer("Node ID", limit.GetLimit().StorageNodeId),
+					zap.Stringer("Piece ID", limit.Limit.PieceId),
+					zap.Error(err))
+				pieces.Offline = append(pieces.Offline, PieceFetchResult{Piece: piece, Err: err})
+
+			case audit.PieceAuditContained:
+				ec.log.Info("Failed to download piece for repair: download timeout (contained)",
+					zap.Stringer("Node ID", limit.GetLimit().StorageNodeId),
+					zap.Stringer("Piece ID", limit.Limit.PieceId),
+					zap.Error(err))
+				pieces.Contained = append(pieces.Contained, PieceFetchResult{Piece: piece, Err: err})
+
+			case audit.PieceAuditUnknown:
+				ec.log.Info("Failed to download piece for repair: unknown transport error (skipped)",
+					zap.Stringer("Node ID", limit.GetLimit().StorageNodeId),
+					zap.Stringer("Piece ID", limit.Limit.PieceId),
+					zap.Error(err))
+				pieces.Unknown = append(pieces.Unknown, PieceFetchResult{Piece: piece, Err: err})
+			}
 			continue
 		}
 
-		currentLimitIndex, limit := currentLimitIndex, limit
-		limiter.Go(ctx, func() {
-			cond.L.Lock()
-			defer cond.Signal()
-			defer cond.L.Unlock()
-
-			for {
-				if successfulPieces >= es.RequiredCount() {
-					// already downloaded minimum number of pieces
-					cond.Broadcast()
-					return
-				}
-				if successfulPieces+inProgress+unusedLimits < es.RequiredCount() {
-					// not enough available limits left to get required number of pieces
-					cond.Broadcast()
-					return
-				}
-
-				if successfulPieces+inProgress >= es.RequiredCount() {
-					cond.Wait()
-					continue
-				}
-
-				unusedLimits--
-				inProgress++
-				cond.L.Unlock()
-
-				info := cachedNodesInfo[limit.GetLimit().StorageNodeId]
-				address := limit.GetStorageNodeAddress().GetAddress()
-				var triedLastIPPort bool
-				if info.LastIPPort != "" && info.LastIPPort != address {
-					address = info.LastIPPort
-					triedLastIPPort = true
-				}
-
-				pieceReadCloser, _, _, err := ec.downloadAndVerifyPiece(ctx, limit, address, privateKey, "", pieceSize)
-				// if piecestore dial with last ip:port failed try again with node address
-				if triedLastIPPort && ErrDialFailed.Has(err) {
-					if pieceReadCloser != nil {
-						_ = pieceReadCloser.Close()
-					}
-					pieceReadCloser, _, _, err = ec.downloadAndVerifyPiece(ctx, limit, limit.GetStorageNodeAddress().GetAddress(), privateKey, "", pieceSize)
-				}
-
-				cond.L.Lock()
-				inProgress--
-				piece := metabase.Piece{
-					Number:      uint16(currentLimitIndex),
-					StorageNode: limit.GetLimit().StorageNodeId,
-				}
-
-				if err != nil {
-					if pieceReadCloser != nil {
-						_ = pieceReadCloser.Close()
-					}
-
-					// gather nodes where the calculated piece hash doesn't match the uplink signed piece hash
-					if ErrPieceHashVerifyFailed.Has(err) {
-						ec.log.Info("audit failed",
-							zap.Stringer("node ID", limit.GetLimit().StorageNodeId),
-							zap.Stringer("Piece ID", limit.Limit.PieceId),
-							zap.String("reason", err.Error()))
-						pieces.Failed = append(pieces.Failed, PieceFetchResult{Piece: piece, Err: err})
-						return
-					}
-
-					pieceAudit := audit.PieceAuditFromErr(err)
-					switch pieceAudit {
-					case audit.PieceAuditFailure:
-						ec.log.Debug("Failed to download piece for repair: piece not found (audit failed)",
-							zap.Stringer("Node ID", limit.GetLimit().StorageNodeId),
-							zap.Stringer("Piece ID", limit.Limit.PieceId),
-							zap.Error(err))
-						pieces.Failed = append(pieces.Failed, PieceFetchResult{Piece: piece, Err: err})
-
-					case audit.PieceAuditOffline:
-						ec.log.Debug("Failed to download piece for repair: dial timeout (offline)",
-							zap.Stringer("Node ID", limit.GetLimit().StorageNodeId),
-							zap.Stringer("Piece ID", limit.Limit.PieceId),
-							zap.Error(err))
-						pieces.Offline = append(pieces.Offline, PieceFetchResult{Piece: piece, Err: err})
-
-					case audit.PieceAuditContained:
-						ec.log.Info("Failed to download piece for repair: download timeout (contained)",
-							zap.Stringer("Node ID", limit.GetLimit().StorageNodeId),
-							zap.Stringer("Piece ID", limit.Limit.PieceId),
-							zap.Error(err))
-						pieces.Contained = append(pieces.Contained, PieceFetchResult{Piece: piece, Err: err})
-
-					case audit.PieceAuditUnknown:
-						ec.log.Info("Failed to download piece for repair: unknown transport error (skipped)",
-							zap.Stringer("Node ID", limit.GetLimit().StorageNodeId),
-							zap.Stringer("Piece ID", limit.Limit.PieceId),
-							zap.Error(err))
-						pieces.Unknown = append(pieces.Unknown, PieceFetchResult{Piece: piece, Err: err})
-					}
-
-					return
-				}
-
-				pieceReaders[currentLimitIndex] = pieceReadCloser
-				pieces.Successful = append(pieces.Successful, PieceFetchResult{Piece: piece})
-				successfulPieces++
-				return
-			}
-		})
-	}
+		ec.latencies.Record(piece.StorageNode, result.duration)
+		mon.DurationVal("repair_piece_download_duration").Observe(result.duration)
 
-	limiter.Wait()
+		pieceReaders[result.index] = result.reader
+		pieces.Successful = append(pieces.Successful, PieceFetchResult{Piece: piece})
+		successfulPieces++
+	}
 
 	if successfulPieces < es.RequiredCount() {
+		for _, reader := range pieceReaders {
+			_ = reader.Close()
+		}
 		mon.Meter("download_failed_not_enough_pieces_repair").Mark(1) //mon:locked
 		return nil, pieces, &irreparableError{
 			piecesAvailable: int32(successfulPieces),
@@ -219,16 +227,52 @@ func (ec *ECRepairer) Get(ctx context.Context, limits []*pb.AddressedOrderLimit,
 
 	fec, err := infectious.NewFEC(es.RequiredCount(), es.TotalCount())
 	if err != nil {
+		for _, reader := range pieceReaders {
+			_ = reader.Close()
+		}
 		return nil, pieces, Error.Wrap(err)
 	}
 
-	esScheme := eestream.NewUnsafeRSScheme(fec, es.ErasureShareSize())
-	expectedSize := pieceSize * int64(es.RequiredCount())
+	return &VerifiedPieces{
+		readers:   pieceReaders,
+		fec:       fec,
+		shareSize: es.ErasureShareSize(),
+		pieceSize: pieceSize,
+	}, pieces, nil
+}
+
+// VerifiedPieces contains the downloaded and verified pieces of a segment.
+type VerifiedPieces struct {
+	readers   map[int]io.ReadCloser
+	fec       *infectious.FEC
+	shareSize int
+	pieceSize int64
+
+	decoder io.ReadCloser
+}
+
+// Decode returns a reader of the segment decoded from the pieces.
+// Closing the returned reader closes the pieces.
+func (pieces *VerifiedPieces) Decode(ctx context.Context) io.ReadCloser {
+	esScheme := eestream.NewUnsafeRSScheme(pieces.fec, pieces.shareSize)
+	expectedSize := pieces.pieceSize * int64(pieces.fec.Required())
 
 	ctx, cancel := context.WithCancel(ctx)
-	decodeReader := eestream.DecodeReaders2(ctx, cancel, pieceReaders, esScheme, expectedSize, 0, false)
+	pieces.decoder = eestream.DecodeReaders2(ctx, cancel, pieces.readers, esScheme, expectedSize, 0, false)
+	return pieces.decoder
+}
 
-	return decodeReader, pieces, nil
+// Close closes the pieces, or the decoder of the segment when it was created.
+func (pieces *VerifiedPieces) Close() error {
+	if pieces.decoder != nil {
+		return pieces.decoder.Close()
+	}
+
+	var group errs.Group
+	for _, reader := range pieces.readers {
+		group.Add(reader.Close())
+	}
+	return group.Err()
 }
 
 // lazyHashWriter is a writer which can get the hash algorithm just before the first write.
@@ -393,6 +437,65 @@ func (ec *ECRepairer) Repair(ctx context.Context, limits []*pb.AddressedOrderLim
 		return nil, nil, err
 	}
 
+	return ec.putPieces(ctx, limits, privateKey, rs, func(_ context.Context, i int) io.ReadCloser {
+		return readers[i]
+	}, timeout, successfulNeeded)
+}
+
+// RepairMissing regenerates only the pieces in need of repair from the verified pieces
+// stripe by stripe, and uploads them directly to new nodes provided by order limits.
+// Every regenerated piece is buffered up to bufferSize bytes. Uploads which fall further
+// behind while enough other uploads wait for data are dropped like the long tail.
+func (ec *ECRepairer) RepairMissing(ctx context.Context, limits []*pb.AddressedOrderLimit, privateKey storj.PiecePrivateKey, rs eestream.RedundancyStrategy, pieces *VerifiedPieces, bufferSize int, timeout time.Duration, successfulNeeded int) (successfulNodes []*pb.Node, successfulHashes []*pb.PieceHash, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	pieceCount := len(limits)
+	if pieceCount != rs.TotalCount() {
+		return nil, nil, Error.New("size of limits slice (%d) does not match total count (%d) of erasure scheme", pieceCount, rs.TotalCount())
+	}
+
+	if !unique(limits) {
+		return nil, nil, Error.New("duplicated nodes are not allowed")
+	}
+
+	var missing []int
+	for i, limit := range limits {
+		if limit != nil {
+			missing = append(missing, i)
+		}
+	}
+
+	regenerator, err := newPieceRegenerator(pieces, missing, bufferSize, successfulNeeded)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		regenerator.Run(ctx)
+	}()
+	// every reader is closed by the uploads, which stops the regeneration.
+	defer func() { <-done }()
+
+	// the reads of the regenerated pieces are bound to the context of the uploads,
+	// so the uploads of the long tail aren't stuck waiting for data.
+	return ec.putPieces(ctx, limits, privateKey, rs, func(ctx context.Context, i int) io.ReadCloser {
+		if limits[i] == nil {
+			return io.NopCloser(bytes.NewReader(nil))
+		}
+		return regenerator.Reader(ctx, i)
+	}, timeout, successfulNeeded)
+}
+
+// putPieces uploads the pieces of the readers to the nodes of the non-nil order limits,
+// until successfulNeeded uploads succeeded or the timeout passed. The readers are created
+// with the context of the uploads.
+func (ec *ECRepairer) putPieces(ctx context.Context, limits []*pb.AddressedOrderLimit, privateKey storj.PiecePrivateKey, rs eestream.RedundancyStrategy, newReader func(ctx context.Context, i int) io.ReadCloser, timeout time.Duration, successfulNeeded int) (successfulNodes []*pb.Node, successfulHashes []*pb.PieceHash, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	pieceCount := len(limits)
+
 	// info contains data about a single piece transfer
 	type info struct {
 		i    int
@@ -407,7 +510,7 @@ func (ec *ECRepairer) Repair(ctx context.Context, limits []*pb.AddressedOrderLim
 
 	for i, addressedLimit := range limits {
 		go func(i int, addressedLimit *pb.AddressedOrderLimit) {
-			hash, err := ec.putPiece(psCtx, ctx, addressedLimit, privateKey, readers[i])
+			hash, err := ec.putPiece(psCtx, ctx, addressedLimit, privateKey, newReader(psCtx, i))
 			infos <- info{i: i, err: err, hash: hash}
 		}(i, addressedLimit)
 	}
@@ -0,0 +1,220 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package repairer
+
+import (
+	"context"
+	"io"
+	"sync"
+	"time"
+
+	"storj.io/common/errs2"
+	"storj.io/common/storj"
+)
+
+// DownloadHedgeConfig contains the configuration for hedging the piece
+// downloads of the repairer.
+type DownloadHedgeConfig struct {
+	Count           int     `help:"number of piece downloads to start in addition to the required ones, the slowest downloads are canceled once enough pieces were downloaded, 0 disables it" default:"0"`
+	LatencyQuantile float64 `help:"start an additional piece download when a download takes longer than this quantile of the earlier download latencies of the node, 0 disables it" default:"0"`
+}
+
+const (
+	// latencyBuckets is the number of buckets of the latency histograms,
+	// the upper bound of bucket i is minLatencyBucket * 2^i.
+	latencyBuckets   = 24
+	minLatencyBucket = 10 * time.Millisecond
+	// latencyMinSamples is the number of the samples needed before the
+	// latency histogram of a node is used.
+	latencyMinSamples = 10
+	// latencyMaxSamples is the number of the samples after which the counts of
+	// the latency histogram of a node are halved, so that recent samples
+	// have more weight.
+	latencyMaxSamples = 1000
+)
+
+// latencyHistogram is a histogram of the piece download latencies of a node.
+type latencyHistogram struct {
+	counts [latencyBuckets]uint32
+	total  uint32
+}
+
+// LatencyTracker keeps histograms of the piece download latencies per node.
+type LatencyTracker struct {
+	mu    sync.Mutex
+	nodes map[storj.NodeID]*latencyHistogram
+}
+
+// NewLatencyTracker creates a new latency tracker.
+func NewLatencyTracker() *LatencyTracker {
+	return &LatencyTracker{
+		nodes: map[storj.NodeID]*latencyHistogram{},
+	}
+}
+
+// Record records the latency of a successful piece download from the node.
+func (tracker *LatencyTracker) Record(nodeID storj.NodeID, latency time.Duration) {
+	bucket := 0
+	for bound := minLatencyBucket; latency > bound && bucket < latencyBuckets-1; bound *= 2 {
+		bucket++
+	}
+
+	tracker.mu.Lock()
+	defer tracker.mu.Unlock()
+
+	histogram, ok := tracker.nodes[nodeID]
+	if !ok {
+		histogram = &latencyHistogram{}
+		tracker.nodes[nodeID] = histogram
+	}
+
+	if histogram.total >= latencyMaxSamples {
+		histogram.total = 0
+		for i := range histogram.counts {
+			histogram.counts[i] /= 2
+			histogram.total += histogram.counts[i]
+		}
+	}
+
+	histogram.counts[bucket]++
+	histogram.total++
+}
+
+// Quantile returns the upper bound of the latency histogram bucket which
+// contains the quantile q of the latencies of the node. ok is false when
+// there are not enough recorded latencies of the node.
+func (tracker *LatencyTracker) Quantile(nodeID storj.NodeID, q float64) (_ time.Duration, ok bool) {
+	tracker.mu.Lock()
+	defer tracker.mu.Unlock()
+
+	histogram, found := tracker.nodes[nodeID]
+	if !found || histogram.total < latencyMinSamples {
+		return 0, false
+	}
+
+	target := q * float64(histogram.total)
+	var cumulative float64
+	bound := minLatencyBucket
+	for _, count := range histogram.counts {
+		cumulative += float64(count)
+		if cumulative >= target {
+			break
+		}
+		bound *= 2
+	}
+	return bound, true
+}
+
+// downloadResult is the result of a single piece download.
+type downloadResult struct {
+	index    int
+	reader   io.ReadCloser
+	err      error
+	duration time.Duration
+	// canceled is true when the download failed, because it was canceled
+	// after enough pieces were downloaded.
+	canceled bool
+}
+
+// hedgedDownloads downloads the pieces in the given order until the required
+// number of downloads succeeded or there are no more pieces to download.
+//
+// It keeps hedge more downloads in progress than needed for the required
+// count. Additionally while a download takes longer than its latency budget,
+// one more download is kept in progress. A zero budget means no budget. Once
+// enough downloads succeeded the remaining ones are canceled.
+//
+// It returns the results of the finished downloads. The downloads which were
+// canceled are not returned, but the ones which failed or succeeded before
+// noticing the cancellation are.
+func hedgedDownloads(ctx context.Context, order []int, required, hedge int, budget func(index int) time.Duration, download func(ctx context.Context, index int) (io.ReadCloser, error)) (finished []downloadResult) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// both channels are buffered enough to never block the senders.
+	results := make(chan downloadResult, len(order))
+	slow := make(chan int, len(order))
+
+	timers := map[int]*time.Timer{}
+	defer func() {
+		for _, timer := range timers {
+			timer.Stop()
+		}
+	}()
+
+	// inProgress contains the downloads in progress, with whether they
+	// exceeded their latency budget.
+	inProgress := map[int]bool{}
+
+	start := func(index int) {
+		inProgress[index] = false
+		if limit := budget(index); limit > 0 {
+			timers[index] = time.AfterFunc(limit, func() { slow <- index })
+		}
+		go func() {
+			started := time.Now()
+			reader, err := download(ctx, index)
+			results <- downloadResult{
+				index:    index,
+				reader:   reader,
+				err:      err,
+				duration: time.Since(started),
+				canceled: ctx.Err() != nil && errs2.IsCanceled(err),
+			}
+		}()
+	}
+
+	finish := func(result downloadResult) {
+		if timer, ok := timers[result.index]; ok {
+			timer.Stop()
+			delete(timers, result.index)
+		}
+		delete(inProgress, result.index)
+
+		if result.canceled {
+			if result.reader != nil {
+				_ = result.reader.Close()
+			}
+			return
+		}
+		finished = append(finished, result)
+	}
+
+	var next, successful, slowDownloads int
+	for {
+		for successful < required && next < len(order) && len(inProgress) < required-successful+hedge+slowDownloads {
+			start(order[next])
+			next++
+		}
+		if len(inProgress) == 0 || successful >= required {
+			break
+		}
+
+		select {
+		case result := <-results:
+			if inProgress[result.index] {
+				// the download over its budget is replaced by the result.
+				slowDownloads--
+			}
+			finish(result)
+			if result.err == nil {
+				successful++
+			}
+		case index := <-slow:
+			if exceeded, ok := inProgress[index]; ok && !exceeded {
+				mon.Meter("repair_download_hedged").Mark(1)
+				inProgress[index] = true
+				slowDownloads++
+			}
+		}
+	}
+
+	// cancel the slowest downloads, only the canceled ones aren't reported.
+	cancel()
+	for len(inProgress) > 0 {
+		finish(<-results)
+	}
+
+	return finished
+}
@@ -0,0 +1,187 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package repairer
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"storj.io/common/testcontext"
+	"storj.io/common/testrand"
+)
+
+func TestLatencyTracker(t *testing.T) {
+	tracker := NewLatencyTracker()
+	nodeID := testrand.NodeID()
+
+	_, ok := tracker.Quantile(nodeID, 0.5)
+	require.False(t, ok)
+
+	for i := 0; i < 9; i++ {
+		tracker.Record(nodeID, 15*time.Millisecond)
+	}
+	tracker.Record(nodeID, time.Second)
+
+	median, ok := tracker.Quantile(nodeID, 0.5)
+	require.True(t, ok)
+	require.Equal(t, 20*time.Millisecond, median)
+
+	slowest, ok := tracker.Quantile(nodeID, 1)
+	require.True(t, ok)
+	require.Equal(t, 1280*time.Millisecond, slowest)
+
+	// old samples lose weight.
+	for i := 0; i < 2*latencyMaxSamples; i++ {
+		tracker.Record(nodeID, 5*time.Second)
+	}
+	median, ok = tracker.Quantile(nodeID, 0.5)
+	require.True(t, ok)
+	require.Equal(t, 5120*time.Millisecond, median)
+
+	_, ok = tracker.Quantile(testrand.NodeID(), 0.5)
+	require.False(t, ok)
+}
+
+func TestHedgedDownloads(t *testing.T) {
+	ctx := testcontext.New(t)
+
+	order := []int{0, 1, 2, 3, 4, 5}
+	noBudget := func(int) time.Duration { return 0 }
+
+	// fakeDownloads simulates downloads, the downloads listed in slow only
+	// finish when they are canceled and the ones listed in failed fail.
+	// the downloads listed in late fail with an error other than the
+	// cancellation, once they are canceled.
+	type fakeDownloads struct {
+		mu       sync.Mutex
+		started  []int
+		canceled []int
+	}
+	run := func(required, hedge int, budget func(int) time.Duration, slow, failed, late map[int]bool) ([]downloadResult, *fakeDownloads) {
+		fake := &fakeDownloads{}
+		results := hedgedDownloads(ctx, order, required, hedge, budget, func(ctx context.Context, index int) (io.ReadCloser, error) {
+			fake.mu.Lock()
+			fake.started = append(fake.started, index)
+			fake.mu.Unlock()
+
+			switch {
+			case late[index]:
+				<-ctx.Done()
+				return nil, errors.New("piece not found")
+			case slow[index]:
+				<-ctx.Done()
+				fake.mu.Lock()
+				fake.canceled = append(fake.canceled, index)
+				fake.mu.Unlock()
+				return nil, ctx.Err()
+			case failed[index]:
+				return nil, errors.New("download failed")
+			}
+			return io.NopCloser(bytes.NewReader(nil)), nil
+		})
+		return results, fake
+	}
+
+	successful := func(results []downloadResult) (indexes []int) {
+		for _, result := range results {
+			if result.err == nil {
+				indexes = append(indexes, result.index)
+			}
+		}
+		sort.Ints(indexes)
+		return indexes
+	}
+
+	t.Run("no hedge", func(t *testing.T) {
+		results, fake := run(3, 0, noBudget, nil, nil, nil)
+		require.Equal(t, []int{0, 1, 2}, successful(results))
+		require.Len(t, fake.started, 3)
+	})
+
+	t.Run("failed downloads are replaced", func(t *testing.T) {
+		results, fake := run(3, 0, noBudget, nil, map[int]bool{1: true}, nil)
+		require.Equal(t, []int{0, 2, 3}, successful(results))
+		require.Len(t, results, 4)
+		require.Len(t, fake.started, 4)
+	})
+
+	t.Run("slow downloads are canceled", func(t *testing.T) {
+		results, fake := run(3, 2, noBudget, map[int]bool{0: true, 1: true}, nil, nil)
+		require.Equal(t, []int{2, 3, 4}, successful(results))
+		// canceled downloads are not reported.
+		require.Len(t, results, 3)
+		require.ElementsMatch(t, []int{0, 1}, fake.canceled)
+	})
+
+	t.Run("latency budget starts more downloads", func(t *testing.T) {
+		budget := func(index int) time.Duration { return time.Millisecond }
+		results, fake := run(2, 0, budget, map[int]bool{0: true, 1: true}, nil, nil)
+		require.Equal(t, []int{2, 3}, successful(results))
+		require.ElementsMatch(t, []int{0, 1}, fake.canceled)
+	})
+
+	t.Run("late failures are reported", func(t *testing.T) {
+		results, fake := run(2, 2, noBudget, map[int]bool{0: true}, nil, map[int]bool{1: true})
+		require.Equal(t, []int{2, 3}, successful(results))
+		require.Len(t, results, 3)
+		for _, result := range results {
+			if result.index == 1 {
+				require.Error(t, result.err)
+			}
+		}
+		require.Equal(t, []int{0}, fake.canceled)
+	})
+
+	t.Run("finished slow downloads are not replaced", func(t *testing.T) {
+		// the first download exceeds its budget and fails while the additional
+		// download is in progress, which already replaces it.
+		var mu sync.Mutex
+		exceeded := make(chan struct{})
+		budget := func(index int) time.Duration {
+			if index == 0 {
+				return time.Millisecond
+			}
+			return 0
+		}
+
+		started := map[int]bool{}
+		results := hedgedDownloads(ctx, order, 1, 0, budget, func(ctx context.Context, index int) (io.ReadCloser, error) {
+			mu.Lock()
+			started[index] = true
+			mu.Unlock()
+
+			switch index {
+			case 0:
+				time.Sleep(50 * time.Millisecond)
+				close(exceeded)
+				return nil, errors.New("download failed")
+			case 1:
+				<-exceeded
+				time.Sleep(100 * time.Millisecond)
+				return io.NopCloser(bytes.NewReader(nil)), nil
+			}
+			return nil, errors.New("unexpected download")
+		})
+
+		require.Equal(t, []int{1}, successful(results))
+		mu.Lock()
+		defer mu.Unlock()
+		require.Equal(t, map[int]bool{0: true, 1: true}, started)
+	})
+
+	t.Run("not enough pieces", func(t *testing.T) {
+		results, fake := run(3, 1, noBudget, nil, map[int]bool{0: true, 1: true, 2: true, 3: true}, nil)
+		require.Equal(t, []int{4, 5}, successful(results))
+		require.Len(t, results, 6)
+		require.Len(t, fake.started, 6)
+	})
+}
@@ -0,0 +1,298 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package repairer
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"sort"
+	"sync"
+
+	"github.com/vivint/infectious"
+	"github.com/zeebo/errs"
+)
+
+// ErrUploadTooSlow is the errs class when the upload of a regenerated piece fell behind
+// the uploads of the other regenerated pieces.
+var ErrUploadTooSlow = errs.Class("upload too slow")
+
+// pieceRegenerator regenerates the erasure shares of the pieces in need of repair stripe
+// by stripe from the erasure shares of the required number of verified pieces. Every
+// stripe is decoded once and only the shares of the regenerated pieces are encoded, so
+// neither the segment nor the regenerated pieces have to be kept whole.
+//
+// It reduces the memory and CPU usage of the repair, but not the egress: every stripe
+// still needs the shares of the required number of pieces, so the required number of
+// pieces is downloaded the same as for the full decoding.
+//
+// Every regenerated piece has a buffer of bounded size, which is read by its upload.
+// When the buffer of a piece is full, the regenerator waits for the upload to read from
+// it. When at least the needed number of other uploads already wait for data meanwhile,
+// the slow upload is dropped instead, the same as the long tail of the uploads.
+type pieceRegenerator struct {
+	fec        *infectious.FEC
+	shareSize  int
+	pieceSize  int64
+	inputs     []int
+	readers    map[int]io.ReadCloser
+	bufferSize int
+	needed     int
+
+	mu     sync.Mutex
+	cond   sync.Cond
+	pieces []*regeneratedPiece
+	// done is closed when the regeneration finished.
+	done chan struct{}
+}
+
+// regeneratedPiece is the reader of a regenerated piece.
+type regeneratedPiece struct {
+	regenerator *pieceRegenerator
+	number      int
+	buffer      bytes.Buffer
+	closed      bool
+	err         error
+}
+
+// newPieceRegenerator creates a regenerator of the pieces with the given numbers from
+// the verified pieces.
+func newPieceRegenerator(pieces *VerifiedPieces, numbers []int, bufferSize, needed int) (*pieceRegenerator, error) {
+	required := pieces.fec.Required()
+	if len(pieces.readers) < required {
+		return nil, Error.New("number of verified pieces (%d) is less than required count (%d)", len(pieces.readers), required)
+	}
+	if pieces.pieceSize%int64(pieces.shareSize) != 0 {
+		return nil, Error.New("piece size (%d) is not a multiple of the erasure share size (%d)", pieces.pieceSize, pieces.shareSize)
+	}
+
+	// the data shares don't need to be decoded, so they are preferred.
+	var inputs []int
+	for number := range pieces.readers {
+		inputs = append(inputs, number)
+	}
+	sort.Ints(inputs)
+
+	if bufferSize < pieces.shareSize {
+		bufferSize = pieces.shareSize
+	}
+	if needed < 1 {
+		needed = 1
+	}
+
+	regenerator := &pieceRegenerator{
+		fec:        pieces.fec,
+		shareSize:  pieces.shareSize,
+		pieceSize:  pieces.pieceSize,
+		inputs:     inputs[:required],
+		readers:    pieces.readers,
+		bufferSize: bufferSize,
+		needed:     needed,
+		done:       make(chan struct{}),
+	}
+	regenerator.cond.L = &regenerator.mu
+
+	for _, number := range numbers {
+		regenerator.pieces = append(regenerator.pieces, &regeneratedPiece{
+			regenerator: regenerator,
+			number:      number,
+		})
+	}
+
+	return regenerator, nil
+}
+
+// Reader returns the reader of the regenerated piece with the number. When the context
+// is canceled, the regeneration of the piece is stopped and the reads waiting for data
+// return the error of the context.
+func (regenerator *pieceRegenerator) Reader(ctx context.Context, number int) io.ReadCloser {
+	for _, piece := range regenerator.pieces {
+		if piece.number == number {
+			regenerator.watch(ctx, piece)
+			return piece
+		}
+	}
+	return nil
+}
+
+// Run regenerates the pieces until all of them are regenerated, all of their readers
+// are closed or an error occurs. The error is returned by the readers.
+func (regenerator *pieceRegenerator) Run(ctx context.Context) {
+	regenerator.watch(ctx, nil)
+
+	err := regenerator.regenerate(ctx)
+	if err == nil {
+		err = io.EOF
+	}
+
+	regenerator.fail(nil, err)
+	close(regenerator.done)
+}
+
+// watch fails the piece, or every piece when piece is nil, with the error of the context
+// when it's canceled before the regeneration finished.
+func (regenerator *pieceRegenerator) watch(ctx context.Context, piece *regeneratedPiece) {
+	if ctx.Done() == nil {
+		return
+	}
+	go func() {
+		select {
+		case <-ctx.Done():
+			regenerator.fail(piece, ctx.Err())
+		case <-regenerator.done:
+		}
+	}()
+}
+
+// fail sets the error of the piece, or of every piece when piece is nil, unless it
+// already has one, and wakes up the waiting reads and writes.
+func (regenerator *pieceRegenerator) fail(piece *regeneratedPiece, err error) {
+	regenerator.mu.Lock()
+	defer regenerator.mu.Unlock()
+
+	for _, p := range regenerator.pieces {
+		if (piece == nil || p == piece) && p.err == nil {
+			p.err = err
+		}
+	}
+	regenerator.cond.Broadcast()
+}
+
+func (regenerator *pieceRegenerator) regenerate(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	required := regenerator.fec.Required()
+
+	shares := make([]infectious.Share, required)
+	buffers := make([][]byte, required)
+	for i := range buffers {
+		buffers[i] = make([]byte, regenerator.shareSize)
+	}
+	stripe := make([]byte, required*regenerator.shareSize)
+	share := make([]byte, regenerator.shareSize)
+
+	for offset := int64(0); offset < regenerator.pieceSize; offset += int64(regenerator.shareSize) {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		for i, number := range regenerator.inputs {
+			if _, err := io.ReadFull(regenerator.readers[number], buffers[i]); err != nil {
+				return Error.Wrap(err)
+			}
+			shares[i] = infectious.Share{Number: number, Data: buffers[i]}
+		}
+
+		err := regenerator.fec.Rebuild(shares, func(s infectious.Share) {
+			copy(stripe[s.Number*regenerator.shareSize:], s.Data)
+		})
+		if err != nil {
+			return Error.Wrap(err)
+		}
+
+		active := 0
+		for _, piece := range regenerator.pieces {
+			if !regenerator.active(piece) {
+				continue
+			}
+			if err := regenerator.fec.EncodeSingle(stripe, share, piece.number); err != nil {
+				return Error.Wrap(err)
+			}
+			if regenerator.write(piece, share) {
+				active++
+			}
+		}
+
+		if active == 0 {
+			return nil
+		}
+	}
+
+	mon.Meter("repair_pieces_regenerated").Mark(len(regenerator.pieces))
+	return nil
+}
+
+// active returns whether the piece is still read by its upload.
+func (regenerator *pieceRegenerator) active(piece *regeneratedPiece) bool {
+	regenerator.mu.Lock()
+	defer regenerator.mu.Unlock()
+
+	return !piece.closed && piece.err == nil
+}
+
+// write writes the share to the buffer of the piece, when the buffer is full it waits
+// for the upload to read from it or drops the upload when it's too slow. It returns
+// whether the share was written.
+func (regenerator *pieceRegenerator) write(piece *regeneratedPiece, share []byte) bool {
+	regenerator.mu.Lock()
+	defer regenerator.mu.Unlock()
+
+	for {
+		if piece.closed || piece.err != nil {
+			return false
+		}
+
+		if piece.buffer.Len()+len(share) <= regenerator.bufferSize {
+			_, _ = piece.buffer.Write(share)
+			regenerator.cond.Broadcast()
+			return true
+		}
+
+		if regenerator.waitingLocked(piece) >= regenerator.needed {
+			mon.Meter("repair_regenerated_upload_too_slow").Mark(1)
+			piece.buffer = bytes.Buffer{}
+			// make sure context.Canceled is in the error chain, so the upload
+			// is handled the same as a canceled upload of the long tail.
+			piece.err = errs.Combine(context.Canceled, ErrUploadTooSlow.New("piece %d", piece.number))
+			regenerator.cond.Broadcast()
+			return false
+		}
+
+		regenerator.cond.Wait()
+	}
+}
+
+// waitingLocked returns the number of the other uploads, which read all of their data.
+func (regenerator *pieceRegenerator) waitingLocked(slow *regeneratedPiece) int {
+	waiting := 0
+	for _, piece := range regenerator.pieces {
+		if piece != slow && !piece.closed && piece.err == nil && piece.buffer.Len() == 0 {
+			waiting++
+		}
+	}
+	return waiting
+}
+
+// Read reads the regenerated piece.
+func (piece *regeneratedPiece) Read(p []byte) (n int, err error) {
+	regenerator := piece.regenerator
+	regenerator.mu.Lock()
+	defer regenerator.mu.Unlock()
+
+	for piece.buffer.Len() == 0 {
+		if piece.closed {
+			return 0, io.ErrClosedPipe
+		}
+		if piece.err != nil {
+			return 0, piece.err
+		}
+		regenerator.cond.Wait()
+	}
+
+	n, _ = piece.buffer.Read(p)
+	regenerator.cond.Broadcast()
+	return n, nil
+}
+
+// Close stops the regeneration of the piece.
+func (piece *regeneratedPiece) Close() error {
+	regenerator := piece.regenerator
+	regenerator.mu.Lock()
+	defer regenerator.mu.Unlock()
+
+	piece.closed = true
+	piece.buffer = bytes.Buffer{}
+	regenerator.cond.Broadcast()
+	return nil
+}
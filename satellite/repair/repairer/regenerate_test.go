@@ -0,0 +1,166 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package repairer
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/vivint/infectious"
+	"github.com/zeebo/errs"
+	"golang.org/x/sync/errgroup"
+
+	"storj.io/common/testcontext"
+	"storj.io/common/testrand"
+)
+
+func TestPieceRegenerator(t *testing.T) {
+	ctx := testcontext.New(t)
+
+	fec, pieces := encodeTestPieces(t, 4, 8, 16, 10)
+
+	verified := verifiedTestPieces(fec, 16, pieces, 1, 2, 5, 6, 7)
+	regenerator, err := newPieceRegenerator(verified, []int{0, 3, 4}, 64, 3)
+	require.NoError(t, err)
+
+	ctx.Go(func() error {
+		regenerator.Run(ctx)
+		return nil
+	})
+
+	var group errgroup.Group
+	results := make([][]byte, 3)
+	for i, number := range []int{0, 3, 4} {
+		i, reader := i, regenerator.Reader(ctx, number)
+		group.Go(func() (err error) {
+			defer func() { err = errs.Combine(err, reader.Close()) }()
+			results[i], err = io.ReadAll(reader)
+			return err
+		})
+	}
+	require.NoError(t, group.Wait())
+
+	require.Equal(t, pieces[0], results[0])
+	require.Equal(t, pieces[3], results[1])
+	require.Equal(t, pieces[4], results[2])
+}
+
+func TestPieceRegenerator_SlowUpload(t *testing.T) {
+	ctx := testcontext.New(t)
+
+	fec, pieces := encodeTestPieces(t, 4, 8, 16, 10)
+
+	verified := verifiedTestPieces(fec, 16, pieces, 4, 5, 6, 7)
+	regenerator, err := newPieceRegenerator(verified, []int{0, 3}, 16, 1)
+	require.NoError(t, err)
+
+	ctx.Go(func() error {
+		regenerator.Run(ctx)
+		return nil
+	})
+
+	fast, slow := regenerator.Reader(ctx, 0), regenerator.Reader(ctx, 3)
+
+	data, err := io.ReadAll(fast)
+	require.NoError(t, err)
+	require.Equal(t, pieces[0], data)
+
+	_, err = io.ReadAll(slow)
+	require.True(t, ErrUploadTooSlow.Has(err))
+	require.True(t, errors.Is(err, context.Canceled))
+
+	require.NoError(t, fast.Close())
+	require.NoError(t, slow.Close())
+}
+
+func TestPieceRegenerator_Canceled(t *testing.T) {
+	ctx := testcontext.New(t)
+
+	fec, pieces := encodeTestPieces(t, 4, 8, 16, 10)
+
+	verified := verifiedTestPieces(fec, 16, pieces, 4, 5, 6, 7)
+	// the slow upload is never dropped, because not enough uploads wait.
+	regenerator, err := newPieceRegenerator(verified, []int{0, 3}, 16, 2)
+	require.NoError(t, err)
+
+	ctx.Go(func() error {
+		regenerator.Run(ctx)
+		return nil
+	})
+
+	slowCtx, cancel := context.WithCancel(ctx)
+	fast, slow := regenerator.Reader(ctx, 0), regenerator.Reader(slowCtx, 3)
+
+	// the regeneration waits for the slow upload, until its context is canceled.
+	buf := make([]byte, 16)
+	_, err = io.ReadFull(fast, buf)
+	require.NoError(t, err)
+	cancel()
+
+	rest, err := io.ReadAll(fast)
+	require.NoError(t, err)
+	require.Equal(t, pieces[0], append(buf, rest...))
+
+	_, err = io.ReadAll(slow)
+	require.ErrorIs(t, err, context.Canceled)
+
+	require.NoError(t, fast.Close())
+	require.NoError(t, slow.Close())
+}
+
+func TestPieceRegenerator_Closed(t *testing.T) {
+	ctx := testcontext.New(t)
+
+	fec, pieces := encodeTestPieces(t, 4, 8, 16, 10)
+
+	verified := verifiedTestPieces(fec, 16, pieces, 0, 1, 2, 3)
+	regenerator, err := newPieceRegenerator(verified, []int{4, 5}, 16, 1)
+	require.NoError(t, err)
+
+	require.NoError(t, regenerator.Reader(ctx, 4).Close())
+	require.NoError(t, regenerator.Reader(ctx, 5).Close())
+
+	// returns after the first stripe, without waiting for any reader.
+	regenerator.Run(ctx)
+
+	remaining, err := io.ReadAll(verified.readers[0])
+	require.NoError(t, err)
+	require.Len(t, remaining, len(pieces[0])-16)
+
+	_, err = newPieceRegenerator(verifiedTestPieces(fec, 16, pieces, 0, 1, 2), []int{4}, 16, 1)
+	require.Error(t, err)
+}
+
+// encodeTestPieces encodes random data into pieces of the given number of stripes.
+func encodeTestPieces(t *testing.T, required, total, shareSize, stripes int) (*infectious.FEC, [][]byte) {
+	fec, err := infectious.NewFEC(required, total)
+	require.NoError(t, err)
+
+	pieces := make([][]byte, total)
+	for i := 0; i < stripes; i++ {
+		err := fec.Encode(testrand.BytesInt(required*shareSize), func(share infectious.Share) {
+			pieces[share.Number] = append(pieces[share.Number], share.Data...)
+		})
+		require.NoError(t, err)
+	}
+	return fec, pieces
+}
+
+// verifiedTestPieces returns the pieces with the numbers as verified pieces.
+func verifiedTestPieces(fec *infectious.FEC, shareSize int, pieces [][]byte, numbers ...int) *VerifiedPieces {
+	readers := make(map[int]io.ReadCloser, len(numbers))
+	for _, number := range numbers {
+		readers[number] = io.NopCloser(bytes.NewReader(pieces[number]))
+	}
+	return &VerifiedPieces{
+		readers:   readers,
+		fec:       fec,
+		shareSize: shareSize,
+		pieceSize: int64(len(pieces[0])),
+	}
+}
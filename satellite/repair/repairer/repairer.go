@@ -35,6 +35,9 @@ type Config struct {
 	MaxExcessRateOptimalThreshold float64       `help:"ratio applied to the optimal threshold to calculate the excess of the maximum number of repaired pieces to upload" default:"0.05"`
 	InMemoryRepair                bool          `help:"whether to download pieces for repair in memory (true) or download to disk (false)" default:"false"`
 	ReputationUpdateEnabled       bool          `help:"whether the audit score of nodes should be updated as a part of repair" default:"false"`
+	StreamingRepair               bool          `help:"whether to regenerate only the missing pieces stripe by stripe (true) or decode and re-encode the whole segment (false), it reduces the memory usage, but not the egress of the repair" default:"false"`
+	StreamingBufferSize           memory.Size   `help:"maximum buffered size of every regenerated piece with streaming repair" default:"1.0 MiB"`
+	DownloadHedge                 DownloadHedgeConfig
 }
 
 // Service contains the information needed to run the repair service.
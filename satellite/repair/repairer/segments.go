@@ -86,6 +86,11 @@ type SegmentRepairer struct {
 
 	reputationUpdateEnabled bool
 
+	// streaming and streamingBufferSize configure whether only the missing
+	// pieces are regenerated stripe by stripe.
+	streaming           bool
+	streamingBufferSize int
+
 	// multiplierOptimalThreshold is the value that multiplied by the optimal
 	// threshold results in the maximum limit of number of nodes to upload
 	// repaired pieces
@@ -132,6 +137,8 @@ func NewSegmentRepairer(
 		repairOverrides:            repairOverrides.GetMap(),
 		reporter:                   reporter,
 		reputationUpdateEnabled:    config.ReputationUpdateEnabled,
+		streaming:                  config.StreamingRepair,
+		streamingBufferSize:        config.StreamingBufferSize.Int(),
 
 		nowFn: time.Now,
 	}
@@ -212,7 +219,7 @@ func (repairer *SegmentRepairer) Repair(ctx context.Context, queueSegment *queue
 		return false, nil
 	}
 
-	piecesInExcludedCountries, err := repairer.overlay.GetReliablePiecesInExcludedCountries(ctx, pieces)
+	piecesInExcludedCountries, err := repairer.overlay.GetReliablePiecesInExcludedCountries(ctx, pieces, segment.Placement)
 	if err != nil {
 		return false, overlayQueryError.New("error identifying pieces in excluded countries: %w", err)
 	}
@@ -308,6 +315,7 @@ func (repairer *SegmentRepairer) Repair(ctx context.Context, queueSegment *queue
 	request := overlay.FindStorageNodesRequest{
 		RequestedCount: requestCount,
 		ExcludedIDs:    excludeNodeIDs,
+		Placement:      segment.Placement,
 	}
 	newNodes, err := repairer.overlay.FindStorageNodesForUpload(ctx, request)
 	if err != nil {
@@ -320,8 +328,8 @@ func (repairer *SegmentRepairer) Repair(ctx context.Context, queueSegment *queue
 		return false, orderLimitFailureError.New("could not create PUT_REPAIR order limits: %w", err)
 	}
 
-	// Download the segment using just the healthy pieces
-	segmentReader, piecesReport, err := repairer.ec.Get(ctx, getOrderLimits, cachedNodesInfo, getPrivateKey, redundancy, int64(segment.EncryptedSize))
+	// Download the pieces of the segment using just the healthy pieces
+	segmentPieces, piecesReport, err := repairer.ec.GetPieces(ctx, getOrderLimits, cachedNodesInfo, getPrivateKey, redundancy, int64(segment.EncryptedSize))
 
 	// ensure we get values, even if only zero values, so that redash can have an alert based on this
 	mon.Meter("repair_too_many_nodes_failed").Mark(0)     //mon:locked
@@ -428,7 +436,7 @@ func (repairer *SegmentRepairer) Repair(ctx context.Context, queueSegment *queue
 		// The segment's redundancy strategy is invalid, or else there was an internal error.
 		return true, repairReconstructError.New("segment could not be reconstructed: %w", err)
 	}
-	defer func() { err = errs.Combine(err, segmentReader.Close()) }()
+	defer func() { err = errs.Combine(err, segmentPieces.Close()) }()
 
 	// only report audit result when segment can be successfully downloaded
 	cachedNodesReputation := make(map[storj.NodeID]overlay.ReputationStatus, len(cachedNodesInfo))
@@ -438,6 +446,7 @@ func (repairer *SegmentRepairer) Repair(ctx context.Context, queueSegment *queue
 
 	report := audit.Report{
 		NodesReputation: cachedNodesReputation,
+		Segment:         &audit.Segment{StreamID: segment.StreamID, Position: segment.Position},
 	}
 
 	for _, outcome := range piecesReport.Successful {
@@ -461,7 +470,12 @@ func (repairer *SegmentRepairer) Repair(ctx context.Context, queueSegment *queue
 	}
 
 	// Upload the repaired pieces
-	successfulNodes, _, err := repairer.ec.Repair(ctx, putLimits, putPrivateKey, redundancy, segmentReader, repairer.timeout, minSuccessfulNeeded)
+	var successfulNodes []*pb.Node
+	if repairer.streaming {
+		successfulNodes, _, err = repairer.ec.RepairMissing(ctx, putLimits, putPrivateKey, redundancy, segmentPieces, repairer.streamingBufferSize, repairer.timeout, minSuccessfulNeeded)
+	} else {
+		successfulNodes, _, err = repairer.ec.Repair(ctx, putLimits, putPrivateKey, redundancy, segmentPieces.Decode(ctx), repairer.timeout, minSuccessfulNeeded)
+	}
 	if err != nil {
 		return false, repairPutError.Wrap(err)
 	}
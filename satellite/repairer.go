@@ -229,7 +229,8 @@ func NewRepairer(log *zap.Logger, full *identity.FullIdentity,
 			peer.Dialer,
 			signing.SigneeFromPeerIdentity(peer.Identity.PeerIdentity()),
 			config.Repairer.DownloadTimeout,
-			config.Repairer.InMemoryRepair)
+			config.Repairer.InMemoryRepair,
+			config.Repairer.DownloadHedge)
 
 		peer.SegmentRepairer = repairer.NewSegmentRepairer(
 			log.Named("segment-repair"),
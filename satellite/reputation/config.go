@@ -38,6 +38,7 @@ type Config struct {
 	ErrorRetryInterval    time.Duration `help:"the amount of time that should elapse before the cache retries failed database operations" releaseDefault:"1m" devDefault:"5s"`
 	InitialAlpha          float64       `help:"the value to which an alpha reputation value should be initialized" default:"1000"`
 	InitialBeta           float64       `help:"the value to which a beta reputation value should be initialized" default:"0"`
+	ExplanationAudits     int           `help:"the number of most recent audits of a node stored to explain its disqualification, 0 disables storing them" default:"0"`
 }
 
 // UpdateRequest is used to update a node's reputation status.
@@ -15,6 +15,7 @@ import (
 
 	"storj.io/common/errs2"
 	"storj.io/common/pb"
+	"storj.io/common/storj"
 	"storj.io/common/testcontext"
 	"storj.io/common/testrand"
 	"storj.io/storj/private/testplanet"
@@ -242,6 +243,50 @@ func TestApplyUpdatesEquivalentToMultipleUpdatesCached(t *testing.T) {
 	})
 }
 
+func TestDBAuditStats(t *testing.T) {
+	satellitedbtest.Run(t, func(ctx *testcontext.Context, t *testing.T, db satellite.DB) {
+		reputationDB := db.Reputation()
+		now := time.Now()
+
+		vetted, unvetted, disqualified := testrand.NodeID(), testrand.NodeID(), testrand.NodeID()
+		for _, audit := range []struct {
+			nodeID storj.NodeID
+			count  int
+		}{
+			{vetted, 2},
+			{unvetted, 1},
+			{disqualified, 1},
+		} {
+			for i := 0; i < audit.count; i++ {
+				_, err := reputationDB.Update(ctx, reputation.UpdateRequest{
+					NodeID:       audit.nodeID,
+					AuditOutcome: reputation.AuditSuccess,
+					Config: reputation.Config{
+						AuditCount:   2,
+						AuditHistory: testAuditHistoryConfig(),
+					},
+				}, now)
+				require.NoError(t, err)
+			}
+		}
+		require.NoError(t, reputationDB.DisqualifyNode(ctx, disqualified, now, overlay.DisqualificationReasonAuditFailure))
+
+		stats, err := reputationDB.AuditStats(ctx, now.Add(-2*time.Hour))
+		require.NoError(t, err)
+		require.Equal(t, map[storj.NodeID]reputation.AuditStats{
+			vetted:   {Vetted: true, RecentAudits: 2},
+			unvetted: {Vetted: false, RecentAudits: 1},
+		}, stats)
+
+		stats, err = reputationDB.AuditStats(ctx, now.Add(2*time.Hour))
+		require.NoError(t, err)
+		require.Equal(t, map[storj.NodeID]reputation.AuditStats{
+			vetted:   {Vetted: true, RecentAudits: 0},
+			unvetted: {Vetted: false, RecentAudits: 0},
+		}, stats)
+	})
+}
+
 func TestDBDisqualifyNode(t *testing.T) {
 	satellitedbtest.Run(t, func(ctx *testcontext.Context, t *testing.T, db satellite.DB) {
 		reputationDB := db.Reputation()
@@ -392,3 +437,48 @@ func testAuditHistoryConfig() reputation.AuditHistoryConfig {
 		OfflineThreshold: 0,
 	}
 }
+
+func TestRecentAuditsDB(t *testing.T) {
+	satellitedbtest.Run(t, func(ctx *testcontext.Context, t *testing.T, db satellite.DB) {
+		reputationDB := db.Reputation()
+		nodeID := testrand.NodeID()
+		other := testrand.NodeID()
+		now := time.Now().UTC().Truncate(time.Second)
+
+		audits, err := reputationDB.GetRecentAudits(ctx, nodeID)
+		require.NoError(t, err)
+		require.Empty(t, audits)
+
+		audit := func(i int) overlay.DisqualificationAudit {
+			return overlay.DisqualificationAudit{
+				AuditedAt:  now.Add(time.Duration(i) * time.Minute),
+				Outcome:    reputation.AuditSuccess.String(),
+				Segment:    &overlay.AuditedSegment{StreamID: testrand.UUID(), Position: uint64(i)},
+				AuditScore: 1,
+			}
+		}
+
+		// audits with the same time are distinct.
+		require.NoError(t, reputationDB.AddRecentAudits(ctx, nodeID, []overlay.DisqualificationAudit{audit(0), audit(0)}, 3))
+		audits, err = reputationDB.GetRecentAudits(ctx, nodeID)
+		require.NoError(t, err)
+		require.Len(t, audits, 2)
+
+		require.NoError(t, reputationDB.AddRecentAudits(ctx, nodeID, []overlay.DisqualificationAudit{audit(3), audit(2)}, 3))
+		require.NoError(t, reputationDB.AddRecentAudits(ctx, nodeID, []overlay.DisqualificationAudit{audit(4)}, 3))
+		require.NoError(t, reputationDB.AddRecentAudits(ctx, other, []overlay.DisqualificationAudit{audit(0)}, 3))
+
+		// only the most recent audits of the node are kept, the oldest first.
+		audits, err = reputationDB.GetRecentAudits(ctx, nodeID)
+		require.NoError(t, err)
+		require.Len(t, audits, 3)
+		for i, audit := range audits {
+			require.Equal(t, now.Add(time.Duration(i+2)*time.Minute), audit.AuditedAt.UTC())
+			require.Equal(t, uint64(i+2), audit.Segment.Position)
+		}
+
+		audits, err = reputationDB.GetRecentAudits(ctx, other)
+		require.NoError(t, err)
+		require.Len(t, audits, 1)
+	})
+}
@@ -0,0 +1,81 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package reputation
+
+import (
+	"time"
+
+	"storj.io/common/storj"
+	"storj.io/storj/satellite/overlay"
+)
+
+// auditOutcome returns the audit with the scores of the node after it.
+func auditOutcome(info *Info, segment *overlay.AuditedSegment, result AuditType, now time.Time) overlay.DisqualificationAudit {
+	return overlay.DisqualificationAudit{
+		AuditedAt:         now,
+		Outcome:           result.String(),
+		Segment:           segment,
+		AuditScore:        score(info.AuditReputationAlpha, info.AuditReputationBeta),
+		UnknownAuditScore: score(info.UnknownAuditReputationAlpha, info.UnknownAuditReputationBeta),
+		OnlineScore:       info.OnlineScore,
+	}
+}
+
+// lastAudits returns the limit most recent audits of the audits, which are
+// ordered from the oldest.
+func lastAudits(audits []overlay.DisqualificationAudit, limit int) []overlay.DisqualificationAudit {
+	if len(audits) > limit {
+		return audits[len(audits)-limit:]
+	}
+	return audits
+}
+
+// explainDisqualification creates the explanation of the disqualification of a node
+// from its reputation and its recent audits.
+func explainDisqualification(nodeID storj.NodeID, info *Info, audits []overlay.DisqualificationAudit, config Config) overlay.DisqualificationExplanation {
+	explanation := overlay.DisqualificationExplanation{
+		NodeID:         nodeID,
+		DisqualifiedAt: *info.Disqualified,
+		Reason:         info.DisqualificationReason,
+		Scores: &overlay.DisqualificationScores{
+			AuditScore:            score(info.AuditReputationAlpha, info.AuditReputationBeta),
+			UnknownAuditScore:     score(info.UnknownAuditReputationAlpha, info.UnknownAuditReputationBeta),
+			OnlineScore:           info.OnlineScore,
+			AuditSuccessCount:     info.AuditSuccessCount,
+			TotalAuditCount:       info.TotalAuditCount,
+			AuditDQ:               config.AuditDQ,
+			UnknownAuditDQ:        config.UnknownAuditDQ,
+			OfflineThreshold:      config.AuditHistory.OfflineThreshold,
+			UnknownAuditSuspended: cloneTime(info.UnknownAuditSuspended),
+			OfflineSuspended:      cloneTime(info.OfflineSuspended),
+		},
+		RecentAudits: audits,
+	}
+
+	if info.AuditHistory != nil {
+		for _, window := range info.AuditHistory.Windows {
+			if window.TotalCount == 0 {
+				continue
+			}
+			if float64(window.OnlineCount)/float64(window.TotalCount) >= config.AuditHistory.OfflineThreshold {
+				continue
+			}
+			explanation.FailingWindows = append(explanation.FailingWindows, overlay.DisqualificationWindow{
+				WindowStart: window.WindowStart,
+				OnlineCount: window.OnlineCount,
+				TotalCount:  window.TotalCount,
+			})
+		}
+	}
+
+	return explanation
+}
+
+// score returns the reputation score of the alpha and beta values.
+func score(alpha, beta float64) float64 {
+	if alpha+beta == 0 {
+		return 1
+	}
+	return alpha / (alpha + beta)
+}
@@ -0,0 +1,59 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package reputation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"storj.io/common/pb"
+	"storj.io/common/testrand"
+	"storj.io/storj/satellite/overlay"
+)
+
+func TestExplainDisqualification(t *testing.T) {
+	nodeID := testrand.NodeID()
+	now := time.Now()
+
+	config := Config{AuditDQ: 0.96, UnknownAuditDQ: 0.6}
+	config.AuditHistory.OfflineThreshold = 0.6
+
+	info := &Info{
+		AuditSuccessCount:           8,
+		TotalAuditCount:             10,
+		Disqualified:                &now,
+		DisqualificationReason:      overlay.DisqualificationReasonAuditFailure,
+		OnlineScore:                 0.7,
+		AuditReputationAlpha:        9,
+		AuditReputationBeta:         1,
+		UnknownAuditReputationAlpha: 1,
+		AuditHistory: &pb.AuditHistory{
+			Windows: []*pb.AuditWindow{
+				{WindowStart: now.Add(-3 * time.Hour), OnlineCount: 1, TotalCount: 4},
+				{WindowStart: now.Add(-2 * time.Hour), OnlineCount: 3, TotalCount: 4},
+				{WindowStart: now.Add(-time.Hour), OnlineCount: 0, TotalCount: 0},
+			},
+		},
+	}
+	audits := []overlay.DisqualificationAudit{{AuditedAt: now, Outcome: AuditFailure.String()}}
+
+	explanation := explainDisqualification(nodeID, info, audits, config)
+	require.Equal(t, nodeID, explanation.NodeID)
+	require.Equal(t, now, explanation.DisqualifiedAt)
+	require.Equal(t, overlay.DisqualificationReasonAuditFailure, explanation.Reason)
+	require.Equal(t, audits, explanation.RecentAudits)
+
+	require.NotNil(t, explanation.Scores)
+	require.InDelta(t, 0.9, explanation.Scores.AuditScore, 1e-9)
+	require.InDelta(t, 1, explanation.Scores.UnknownAuditScore, 1e-9)
+	require.Equal(t, 0.7, explanation.Scores.OnlineScore)
+	require.Equal(t, int64(10), explanation.Scores.TotalAuditCount)
+	require.Equal(t, 0.96, explanation.Scores.AuditDQ)
+
+	require.Equal(t, []overlay.DisqualificationWindow{
+		{WindowStart: now.Add(-3 * time.Hour), OnlineCount: 1, TotalCount: 4},
+	}, explanation.FailingWindows)
+}
@@ -29,6 +29,21 @@ type DB interface {
 	DisqualifyNode(ctx context.Context, nodeID storj.NodeID, disqualifiedAt time.Time, reason overlay.DisqualificationReason) (err error)
 	// SuspendNodeUnknownAudit suspends a storage node for unknown audits.
 	SuspendNodeUnknownAudit(ctx context.Context, nodeID storj.NodeID, suspendedAt time.Time) (err error)
+	// AuditStats returns the audit statistics of all nodes which are not disqualified.
+	// The audits of the audit history windows starting before since are not counted as recent.
+	AuditStats(ctx context.Context, since time.Time) (map[storj.NodeID]AuditStats, error)
+
+	// AddRecentAudits stores audits of a node for explaining its disqualification,
+	// keeping only the limit most recent audits of the node.
+	AddRecentAudits(ctx context.Context, nodeID storj.NodeID, audits []overlay.DisqualificationAudit, limit int) (err error)
+	// GetRecentAudits returns the stored audits of a node, the oldest first.
+	GetRecentAudits(ctx context.Context, nodeID storj.NodeID) (_ []overlay.DisqualificationAudit, err error)
+}
+
+// AuditStats contains the audit statistics of a node.
+type AuditStats struct {
+	Vetted       bool
+	RecentAudits int64
 }
 
 // Info contains all reputation data to be stored in DB.
@@ -104,6 +119,17 @@ func NewService(log *zap.Logger, overlay *overlay.Service, db DB, config Config)
 // ApplyAudit receives an audit result and applies it to the relevant node in DB.
 func (service *Service) ApplyAudit(ctx context.Context, nodeID storj.NodeID, reputation overlay.ReputationStatus, result AuditType) (err error) {
 	defer mon.Task()(&ctx)(&err)
+	return service.applyAudit(ctx, nodeID, nil, reputation, result)
+}
+
+// ApplySegmentAudit receives the result of an audit of the segment and applies it to the
+// relevant node in DB. The segment is kept for explaining the disqualification of the node.
+func (service *Service) ApplySegmentAudit(ctx context.Context, nodeID storj.NodeID, segment *overlay.AuditedSegment, reputation overlay.ReputationStatus, result AuditType) (err error) {
+	defer mon.Task()(&ctx)(&err)
+	return service.applyAudit(ctx, nodeID, segment, reputation, result)
+}
+
+func (service *Service) applyAudit(ctx context.Context, nodeID storj.NodeID, segment *overlay.AuditedSegment, reputation overlay.ReputationStatus, result AuditType) (err error) {
 
 	now := time.Now()
 	statusUpdate, err := service.db.Update(ctx, UpdateRequest{
@@ -114,6 +140,14 @@ func (service *Service) ApplyAudit(ctx context.Context, nodeID storj.NodeID, rep
 	if err != nil {
 		return err
 	}
+	if service.config.ExplanationAudits > 0 {
+		audits := []overlay.DisqualificationAudit{auditOutcome(statusUpdate, segment, result, now)}
+		err = service.db.AddRecentAudits(ctx, nodeID, audits, service.config.ExplanationAudits)
+		if err != nil {
+			// the audit is applied anyway, only the explanation of a disqualification misses it.
+			service.log.Error("could not store recent audit", zap.Stringer("Node ID", nodeID), zap.Error(err))
+		}
+	}
 
 	// only update node if its health status has changed, or it's a newly vetted
 	// node.
@@ -134,11 +168,31 @@ func (service *Service) ApplyAudit(ctx context.Context, nodeID storj.NodeID, rep
 		if err != nil {
 			return err
 		}
+		if statusUpdate.Disqualified != nil && containsEvent(repChanges, nodeevents.Disqualified) {
+			service.storeExplanation(ctx, nodeID, statusUpdate)
+		}
 	}
 
 	return err
 }
 
+// storeExplanation stores the explanation of the disqualification of a node. It only
+// logs the failures, because the node is disqualified anyway.
+func (service *Service) storeExplanation(ctx context.Context, nodeID storj.NodeID, info *Info) {
+	var audits []overlay.DisqualificationAudit
+	if service.config.ExplanationAudits > 0 {
+		var err error
+		audits, err = service.db.GetRecentAudits(ctx, nodeID)
+		if err != nil {
+			service.log.Error("could not get recent audits", zap.Stringer("Node ID", nodeID), zap.Error(err))
+		}
+	}
+	explanation := explainDisqualification(nodeID, info, audits, service.config)
+	if err := service.overlay.SetDisqualificationExplanation(ctx, explanation); err != nil {
+		service.log.Error("could not store disqualification explanation", zap.Stringer("Node ID", nodeID), zap.Error(err))
+	}
+}
+
 // Get returns a node's reputation info from DB.
 // If a node is not found in the DB, default reputation information is returned.
 func (service *Service) Get(ctx context.Context, nodeID storj.NodeID) (info *Info, err error) {
@@ -280,6 +334,16 @@ func hasReputationChanged(updated Info, current overlay.ReputationStatus, now ti
 	return changed, repChanges
 }
 
+// containsEvent determines if the event is one of the given events.
+func containsEvent(events []nodeevents.Type, event nodeevents.Type) bool {
+	for _, e := range events {
+		if e == event {
+			return true
+		}
+	}
+	return false
+}
+
 // statusChanged determines if the two given statuses are different.
 func statusChanged(s1, s2 *time.Time) bool {
 	if s1 == nil && s2 == nil {
@@ -4,7 +4,11 @@
 package reputation_test
 
 import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -12,8 +16,10 @@ import (
 	"golang.org/x/sync/errgroup"
 
 	"storj.io/common/memory"
+	"storj.io/common/rpc/rpcpeer"
 	"storj.io/common/testcontext"
 	"storj.io/common/testrand"
+	"storj.io/storj/private/nodeextpb"
 	"storj.io/storj/private/testplanet"
 	"storj.io/storj/satellite"
 	"storj.io/storj/satellite/overlay"
@@ -190,3 +196,73 @@ func TestDisqualificationAuditFailure(t *testing.T) {
 		assert.NotNil(t, nodeInfo.Disqualified)
 	})
 }
+
+func TestDisqualificationExplanation(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount: 1, StorageNodeCount: 1, UplinkCount: 0,
+		Reconfigure: testplanet.Reconfigure{
+			Satellite: func(log *zap.Logger, index int, config *satellite.Config) {
+				config.Reputation.InitialAlpha = 1
+				config.Reputation.AuditLambda = 1
+				config.Reputation.AuditWeight = 1
+				config.Reputation.AuditDQ = 0.4
+				config.Reputation.ExplanationAudits = 10
+			},
+		},
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		satel := planet.Satellites[0]
+		node := planet.StorageNodes[0]
+		nodeID := node.ID()
+
+		segment := &overlay.AuditedSegment{StreamID: testrand.UUID(), Position: 1}
+
+		for i := 0; i < 2; i++ {
+			nodeInfo, err := satel.Overlay.Service.Get(ctx, nodeID)
+			require.NoError(t, err)
+			_, err = satel.Overlay.Service.GetDisqualificationExplanation(ctx, nodeID)
+			require.True(t, overlay.ErrExplanationNotFound.Has(err))
+
+			err = satel.Reputation.Service.ApplySegmentAudit(ctx, nodeID, segment, nodeInfo.Reputation.Status, reputation.AuditFailure)
+			require.NoError(t, err)
+		}
+
+		nodeInfo, err := satel.Overlay.Service.Get(ctx, nodeID)
+		require.NoError(t, err)
+		require.NotNil(t, nodeInfo.Disqualified)
+
+		explanation, err := satel.Overlay.Service.GetDisqualificationExplanation(ctx, nodeID)
+		require.NoError(t, err)
+		require.Equal(t, nodeID, explanation.NodeID)
+		require.Equal(t, overlay.DisqualificationReasonAuditFailure, explanation.Reason)
+		require.WithinDuration(t, *nodeInfo.Disqualified, explanation.DisqualifiedAt, time.Second)
+		require.NotNil(t, explanation.Scores)
+		require.Less(t, explanation.Scores.AuditScore, explanation.Scores.AuditDQ)
+		require.Len(t, explanation.RecentAudits, 2)
+		for _, audit := range explanation.RecentAudits {
+			require.Equal(t, reputation.AuditFailure.String(), audit.Outcome)
+			require.Equal(t, segment, audit.Segment)
+		}
+		require.Greater(t, explanation.RecentAudits[0].AuditScore, explanation.RecentAudits[1].AuditScore)
+
+		peer := rpcpeer.Peer{
+			State: tls.ConnectionState{
+				PeerCertificates: []*x509.Certificate{node.Identity.Leaf, node.Identity.CA},
+			},
+		}
+		resp, err := satel.NodeStats.Endpoint.GetDisqualificationExplanation(rpcpeer.NewContext(ctx, &peer), &nodeextpb.GetDisqualificationExplanationRequest{})
+		require.NoError(t, err)
+		var fromEndpoint overlay.DisqualificationExplanation
+		require.NoError(t, json.Unmarshal(resp.Explanation, &fromEndpoint))
+		require.Equal(t, explanation.NodeID, fromEndpoint.NodeID)
+		require.Len(t, fromEndpoint.RecentAudits, 2)
+
+		// the node retrieves the explanation from the satellite.
+		fromNode, err := node.NodeStats.Service.GetDisqualificationExplanation(ctx, satel.ID())
+		require.NoError(t, err)
+		var received overlay.DisqualificationExplanation
+		require.NoError(t, json.Unmarshal(fromNode, &received))
+		require.Equal(t, explanation.NodeID, received.NodeID)
+		require.Equal(t, explanation.Reason, received.Reason)
+		require.Len(t, received.RecentAudits, 2)
+	})
+}
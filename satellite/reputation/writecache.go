@@ -112,6 +112,10 @@ type cachedNodeReputationInfo struct {
 	// mutations contains the set of changes to be made to a reputations
 	// entry when the next sync operation fires.
 	mutations Mutations
+
+	// recentAudits contains the audits of the node to be stored for
+	// explaining its disqualification when the next sync operation fires.
+	recentAudits []overlay.DisqualificationAudit
 }
 
 // Update applies a single update (one audit outcome) to a node's reputations
@@ -367,6 +371,14 @@ func (cdb *CachingDB) DisqualifyNode(ctx context.Context, nodeID storj.NodeID, d
 	return cdb.RequestSync(ctx, nodeID)
 }
 
+// AuditStats returns the audit statistics of all nodes which are not disqualified,
+// as stored in the backing store.
+func (cdb *CachingDB) AuditStats(ctx context.Context, since time.Time) (_ map[storj.NodeID]AuditStats, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	return cdb.backingStore.AuditStats(ctx, since)
+}
+
 // SuspendNodeUnknownAudit suspends a storage node for unknown audits.
 func (cdb *CachingDB) SuspendNodeUnknownAudit(ctx context.Context, nodeID storj.NodeID, suspendedAt time.Time) (err error) {
 	defer mon.Task()(&ctx)(&err)
@@ -379,6 +391,32 @@ func (cdb *CachingDB) SuspendNodeUnknownAudit(ctx context.Context, nodeID storj.
 	return cdb.RequestSync(ctx, nodeID)
 }
 
+// AddRecentAudits adds audits of a node to the cache. They are stored in the
+// backing store with the pending mutations of the node.
+func (cdb *CachingDB) AddRecentAudits(ctx context.Context, nodeID storj.NodeID, audits []overlay.DisqualificationAudit, limit int) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	cdb.getEntry(ctx, nodeID, cdb.nowFunc(), func(entry *cachedNodeReputationInfo) {
+		entry.recentAudits = lastAudits(append(entry.recentAudits, audits...), limit)
+	})
+	return nil
+}
+
+// GetRecentAudits returns the stored audits of a node followed by the audits
+// of the node which are not yet stored, the oldest first.
+func (cdb *CachingDB) GetRecentAudits(ctx context.Context, nodeID storj.NodeID) (_ []overlay.DisqualificationAudit, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	audits, err := cdb.backingStore.GetRecentAudits(ctx, nodeID)
+	if err != nil {
+		return nil, err
+	}
+	cdb.getExistingEntry(nodeID, func(entry *cachedNodeReputationInfo) {
+		audits = append(audits, entry.recentAudits...)
+	})
+	return lastAudits(audits, cdb.reputationConfig.ExplanationAudits), nil
+}
+
 // RequestSync requests the managing goroutine to perform a sync of cached info
 // about the specified node to the backing store. This involves applying the
 // cached mutations and resetting the info attribute to match a snapshot of what
@@ -616,6 +654,16 @@ func (cdb *CachingDB) syncEntry(ctx context.Context, entry *cachedNodeReputation
 	entry.mutations = Mutations{
 		OnlineHistory: &pb.AuditHistory{},
 	}
+
+	if len(entry.recentAudits) > 0 {
+		err := cdb.backingStore.AddRecentAudits(ctx, entry.nodeID, entry.recentAudits, cdb.reputationConfig.ExplanationAudits)
+		if err != nil {
+			// the audits are kept for the next sync.
+			cdb.log.Error("could not store recent audits", zap.Stringer("node-id", entry.nodeID), zap.Error(err))
+			return
+		}
+		entry.recentAudits = nil
+	}
 }
 
 // Get retrieves the cached *Info record for the given node ID. If the
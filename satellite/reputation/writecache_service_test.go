@@ -20,6 +20,7 @@ import (
 	"storj.io/common/testcontext"
 	"storj.io/common/testrand"
 	"storj.io/storj/satellite"
+	"storj.io/storj/satellite/overlay"
 	"storj.io/storj/satellite/reputation"
 	"storj.io/storj/satellite/satellitedb/satellitedbtest"
 )
@@ -296,3 +297,49 @@ func TestFetchingInfoWhileEntryIsSyncing(t *testing.T) {
 		}
 	})
 }
+
+func TestCachingRecentAudits(t *testing.T) {
+	satellitedbtest.Run(t, func(ctx *testcontext.Context, t *testing.T, db satellite.DB) {
+		config := reputation.Config{
+			AuditLambda:       1,
+			AuditWeight:       1,
+			InitialAlpha:      1000,
+			AuditHistory:      reputation.AuditHistoryConfig{WindowSize: time.Hour, TrackingPeriod: time.Hour},
+			FlushInterval:     2 * time.Hour,
+			ExplanationAudits: 2,
+		}
+		reputationDB := db.Reputation()
+		writecache := reputation.NewCachingDB(zaptest.NewLogger(t), reputationDB, config)
+
+		nodeID := testrand.NodeID()
+		now := time.Now().UTC().Truncate(time.Second)
+		_, err := writecache.ApplyUpdates(ctx, nodeID, reputation.Mutations{}, config, now)
+		require.NoError(t, err)
+
+		for i := 0; i < 3; i++ {
+			audit := overlay.DisqualificationAudit{AuditedAt: now.Add(time.Duration(i) * time.Minute)}
+			require.NoError(t, writecache.AddRecentAudits(ctx, nodeID, []overlay.DisqualificationAudit{audit}, config.ExplanationAudits))
+		}
+
+		// the audits aren't stored before the sync, but they're returned by the cache.
+		stored, err := reputationDB.GetRecentAudits(ctx, nodeID)
+		require.NoError(t, err)
+		require.Empty(t, stored)
+
+		audits, err := writecache.GetRecentAudits(ctx, nodeID)
+		require.NoError(t, err)
+		require.Len(t, audits, 2)
+		require.Equal(t, now.Add(time.Minute), audits[0].AuditedAt)
+
+		require.NoError(t, writecache.FlushAll(ctx))
+
+		stored, err = reputationDB.GetRecentAudits(ctx, nodeID)
+		require.NoError(t, err)
+		require.Len(t, stored, 2)
+		require.Equal(t, now.Add(2*time.Minute), stored[1].AuditedAt.UTC())
+
+		audits, err = writecache.GetRecentAudits(ctx, nodeID)
+		require.NoError(t, err)
+		require.Len(t, audits, 2)
+	})
+}
@@ -6,6 +6,7 @@ package satellitedb
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"errors"
 
 	"github.com/zeebo/errs"
@@ -99,6 +100,132 @@ func (db *bucketsDB) GetBucketPlacement(ctx context.Context, bucketName []byte,
 	return placement, nil
 }
 
+// GetBucketVersioningState returns the versioning state of the bucket.
+func (db *bucketsDB) GetBucketVersioningState(ctx context.Context, bucketName []byte, projectID uuid.UUID) (versioning buckets.Versioning, err error) {
+	defer mon.Task()(&ctx)(&err)
+	dbxVersioning, err := db.db.Get_BucketMetainfo_Versioning_By_ProjectId_And_Name(ctx,
+		dbx.BucketMetainfo_ProjectId(projectID[:]),
+		dbx.BucketMetainfo_Name(bucketName),
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return buckets.Unversioned, storj.ErrBucketNotFound.New("%s", bucketName)
+		}
+		return buckets.Unversioned, storj.ErrBucket.Wrap(err)
+	}
+	versioning = buckets.Unversioned
+	if dbxVersioning.Versioning != nil {
+		versioning = buckets.Versioning(*dbxVersioning.Versioning)
+	}
+
+	return versioning, nil
+}
+
+// SetBucketVersioningState updates the versioning state of the bucket.
+func (db *bucketsDB) SetBucketVersioningState(ctx context.Context, bucketName []byte, projectID uuid.UUID, versioning buckets.Versioning) (err error) {
+	defer mon.Task()(&ctx)(&err)
+	dbxBucket, err := db.db.Update_BucketMetainfo_By_ProjectId_And_Name(ctx,
+		dbx.BucketMetainfo_ProjectId(projectID[:]),
+		dbx.BucketMetainfo_Name(bucketName),
+		dbx.BucketMetainfo_Update_Fields{
+			Versioning: dbx.BucketMetainfo_Versioning(int(versioning)),
+		},
+	)
+	if err != nil {
+		return storj.ErrBucket.Wrap(err)
+	}
+	if dbxBucket == nil {
+		return storj.ErrBucketNotFound.New("%s", bucketName)
+	}
+	return nil
+}
+
+// GetBucketLifecycleRules returns the lifecycle rules of the bucket.
+func (db *bucketsDB) GetBucketLifecycleRules(ctx context.Context, bucketName []byte, projectID uuid.UUID) (rules buckets.LifecycleRules, err error) {
+	defer mon.Task()(&ctx)(&err)
+	dbxRules, err := db.db.Get_BucketMetainfo_LifecycleRules_By_ProjectId_And_Name(ctx,
+		dbx.BucketMetainfo_ProjectId(projectID[:]),
+		dbx.BucketMetainfo_Name(bucketName),
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, storj.ErrBucketNotFound.New("%s", bucketName)
+		}
+		return nil, storj.ErrBucket.Wrap(err)
+	}
+	if len(dbxRules.LifecycleRules) == 0 {
+		return nil, nil
+	}
+
+	if err := json.Unmarshal(dbxRules.LifecycleRules, &rules); err != nil {
+		return nil, storj.ErrBucket.Wrap(err)
+	}
+	return rules, nil
+}
+
+// SetBucketLifecycleRules replaces the lifecycle rules of the bucket.
+func (db *bucketsDB) SetBucketLifecycleRules(ctx context.Context, bucketName []byte, projectID uuid.UUID, rules buckets.LifecycleRules) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	field := dbx.BucketMetainfo_LifecycleRules_Null()
+	if len(rules) > 0 {
+		data, err := json.Marshal(rules)
+		if err != nil {
+			return storj.ErrBucket.Wrap(err)
+		}
+		field = dbx.BucketMetainfo_LifecycleRules(data)
+	}
+
+	dbxBucket, err := db.db.Update_BucketMetainfo_By_ProjectId_And_Name(ctx,
+		dbx.BucketMetainfo_ProjectId(projectID[:]),
+		dbx.BucketMetainfo_Name(bucketName),
+		dbx.BucketMetainfo_Update_Fields{
+			LifecycleRules: field,
+		},
+	)
+	if err != nil {
+		return storj.ErrBucket.Wrap(err)
+	}
+	if dbxBucket == nil {
+		return storj.ErrBucketNotFound.New("%s", bucketName)
+	}
+	return nil
+}
+
+// ListBucketLifecycles returns all buckets which have lifecycle rules.
+func (db *bucketsDB) ListBucketLifecycles(ctx context.Context) (lifecycles []buckets.BucketLifecycle, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	rows, err := db.db.QueryContext(ctx, `
+		SELECT project_id, name, versioning, lifecycle_rules
+		FROM bucket_metainfos
+		WHERE lifecycle_rules IS NOT NULL
+		ORDER BY project_id, name
+	`)
+	if err != nil {
+		return nil, storj.ErrBucket.Wrap(err)
+	}
+	defer func() { err = errs.Combine(err, rows.Close()) }()
+
+	for rows.Next() {
+		var lifecycle buckets.BucketLifecycle
+		var versioning *int
+		var data []byte
+		if err := rows.Scan(&lifecycle.Location.ProjectID, &lifecycle.Location.BucketName, &versioning, &data); err != nil {
+			return nil, storj.ErrBucket.Wrap(err)
+		}
+		if versioning != nil {
+			lifecycle.Versioning = buckets.Versioning(*versioning)
+		}
+		if err := json.Unmarshal(data, &lifecycle.Rules); err != nil {
+			return nil, storj.ErrBucket.New("invalid lifecycle rules for bucket %q: %v", lifecycle.Location.BucketName, err)
+		}
+		lifecycles = append(lifecycles, lifecycle)
+	}
+
+	return lifecycles, storj.ErrBucket.Wrap(rows.Err())
+}
+
 // GetMinimalBucket returns existing bucket with minimal number of fields.
 func (db *bucketsDB) GetMinimalBucket(ctx context.Context, bucketName []byte, projectID uuid.UUID) (_ buckets.Bucket, err error) {
 	defer mon.Task()(&ctx)(&err)
@@ -9,6 +9,7 @@ import (
 	"database/sql"
 	"errors"
 
+	"github.com/zeebo/errs"
 	"go.uber.org/zap"
 
 	"storj.io/common/pb"
@@ -101,6 +102,72 @@ func (containment *containment) Delete(ctx context.Context, id pb.NodeID) (isDel
 	return isDeleted, audit.ContainError.Wrap(err)
 }
 
+// GetContained gets the pending audit by node id together with when the node was contained.
+func (containment *containment) GetContained(ctx context.Context, id pb.NodeID) (_ *audit.ContainedNode, err error) {
+	defer mon.Task()(&ctx)(&err)
+	if id.IsZero() {
+		return nil, audit.ContainError.New("node ID empty")
+	}
+
+	pending, err := containment.db.Get_SegmentPendingAudits_By_NodeId(ctx, dbx.SegmentPendingAudits_NodeId(id.Bytes()))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, audit.ErrContainedNotFound.New("%v", id)
+		}
+		return nil, audit.ContainError.Wrap(err)
+	}
+
+	pendingAudit, err := convertDBPending(ctx, pending)
+	if err != nil {
+		return nil, err
+	}
+
+	return &audit.ContainedNode{
+		PendingAudit: *pendingAudit,
+		InsertedAt:   pending.InsertedAt,
+	}, nil
+}
+
+// ListContained lists the contained nodes, the longest contained first.
+func (containment *containment) ListContained(ctx context.Context, limit int) (_ []audit.ContainedNode, err error) {
+	defer mon.Task()(&ctx)(&err)
+	if limit <= 0 {
+		return nil, nil
+	}
+
+	rows, err := containment.db.QueryContext(ctx, `
+		SELECT node_id, stream_id, position, piece_id, stripe_index, share_size,
+			expected_share_hash, reverify_count, inserted_at
+		FROM segment_pending_audits
+		ORDER BY inserted_at, node_id
+		LIMIT $1
+	`, limit)
+	if err != nil {
+		return nil, audit.ContainError.Wrap(err)
+	}
+	defer func() { err = errs.Combine(err, rows.Close()) }()
+
+	var contained []audit.ContainedNode
+	for rows.Next() {
+		var pending dbx.SegmentPendingAudits
+		err := rows.Scan(&pending.NodeId, &pending.StreamId, &pending.Position, &pending.PieceId, &pending.StripeIndex, &pending.ShareSize,
+			&pending.ExpectedShareHash, &pending.ReverifyCount, &pending.InsertedAt)
+		if err != nil {
+			return nil, audit.ContainError.Wrap(err)
+		}
+
+		pendingAudit, err := convertDBPending(ctx, &pending)
+		if err != nil {
+			return nil, err
+		}
+		contained = append(contained, audit.ContainedNode{
+			PendingAudit: *pendingAudit,
+			InsertedAt:   pending.InsertedAt,
+		})
+	}
+	return contained, audit.ContainError.Wrap(rows.Err())
+}
+
 func convertDBPending(ctx context.Context, info *dbx.SegmentPendingAudits) (_ *audit.PendingAudit, err error) {
 	defer mon.Task()(&ctx)(&err)
 	if info == nil {
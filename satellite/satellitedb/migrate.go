@@ -2165,6 +2165,106 @@ func (db *satelliteDB) PostgresMigration() *migrate.Migration {
 					`ALTER TABLE nodes ADD COLUMN last_software_update_email timestamp with time zone;`,
 				},
 			},
+			{
+				DB:          &db.migrationDB,
+				Description: "Add column versioning to bucket_metainfos table",
+				Version:     218,
+				Action: migrate.SQL{
+					`ALTER TABLE bucket_metainfos ADD COLUMN versioning integer;`,
+				},
+			},
+			{
+				DB:          &db.migrationDB,
+				Description: "Add column lifecycle_rules to bucket_metainfos table",
+				Version:     219,
+				Action: migrate.SQL{
+					`ALTER TABLE bucket_metainfos ADD COLUMN lifecycle_rules bytea;`,
+				},
+			},
+			{
+				DB:          &db.migrationDB,
+				Description: "Add node_tags table",
+				Version:     220,
+				Action: migrate.SQL{
+					`CREATE TABLE node_tags (
+						node_id bytea NOT NULL,
+						name text NOT NULL,
+						value bytea NOT NULL,
+						signed_at timestamp with time zone NOT NULL,
+						signer bytea NOT NULL,
+						PRIMARY KEY ( node_id, name, signer )
+					);`,
+				},
+			},
+			{
+				DB:          &db.migrationDB,
+				Description: "Add prioritized and do_not_repair columns to repair_queue",
+				Version:     221,
+				Action: migrate.SQL{
+					`ALTER TABLE repair_queue ADD COLUMN prioritized boolean NOT NULL DEFAULT false;`,
+					`ALTER TABLE repair_queue ADD COLUMN do_not_repair boolean NOT NULL DEFAULT false;`,
+				},
+			},
+			{
+				DB:          &db.migrationDB,
+				Description: "Add inserted_at column to segment_pending_audits",
+				Version:     222,
+				Action: migrate.SQL{
+					`ALTER TABLE segment_pending_audits ADD COLUMN inserted_at timestamp with time zone NOT NULL DEFAULT current_timestamp;`,
+				},
+			},
+			{
+				DB:          &db.migrationDB,
+				Description: "add node_disqualification_explanations table",
+				Version:     223,
+				Action: migrate.SQL{
+					`CREATE TABLE node_disqualification_explanations (
+						node_id bytea NOT NULL,
+						disqualified_at timestamp with time zone NOT NULL,
+						reason integer NOT NULL,
+						explanation bytea NOT NULL,
+						PRIMARY KEY ( node_id )
+					);`,
+				},
+			},
+			{
+				DB:          &db.migrationDB,
+				Description: "add node_recent_audits table",
+				Version:     224,
+				Action: migrate.SQL{
+					`CREATE TABLE node_recent_audits (
+						node_id bytea NOT NULL,
+						audited_at timestamp with time zone NOT NULL,
+						audit bytea NOT NULL,
+						PRIMARY KEY ( node_id, audited_at )
+					);`,
+				},
+			},
+			{
+				DB:          &db.migrationDB,
+				Description: "add node_bloom_filter_refusals table",
+				Version:     225,
+				Action: migrate.SQL{
+					`CREATE TABLE node_bloom_filter_refusals (
+						node_id bytea NOT NULL,
+						refusals integer NOT NULL,
+						PRIMARY KEY ( node_id )
+					);`,
+				},
+			},
+			{
+				DB:          &db.migrationDB,
+				Description: "replace node_recent_audits table with node_audit_trails table",
+				Version:     226,
+				Action: migrate.SQL{
+					`DROP TABLE node_recent_audits;`,
+					`CREATE TABLE node_audit_trails (
+						node_id bytea NOT NULL,
+						audits bytea NOT NULL,
+						PRIMARY KEY ( node_id )
+					);`,
+				},
+			},
 			// NB: after updating testdata in `testdata`, run
 			//     `go generate` to update `migratez.go`.
 		},
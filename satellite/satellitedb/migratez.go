@@ -13,7 +13,7 @@ func (db *satelliteDB) testMigration() *migrate.Migration {
 			{
 				DB:          &db.migrationDB,
 				Description: "Testing setup",
-				Version:     217,
+				Version:     226,
 				Action: migrate.SQL{`-- AUTOGENERATED BY storj.io/dbx
 -- DO NOT EDIT
 CREATE TABLE accounting_rollups (
@@ -191,7 +191,7 @@ CREATE TABLE nodes (
 	exit_finished_at timestamp with time zone,
 	exit_success boolean NOT NULL DEFAULT false,
 	contained timestamp with time zone,
-    last_offline_email timestamp with time zone,
+	last_offline_email timestamp with time zone,
 	last_software_update_email timestamp with time zone,
 	PRIMARY KEY ( id )
 );
@@ -211,6 +211,31 @@ CREATE TABLE node_api_versions (
 	updated_at timestamp with time zone NOT NULL,
 	PRIMARY KEY ( id )
 );
+CREATE TABLE node_audit_trails (
+	node_id bytea NOT NULL,
+	audits bytea NOT NULL,
+	PRIMARY KEY ( node_id )
+);
+CREATE TABLE node_bloom_filter_refusals (
+	node_id bytea NOT NULL,
+	refusals integer NOT NULL,
+	PRIMARY KEY ( node_id )
+);
+CREATE TABLE node_disqualification_explanations (
+	node_id bytea NOT NULL,
+	disqualified_at timestamp with time zone NOT NULL,
+	reason integer NOT NULL,
+	explanation bytea NOT NULL,
+	PRIMARY KEY ( node_id )
+);
+CREATE TABLE node_tags (
+	node_id bytea NOT NULL,
+	name text NOT NULL,
+	value bytea NOT NULL,
+	signed_at timestamp with time zone NOT NULL,
+	signer bytea NOT NULL,
+	PRIMARY KEY ( node_id, name, signer )
+);
 CREATE TABLE oauth_clients (
 	id bytea NOT NULL,
 	encrypted_secret bytea NOT NULL,
@@ -314,6 +339,8 @@ CREATE TABLE repair_queue (
 	updated_at timestamp with time zone NOT NULL DEFAULT current_timestamp,
 	inserted_at timestamp with time zone NOT NULL DEFAULT current_timestamp,
 	segment_health double precision NOT NULL DEFAULT 1,
+	prioritized boolean NOT NULL DEFAULT false,
+	do_not_repair boolean NOT NULL DEFAULT false,
 	PRIMARY KEY ( stream_id, position )
 );
 CREATE TABLE reputations (
@@ -375,6 +402,7 @@ CREATE TABLE segment_pending_audits (
 	share_size bigint NOT NULL,
 	expected_share_hash bytea NOT NULL,
 	reverify_count bigint NOT NULL,
+	inserted_at timestamp with time zone NOT NULL DEFAULT current_timestamp,
 	PRIMARY KEY ( node_id )
 );
 CREATE TABLE storagenode_bandwidth_rollups (
@@ -574,6 +602,8 @@ CREATE TABLE bucket_metainfos (
 	default_redundancy_optimal_shares integer NOT NULL,
 	default_redundancy_total_shares integer NOT NULL,
 	placement integer,
+	versioning integer,
+	lifecycle_rules bytea,
 	PRIMARY KEY ( id ),
 	UNIQUE ( project_id, name )
 );
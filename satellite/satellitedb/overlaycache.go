@@ -6,6 +6,7 @@ package satellitedb
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"sort"
@@ -23,6 +24,7 @@ import (
 	"storj.io/private/dbutil/pgutil"
 	"storj.io/private/tagsql"
 	"storj.io/private/version"
+	"storj.io/storj/satellite/nodeselection/uploadselection"
 	"storj.io/storj/satellite/overlay"
 	"storj.io/storj/satellite/satellitedb/dbx"
 )
@@ -57,7 +59,7 @@ func (cache *overlaycache) selectAllStorageNodesUpload(ctx context.Context, sele
 	defer mon.Task()(&ctx)(&err)
 
 	query := `
-		SELECT id, address, last_net, last_ip_port, vetted_at, country_code
+		SELECT id, address, last_net, last_ip_port, vetted_at, country_code, wallet, free_disk
 			FROM nodes
 			` + cache.db.impl.AsOfSystemInterval(selectionCfg.AsOfSystemTime.Interval()) + `
 			WHERE disqualified IS NULL
@@ -101,7 +103,7 @@ func (cache *overlaycache) selectAllStorageNodesUpload(ctx context.Context, sele
 		node.Address = &pb.NodeAddress{}
 		var lastIPPort sql.NullString
 		var vettedAt *time.Time
-		err = rows.Scan(&node.ID, &node.Address.Address, &node.LastNet, &lastIPPort, &vettedAt, &node.CountryCode)
+		err = rows.Scan(&node.ID, &node.Address.Address, &node.LastNet, &lastIPPort, &vettedAt, &node.CountryCode, &node.Wallet, &node.FreeDisk)
 		if err != nil {
 			return nil, nil, err
 		}
@@ -115,8 +117,22 @@ func (cache *overlaycache) selectAllStorageNodesUpload(ctx context.Context, sele
 		}
 		reputableNodes = append(reputableNodes, &node)
 	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, Error.Wrap(err)
+	}
+
+	tags, err := cache.getNodeTags(ctx, selectionCfg.AsOfSystemTime.Interval(), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, node := range reputableNodes {
+		node.Tags = tags[node.ID]
+	}
+	for _, node := range newNodes {
+		node.Tags = tags[node.ID]
+	}
 
-	return reputableNodes, newNodes, Error.Wrap(rows.Err())
+	return reputableNodes, newNodes, nil
 }
 
 // SelectAllStorageNodesDownload returns all nodes that qualify to store data, organized as reputable nodes and new nodes.
@@ -531,6 +547,74 @@ func (cache *overlaycache) knownReliableInExcludedCountries(ctx context.Context,
 	return reliableInExcluded, Error.Wrap(rows.Err())
 }
 
+// KnownReliableSelectedNodes filters a set of nodes to reliable nodes, returning the information needed for node selection.
+func (cache *overlaycache) KnownReliableSelectedNodes(ctx context.Context, criteria *overlay.NodeCriteria, nodeIDs storj.NodeIDList) (nodes []*overlay.SelectedNode, err error) {
+	for {
+		nodes, err = cache.knownReliableSelectedNodes(ctx, criteria, nodeIDs)
+		if err != nil {
+			if cockroachutil.NeedsRetry(err) {
+				continue
+			}
+			return nodes, err
+		}
+		break
+	}
+
+	return nodes, err
+}
+
+func (cache *overlaycache) knownReliableSelectedNodes(ctx context.Context, criteria *overlay.NodeCriteria, nodeIDs storj.NodeIDList) (nodes []*overlay.SelectedNode, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if len(nodeIDs) == 0 {
+		return nil, Error.New("no ids provided")
+	}
+
+	rows, err := cache.db.Query(ctx, cache.db.Rebind(`
+			SELECT id, address, last_net, last_ip_port, country_code, wallet
+			FROM nodes
+			`+cache.db.impl.AsOfSystemInterval(criteria.AsOfSystemInterval)+`
+			WHERE id = any($1::bytea[])
+			AND disqualified IS NULL
+			AND unknown_audit_suspended IS NULL
+			AND offline_suspended IS NULL
+			AND exit_finished_at IS NULL
+			AND last_contact_success > $2
+		`), pgutil.NodeIDArray(nodeIDs), time.Now().Add(-criteria.OnlineWindow),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { err = errs.Combine(err, rows.Close()) }()
+
+	for rows.Next() {
+		var node overlay.SelectedNode
+		node.Address = &pb.NodeAddress{}
+		var lastIPPort sql.NullString
+		err = rows.Scan(&node.ID, &node.Address.Address, &node.LastNet, &lastIPPort, &node.CountryCode, &node.Wallet)
+		if err != nil {
+			return nil, err
+		}
+		if lastIPPort.Valid {
+			node.LastIPPort = lastIPPort.String
+		}
+		nodes = append(nodes, &node)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	tags, err := cache.getNodeTags(ctx, criteria.AsOfSystemInterval, nodeIDs)
+	if err != nil {
+		return nil, err
+	}
+	for _, node := range nodes {
+		node.Tags = tags[node.ID]
+	}
+
+	return nodes, nil
+}
+
 func (cache *overlaycache) knownUnreliableOrOffline(ctx context.Context, criteria *overlay.NodeCriteria, nodeIDs storj.NodeIDList) (badNodes storj.NodeIDList, err error) {
 	defer mon.Task()(&ctx)(&err)
 
@@ -646,29 +730,14 @@ func (cache *overlaycache) Reliable(ctx context.Context, criteria *overlay.NodeC
 }
 
 func (cache *overlaycache) reliable(ctx context.Context, criteria *overlay.NodeCriteria) (nodes storj.NodeIDList, err error) {
-	args := []interface{}{
-		time.Now().Add(-criteria.OnlineWindow),
-	}
-
-	// When this config is not set, it's a string slice with one empty string. I added some sanity checks to make sure we don't
-	// dereference a nil pointer or index an element that doesn't exist.
-	var excludedCountriesCondition string
-	if criteria.ExcludedCountries != nil && len(criteria.ExcludedCountries) != 0 && criteria.ExcludedCountries[0] != "" {
-		excludedCountriesCondition = "AND country_code NOT IN (SELECT UNNEST($2::TEXT[]))"
-		args = append(args, pgutil.TextArray(criteria.ExcludedCountries))
-	}
+	condition, args := reliableCondition(criteria, "")
 
 	// get reliable and online nodes
 	rows, err := cache.db.Query(ctx, cache.db.Rebind(`
 		SELECT id
 		FROM nodes
 		`+cache.db.impl.AsOfSystemInterval(criteria.AsOfSystemInterval)+`
-		WHERE disqualified IS NULL
-		AND unknown_audit_suspended IS NULL
-		AND offline_suspended IS NULL
-		AND exit_finished_at IS NULL
-		AND last_contact_success > $1
-		`+excludedCountriesCondition+`
+		WHERE `+condition+`
 	`), args...)
 	if err != nil {
 		return nil, err
@@ -688,6 +757,77 @@ func (cache *overlaycache) reliable(ctx context.Context, criteria *overlay.NodeC
 	return nodes, Error.Wrap(rows.Err())
 }
 
+// ReliableWithHistory returns all reliable nodes with their audit and uptime
+// history. Nodes without reputation have a perfect history.
+func (cache *overlaycache) ReliableWithHistory(ctx context.Context, criteria *overlay.NodeCriteria) (nodes []*overlay.NodeHistory, err error) {
+	for {
+		nodes, err = cache.reliableWithHistory(ctx, criteria)
+		if err != nil {
+			if cockroachutil.NeedsRetry(err) {
+				continue
+			}
+			return nodes, err
+		}
+		break
+	}
+
+	return nodes, err
+}
+
+func (cache *overlaycache) reliableWithHistory(ctx context.Context, criteria *overlay.NodeCriteria) (nodes []*overlay.NodeHistory, err error) {
+	condition, args := reliableCondition(criteria, "nodes.")
+
+	rows, err := cache.db.Query(ctx, cache.db.Rebind(`
+		SELECT nodes.id,
+			COALESCE(reputations.online_score, 1),
+			COALESCE(reputations.audit_reputation_alpha, 1),
+			COALESCE(reputations.audit_reputation_beta, 0)
+		FROM nodes
+		LEFT JOIN reputations ON reputations.id = nodes.id
+		`+cache.db.impl.AsOfSystemInterval(criteria.AsOfSystemInterval)+`
+		WHERE `+condition+`
+	`), args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		err = errs.Combine(err, rows.Close())
+	}()
+
+	for rows.Next() {
+		var node overlay.NodeHistory
+		err = rows.Scan(&node.ID, &node.OnlineScore, &node.AuditReputationAlpha, &node.AuditReputationBeta)
+		if err != nil {
+			return nil, err
+		}
+		nodes = append(nodes, &node)
+	}
+	return nodes, Error.Wrap(rows.Err())
+}
+
+// reliableCondition returns the condition and its arguments for selecting
+// the reliable and online nodes. The columns are qualified with the prefix.
+func reliableCondition(criteria *overlay.NodeCriteria, prefix string) (condition string, args []interface{}) {
+	args = []interface{}{
+		time.Now().Add(-criteria.OnlineWindow),
+	}
+
+	// When this config is not set, it's a string slice with one empty string. I added some sanity checks to make sure we don't
+	// dereference a nil pointer or index an element that doesn't exist.
+	var excludedCountriesCondition string
+	if criteria.ExcludedCountries != nil && len(criteria.ExcludedCountries) != 0 && criteria.ExcludedCountries[0] != "" {
+		excludedCountriesCondition = "AND " + prefix + "country_code NOT IN (SELECT UNNEST($2::TEXT[]))"
+		args = append(args, pgutil.TextArray(criteria.ExcludedCountries))
+	}
+
+	return prefix + `disqualified IS NULL
+		AND ` + prefix + `unknown_audit_suspended IS NULL
+		AND ` + prefix + `offline_suspended IS NULL
+		AND ` + prefix + `exit_finished_at IS NULL
+		AND ` + prefix + `last_contact_success > $1
+		` + excludedCountriesCondition, args
+}
+
 // UpdateReputation updates the DB columns for any of the reputation fields in ReputationUpdate.
 func (cache *overlaycache) UpdateReputation(ctx context.Context, id storj.NodeID, request overlay.ReputationUpdate) (err error) {
 	defer mon.Task()(&ctx)(&err)
@@ -868,6 +1008,55 @@ func (cache *overlaycache) UpdatePieceCounts(ctx context.Context, pieceCounts ma
 	return Error.Wrap(err)
 }
 
+// UpdateBloomFilterRefusals increments the numbers of consecutive refused bloom filters of the
+// refused nodes and resets them for the reset nodes. It returns the numbers of the refused nodes.
+func (cache *overlaycache) UpdateBloomFilterRefusals(ctx context.Context, refused, reset []storj.NodeID) (refusals map[storj.NodeID]int, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	err = cache.db.WithTx(ctx, func(ctx context.Context, tx *dbx.Tx) (err error) {
+		refusals = make(map[storj.NodeID]int, len(refused))
+		if len(reset) > 0 {
+			_, err = tx.Tx.ExecContext(ctx, `
+				DELETE FROM node_bloom_filter_refusals
+				WHERE node_id = ANY($1::bytea[])
+			`, pgutil.NodeIDArray(reset))
+			if err != nil {
+				return err
+			}
+		}
+
+		if len(refused) == 0 {
+			return nil
+		}
+
+		rows, err := tx.Tx.QueryContext(ctx, `
+			INSERT INTO node_bloom_filter_refusals (node_id, refusals)
+			SELECT unnest($1::bytea[]), 1
+			ON CONFLICT (node_id)
+			DO UPDATE SET refusals = node_bloom_filter_refusals.refusals + 1
+			RETURNING node_id, refusals
+		`, pgutil.NodeIDArray(refused))
+		if err != nil {
+			return err
+		}
+		defer func() { err = errs.Combine(err, rows.Close()) }()
+
+		for rows.Next() {
+			var nodeID storj.NodeID
+			var count int
+			if err := rows.Scan(&nodeID, &count); err != nil {
+				return err
+			}
+			refusals[nodeID] = count
+		}
+		return rows.Err()
+	})
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	return refusals, nil
+}
+
 // GetExitingNodes returns nodes who have initiated a graceful exit and is not disqualified, but have not completed it.
 func (cache *overlaycache) GetExitingNodes(ctx context.Context) (exitingNodes []*overlay.ExitStatus, err error) {
 	for {
@@ -1552,3 +1741,139 @@ func (cache *overlaycache) IterateAllNodeDossiers(ctx context.Context, cb func(c
 		}
 	}
 }
+
+// UpdateNodeTags replaces all the tags of the node.
+func (cache *overlaycache) UpdateNodeTags(ctx context.Context, nodeID storj.NodeID, tags uploadselection.NodeTags) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	for _, tag := range tags {
+		if tag.NodeID != nodeID {
+			return Error.New("tag %q belongs to node %s instead of %s", tag.Name, tag.NodeID, nodeID)
+		}
+	}
+
+	err = cache.db.WithTx(ctx, func(ctx context.Context, tx *dbx.Tx) error {
+		_, err := tx.Tx.ExecContext(ctx, `DELETE FROM node_tags WHERE node_id = $1`, nodeID)
+		if err != nil {
+			return err
+		}
+
+		for _, tag := range tags {
+			_, err = tx.Tx.ExecContext(ctx, `
+				INSERT INTO node_tags (node_id, name, value, signed_at, signer)
+				VALUES ($1, $2, $3, $4, $5)
+				ON CONFLICT (node_id, name, signer)
+				DO UPDATE SET value = EXCLUDED.value, signed_at = EXCLUDED.signed_at
+			`, tag.NodeID, tag.Name, tag.Value, tag.SignedAt, tag.Signer)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	return Error.Wrap(err)
+}
+
+// GetNodeTags returns the tags of a node.
+func (cache *overlaycache) GetNodeTags(ctx context.Context, nodeID storj.NodeID) (tags uploadselection.NodeTags, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	rows, err := cache.db.QueryContext(ctx, `
+		SELECT node_id, name, value, signed_at, signer
+		FROM node_tags
+		WHERE node_id = $1
+		ORDER BY name, signer
+	`, nodeID)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	defer func() { err = errs.Combine(err, rows.Close()) }()
+
+	for rows.Next() {
+		var tag uploadselection.NodeTag
+		err = rows.Scan(&tag.NodeID, &tag.Name, &tag.Value, &tag.SignedAt, &tag.Signer)
+		if err != nil {
+			return nil, Error.Wrap(err)
+		}
+		tags = append(tags, tag)
+	}
+	return tags, Error.Wrap(rows.Err())
+}
+
+// getNodeTags returns the tags of the specified nodes, or of all the nodes when nodeIDs is nil.
+func (cache *overlaycache) getNodeTags(ctx context.Context, asOf time.Duration, nodeIDs storj.NodeIDList) (_ map[storj.NodeID]uploadselection.NodeTags, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	query := `
+		SELECT node_id, name, value, signed_at, signer
+		FROM node_tags
+		` + cache.db.impl.AsOfSystemInterval(asOf)
+	var args []interface{}
+	if nodeIDs != nil {
+		query += ` WHERE node_id = any($1::bytea[])`
+		args = append(args, pgutil.NodeIDArray(nodeIDs))
+	}
+
+	rows, err := cache.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	defer func() { err = errs.Combine(err, rows.Close()) }()
+
+	tags := map[storj.NodeID]uploadselection.NodeTags{}
+	for rows.Next() {
+		var tag uploadselection.NodeTag
+		err = rows.Scan(&tag.NodeID, &tag.Name, &tag.Value, &tag.SignedAt, &tag.Signer)
+		if err != nil {
+			return nil, Error.Wrap(err)
+		}
+		tags[tag.NodeID] = append(tags[tag.NodeID], tag)
+	}
+	return tags, Error.Wrap(rows.Err())
+}
+
+// SetDisqualificationExplanation stores the explanation of the disqualification of a node,
+// replacing the explanation of an earlier disqualification.
+func (cache *overlaycache) SetDisqualificationExplanation(ctx context.Context, explanation overlay.DisqualificationExplanation) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	data, err := json.Marshal(explanation)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	_, err = cache.db.ExecContext(ctx, `
+		INSERT INTO node_disqualification_explanations (node_id, disqualified_at, reason, explanation)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (node_id)
+		DO UPDATE SET
+			disqualified_at = EXCLUDED.disqualified_at,
+			reason = EXCLUDED.reason,
+			explanation = EXCLUDED.explanation
+	`, explanation.NodeID, explanation.DisqualifiedAt, int(explanation.Reason), data)
+	return Error.Wrap(err)
+}
+
+// GetDisqualificationExplanation returns the explanation of the disqualification of a node.
+func (cache *overlaycache) GetDisqualificationExplanation(ctx context.Context, nodeID storj.NodeID) (_ *overlay.DisqualificationExplanation, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	var data []byte
+	err = cache.db.QueryRowContext(ctx, `
+		SELECT explanation
+		FROM node_disqualification_explanations
+		WHERE node_id = $1
+	`, nodeID).Scan(&data)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, overlay.ErrExplanationNotFound.New("%v", nodeID)
+		}
+		return nil, Error.Wrap(err)
+	}
+
+	var explanation overlay.DisqualificationExplanation
+	if err := json.Unmarshal(data, &explanation); err != nil {
+		return nil, Error.Wrap(err)
+	}
+	return &explanation, nil
+}
@@ -7,6 +7,9 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"fmt"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/zeebo/errs"
@@ -48,7 +51,7 @@ func (r *repairQueue) Insert(ctx context.Context, seg *queue.InjuredSegment) (al
 			)
 			ON CONFLICT (stream_id, position)
 			DO UPDATE
-			SET segment_health=$3, updated_at=current_timestamp
+			SET segment_health=CASE WHEN repair_queue.prioritized THEN repair_queue.segment_health ELSE $3 END, updated_at=current_timestamp
 			RETURNING (xmax != 0) AS alreadyInserted
 		`
 	case dbutil.Cockroach:
@@ -67,7 +70,7 @@ func (r *repairQueue) Insert(ctx context.Context, seg *queue.InjuredSegment) (al
 			)
 			ON CONFLICT (stream_id, position)
 			DO UPDATE
-			SET segment_health=$3, updated_at=current_timestamp
+			SET segment_health=CASE WHEN repair_queue.prioritized THEN repair_queue.segment_health ELSE $3 END, updated_at=current_timestamp
 			RETURNING (SELECT alreadyInserted FROM inserted)
 		`
 	}
@@ -118,7 +121,7 @@ func (r *repairQueue) InsertBatch(
 			)
 			ON CONFLICT (stream_id, position)
 			DO UPDATE
-			SET segment_health=EXCLUDED.segment_health, updated_at=current_timestamp
+			SET segment_health=CASE WHEN repair_queue.prioritized THEN repair_queue.segment_health ELSE EXCLUDED.segment_health END, updated_at=current_timestamp
 			RETURNING NOT(xmax != 0) AS newlyInserted
 		`
 	case dbutil.Cockroach:
@@ -139,7 +142,7 @@ func (r *repairQueue) InsertBatch(
 				ON CONFLICT (stream_id, position)
 				DO UPDATE
 				SET
-					segment_health=EXCLUDED.segment_health,
+					segment_health=CASE WHEN repair_queue.prioritized THEN repair_queue.segment_health ELSE EXCLUDED.segment_health END,
 					updated_at=current_timestamp
 				RETURNING false
 			)
@@ -202,7 +205,8 @@ func (r *repairQueue) Select(ctx context.Context) (seg *queue.InjuredSegment, er
 	case dbutil.Cockroach:
 		err = r.db.QueryRowContext(ctx, `
 				UPDATE repair_queue SET attempted_at = now()
-				WHERE attempted_at IS NULL OR attempted_at < now() - interval '6 hours'
+				WHERE (attempted_at IS NULL OR attempted_at < now() - interval '6 hours')
+				AND NOT do_not_repair
 				ORDER BY segment_health ASC, attempted_at NULLS FIRST
 				LIMIT 1
 				RETURNING stream_id, position, attempted_at, updated_at, inserted_at, segment_health
@@ -212,7 +216,8 @@ func (r *repairQueue) Select(ctx context.Context) (seg *queue.InjuredSegment, er
 		err = r.db.QueryRowContext(ctx, `
 				UPDATE repair_queue SET attempted_at = now() WHERE (stream_id, position) = (
 					SELECT stream_id, position FROM repair_queue
-					WHERE attempted_at IS NULL OR attempted_at < now() - interval '6 hours'
+					WHERE (attempted_at IS NULL OR attempted_at < now() - interval '6 hours')
+					AND NOT do_not_repair
 					ORDER BY segment_health ASC, attempted_at NULLS FIRST FOR UPDATE SKIP LOCKED LIMIT 1
 				) RETURNING stream_id, position, attempted_at, updated_at, inserted_at, segment_health
 		`).Scan(&segment.StreamID, &segment.Position, &segment.AttemptedAt,
@@ -278,6 +283,103 @@ func (r *repairQueue) Count(ctx context.Context) (count int, err error) {
 	return count, Error.Wrap(err)
 }
 
+func (r *repairQueue) CountByHealth(ctx context.Context, boundaries []float64) (counts []int, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if !sort.Float64sAreSorted(boundaries) {
+		return nil, Error.New("health boundaries must be in ascending order")
+	}
+
+	var bucket strings.Builder
+	bucket.WriteString("CASE")
+	args := make([]interface{}, 0, len(boundaries))
+	for i, boundary := range boundaries {
+		fmt.Fprintf(&bucket, " WHEN segment_health < ? THEN %d", i)
+		args = append(args, boundary)
+	}
+	fmt.Fprintf(&bucket, " ELSE %d END", len(boundaries))
+
+	rows, err := r.db.QueryContext(ctx, r.db.Rebind(`
+		SELECT `+bucket.String()+` AS bucket, COUNT(*)
+		FROM repair_queue
+		GROUP BY bucket
+	`), args...)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	defer func() { err = errs.Combine(err, rows.Close()) }()
+
+	counts = make([]int, len(boundaries)+1)
+	for rows.Next() {
+		var bucket, count int
+		if err := rows.Scan(&bucket, &count); err != nil {
+			return nil, Error.Wrap(err)
+		}
+		counts[bucket] = count
+	}
+	return counts, Error.Wrap(rows.Err())
+}
+
+func (r *repairQueue) Get(ctx context.Context, streamID uuid.UUID, position metabase.SegmentPosition) (_ *queue.InjuredSegment, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	segment := queue.InjuredSegment{}
+	err = r.db.QueryRowContext(ctx, r.db.Rebind(`
+		SELECT stream_id, position, attempted_at, updated_at, inserted_at, segment_health, prioritized, do_not_repair
+		FROM repair_queue
+		WHERE stream_id = ? AND position = ?
+	`), streamID, position.Encode()).Scan(&segment.StreamID, &segment.Position, &segment.AttemptedAt,
+		&segment.UpdatedAt, &segment.InsertedAt, &segment.SegmentHealth, &segment.Prioritized, &segment.DoNotRepair)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, queue.ErrSegmentNotFound.New("%s/%d", streamID, position.Encode())
+		}
+		return nil, Error.Wrap(err)
+	}
+	return &segment, nil
+}
+
+func (r *repairQueue) Prioritize(ctx context.Context, streamID uuid.UUID, position metabase.SegmentPosition) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	// the attempted time is reset, so that the segment can be selected right away.
+	res, err := r.db.ExecContext(ctx, r.db.Rebind(`
+		UPDATE repair_queue
+		SET prioritized = true, do_not_repair = false, segment_health = ?, attempted_at = NULL
+		WHERE stream_id = ? AND position = ?
+	`), queue.PrioritizedSegmentHealth, streamID, position.Encode())
+	if err != nil {
+		return Error.Wrap(err)
+	}
+	return r.checkAffected(res, streamID, position)
+}
+
+func (r *repairQueue) SetDoNotRepair(ctx context.Context, streamID uuid.UUID, position metabase.SegmentPosition, doNotRepair bool) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	res, err := r.db.ExecContext(ctx, r.db.Rebind(`
+		UPDATE repair_queue
+		SET do_not_repair = ?
+		WHERE stream_id = ? AND position = ?
+	`), doNotRepair, streamID, position.Encode())
+	if err != nil {
+		return Error.Wrap(err)
+	}
+	return r.checkAffected(res, streamID, position)
+}
+
+// checkAffected returns ErrSegmentNotFound when the update didn't affect the segment.
+func (r *repairQueue) checkAffected(res sql.Result, streamID uuid.UUID, position metabase.SegmentPosition) error {
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return Error.Wrap(err)
+	}
+	if affected == 0 {
+		return queue.ErrSegmentNotFound.New("%s/%d", streamID, position.Encode())
+	}
+	return nil
+}
+
 // TestingSetAttemptedTime sets attempted time for a segment.
 func (r *repairQueue) TestingSetAttemptedTime(ctx context.Context, streamID uuid.UUID,
 	position metabase.SegmentPosition, t time.Time) (rowsAffected int64, err error) {
@@ -6,8 +6,10 @@ package satellitedb
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"sort"
 	"time"
 
 	"github.com/zeebo/errs"
@@ -184,6 +186,50 @@ func (reputations *reputations) Get(ctx context.Context, nodeID storj.NodeID) (*
 	}, nil
 }
 
+// AuditStats returns the audit statistics of all nodes which are not disqualified.
+// The audits of the audit history windows starting before since are not counted as recent.
+func (reputations *reputations) AuditStats(ctx context.Context, since time.Time) (_ map[storj.NodeID]reputation.AuditStats, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	rows, err := reputations.db.Query(ctx, `
+		SELECT id, vetted_at, audit_history
+		FROM reputations
+		WHERE disqualified IS NULL
+	`)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	defer func() { err = errs.Combine(err, rows.Close()) }()
+
+	stats := make(map[storj.NodeID]reputation.AuditStats)
+	for rows.Next() {
+		var nodeID storj.NodeID
+		var vettedAt *time.Time
+		var historyBytes []byte
+		if err := rows.Scan(&nodeID, &vettedAt, &historyBytes); err != nil {
+			return nil, Error.Wrap(err)
+		}
+
+		history := &pb.AuditHistory{}
+		if err := pb.Unmarshal(historyBytes, history); err != nil {
+			return nil, Error.Wrap(err)
+		}
+
+		var recent int64
+		for _, window := range history.Windows {
+			if !window.WindowStart.Before(since) {
+				recent += int64(window.TotalCount)
+			}
+		}
+
+		stats[nodeID] = reputation.AuditStats{
+			Vetted:       vettedAt != nil,
+			RecentAudits: recent,
+		}
+	}
+	return stats, Error.Wrap(rows.Err())
+}
+
 // DisqualifyNode disqualifies a storage node.
 func (reputations *reputations) DisqualifyNode(ctx context.Context, nodeID storj.NodeID, disqualifiedAt time.Time, disqualificationReason overlay.DisqualificationReason) (err error) {
 	defer mon.Task()(&ctx)(&err)
@@ -300,6 +346,84 @@ func (reputations *reputations) UnsuspendNodeUnknownAudit(ctx context.Context, n
 	return Error.Wrap(err)
 }
 
+// AddRecentAudits stores the audits of a node, keeping only the limit most recent
+// audits of the node. The audits of a node are stored together, so a batch of
+// audits is added with a single update.
+func (reputations *reputations) AddRecentAudits(ctx context.Context, nodeID storj.NodeID, audits []overlay.DisqualificationAudit, limit int) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if len(audits) == 0 || limit <= 0 {
+		return nil
+	}
+
+	err = reputations.db.WithTx(ctx, func(ctx context.Context, tx *dbx.Tx) (err error) {
+		_, err = tx.Tx.ExecContext(ctx, "SET TRANSACTION ISOLATION LEVEL SERIALIZABLE")
+		if err != nil {
+			return err
+		}
+
+		stored, err := scanRecentAudits(tx.Tx.QueryRowContext(ctx, `
+			SELECT audits
+			FROM node_audit_trails
+			WHERE node_id = $1
+		`, nodeID))
+		if err != nil {
+			return err
+		}
+
+		trail := append(stored, audits...)
+		sort.SliceStable(trail, func(i, k int) bool {
+			return trail[i].AuditedAt.Before(trail[k].AuditedAt)
+		})
+		if len(trail) > limit {
+			trail = trail[len(trail)-limit:]
+		}
+
+		data, err := json.Marshal(trail)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.Tx.ExecContext(ctx, `
+			INSERT INTO node_audit_trails (node_id, audits)
+			VALUES ($1, $2)
+			ON CONFLICT (node_id)
+			DO UPDATE SET audits = EXCLUDED.audits
+		`, nodeID, data)
+		return err
+	})
+	return Error.Wrap(err)
+}
+
+// GetRecentAudits returns the stored audits of a node, the oldest first.
+func (reputations *reputations) GetRecentAudits(ctx context.Context, nodeID storj.NodeID) (_ []overlay.DisqualificationAudit, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	audits, err := scanRecentAudits(reputations.db.QueryRowContext(ctx, `
+		SELECT audits
+		FROM node_audit_trails
+		WHERE node_id = $1
+	`, nodeID))
+	return audits, Error.Wrap(err)
+}
+
+// scanRecentAudits decodes the audits of the node_audit_trails row. A node without a row
+// has no audits.
+func scanRecentAudits(row *sql.Row) (audits []overlay.DisqualificationAudit, err error) {
+	var data []byte
+	if err := row.Scan(&data); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	if err := json.Unmarshal(data, &audits); err != nil {
+		return nil, err
+	}
+	return audits, nil
+}
+
 func (reputations *reputations) populateCreateFields(update updateNodeStats) dbx.Reputation_Create_Fields {
 	createFields := dbx.Reputation_Create_Fields{}
 
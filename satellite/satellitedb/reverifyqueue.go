@@ -8,6 +8,9 @@ import (
 	"database/sql"
 	"time"
 
+	"github.com/zeebo/errs"
+
+	"storj.io/common/storj"
 	"storj.io/storj/satellite/audit"
 	"storj.io/storj/satellite/satellitedb/dbx"
 )
@@ -64,7 +67,7 @@ func (rq *reverifyQueue) GetNextJob(ctx context.Context) (job audit.Reverificati
 		WHERE ra.node_id = next_entry.node_id
 			AND ra.stream_id = next_entry.stream_id
 			AND ra.position = next_entry.position
-		RETURNING ra.node_id, ra.stream_id, ra.position, ra.piece_num, ra.inserted_at, ra.reverify_count
+		RETURNING ra.node_id, ra.stream_id, ra.position, ra.piece_num, ra.inserted_at, ra.reverify_count, ra.last_attempt
 	`, ReverifyRetryInterval.Microseconds()).Scan(
 		&job.Locator.NodeID,
 		&job.Locator.StreamID,
@@ -72,6 +75,7 @@ func (rq *reverifyQueue) GetNextJob(ctx context.Context) (job audit.Reverificati
 		&job.Locator.PieceNum,
 		&job.InsertedAt,
 		&job.ReverifyCount,
+		&job.LastAttempt,
 	)
 	return job, err
 }
@@ -91,6 +95,53 @@ func (rq *reverifyQueue) Remove(ctx context.Context, piece audit.PieceLocator) (
 	)
 }
 
+// List returns the jobs of the node, or of all nodes when nodeID is zero, the
+// job which has been in the queue the longest first.
+func (rq *reverifyQueue) List(ctx context.Context, nodeID storj.NodeID, limit int) (jobs []audit.ReverificationJob, err error) {
+	defer mon.Task()(&ctx)(&err)
+	if limit <= 0 {
+		return nil, nil
+	}
+
+	rows, err := rq.db.QueryContext(ctx, `
+		SELECT node_id, stream_id, position, piece_num, inserted_at, reverify_count, last_attempt
+		FROM reverification_audits
+		WHERE $1::bytea IS NULL OR node_id = $1::bytea
+		ORDER BY inserted_at, node_id, stream_id, position
+		LIMIT $2
+	`, nullNodeID(nodeID), limit)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { err = errs.Combine(err, rows.Close()) }()
+
+	for rows.Next() {
+		var job audit.ReverificationJob
+		err := rows.Scan(
+			&job.Locator.NodeID,
+			&job.Locator.StreamID,
+			&job.Locator.Position,
+			&job.Locator.PieceNum,
+			&job.InsertedAt,
+			&job.ReverifyCount,
+			&job.LastAttempt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, job)
+	}
+	return jobs, rows.Err()
+}
+
+// nullNodeID returns nil for the zero node ID, so it can be used as a NULL filter.
+func nullNodeID(nodeID storj.NodeID) []byte {
+	if nodeID.IsZero() {
+		return nil
+	}
+	return nodeID.Bytes()
+}
+
 // TestingFudgeUpdateTime (used only for testing) changes the last_update
 // timestamp for an entry in the reverification queue to a specific value.
 func (rq *reverifyQueue) TestingFudgeUpdateTime(ctx context.Context, piece audit.PieceLocator, updateTime time.Time) error {
@@ -11,6 +11,7 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	"storj.io/common/storj"
 	"storj.io/common/sync2"
 	"storj.io/common/testcontext"
 	"storj.io/common/testrand"
@@ -97,3 +98,51 @@ func TestReverifyQueue(t *testing.T) {
 		require.Error(t, sql.ErrNoRows, err)
 	})
 }
+
+func TestReverifyQueueList(t *testing.T) {
+	satellitedbtest.Run(t, func(ctx *testcontext.Context, t *testing.T, db satellite.DB) {
+		reverifyQueue := db.ReverifyQueue()
+
+		nodeID := testrand.NodeID()
+		var locators []audit.PieceLocator
+		for i := 0; i < 3; i++ {
+			locator := audit.PieceLocator{
+				StreamID: testrand.UUID(),
+				NodeID:   nodeID,
+				PieceNum: i,
+			}
+			if i == 2 {
+				locator.NodeID = testrand.NodeID()
+			}
+			require.NoError(t, reverifyQueue.Insert(ctx, locator))
+			locators = append(locators, locator)
+
+			// make sure the insertion times differ.
+			sync2.Sleep(ctx, time.Microsecond)
+		}
+
+		jobs, err := reverifyQueue.List(ctx, storj.NodeID{}, 10)
+		require.NoError(t, err)
+		require.Len(t, jobs, 3)
+		for i, job := range jobs {
+			require.Equal(t, locators[i], job.Locator)
+			require.Nil(t, job.LastAttempt)
+		}
+
+		jobs, err = reverifyQueue.List(ctx, storj.NodeID{}, 1)
+		require.NoError(t, err)
+		require.Len(t, jobs, 1)
+
+		_, err = reverifyQueue.GetNextJob(ctx)
+		require.NoError(t, err)
+
+		jobs, err = reverifyQueue.List(ctx, nodeID, 10)
+		require.NoError(t, err)
+		require.Len(t, jobs, 2)
+		require.Equal(t, locators[0], jobs[0].Locator)
+		require.NotNil(t, jobs[0].LastAttempt)
+		require.Equal(t, 1, jobs[0].ReverifyCount)
+		require.Equal(t, locators[1], jobs[1].Locator)
+		require.Nil(t, jobs[1].LastAttempt)
+	})
+}
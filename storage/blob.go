@@ -38,6 +38,15 @@ func (ref *BlobRef) IsValid() bool {
 	return len(ref.Namespace) > 0 && len(ref.Key) > 0
 }
 
+// RestoreTrashOptions restricts which files are restored from the trash. The zero
+// value restores all files.
+type RestoreTrashOptions struct {
+	// TrashedAfter restores only the files moved to the trash at or after it, when it isn't zero.
+	TrashedAfter time.Time
+	// Keys restores only the files with one of the keys, when it isn't empty.
+	Keys [][]byte
+}
+
 // BlobReader is an interface that groups Read, ReadAt, Seek and Close.
 type BlobReader interface {
 	io.Reader
@@ -87,6 +96,9 @@ type Blobs interface {
 	Trash(ctx context.Context, ref BlobRef) error
 	// RestoreTrash restores all files in the trash for a given namespace and returns the keys restored.
 	RestoreTrash(ctx context.Context, namespace []byte) ([][]byte, error)
+	// RestoreTrashWithOptions restores the files in the trash for a given namespace, which are
+	// selected by the options, and returns the keys restored.
+	RestoreTrashWithOptions(ctx context.Context, namespace []byte, opts RestoreTrashOptions) ([][]byte, error)
 	// EmptyTrash removes all files in trash that were moved to trash prior to trashedBefore and returns the total bytes emptied and keys deleted.
 	EmptyTrash(ctx context.Context, namespace []byte, trashedBefore time.Time) (int64, [][]byte, error)
 	// Stat looks up disk metadata on the blob file.
@@ -0,0 +1,132 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package filestore
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	"storj.io/storj/storage"
+)
+
+const (
+	// BackendFiles is the backend, which stores every blob in its own file.
+	BackendFiles = "files"
+	// BackendPacked is the backend, which appends blobs to large log files.
+	BackendPacked = "packed"
+
+	// backendsFileName is the name of the file, which lists the backends that may
+	// store blobs in the directory. When it doesn't exist, only BackendFiles may.
+	backendsFileName = "storage-backends"
+)
+
+// OpenBlobs opens the blob store of the configured backend in the directory.
+//
+// When blobs may still be stored by another backend, because the configured backend
+// was changed, the returned store is a *MigratingBlobs, which finds the blobs of both
+// backends and moves the blobs to the configured backend with MigratingBlobs.Migrate.
+func OpenBlobs(log *zap.Logger, dir *Dir, config Config) (_ storage.Blobs, err error) {
+	// a zero config keeps storing every blob in its own file, like before the backends
+	// were configurable.
+	if config.Backend == "" {
+		config.Backend = BackendFiles
+	}
+	if config.PackedLogSize <= 0 {
+		config.PackedLogSize = DefaultConfig.PackedLogSize
+	}
+
+	switch config.Backend {
+	case BackendFiles, BackendPacked:
+	default:
+		return nil, Error.New("unknown backend %q", config.Backend)
+	}
+
+	backends, err := readBackends(dir)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	var source string
+	for _, backend := range backends {
+		if backend != config.Backend {
+			source = backend
+		}
+	}
+	if len(backends) != 1 || source != "" {
+		// the backends file is written before anything is stored by the configured
+		// backend, so that the blobs aren't lost when it's changed again.
+		err := writeBackends(dir, []string{source, config.Backend})
+		if err != nil {
+			return nil, Error.Wrap(err)
+		}
+	}
+
+	to, err := openBackend(log, dir, config, config.Backend)
+	if err != nil {
+		return nil, err
+	}
+	if source == "" {
+		return to, nil
+	}
+
+	from, err := openBackend(log, dir, config, source)
+	if err != nil {
+		return nil, errs.Combine(err, to.Close())
+	}
+
+	log.Info("migrating blobs to the configured backend", zap.String("From", source), zap.String("To", config.Backend))
+	return newMigratingBlobs(log, dir, config, from, to), nil
+}
+
+// openBackend opens the blob store of the backend.
+func openBackend(log *zap.Logger, dir *Dir, config Config, backend string) (migratableBlobs, error) {
+	switch backend {
+	case BackendFiles:
+		return &blobStore{dir: dir, log: log, config: config}, nil
+	case BackendPacked:
+		return newPackedStore(log, dir, config)
+	default:
+		return nil, Error.New("unknown backend %q", backend)
+	}
+}
+
+// readBackends returns the backends, which may store blobs in the directory.
+func readBackends(dir *Dir) ([]string, error) {
+	data, err := os.ReadFile(filepath.Join(dir.Path(), backendsFileName))
+	if os.IsNotExist(err) {
+		return []string{BackendFiles}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var backends []string
+	for _, backend := range strings.Split(string(data), "\n") {
+		if backend = strings.TrimSpace(backend); backend != "" {
+			backends = append(backends, backend)
+		}
+	}
+	return backends, nil
+}
+
+// writeBackends replaces the list of the backends, which may store blobs in the directory.
+func writeBackends(dir *Dir, backends []string) error {
+	var data strings.Builder
+	for _, backend := range backends {
+		if backend != "" {
+			data.WriteString(backend + "\n")
+		}
+	}
+
+	path := filepath.Join(dir.Path(), backendsFileName)
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, []byte(data.String()), blobPermission); err != nil {
+		return err
+	}
+	return rename(tmpPath, path)
+}
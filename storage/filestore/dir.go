@@ -424,7 +424,36 @@ func (dir *Dir) ReplaceTrashnow(trashnow func() time.Time) {
 
 // RestoreTrash moves every piece in the trash folder back into blobsdir.
 func (dir *Dir) RestoreTrash(ctx context.Context, namespace []byte) (keysRestored [][]byte, err error) {
+	return dir.RestoreTrashWithOptions(ctx, namespace, storage.RestoreTrashOptions{})
+}
+
+// RestoreTrashWithOptions moves the pieces in the trash folder, which are selected by the
+// options, back into blobsdir. The time a piece was moved to the trash is its mtime.
+func (dir *Dir) RestoreTrashWithOptions(ctx context.Context, namespace []byte, opts storage.RestoreTrashOptions) (keysRestored [][]byte, err error) {
+	var keys map[string]struct{}
+	if len(opts.Keys) > 0 {
+		keys = make(map[string]struct{}, len(opts.Keys))
+		for _, key := range opts.Keys {
+			keys[string(key)] = struct{}{}
+		}
+	}
+
 	err = dir.walkNamespaceInPath(ctx, namespace, dir.trashdir(), func(info storage.BlobInfo) error {
+		if keys != nil {
+			if _, ok := keys[string(info.BlobRef().Key)]; !ok {
+				return nil
+			}
+		}
+		if !opts.TrashedAfter.IsZero() {
+			fileInfo, err := info.Stat(ctx)
+			if err != nil {
+				return err
+			}
+			if fileInfo.ModTime().Before(opts.TrashedAfter) {
+				return nil
+			}
+		}
+
 		blobsBasePath, err := dir.blobToBasePath(info.BlobRef())
 		if err != nil {
 			return err
@@ -838,6 +867,7 @@ func removeAllContent(ctx context.Context, path string) (err error) {
 type DiskInfo struct {
 	ID             string
 	AvailableSpace int64
+	TotalSpace     int64
 }
 
 // Info returns information about the current state of the dir.
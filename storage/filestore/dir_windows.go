@@ -29,19 +29,19 @@ func diskInfoFromPath(path string) (info DiskInfo, err error) {
 		absPath = path
 	}
 	var filesystemID string
-	var availableSpace int64
+	var availableSpace, totalSpace int64
 
-	availableSpace, err = getDiskFreeSpace(absPath)
+	availableSpace, totalSpace, err = getDiskFreeSpace(absPath)
 	if err != nil {
-		return DiskInfo{"", -1}, err
+		return DiskInfo{"", -1, -1}, err
 	}
 
 	filesystemID, err = getVolumeSerialNumber(absPath)
 	if err != nil {
-		return DiskInfo{"", availableSpace}, err
+		return DiskInfo{"", availableSpace, totalSpace}, err
 	}
 
-	return DiskInfo{filesystemID, availableSpace}, nil
+	return DiskInfo{filesystemID, availableSpace, totalSpace}, nil
 }
 
 var (
@@ -49,16 +49,15 @@ var (
 	procGetDiskFreeSpace = kernel32.MustFindProc("GetDiskFreeSpaceExW")
 )
 
-func getDiskFreeSpace(path string) (int64, error) {
+func getDiskFreeSpace(path string) (available, total int64, err error) {
 	path16, err := windows.UTF16PtrFromString(path)
 	if err != nil {
-		return -1, err
+		return -1, -1, err
 	}
 
-	var bytes int64
-	_, _, err = procGetDiskFreeSpace.Call(uintptr(unsafe.Pointer(path16)), uintptr(unsafe.Pointer(&bytes)), 0, 0)
+	_, _, err = procGetDiskFreeSpace.Call(uintptr(unsafe.Pointer(path16)), uintptr(unsafe.Pointer(&available)), uintptr(unsafe.Pointer(&total)), 0)
 	err = ignoreSuccess(err)
-	return bytes, err
+	return available, total, err
 }
 
 func getVolumeSerialNumber(path string) (string, error) {
@@ -0,0 +1,439 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package filestore
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	"storj.io/common/storj"
+	"storj.io/common/sync2"
+	"storj.io/storj/storage"
+)
+
+var _ storage.Blobs = (*MigratingBlobs)(nil)
+
+// migratableBlobs is a blob store of a backend, which blobs can be migrated from and to.
+type migratableBlobs interface {
+	storage.Blobs
+
+	// createWithStorageFormat creates a new blob with the storage format version that can be written.
+	createWithStorageFormat(ctx context.Context, ref storage.BlobRef, size int64, formatVer storage.FormatVersion) (storage.BlobWriter, error)
+	// hasTrash returns whether any blob is in the trash.
+	hasTrash(ctx context.Context) (bool, error)
+}
+
+// MigratingBlobs is a blob store, which moves the blobs of one backend to another one
+// while being used. New blobs are stored by the target backend, and the blobs are looked
+// for in both backends.
+//
+// The blobs in the trash of the source backend aren't moved; they are restored or
+// emptied from it. The migration is done, once the source backend doesn't store any
+// blobs, and the source backend isn't opened anymore afterwards.
+type MigratingBlobs struct {
+	log    *zap.Logger
+	dir    *Dir
+	config Config
+
+	from migratableBlobs
+	to   migratableBlobs
+
+	mu        sync.Mutex
+	moving    *storage.BlobRef // the blob being moved.
+	untouched bool             // whether the blob being moved wasn't deleted or trashed meanwhile.
+	done      bool
+}
+
+func newMigratingBlobs(log *zap.Logger, dir *Dir, config Config, from, to migratableBlobs) *MigratingBlobs {
+	return &MigratingBlobs{
+		log:    log,
+		dir:    dir,
+		config: config,
+		from:   from,
+		to:     to,
+	}
+}
+
+// Migrate moves all blobs, which aren't in the trash, from the source backend to the target
+// backend. It returns whether the migration is done, which is when the source backend
+// doesn't store any blobs, including the trash.
+func (blobs *MigratingBlobs) Migrate(ctx context.Context) (done bool, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	blobs.mu.Lock()
+	done = blobs.done
+	blobs.mu.Unlock()
+	if done {
+		return true, nil
+	}
+
+	namespaces, err := blobs.from.ListNamespaces(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	for _, namespace := range namespaces {
+		err := blobs.from.WalkNamespace(ctx, namespace, func(info storage.BlobInfo) error {
+			return blobs.move(ctx, info.BlobRef(), info.StorageFormatVersion())
+		})
+		if err != nil {
+			return false, err
+		}
+	}
+
+	hasTrash, err := blobs.from.hasTrash(ctx)
+	if err != nil || hasTrash {
+		return false, err
+	}
+
+	for _, namespace := range namespaces {
+		empty := true
+		err := blobs.from.WalkNamespace(ctx, namespace, func(storage.BlobInfo) error {
+			empty = false
+			return nil
+		})
+		if err != nil || !empty {
+			return false, err
+		}
+	}
+
+	for _, namespace := range namespaces {
+		if err := blobs.from.DeleteNamespace(ctx, namespace); err != nil {
+			return false, err
+		}
+	}
+	if err := writeBackends(blobs.dir, []string{blobs.config.Backend}); err != nil {
+		return false, err
+	}
+
+	blobs.mu.Lock()
+	blobs.done = true
+	blobs.mu.Unlock()
+
+	blobs.log.Info("migrating blobs done", zap.String("Backend", blobs.config.Backend))
+	return true, nil
+}
+
+// move copies the blob to the target backend and deletes it from the source backend.
+func (blobs *MigratingBlobs) move(ctx context.Context, ref storage.BlobRef, formatVer storage.FormatVersion) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	reader, err := blobs.from.OpenWithStorageFormat(ctx, ref, formatVer)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			// deleted or trashed meanwhile.
+			return nil
+		}
+		return err
+	}
+	defer func() { err = errs.Combine(err, reader.Close()) }()
+
+	size, err := reader.Size()
+	if err != nil {
+		return err
+	}
+
+	blobs.mu.Lock()
+	blobs.moving, blobs.untouched = &ref, true
+	blobs.mu.Unlock()
+	defer func() {
+		blobs.mu.Lock()
+		blobs.moving = nil
+		blobs.mu.Unlock()
+	}()
+
+	writer, err := blobs.to.createWithStorageFormat(ctx, ref, size, formatVer)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(writer, reader); err != nil {
+		return errs.Combine(err, writer.Cancel(ctx))
+	}
+
+	// the blob is committed with the lock held, so that it can't be deleted or trashed
+	// between checking and committing it.
+	blobs.mu.Lock()
+	if !blobs.untouched {
+		blobs.mu.Unlock()
+		return writer.Cancel(ctx)
+	}
+	err = writer.Commit(ctx)
+	blobs.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	return blobs.from.DeleteWithStorageFormat(ctx, ref, formatVer)
+}
+
+// touch records that the blob is deleted or trashed, in case it's being moved.
+func (blobs *MigratingBlobs) touch(ref storage.BlobRef) {
+	blobs.mu.Lock()
+	defer blobs.mu.Unlock()
+
+	if blobs.moving != nil && bytes.Equal(blobs.moving.Namespace, ref.Namespace) && bytes.Equal(blobs.moving.Key, ref.Key) {
+		blobs.untouched = false
+	}
+}
+
+// touchNamespace records that all blobs of the namespace are deleted, in case one of them
+// is being moved.
+func (blobs *MigratingBlobs) touchNamespace(namespace []byte) {
+	blobs.mu.Lock()
+	defer blobs.mu.Unlock()
+
+	if blobs.moving != nil && bytes.Equal(blobs.moving.Namespace, namespace) {
+		blobs.untouched = false
+	}
+}
+
+// MigrationChore migrates the blobs of MigratingBlobs regularly.
+type MigrationChore struct {
+	log   *zap.Logger
+	blobs []*MigratingBlobs
+
+	Loop *sync2.Cycle
+}
+
+// NewMigrationChore creates a new chore, which migrates the blobs at the interval.
+func NewMigrationChore(log *zap.Logger, blobs []*MigratingBlobs, interval time.Duration) *MigrationChore {
+	return &MigrationChore{
+		log:   log,
+		blobs: blobs,
+		Loop:  sync2.NewCycle(interval),
+	}
+}
+
+// Run moves the blobs to the target backend regularly. Once the migration is done, it does nothing.
+func (chore *MigrationChore) Run(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+	return chore.Loop.Run(ctx, func(ctx context.Context) error {
+		for _, blobs := range chore.blobs {
+			if _, err := blobs.Migrate(ctx); err != nil {
+				chore.log.Error("failed to migrate blobs", zap.String("Path", blobs.dir.Path()), zap.Error(err))
+			}
+		}
+		return nil
+	})
+}
+
+// Close stops the chore.
+func (chore *MigrationChore) Close() error {
+	chore.Loop.Close()
+	return nil
+}
+
+// Close closes both backends.
+func (blobs *MigratingBlobs) Close() error {
+	return errs.Combine(blobs.to.Close(), blobs.from.Close())
+}
+
+// Create creates a new blob in the target backend.
+func (blobs *MigratingBlobs) Create(ctx context.Context, ref storage.BlobRef, size int64) (_ storage.BlobWriter, err error) {
+	defer mon.Task()(&ctx)(&err)
+	return blobs.to.Create(ctx, ref, size)
+}
+
+// TestCreateV0 creates a new V0 blob that can be written. This is ONLY appropriate in test situations.
+func (blobs *MigratingBlobs) TestCreateV0(ctx context.Context, ref storage.BlobRef) (_ storage.BlobWriter, err error) {
+	defer mon.Task()(&ctx)(&err)
+	return blobs.to.createWithStorageFormat(ctx, ref, -1, FormatV0)
+}
+
+// Open opens the blob from the target backend, or from the source backend when it isn't moved yet.
+func (blobs *MigratingBlobs) Open(ctx context.Context, ref storage.BlobRef) (_ storage.BlobReader, err error) {
+	defer mon.Task()(&ctx)(&err)
+	reader, err := blobs.to.Open(ctx, ref)
+	if errors.Is(err, os.ErrNotExist) {
+		return blobs.from.Open(ctx, ref)
+	}
+	return reader, err
+}
+
+// OpenWithStorageFormat opens the blob with the storage format version from the target backend,
+// or from the source backend when it isn't moved yet.
+func (blobs *MigratingBlobs) OpenWithStorageFormat(ctx context.Context, ref storage.BlobRef, formatVer storage.FormatVersion) (_ storage.BlobReader, err error) {
+	defer mon.Task()(&ctx)(&err)
+	reader, err := blobs.to.OpenWithStorageFormat(ctx, ref, formatVer)
+	if errors.Is(err, os.ErrNotExist) {
+		return blobs.from.OpenWithStorageFormat(ctx, ref, formatVer)
+	}
+	return reader, err
+}
+
+// Stat looks up the blob in the target backend, or in the source backend when it isn't moved yet.
+func (blobs *MigratingBlobs) Stat(ctx context.Context, ref storage.BlobRef) (_ storage.BlobInfo, err error) {
+	defer mon.Task()(&ctx)(&err)
+	info, err := blobs.to.Stat(ctx, ref)
+	if errors.Is(err, os.ErrNotExist) {
+		return blobs.from.Stat(ctx, ref)
+	}
+	return info, err
+}
+
+// StatWithStorageFormat looks up the blob with the storage format version in the target backend,
+// or in the source backend when it isn't moved yet.
+func (blobs *MigratingBlobs) StatWithStorageFormat(ctx context.Context, ref storage.BlobRef, formatVer storage.FormatVersion) (_ storage.BlobInfo, err error) {
+	defer mon.Task()(&ctx)(&err)
+	info, err := blobs.to.StatWithStorageFormat(ctx, ref, formatVer)
+	if errors.Is(err, os.ErrNotExist) {
+		return blobs.from.StatWithStorageFormat(ctx, ref, formatVer)
+	}
+	return info, err
+}
+
+// Delete deletes the blob from both backends.
+func (blobs *MigratingBlobs) Delete(ctx context.Context, ref storage.BlobRef) (err error) {
+	defer mon.Task()(&ctx)(&err)
+	blobs.touch(ref)
+	return errs.Combine(blobs.from.Delete(ctx, ref), blobs.to.Delete(ctx, ref))
+}
+
+// DeleteWithStorageFormat deletes the blob with the storage format version from both backends.
+func (blobs *MigratingBlobs) DeleteWithStorageFormat(ctx context.Context, ref storage.BlobRef, formatVer storage.FormatVersion) (err error) {
+	defer mon.Task()(&ctx)(&err)
+	blobs.touch(ref)
+	return errs.Combine(
+		blobs.from.DeleteWithStorageFormat(ctx, ref, formatVer),
+		blobs.to.DeleteWithStorageFormat(ctx, ref, formatVer),
+	)
+}
+
+// DeleteNamespace deletes the blobs of the namespace from both backends.
+func (blobs *MigratingBlobs) DeleteNamespace(ctx context.Context, ref []byte) (err error) {
+	defer mon.Task()(&ctx)(&err)
+	blobs.touchNamespace(ref)
+	return errs.Combine(blobs.from.DeleteNamespace(ctx, ref), blobs.to.DeleteNamespace(ctx, ref))
+}
+
+// Trash moves the blob to the trash in both backends.
+func (blobs *MigratingBlobs) Trash(ctx context.Context, ref storage.BlobRef) (err error) {
+	defer mon.Task()(&ctx)(&err)
+	blobs.touch(ref)
+	return errs.Combine(blobs.from.Trash(ctx, ref), blobs.to.Trash(ctx, ref))
+}
+
+// RestoreTrash restores every blob in the trash of both backends.
+func (blobs *MigratingBlobs) RestoreTrash(ctx context.Context, namespace []byte) (_ [][]byte, err error) {
+	defer mon.Task()(&ctx)(&err)
+	return blobs.RestoreTrashWithOptions(ctx, namespace, storage.RestoreTrashOptions{})
+}
+
+// RestoreTrashWithOptions restores the blobs in the trash of both backends, which are selected
+// by the options. The blobs restored in the source backend are moved later.
+func (blobs *MigratingBlobs) RestoreTrashWithOptions(ctx context.Context, namespace []byte, opts storage.RestoreTrashOptions) (_ [][]byte, err error) {
+	defer mon.Task()(&ctx)(&err)
+	fromKeys, fromErr := blobs.from.RestoreTrashWithOptions(ctx, namespace, opts)
+	toKeys, toErr := blobs.to.RestoreTrashWithOptions(ctx, namespace, opts)
+	return append(fromKeys, toKeys...), errs.Combine(fromErr, toErr)
+}
+
+// EmptyTrash empties the trash of both backends.
+func (blobs *MigratingBlobs) EmptyTrash(ctx context.Context, namespace []byte, trashedBefore time.Time) (_ int64, _ [][]byte, err error) {
+	defer mon.Task()(&ctx)(&err)
+	fromBytes, fromKeys, fromErr := blobs.from.EmptyTrash(ctx, namespace, trashedBefore)
+	toBytes, toKeys, toErr := blobs.to.EmptyTrash(ctx, namespace, trashedBefore)
+	return fromBytes + toBytes, append(fromKeys, toKeys...), errs.Combine(fromErr, toErr)
+}
+
+// FreeSpace returns how much space left in underlying directory.
+func (blobs *MigratingBlobs) FreeSpace(ctx context.Context) (int64, error) {
+	return blobs.to.FreeSpace(ctx)
+}
+
+// CheckWritability tests writability of the storage directory of the target backend.
+func (blobs *MigratingBlobs) CheckWritability(ctx context.Context) error {
+	return blobs.to.CheckWritability(ctx)
+}
+
+// SpaceUsedForTrash returns the total space used by the trash of both backends.
+func (blobs *MigratingBlobs) SpaceUsedForTrash(ctx context.Context) (_ int64, err error) {
+	defer mon.Task()(&ctx)(&err)
+	fromUsed, fromErr := blobs.from.SpaceUsedForTrash(ctx)
+	toUsed, toErr := blobs.to.SpaceUsedForTrash(ctx)
+	return fromUsed + toUsed, errs.Combine(fromErr, toErr)
+}
+
+// SpaceUsedForBlobs adds up the space used by both backends in all namespaces.
+func (blobs *MigratingBlobs) SpaceUsedForBlobs(ctx context.Context) (_ int64, err error) {
+	defer mon.Task()(&ctx)(&err)
+	fromUsed, fromErr := blobs.from.SpaceUsedForBlobs(ctx)
+	toUsed, toErr := blobs.to.SpaceUsedForBlobs(ctx)
+	return fromUsed + toUsed, errs.Combine(fromErr, toErr)
+}
+
+// SpaceUsedForBlobsInNamespace adds up the space used by both backends in the given namespace.
+func (blobs *MigratingBlobs) SpaceUsedForBlobsInNamespace(ctx context.Context, namespace []byte) (_ int64, err error) {
+	defer mon.Task()(&ctx)(&err)
+	fromUsed, fromErr := blobs.from.SpaceUsedForBlobsInNamespace(ctx, namespace)
+	toUsed, toErr := blobs.to.SpaceUsedForBlobsInNamespace(ctx, namespace)
+	return fromUsed + toUsed, errs.Combine(fromErr, toErr)
+}
+
+// ListNamespaces finds the namespaces in use by either backend.
+func (blobs *MigratingBlobs) ListNamespaces(ctx context.Context) (_ [][]byte, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	fromNamespaces, err := blobs.from.ListNamespaces(ctx)
+	if err != nil {
+		return nil, err
+	}
+	toNamespaces, err := blobs.to.ListNamespaces(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	namespaces := toNamespaces
+	seen := make(map[string]struct{}, len(toNamespaces))
+	for _, namespace := range toNamespaces {
+		seen[string(namespace)] = struct{}{}
+	}
+	for _, namespace := range fromNamespaces {
+		if _, ok := seen[string(namespace)]; !ok {
+			namespaces = append(namespaces, namespace)
+		}
+	}
+	return namespaces, nil
+}
+
+// WalkNamespace executes walkFunc for each blob in the given namespace, which is stored by either
+// backend. A blob, which is being moved, is only walked once.
+func (blobs *MigratingBlobs) WalkNamespace(ctx context.Context, namespace []byte, walkFunc func(storage.BlobInfo) error) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	err = blobs.from.WalkNamespace(ctx, namespace, func(info storage.BlobInfo) error {
+		_, err := blobs.to.StatWithStorageFormat(ctx, info.BlobRef(), info.StorageFormatVersion())
+		if err == nil {
+			// already moved; it's walked in the target backend.
+			return nil
+		}
+		if !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+		return walkFunc(info)
+	})
+	if err != nil {
+		return err
+	}
+	return blobs.to.WalkNamespace(ctx, namespace, walkFunc)
+}
+
+// CreateVerificationFile creates a file to be used for storage directory verification.
+func (blobs *MigratingBlobs) CreateVerificationFile(ctx context.Context, id storj.NodeID) error {
+	return blobs.to.CreateVerificationFile(ctx, id)
+}
+
+// VerifyStorageDir verifies that the storage directory is correct by checking for the existence and validity
+// of the verification file.
+func (blobs *MigratingBlobs) VerifyStorageDir(ctx context.Context, id storj.NodeID) error {
+	return blobs.to.VerifyStorageDir(ctx, id)
+}
@@ -0,0 +1,107 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package filestore_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"storj.io/common/testcontext"
+	"storj.io/common/testrand"
+	"storj.io/storj/storage"
+	"storj.io/storj/storage/filestore"
+)
+
+func TestMigratingBlobs(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	log := zaptest.NewLogger(t)
+	dir, err := filestore.NewDir(log, ctx.Dir("store"))
+	require.NoError(t, err)
+
+	filesConfig := filestore.DefaultConfig
+	packedConfig := filestore.DefaultConfig
+	packedConfig.Backend = filestore.BackendPacked
+
+	store, err := filestore.OpenBlobs(log, dir, filesConfig)
+	require.NoError(t, err)
+	_, migrating := store.(*filestore.MigratingBlobs)
+	require.False(t, migrating)
+
+	namespace := testrand.Bytes(namespaceSize)
+	blobs := map[string][]byte{}
+	var refs []storage.BlobRef
+	for i := 0; i < 5; i++ {
+		ref := storage.BlobRef{Namespace: namespace, Key: testrand.Bytes(keySize)}
+		data := testrand.BytesInt(1000 + i)
+		writeBlob(ctx, t, store, ref, data)
+		blobs[string(ref.Key)] = data
+		refs = append(refs, ref)
+	}
+	require.NoError(t, store.Trash(ctx, refs[0]))
+	delete(blobs, string(refs[0].Key))
+	require.NoError(t, store.Close())
+
+	// changing the backend migrates the blobs
+	store, err = filestore.OpenBlobs(log, dir, packedConfig)
+	require.NoError(t, err)
+	migratingBlobs, ok := store.(*filestore.MigratingBlobs)
+	require.True(t, ok)
+
+	// new blobs are stored by the configured backend, and all are found during migration
+	ref := storage.BlobRef{Namespace: namespace, Key: testrand.Bytes(keySize)}
+	blobs[string(ref.Key)] = testrand.BytesInt(2000)
+	writeBlob(ctx, t, store, ref, blobs[string(ref.Key)])
+	requireBlobs(ctx, t, store, namespace, blobs)
+
+	// the migration isn't done while the old backend has trash
+	done, err := migratingBlobs.Migrate(ctx)
+	require.NoError(t, err)
+	require.False(t, done)
+	requireBlobs(ctx, t, store, namespace, blobs)
+
+	// the trash of the old backend is restored from it
+	restored, err := store.RestoreTrash(ctx, namespace)
+	require.NoError(t, err)
+	require.Equal(t, [][]byte{refs[0].Key}, restored)
+	blobs[string(refs[0].Key)] = readBlob(ctx, t, store, refs[0])
+
+	done, err = migratingBlobs.Migrate(ctx)
+	require.NoError(t, err)
+	require.True(t, done)
+	requireBlobs(ctx, t, store, namespace, blobs)
+	require.NoError(t, store.Close())
+
+	// the old backend isn't used after the migration is done
+	store, err = filestore.OpenBlobs(log, dir, packedConfig)
+	require.NoError(t, err)
+	_, migrating = store.(*filestore.MigratingBlobs)
+	require.False(t, migrating)
+	requireBlobs(ctx, t, store, namespace, blobs)
+
+	// the trash of the new backend is emptied from the migrating blobs too
+	require.NoError(t, store.Trash(ctx, refs[1]))
+	delete(blobs, string(refs[1].Key))
+	require.NoError(t, store.Close())
+
+	// the blobs are migrated back
+	store, err = filestore.OpenBlobs(log, dir, filesConfig)
+	require.NoError(t, err)
+	defer ctx.Check(store.Close)
+	migratingBlobs, ok = store.(*filestore.MigratingBlobs)
+	require.True(t, ok)
+
+	_, keys, err := store.EmptyTrash(ctx, namespace, time.Now().Add(time.Hour))
+	require.NoError(t, err)
+	require.Equal(t, [][]byte{refs[1].Key}, keys)
+
+	done, err = migratingBlobs.Migrate(ctx)
+	require.NoError(t, err)
+	require.True(t, done)
+	requireBlobs(ctx, t, store, namespace, blobs)
+}
@@ -0,0 +1,573 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package filestore
+
+import (
+	"context"
+	"errors"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	"storj.io/common/storj"
+	"storj.io/storj/storage"
+)
+
+// Disk is a blob store, which stores blobs in a directory on a single disk.
+type Disk struct {
+	Path  string
+	Blobs storage.Blobs
+}
+
+// DiskStatus is the status of a disk of a MultiBlobs.
+type DiskStatus struct {
+	Path    string
+	Healthy bool
+	// Err is the reason why the disk isn't healthy.
+	Err  error
+	Free int64
+	// Used is the space used on the disk, including the files, which aren't blobs.
+	Used int64
+}
+
+// multiDisk is a disk of a MultiBlobs with its health and space.
+type multiDisk struct {
+	Disk
+
+	mu sync.Mutex
+	// readErr and writeErr are the errors of the last readability and writability checks.
+	readErr  error
+	writeErr error
+	// free and used are the space of the disk at the last refresh, and spaceErr is the
+	// error of getting it. free is reduced by the size of the blobs committed since.
+	refreshed bool
+	free      int64
+	used      int64
+	spaceErr  error
+}
+
+// healthErr returns why the disk isn't healthy, or nil when it is.
+func (disk *multiDisk) healthErr() error {
+	disk.mu.Lock()
+	defer disk.mu.Unlock()
+	return errs.Combine(disk.readErr, disk.writeErr)
+}
+
+// refreshSpace updates the free and used space of the disk.
+func (disk *multiDisk) refreshSpace(ctx context.Context) {
+	free, err := disk.Blobs.FreeSpace(ctx)
+	var used int64
+	if err == nil {
+		var info DiskInfo
+		info, err = diskInfoFromPath(disk.Path)
+		used = info.TotalSpace - info.AvailableSpace
+	}
+
+	disk.mu.Lock()
+	defer disk.mu.Unlock()
+	disk.refreshed = true
+	disk.free, disk.used, disk.spaceErr = free, used, err
+}
+
+// space returns the free and used space of the disk at the last refresh, refreshing it
+// when it wasn't yet.
+func (disk *multiDisk) space(ctx context.Context) (free, used int64, err error) {
+	disk.mu.Lock()
+	refreshed := disk.refreshed
+	disk.mu.Unlock()
+	if !refreshed {
+		disk.refreshSpace(ctx)
+	}
+
+	disk.mu.Lock()
+	defer disk.mu.Unlock()
+	return disk.free, disk.used, disk.spaceErr
+}
+
+// committed accounts for the space used by a blob committed to the disk until the
+// next refresh.
+func (disk *multiDisk) committed(size int64) {
+	disk.mu.Lock()
+	defer disk.mu.Unlock()
+	disk.free -= size
+	disk.used += size
+}
+
+// MultiBlobs is a blob store, which spans blob stores on several disks.
+//
+// The first disk is the main storage directory of the storage node, which must stay
+// available like without the other disks.
+//
+// New blobs are stored on the healthy disk with the most free space and blobs are looked
+// up on every disk. A disk is unhealthy while its last readability or writability check
+// failed. Errors of unhealthy disks are ignored, except when looking up blobs, so that a
+// failing disk only makes the blobs on it unavailable.
+//
+// The free space of the disks is cached, so that creating a blob doesn't access every
+// disk. It's refreshed by FreeSpace and CheckWritability, which the storage node's
+// monitor calls periodically, and reduced by the size of every committed blob in
+// between. A blob, which is created again, isn't looked up either, so it may be stored
+// on another disk than its previous copy. The data of a piece ID never changes, so the
+// copies are the same, and Delete and Trash remove every copy.
+//
+// architecture: Database
+type MultiBlobs struct {
+	log   *zap.Logger
+	disks []*multiDisk
+}
+
+var _ storage.Blobs = (*MultiBlobs)(nil)
+
+// NewMulti creates a new blob store, which spans the disks.
+func NewMulti(log *zap.Logger, disks []Disk) *MultiBlobs {
+	blobs := &MultiBlobs{log: log}
+	for _, disk := range disks {
+		blobs.disks = append(blobs.disks, &multiDisk{Disk: disk})
+	}
+	return blobs
+}
+
+// Disks returns the blob stores of the disks.
+func (blobs *MultiBlobs) Disks() []Disk {
+	disks := make([]Disk, len(blobs.disks))
+	for i, disk := range blobs.disks {
+		disks[i] = disk.Disk
+	}
+	return disks
+}
+
+// Migrating returns the blob stores of the disks, which are migrated to another backend.
+func (blobs *MultiBlobs) Migrating() []*MigratingBlobs {
+	var migrating []*MigratingBlobs
+	for _, disk := range blobs.disks {
+		if disk, ok := disk.Blobs.(*MigratingBlobs); ok {
+			migrating = append(migrating, disk)
+		}
+	}
+	return migrating
+}
+
+// DiskStatuses returns the status of every disk.
+func (blobs *MultiBlobs) DiskStatuses(ctx context.Context) (_ []DiskStatus, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	statuses := make([]DiskStatus, len(blobs.disks))
+	for i, disk := range blobs.disks {
+		healthErr := disk.healthErr()
+		free, used, spaceErr := disk.space(ctx)
+		statuses[i] = DiskStatus{
+			Path:    disk.Path,
+			Healthy: healthErr == nil && spaceErr == nil,
+			Err:     errs.Combine(healthErr, spaceErr),
+			Free:    free,
+			Used:    used,
+		}
+	}
+	return statuses, nil
+}
+
+// each calls fn for every disk. The errors of unhealthy disks are logged instead of returned.
+func (blobs *MultiBlobs) each(fn func(disk *multiDisk) error) error {
+	var group errs.Group
+	for _, disk := range blobs.disks {
+		err := fn(disk)
+		if err == nil {
+			continue
+		}
+		if healthErr := disk.healthErr(); healthErr != nil {
+			blobs.log.Debug("ignoring error of unhealthy disk", zap.String("Path", disk.Path), zap.Error(err))
+			continue
+		}
+		group.Add(err)
+	}
+	return group.Err()
+}
+
+// find calls fn for the disks until it finds the blob. When no disk has the blob, it
+// returns the error of a disk, which failed for another reason, or a not exist error.
+func (blobs *MultiBlobs) find(fn func(disk *multiDisk) error) error {
+	var notExistErr, failedErr error
+	for _, disk := range blobs.disks {
+		err := fn(disk)
+		switch {
+		case err == nil:
+			return nil
+		case errors.Is(err, os.ErrNotExist):
+			notExistErr = err
+		case failedErr == nil:
+			failedErr = err
+		}
+	}
+	if failedErr != nil {
+		return failedErr
+	}
+	if notExistErr == nil {
+		notExistErr = os.ErrNotExist
+	}
+	return notExistErr
+}
+
+// diskFor returns the healthy disk with the most free space for new blobs.
+func (blobs *MultiBlobs) diskFor(ctx context.Context) (*multiDisk, error) {
+	var best *multiDisk
+	var bestFree int64
+	for _, disk := range blobs.disks {
+		if disk.healthErr() != nil {
+			continue
+		}
+		free, _, err := disk.space(ctx)
+		if err != nil {
+			continue
+		}
+		if best == nil || free > bestFree {
+			best, bestFree = disk, free
+		}
+	}
+	if best == nil {
+		return nil, Error.New("no healthy disk")
+	}
+	return best, nil
+}
+
+// Create creates a new blob on the healthy disk with the most free space.
+func (blobs *MultiBlobs) Create(ctx context.Context, ref storage.BlobRef, size int64) (_ storage.BlobWriter, err error) {
+	defer mon.Task()(&ctx)(&err)
+	disk, err := blobs.diskFor(ctx)
+	if err != nil {
+		return nil, err
+	}
+	writer, err := disk.Blobs.Create(ctx, ref, size)
+	if err != nil {
+		return nil, err
+	}
+	return &multiBlobWriter{BlobWriter: writer, disk: disk}, nil
+}
+
+// TestCreateV0 creates a new V0 blob that can be written. This is only appropriate in test situations.
+func (blobs *MultiBlobs) TestCreateV0(ctx context.Context, ref storage.BlobRef) (_ storage.BlobWriter, err error) {
+	defer mon.Task()(&ctx)(&err)
+	disk, err := blobs.diskFor(ctx)
+	if err != nil {
+		return nil, err
+	}
+	fStore, ok := disk.Blobs.(interface {
+		TestCreateV0(ctx context.Context, ref storage.BlobRef) (_ storage.BlobWriter, err error)
+	})
+	if !ok {
+		return nil, Error.New("can't create V0 blobs with this blob store (%T)", disk.Blobs)
+	}
+	writer, err := fStore.TestCreateV0(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+	return &multiBlobWriter{BlobWriter: writer, disk: disk}, nil
+}
+
+// Open opens the blob from the disk, which stores it.
+func (blobs *MultiBlobs) Open(ctx context.Context, ref storage.BlobRef) (reader storage.BlobReader, err error) {
+	defer mon.Task()(&ctx)(&err)
+	err = blobs.find(func(disk *multiDisk) (err error) {
+		reader, err = disk.Blobs.Open(ctx, ref)
+		return err
+	})
+	return reader, err
+}
+
+// OpenWithStorageFormat opens the blob with the storage format from the disk, which stores it.
+func (blobs *MultiBlobs) OpenWithStorageFormat(ctx context.Context, ref storage.BlobRef, formatVer storage.FormatVersion) (reader storage.BlobReader, err error) {
+	defer mon.Task()(&ctx)(&err)
+	err = blobs.find(func(disk *multiDisk) (err error) {
+		reader, err = disk.Blobs.OpenWithStorageFormat(ctx, ref, formatVer)
+		return err
+	})
+	return reader, err
+}
+
+// Stat looks up the blob on the disk, which stores it.
+func (blobs *MultiBlobs) Stat(ctx context.Context, ref storage.BlobRef) (info storage.BlobInfo, err error) {
+	defer mon.Task()(&ctx)(&err)
+	err = blobs.find(func(disk *multiDisk) (err error) {
+		info, err = disk.Blobs.Stat(ctx, ref)
+		return err
+	})
+	return info, err
+}
+
+// StatWithStorageFormat looks up the blob with the storage format on the disk, which stores it.
+func (blobs *MultiBlobs) StatWithStorageFormat(ctx context.Context, ref storage.BlobRef, formatVer storage.FormatVersion) (info storage.BlobInfo, err error) {
+	defer mon.Task()(&ctx)(&err)
+	err = blobs.find(func(disk *multiDisk) (err error) {
+		info, err = disk.Blobs.StatWithStorageFormat(ctx, ref, formatVer)
+		return err
+	})
+	return info, err
+}
+
+// Delete deletes the blob from every disk.
+func (blobs *MultiBlobs) Delete(ctx context.Context, ref storage.BlobRef) (err error) {
+	defer mon.Task()(&ctx)(&err)
+	return blobs.each(func(disk *multiDisk) error {
+		return disk.Blobs.Delete(ctx, ref)
+	})
+}
+
+// DeleteWithStorageFormat deletes the blob with the storage format from every disk.
+func (blobs *MultiBlobs) DeleteWithStorageFormat(ctx context.Context, ref storage.BlobRef, formatVer storage.FormatVersion) (err error) {
+	defer mon.Task()(&ctx)(&err)
+	return blobs.each(func(disk *multiDisk) error {
+		return disk.Blobs.DeleteWithStorageFormat(ctx, ref, formatVer)
+	})
+}
+
+// DeleteNamespace deletes the namespace from every disk.
+func (blobs *MultiBlobs) DeleteNamespace(ctx context.Context, ref []byte) (err error) {
+	defer mon.Task()(&ctx)(&err)
+	return blobs.each(func(disk *multiDisk) error {
+		return disk.Blobs.DeleteNamespace(ctx, ref)
+	})
+}
+
+// Trash moves the blob to the trash of the disk, which stores it.
+func (blobs *MultiBlobs) Trash(ctx context.Context, ref storage.BlobRef) (err error) {
+	defer mon.Task()(&ctx)(&err)
+	return blobs.each(func(disk *multiDisk) error {
+		return disk.Blobs.Trash(ctx, ref)
+	})
+}
+
+// RestoreTrash restores the trash of the namespace on every disk.
+func (blobs *MultiBlobs) RestoreTrash(ctx context.Context, namespace []byte) (_ [][]byte, err error) {
+	defer mon.Task()(&ctx)(&err)
+	return blobs.RestoreTrashWithOptions(ctx, namespace, storage.RestoreTrashOptions{})
+}
+
+// RestoreTrashWithOptions restores the trash of the namespace, which is selected by the
+// options, on every disk.
+func (blobs *MultiBlobs) RestoreTrashWithOptions(ctx context.Context, namespace []byte, opts storage.RestoreTrashOptions) (keys [][]byte, err error) {
+	defer mon.Task()(&ctx)(&err)
+	err = blobs.each(func(disk *multiDisk) error {
+		restored, err := disk.Blobs.RestoreTrashWithOptions(ctx, namespace, opts)
+		keys = append(keys, restored...)
+		return err
+	})
+	return keys, err
+}
+
+// EmptyTrash empties the trash of the namespace on every disk.
+func (blobs *MultiBlobs) EmptyTrash(ctx context.Context, namespace []byte, trashedBefore time.Time) (bytesEmptied int64, keys [][]byte, err error) {
+	defer mon.Task()(&ctx)(&err)
+	err = blobs.each(func(disk *multiDisk) error {
+		emptied, emptiedKeys, err := disk.Blobs.EmptyTrash(ctx, namespace, trashedBefore)
+		bytesEmptied += emptied
+		keys = append(keys, emptiedKeys...)
+		return err
+	})
+	return bytesEmptied, keys, err
+}
+
+// FreeSpace refreshes the free space of every disk and returns the free space of the
+// healthy disks.
+func (blobs *MultiBlobs) FreeSpace(ctx context.Context) (total int64, err error) {
+	defer mon.Task()(&ctx)(&err)
+	blobs.refreshSpace(ctx)
+	for _, disk := range blobs.disks {
+		if disk.healthErr() != nil {
+			continue
+		}
+		free, _, err := disk.space(ctx)
+		if err != nil {
+			return 0, err
+		}
+		total += free
+	}
+	return total, nil
+}
+
+// refreshSpace refreshes the free and used space of every disk.
+func (blobs *MultiBlobs) refreshSpace(ctx context.Context) {
+	for _, disk := range blobs.disks {
+		disk.refreshSpace(ctx)
+		if _, _, err := disk.space(ctx); err != nil {
+			blobs.log.Warn("failed to get free space of disk", zap.String("Path", disk.Path), zap.Error(err))
+		}
+	}
+}
+
+// CheckWritability checks the writability of every disk. It fails only when the main disk
+// or every disk isn't writable.
+func (blobs *MultiBlobs) CheckWritability(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	var group errs.Group
+	for i, disk := range blobs.disks {
+		err := disk.Blobs.CheckWritability(ctx)
+		if i == 0 && err != nil {
+			return err
+		}
+		blobs.setHealth(disk, func() { disk.writeErr = err })
+		group.Add(err)
+	}
+	if len(group) == len(blobs.disks) {
+		return Error.New("no writable disk: %v", group.Err())
+	}
+	blobs.refreshSpace(ctx)
+	return nil
+}
+
+// SpaceUsedForTrash returns the space used by the trash of every disk.
+func (blobs *MultiBlobs) SpaceUsedForTrash(ctx context.Context) (total int64, err error) {
+	defer mon.Task()(&ctx)(&err)
+	err = blobs.each(func(disk *multiDisk) error {
+		used, err := disk.Blobs.SpaceUsedForTrash(ctx)
+		total += used
+		return err
+	})
+	return total, err
+}
+
+// SpaceUsedForBlobs returns the space used by the blobs of every disk.
+func (blobs *MultiBlobs) SpaceUsedForBlobs(ctx context.Context) (total int64, err error) {
+	defer mon.Task()(&ctx)(&err)
+	err = blobs.each(func(disk *multiDisk) error {
+		used, err := disk.Blobs.SpaceUsedForBlobs(ctx)
+		total += used
+		return err
+	})
+	return total, err
+}
+
+// SpaceUsedForBlobsInNamespace returns the space used by the blobs in the namespace of
+// every disk.
+func (blobs *MultiBlobs) SpaceUsedForBlobsInNamespace(ctx context.Context, namespace []byte) (total int64, err error) {
+	defer mon.Task()(&ctx)(&err)
+	err = blobs.each(func(disk *multiDisk) error {
+		used, err := disk.Blobs.SpaceUsedForBlobsInNamespace(ctx, namespace)
+		total += used
+		return err
+	})
+	return total, err
+}
+
+// ListNamespaces returns the namespaces of every disk.
+func (blobs *MultiBlobs) ListNamespaces(ctx context.Context) (namespaces [][]byte, err error) {
+	defer mon.Task()(&ctx)(&err)
+	seen := map[string]struct{}{}
+	err = blobs.each(func(disk *multiDisk) error {
+		diskNamespaces, err := disk.Blobs.ListNamespaces(ctx)
+		for _, namespace := range diskNamespaces {
+			if _, ok := seen[string(namespace)]; !ok {
+				seen[string(namespace)] = struct{}{}
+				namespaces = append(namespaces, namespace)
+			}
+		}
+		return err
+	})
+	return namespaces, err
+}
+
+// WalkNamespace executes walkFunc for each blob in the namespace of every disk.
+func (blobs *MultiBlobs) WalkNamespace(ctx context.Context, namespace []byte, walkFunc func(storage.BlobInfo) error) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	// errors of walkFunc are returned immediately, even for unhealthy disks.
+	var walkErr error
+	err = blobs.each(func(disk *multiDisk) error {
+		if walkErr != nil {
+			return nil
+		}
+		return disk.Blobs.WalkNamespace(ctx, namespace, func(info storage.BlobInfo) error {
+			walkErr = walkFunc(info)
+			return walkErr
+		})
+	})
+	if walkErr != nil {
+		return walkErr
+	}
+	return err
+}
+
+// CreateVerificationFile creates the verification file on every disk.
+func (blobs *MultiBlobs) CreateVerificationFile(ctx context.Context, id storj.NodeID) (err error) {
+	defer mon.Task()(&ctx)(&err)
+	var group errs.Group
+	for _, disk := range blobs.disks {
+		group.Add(disk.Blobs.CreateVerificationFile(ctx, id))
+	}
+	return group.Err()
+}
+
+// VerifyStorageDir verifies the directory of every disk. It fails only when the main disk
+// or every disk fails the verification.
+//
+// A new disk, which doesn't store blobs yet, gets its verification file.
+func (blobs *MultiBlobs) VerifyStorageDir(ctx context.Context, id storj.NodeID) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	var group errs.Group
+	for i, disk := range blobs.disks {
+		err := disk.Blobs.VerifyStorageDir(ctx, id)
+		if i > 0 && errors.Is(err, os.ErrNotExist) {
+			namespaces, listErr := disk.Blobs.ListNamespaces(ctx)
+			if listErr == nil && len(namespaces) == 0 {
+				blobs.log.Info("creating verification file on new disk", zap.String("Path", disk.Path))
+				err = disk.Blobs.CreateVerificationFile(ctx, id)
+			}
+		}
+		if i == 0 && err != nil {
+			return err
+		}
+		blobs.setHealth(disk, func() { disk.readErr = err })
+		group.Add(err)
+	}
+	if len(group) == len(blobs.disks) {
+		return Error.New("no readable disk: %v", group.Err())
+	}
+	return nil
+}
+
+// setHealth updates the health of the disk and logs when it changed.
+func (blobs *MultiBlobs) setHealth(disk *multiDisk, update func()) {
+	disk.mu.Lock()
+	before := errs.Combine(disk.readErr, disk.writeErr)
+	update()
+	after := errs.Combine(disk.readErr, disk.writeErr)
+	disk.mu.Unlock()
+
+	switch {
+	case before == nil && after != nil:
+		blobs.log.Error("disk is unhealthy, its pieces are unavailable", zap.String("Path", disk.Path), zap.Error(after))
+	case before != nil && after == nil:
+		blobs.log.Info("disk is healthy again", zap.String("Path", disk.Path))
+	}
+}
+
+// multiBlobWriter accounts for the size of the blob on its disk, when it's committed.
+type multiBlobWriter struct {
+	storage.BlobWriter
+	disk *multiDisk
+}
+
+// Commit commits the blob and reduces the cached free space of its disk.
+func (writer *multiBlobWriter) Commit(ctx context.Context) error {
+	size, sizeErr := writer.BlobWriter.Size()
+	if err := writer.BlobWriter.Commit(ctx); err != nil {
+		return err
+	}
+	if sizeErr == nil {
+		writer.disk.committed(size)
+	}
+	return nil
+}
+
+// Close closes the blob stores of every disk.
+func (blobs *MultiBlobs) Close() error {
+	var group errs.Group
+	for _, disk := range blobs.disks {
+		group.Add(disk.Blobs.Close())
+	}
+	return group.Err()
+}
@@ -0,0 +1,162 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package filestore_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"storj.io/common/memory"
+	"storj.io/common/testcontext"
+	"storj.io/common/testrand"
+	"storj.io/storj/storage"
+	"storj.io/storj/storage/filestore"
+)
+
+// fixedFreeSpaceBlobs reports a fixed free space, so that the disk of new blobs can be chosen.
+type fixedFreeSpaceBlobs struct {
+	storage.Blobs
+	free int64
+}
+
+func (blobs *fixedFreeSpaceBlobs) FreeSpace(ctx context.Context) (int64, error) {
+	return blobs.free, nil
+}
+
+func TestMultiBlobs(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	log := zaptest.NewLogger(t)
+	openDisk := func(name string, free int64) filestore.Disk {
+		dir, err := filestore.NewDir(log, ctx.Dir(name))
+		require.NoError(t, err)
+		blobs, err := filestore.OpenBlobs(log, dir, filestore.DefaultConfig)
+		require.NoError(t, err)
+		return filestore.Disk{Path: dir.Path(), Blobs: &fixedFreeSpaceBlobs{Blobs: blobs, free: free}}
+	}
+	main := openDisk("main", memory.GB.Int64())
+	extra := openDisk("extra", 2*memory.GB.Int64())
+
+	store := filestore.NewMulti(log, []filestore.Disk{main, extra})
+	defer ctx.Check(store.Close)
+
+	nodeID := testrand.NodeID()
+	require.NoError(t, store.CreateVerificationFile(ctx, nodeID))
+	require.NoError(t, store.VerifyStorageDir(ctx, nodeID))
+	require.NoError(t, store.CheckWritability(ctx))
+
+	free, err := store.FreeSpace(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 3*memory.GB.Int64(), free)
+
+	// new blobs are stored on the disk with the most free space
+	namespace := testrand.Bytes(namespaceSize)
+	blobs := map[string][]byte{}
+	onExtra := storage.BlobRef{Namespace: namespace, Key: testrand.Bytes(keySize)}
+	blobs[string(onExtra.Key)] = testrand.BytesInt(1000)
+	writeBlob(ctx, t, store, onExtra, blobs[string(onExtra.Key)])
+	_, err = extra.Blobs.Stat(ctx, onExtra)
+	require.NoError(t, err)
+
+	// the cached free space is reduced by the committed blobs until it's refreshed
+	extraFree := extra.Blobs.(*fixedFreeSpaceBlobs)
+	extraFree.free = memory.GB.Int64() + 500
+	_, err = store.FreeSpace(ctx)
+	require.NoError(t, err)
+	writeBlob(ctx, t, store, storage.BlobRef{Namespace: namespace, Key: onExtra.Key}, blobs[string(onExtra.Key)])
+	onMain := storage.BlobRef{Namespace: namespace, Key: testrand.Bytes(keySize)}
+	blobs[string(onMain.Key)] = testrand.BytesInt(700)
+	writeBlob(ctx, t, store, onMain, blobs[string(onMain.Key)])
+	_, err = main.Blobs.Stat(ctx, onMain)
+	require.NoError(t, err)
+	_, err = extra.Blobs.Stat(ctx, onMain)
+	require.ErrorIs(t, err, os.ErrNotExist)
+
+	statuses, err := store.DiskStatuses(ctx)
+	require.NoError(t, err)
+	require.Len(t, statuses, 2)
+	require.Equal(t, memory.GB.Int64()-700, statuses[0].Free)
+	require.Equal(t, memory.GB.Int64()-500, statuses[1].Free)
+	require.Positive(t, statuses[0].Used)
+	extraFree.free = 2 * memory.GB.Int64()
+	_, err = store.FreeSpace(ctx)
+	require.NoError(t, err)
+
+	// blobs of every disk are found
+	requireBlobs(ctx, t, store, namespace, blobs)
+	namespaces, err := store.ListNamespaces(ctx)
+	require.NoError(t, err)
+	require.Equal(t, [][]byte{namespace}, namespaces)
+
+	// a failing disk isn't used for new blobs, and the node keeps running
+	otherID := testrand.NodeID()
+	require.NoError(t, os.WriteFile(filepath.Join(extra.Path, "storage-dir-verification"), otherID.Bytes(), 0644))
+	require.NoError(t, store.VerifyStorageDir(ctx, nodeID))
+
+	statuses, err = store.DiskStatuses(ctx)
+	require.NoError(t, err)
+	require.True(t, statuses[0].Healthy)
+	require.False(t, statuses[1].Healthy)
+	require.Error(t, statuses[1].Err)
+
+	free, err = store.FreeSpace(ctx)
+	require.NoError(t, err)
+	require.Equal(t, memory.GB.Int64(), free)
+
+	added := storage.BlobRef{Namespace: namespace, Key: testrand.Bytes(keySize)}
+	blobs[string(added.Key)] = testrand.BytesInt(300)
+	writeBlob(ctx, t, store, added, blobs[string(added.Key)])
+	_, err = main.Blobs.Stat(ctx, added)
+	require.NoError(t, err)
+
+	// the disk is used again, once it's healthy
+	require.NoError(t, extra.Blobs.CreateVerificationFile(ctx, nodeID))
+	require.NoError(t, store.VerifyStorageDir(ctx, nodeID))
+	statuses, err = store.DiskStatuses(ctx)
+	require.NoError(t, err)
+	require.True(t, statuses[1].Healthy)
+	requireBlobs(ctx, t, store, namespace, blobs)
+
+	// trash is restored on every disk
+	require.NoError(t, store.Trash(ctx, onExtra))
+	require.NoError(t, store.Trash(ctx, onMain))
+	restored, err := store.RestoreTrash(ctx, namespace)
+	require.NoError(t, err)
+	require.ElementsMatch(t, [][]byte{onExtra.Key, onMain.Key}, restored)
+	requireBlobs(ctx, t, store, namespace, blobs)
+
+	// the main disk failing stops the node
+	require.NoError(t, os.WriteFile(filepath.Join(main.Path, "storage-dir-verification"), otherID.Bytes(), 0644))
+	require.Error(t, store.VerifyStorageDir(ctx, nodeID))
+}
+
+func TestMultiBlobsNewDisk(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	log := zaptest.NewLogger(t)
+	var disks []filestore.Disk
+	for _, name := range []string{"main", "new"} {
+		dir, err := filestore.NewDir(log, ctx.Dir(name))
+		require.NoError(t, err)
+		blobs, err := filestore.OpenBlobs(log, dir, filestore.DefaultConfig)
+		require.NoError(t, err)
+		disks = append(disks, filestore.Disk{Path: dir.Path(), Blobs: blobs})
+	}
+
+	nodeID := testrand.NodeID()
+	require.NoError(t, disks[0].Blobs.CreateVerificationFile(ctx, nodeID))
+
+	// a new disk gets its verification file
+	store := filestore.NewMulti(log, disks)
+	defer ctx.Check(store.Close)
+	require.NoError(t, store.VerifyStorageDir(ctx, nodeID))
+	require.NoError(t, disks[1].Blobs.VerifyStorageDir(ctx, nodeID))
+}
@@ -0,0 +1,1370 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package filestore
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	"storj.io/common/experiment"
+	"storj.io/common/storj"
+	"storj.io/storj/storage"
+)
+
+var _ storage.Blobs = (*packedStore)(nil)
+
+const (
+	packedRecordPut     = 1
+	packedRecordDelete  = 2
+	packedRecordTrash   = 3
+	packedRecordRestore = 4
+
+	// packedHeaderSize is the size of the header of every record of a log file:
+	// kind (1), format version (1), key length (2), data length (8), time (8), checksum (4).
+	packedHeaderSize = 24
+	// packedReadAhead is how many bytes are read with a record header while loading a log
+	// file, so that the key of the record usually doesn't need another read.
+	packedReadAhead = packedHeaderSize + 64
+
+	packedLogSuffix = ".log"
+	// packedCorruptSuffix is appended to the name of a corrupted log file, once its
+	// intact records are compacted, so that it's kept for inspection, but not loaded.
+	packedCorruptSuffix = ".corrupt"
+)
+
+// errPackedTorn is returned when a record extends beyond the end of the log file.
+var errPackedTorn = errs.Class("torn record")
+
+// packedStore implements a blob store, which appends the blobs of a namespace to large
+// log files, instead of storing every blob in its own file. Deleting, trashing and
+// restoring blobs appends records to the log files as well.
+//
+// The index of the blobs is kept in memory and is rebuilt from the log files when the
+// store is opened. Every blob costs about 120 bytes of memory in the index (see
+// BenchmarkPackedIndex), so a node with 10 million pieces needs about 1.2GB for it. Log
+// files with a large part of deleted data are compacted, by copying their remaining
+// blobs to the active log file, after the trash is emptied.
+//
+// The records are appended under packedNamespace.writeMu, but the log file is synced
+// after releasing it, and concurrent commits share a single sync (see syncLog).
+type packedStore struct {
+	log    *zap.Logger
+	dir    *Dir
+	config Config
+
+	mu         sync.Mutex
+	namespaces map[string]*packedNamespace
+}
+
+// packedNamespace contains the log files and the index of the blobs of a namespace.
+type packedNamespace struct {
+	namespace []byte
+	path      string
+
+	// writeMu is held while appending records, and is acquired before packedStore.mu.
+	writeMu sync.Mutex
+	// syncMu is held while syncing the active log file, and is acquired before
+	// packedStore.mu, but never together with writeMu.
+	syncMu sync.Mutex
+
+	// the fields below are protected by packedStore.mu.
+	logs     []*packedLog // ordered by id; the last one is the active log file.
+	entries  map[string]*packedEntry
+	deleted  bool
+	appended uint64 // the number of records appended since the namespace was loaded.
+	synced   uint64 // the number of appended records, which are synced to the disk.
+}
+
+// packedLog is a log file of a namespace.
+type packedLog struct {
+	id   uint64
+	path string
+	file *os.File
+
+	// the fields below are protected by packedStore.mu, except size, which is
+	// protected by packedNamespace.writeMu.
+	size    int64
+	live    int64 // the data size of the blobs in the log file, which aren't deleted.
+	refs    int   // the number of open readers.
+	removed bool  // the log file is removed once it has no open readers.
+	// corrupted is set when the log file has a corrupted record. The records before
+	// it are loaded, but nothing is appended to the log file anymore, and it's
+	// compacted and renamed instead of removed.
+	corrupted bool
+}
+
+// packedEntry is the location and the state of a blob.
+type packedEntry struct {
+	log           *packedLog
+	offset        int64 // the offset of the blob data in the log file.
+	size          int64
+	formatVersion storage.FormatVersion
+	modTime       time.Time
+	trashedAt     time.Time // zero when the blob isn't in the trash.
+}
+
+// packedRecord is a record of a log file.
+type packedRecord struct {
+	kind          byte
+	formatVersion storage.FormatVersion
+	key           []byte
+	size          int64
+	time          time.Time
+}
+
+// NewPacked creates a new blob store, which packs the blobs into log files in the
+// specified directory, loading the index of the already stored blobs.
+func NewPacked(log *zap.Logger, dir *Dir, config Config) (storage.Blobs, error) {
+	return newPackedStore(log, dir, config)
+}
+
+func newPackedStore(log *zap.Logger, dir *Dir, config Config) (_ *packedStore, err error) {
+	store := &packedStore{
+		log:        log,
+		dir:        dir,
+		config:     config,
+		namespaces: make(map[string]*packedNamespace),
+	}
+
+	if err := os.MkdirAll(store.packeddir(), dirPermission); err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	names, err := readDirNames(store.packeddir())
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	for _, name := range names {
+		namespace, err := pathEncoding.DecodeString(name)
+		if err != nil {
+			// not a namespace
+			continue
+		}
+		ns, err := store.loadNamespace(namespace)
+		if err != nil {
+			return nil, errs.Combine(Error.Wrap(err), store.Close())
+		}
+		store.namespaces[string(namespace)] = ns
+	}
+
+	return store, nil
+}
+
+// packeddir is the sub-directory containing the log files of all namespaces.
+func (store *packedStore) packeddir() string { return filepath.Join(store.dir.Path(), "packed") }
+
+// loadNamespace opens the log files of the namespace and rebuilds its index.
+func (store *packedStore) loadNamespace(namespace []byte) (_ *packedNamespace, err error) {
+	ns := &packedNamespace{
+		namespace: namespace,
+		path:      filepath.Join(store.packeddir(), pathEncoding.EncodeToString(namespace)),
+		entries:   make(map[string]*packedEntry),
+	}
+	defer func() {
+		if err != nil {
+			for _, log := range ns.logs {
+				_ = log.file.Close()
+			}
+		}
+	}()
+
+	names, err := readDirNames(ns.path)
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []uint64
+	for _, name := range names {
+		if !strings.HasSuffix(name, packedLogSuffix) {
+			continue
+		}
+		id, err := strconv.ParseUint(strings.TrimSuffix(name, packedLogSuffix), 16, 64)
+		if err != nil {
+			continue
+		}
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, k int) bool { return ids[i] < ids[k] })
+
+	for i, id := range ids {
+		path := filepath.Join(ns.path, packedLogName(id))
+		file, err := os.OpenFile(path, os.O_RDWR, blobPermission)
+		if err != nil {
+			return nil, err
+		}
+		log := &packedLog{id: id, path: path, file: file}
+		ns.logs = append(ns.logs, log)
+
+		if err := store.loadLog(ns, log, i == len(ids)-1); err != nil {
+			return nil, err
+		}
+	}
+
+	return ns, nil
+}
+
+// loadLog applies the records of the log file to the index of the namespace.
+//
+// A torn record at the end of the active log file, which is left by a crash, is
+// truncated. Any other invalid record means that the log file is corrupted: the
+// records before it are loaded, and the log file is quarantined, so that it isn't
+// appended to and is compacted, without losing the records after the corruption.
+func (store *packedStore) loadLog(ns *packedNamespace, log *packedLog, active bool) error {
+	stat, err := log.file.Stat()
+	if err != nil {
+		return err
+	}
+	fileSize := stat.Size()
+
+	var offset int64
+	for offset < fileSize {
+		record, err := readPackedRecord(log.file, offset, fileSize)
+		if err != nil {
+			torn := errPackedTorn.Has(err)
+			if !torn {
+				// a crash can leave the end of the file zeroed.
+				var zeroErr error
+				torn, zeroErr = zeroTail(log.file, offset, fileSize)
+				if zeroErr != nil {
+					return zeroErr
+				}
+			}
+			if !active || !torn {
+				store.log.Error("quarantining corrupted log file",
+					zap.String("Path", log.path), zap.Int64("Offset", offset), zap.Error(err))
+				mon.Event("packed_log_corrupted")
+				log.corrupted = true
+				break
+			}
+			store.log.Warn("truncating torn record of the active log file",
+				zap.String("Path", log.path), zap.Int64("Offset", offset), zap.Error(err))
+			if err := log.file.Truncate(offset); err != nil {
+				return err
+			}
+			break
+		}
+
+		dataOffset := offset + packedHeaderSize + int64(len(record.key))
+		ns.apply(log, dataOffset, record)
+		offset = dataOffset + record.size
+	}
+	log.size = offset
+
+	return nil
+}
+
+// apply applies the record to the index. It must be called with packedStore.mu held,
+// unless the namespace is still being loaded.
+func (ns *packedNamespace) apply(log *packedLog, dataOffset int64, record packedRecord) {
+	key := string(record.key)
+	entry := ns.entries[key]
+
+	switch record.kind {
+	case packedRecordPut:
+		if entry != nil {
+			entry.log.live -= entry.size
+		}
+		ns.entries[key] = &packedEntry{
+			log:           log,
+			offset:        dataOffset,
+			size:          record.size,
+			formatVersion: record.formatVersion,
+			modTime:       record.time,
+		}
+		log.live += record.size
+	case packedRecordDelete:
+		if entry != nil {
+			entry.log.live -= entry.size
+			delete(ns.entries, key)
+		}
+	case packedRecordTrash:
+		if entry != nil {
+			entry.trashedAt = record.time
+		}
+	case packedRecordRestore:
+		if entry != nil {
+			entry.trashedAt = time.Time{}
+		}
+	}
+}
+
+// zeroTail returns whether the log file contains only zeros from the offset to the end.
+func zeroTail(file *os.File, offset, fileSize int64) (bool, error) {
+	buf := make([]byte, 32*1024)
+	for offset < fileSize {
+		n, err := file.ReadAt(buf, offset)
+		if err != nil && !errors.Is(err, io.EOF) {
+			return false, err
+		}
+		if n == 0 {
+			break
+		}
+		for _, b := range buf[:n] {
+			if b != 0 {
+				return false, nil
+			}
+		}
+		offset += int64(n)
+	}
+	return true, nil
+}
+
+// readPackedRecord reads the record at the offset of the log file. It returns an
+// errPackedTorn error when the record extends beyond fileSize, but its header and key
+// are intact.
+func readPackedRecord(file *os.File, offset, fileSize int64) (record packedRecord, err error) {
+	var buf [packedReadAhead]byte
+	n, err := file.ReadAt(buf[:], offset)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return record, err
+	}
+	if n < packedHeaderSize {
+		return record, errPackedTorn.New("short record header")
+	}
+
+	header := buf[:packedHeaderSize]
+	record.kind = header[0]
+	record.formatVersion = storage.FormatVersion(header[1])
+	keyLen := int(binary.BigEndian.Uint16(header[2:4]))
+	record.size = int64(binary.BigEndian.Uint64(header[4:12]))
+	record.time = time.Unix(0, int64(binary.BigEndian.Uint64(header[12:20])))
+	checksum := binary.BigEndian.Uint32(header[20:24])
+
+	if offset+packedHeaderSize+int64(keyLen) > fileSize {
+		return record, errPackedTorn.New("short record key")
+	}
+	if n >= packedHeaderSize+keyLen {
+		record.key = append([]byte(nil), buf[packedHeaderSize:packedHeaderSize+keyLen]...)
+	} else {
+		record.key = make([]byte, keyLen)
+		if _, err := file.ReadAt(record.key, offset+packedHeaderSize); err != nil {
+			return record, err
+		}
+	}
+
+	// the checksum is verified first, so that a corrupted data length isn't taken
+	// for a torn record.
+	if packedChecksum(header, record.key) != checksum {
+		return record, Error.New("checksum mismatch")
+	}
+	if record.kind < packedRecordPut || record.kind > packedRecordRestore {
+		return record, Error.New("unknown record kind %d", record.kind)
+	}
+	if record.size < 0 {
+		return record, Error.New("invalid record size %d", record.size)
+	}
+	if offset+packedHeaderSize+int64(keyLen)+record.size > fileSize {
+		return record, errPackedTorn.New("short record")
+	}
+	return record, nil
+}
+
+// packedChecksum returns the checksum of the record header and key.
+func packedChecksum(header, key []byte) uint32 {
+	hash := crc32.NewIEEE()
+	_, _ = hash.Write(header[:packedHeaderSize-4])
+	_, _ = hash.Write(key)
+	return hash.Sum32()
+}
+
+// packedLogName returns the file name of the log file with the id.
+func packedLogName(id uint64) string {
+	return fmt.Sprintf("%016x%s", id, packedLogSuffix)
+}
+
+// appendRecord appends the record to the active log file of the namespace, starting a
+// new one when it's full, and applies it to the index. The data of a put record is read
+// from data, which is copied within the kernel, when it's a file. It must be called
+// with packedNamespace.writeMu held, and syncLog must be called after releasing it.
+func (store *packedStore) appendRecord(ns *packedNamespace, record packedRecord, data io.Reader) (err error) {
+	if len(record.key) > math.MaxUint16 {
+		return storage.ErrInvalidBlobRef.New("key too long")
+	}
+
+	log, err := store.activeLog(ns)
+	if err != nil {
+		return err
+	}
+
+	header := make([]byte, packedHeaderSize, packedHeaderSize+len(record.key))
+	header[0] = record.kind
+	header[1] = byte(record.formatVersion)
+	binary.BigEndian.PutUint16(header[2:4], uint16(len(record.key)))
+	binary.BigEndian.PutUint64(header[4:12], uint64(record.size))
+	binary.BigEndian.PutUint64(header[12:20], uint64(record.time.UnixNano()))
+	binary.BigEndian.PutUint32(header[20:24], packedChecksum(header, record.key))
+
+	// on failure, the partially written record is truncated, so that the next
+	// record doesn't follow a torn one.
+	defer func() {
+		if err != nil {
+			err = errs.Combine(err, log.file.Truncate(log.size))
+		}
+	}()
+
+	if _, err := log.file.Seek(log.size, io.SeekStart); err != nil {
+		return err
+	}
+	if _, err := log.file.Write(append(header, record.key...)); err != nil {
+		return err
+	}
+	if record.size > 0 {
+		// copying to the file directly uses copy_file_range, when data is a file.
+		if _, err := io.CopyN(log.file, data, record.size); err != nil {
+			return err
+		}
+	}
+
+	dataOffset := log.size + packedHeaderSize + int64(len(record.key))
+	log.size = dataOffset + record.size
+
+	store.mu.Lock()
+	ns.apply(log, dataOffset, record)
+	ns.appended++
+	store.mu.Unlock()
+
+	return nil
+}
+
+// activeLog returns the log file to append to, starting a new one when the active
+// log file is full. It must be called with packedNamespace.writeMu held.
+func (store *packedStore) activeLog(ns *packedNamespace) (*packedLog, error) {
+	// the logs of the namespace only change with packedNamespace.writeMu held, so
+	// they don't change after unlocking packedStore.mu.
+	store.mu.Lock()
+	deleted := ns.deleted
+	var active *packedLog
+	if len(ns.logs) > 0 {
+		active = ns.logs[len(ns.logs)-1]
+	}
+	store.mu.Unlock()
+
+	if deleted {
+		return nil, Error.New("namespace deleted")
+	}
+
+	var id uint64
+	if active != nil {
+		if active.size < store.config.PackedLogSize.Int64() && !active.corrupted {
+			return active, nil
+		}
+		// syncLog only syncs the active log file, so the previous one is synced
+		// before starting the next one.
+		if err := active.file.Sync(); err != nil {
+			return nil, err
+		}
+		id = active.id + 1
+	}
+
+	if err := os.MkdirAll(ns.path, dirPermission); err != nil {
+		return nil, err
+	}
+	path := filepath.Join(ns.path, packedLogName(id))
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_EXCL, blobPermission)
+	if err != nil {
+		return nil, err
+	}
+
+	log := &packedLog{id: id, path: path, file: file}
+	store.mu.Lock()
+	ns.logs = append(ns.logs, log)
+	store.mu.Unlock()
+	return log, nil
+}
+
+// syncLog flushes the records, which were appended to the namespace before the call,
+// to the disk. It must be called without packedNamespace.writeMu held, so that other
+// records can be appended while syncing.
+//
+// Concurrent calls are batched: a call waiting for a running sync returns without
+// syncing again, when the running sync already covered its records.
+func (store *packedStore) syncLog(ctx context.Context, ns *packedNamespace) (err error) {
+	defer mon.Task()(&ctx)(&err)
+	if experiment.Has(ctx, "nosync") {
+		return nil
+	}
+
+	store.mu.Lock()
+	want := ns.appended
+	store.mu.Unlock()
+
+	ns.syncMu.Lock()
+	defer ns.syncMu.Unlock()
+
+	store.mu.Lock()
+	if ns.synced >= want || ns.deleted || len(ns.logs) == 0 {
+		store.mu.Unlock()
+		return nil
+	}
+	// every record appended so far is in the active log file, or in a previous one,
+	// which was synced before starting the active one.
+	target := ns.appended
+	log := ns.logs[len(ns.logs)-1]
+	store.mu.Unlock()
+
+	if err := log.file.Sync(); err != nil {
+		store.mu.Lock()
+		deleted := ns.deleted
+		store.mu.Unlock()
+		if deleted {
+			// the log file was closed by DeleteNamespace.
+			return nil
+		}
+		return err
+	}
+
+	store.mu.Lock()
+	if target > ns.synced {
+		ns.synced = target
+	}
+	store.mu.Unlock()
+	return nil
+}
+
+// namespace returns the namespace, creating it when create is true.
+func (store *packedStore) namespace(namespace []byte, create bool) *packedNamespace {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	ns, ok := store.namespaces[string(namespace)]
+	if !ok && create {
+		ns = &packedNamespace{
+			namespace: append([]byte(nil), namespace...),
+			path:      filepath.Join(store.packeddir(), pathEncoding.EncodeToString(namespace)),
+			entries:   make(map[string]*packedEntry),
+		}
+		store.namespaces[string(namespace)] = ns
+	}
+	return ns
+}
+
+// lookup returns a copy of the entry of the blob, when it's stored and not in the trash.
+func (store *packedStore) lookup(ref storage.BlobRef) (packedEntry, bool) {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	ns := store.namespaces[string(ref.Namespace)]
+	if ns == nil {
+		return packedEntry{}, false
+	}
+	entry := ns.entries[string(ref.Key)]
+	if entry == nil || !entry.trashedAt.IsZero() {
+		return packedEntry{}, false
+	}
+	return *entry, true
+}
+
+// Close closes the log files.
+func (store *packedStore) Close() error {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	var group errs.Group
+	for _, ns := range store.namespaces {
+		for _, log := range ns.logs {
+			group.Add(log.file.Close())
+		}
+	}
+	return group.Err()
+}
+
+// Create creates a new blob that can be written.
+// Optionally takes a size argument for performance improvements, -1 is unknown size.
+func (store *packedStore) Create(ctx context.Context, ref storage.BlobRef, size int64) (_ storage.BlobWriter, err error) {
+	defer mon.Task()(&ctx)(&err)
+	return store.createWithStorageFormat(ctx, ref, size, MaxFormatVersionSupported)
+}
+
+// TestCreateV0 creates a new V0 blob that can be written. This is ONLY appropriate in test situations.
+func (store *packedStore) TestCreateV0(ctx context.Context, ref storage.BlobRef) (_ storage.BlobWriter, err error) {
+	defer mon.Task()(&ctx)(&err)
+	return store.createWithStorageFormat(ctx, ref, -1, FormatV0)
+}
+
+// createWithStorageFormat creates a new blob with the storage format version that can be written.
+func (store *packedStore) createWithStorageFormat(ctx context.Context, ref storage.BlobRef, size int64, formatVer storage.FormatVersion) (_ storage.BlobWriter, err error) {
+	defer mon.Task()(&ctx)(&err)
+	if !ref.IsValid() {
+		return nil, storage.ErrInvalidBlobRef.New("")
+	}
+
+	// the blob is written to a temporary file first, because its header is written
+	// last, and because the log file can only be appended to once it's committed. The
+	// temporary file isn't synced and is removed right after it's copied with
+	// copy_file_range, so its data usually never reaches the disk, and filesystems
+	// supporting reflinks don't copy the data at all.
+	file, err := store.dir.CreateTemporaryFile(ctx, size)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	return &packedWriter{
+		ref:           ref,
+		store:         store,
+		formatVersion: formatVer,
+		buffer:        bufio.NewWriterSize(file, store.config.WriteBufferSize.Int()),
+		fh:            file,
+	}, nil
+}
+
+// commit appends the blob, which is written to the file, to the active log file.
+func (store *packedStore) commit(ctx context.Context, ref storage.BlobRef, formatVer storage.FormatVersion, file *os.File, size int64) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+
+	ns := store.namespace(ref.Namespace, true)
+	ns.writeMu.Lock()
+	err = store.appendRecord(ns, packedRecord{
+		kind:          packedRecordPut,
+		formatVersion: formatVer,
+		key:           ref.Key,
+		size:          size,
+		time:          time.Now(),
+	}, file)
+	ns.writeMu.Unlock()
+	if err != nil {
+		return err
+	}
+	return store.syncLog(ctx, ns)
+}
+
+// Open loads blob with the specified hash.
+func (store *packedStore) Open(ctx context.Context, ref storage.BlobRef) (_ storage.BlobReader, err error) {
+	defer mon.Task()(&ctx)(&err)
+	return store.open(ref, func(storage.FormatVersion) bool { return true })
+}
+
+// OpenWithStorageFormat loads the already-located blob, avoiding the potential need to check multiple
+// storage formats to find the blob.
+func (store *packedStore) OpenWithStorageFormat(ctx context.Context, ref storage.BlobRef, formatVer storage.FormatVersion) (_ storage.BlobReader, err error) {
+	defer mon.Task()(&ctx)(&err)
+	return store.open(ref, func(entryVer storage.FormatVersion) bool { return entryVer == formatVer })
+}
+
+func (store *packedStore) open(ref storage.BlobRef, matches func(storage.FormatVersion) bool) (_ storage.BlobReader, err error) {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	var entry *packedEntry
+	if ns := store.namespaces[string(ref.Namespace)]; ns != nil {
+		entry = ns.entries[string(ref.Key)]
+	}
+	if entry == nil || !entry.trashedAt.IsZero() || !matches(entry.formatVersion) {
+		return nil, notExist("open", ref)
+	}
+
+	entry.log.refs++
+	return &packedReader{
+		section:       io.NewSectionReader(entry.log.file, entry.offset, entry.size),
+		store:         store,
+		log:           entry.log,
+		formatVersion: entry.formatVersion,
+	}, nil
+}
+
+// release releases the reference of a reader to the log file, removing the log file
+// when it's compacted and has no readers anymore.
+func (store *packedStore) release(log *packedLog) error {
+	store.mu.Lock()
+	log.refs--
+	remove := log.removed && log.refs == 0
+	store.mu.Unlock()
+
+	if !remove {
+		return nil
+	}
+	return removeLog(log)
+}
+
+// removeLog closes and removes the log file. A corrupted log file is renamed instead,
+// so that it's kept for inspection.
+func removeLog(log *packedLog) error {
+	closeErr := log.file.Close()
+	var removeErr error
+	if log.corrupted {
+		removeErr = os.Rename(log.path, log.path+packedCorruptSuffix)
+	} else {
+		removeErr = os.Remove(log.path)
+	}
+	if os.IsNotExist(removeErr) {
+		removeErr = nil
+	}
+	return errs.Combine(closeErr, removeErr)
+}
+
+// Stat looks up disk metadata on the blob.
+func (store *packedStore) Stat(ctx context.Context, ref storage.BlobRef) (_ storage.BlobInfo, err error) {
+	defer mon.Task()(&ctx)(&err)
+	entry, ok := store.lookup(ref)
+	if !ok {
+		return nil, Error.Wrap(notExist("stat", ref))
+	}
+	return newPackedBlobInfo(ref, entry), nil
+}
+
+// StatWithStorageFormat looks up disk metadata on the blob with the given storage format version.
+func (store *packedStore) StatWithStorageFormat(ctx context.Context, ref storage.BlobRef, formatVer storage.FormatVersion) (_ storage.BlobInfo, err error) {
+	defer mon.Task()(&ctx)(&err)
+	entry, ok := store.lookup(ref)
+	if !ok || entry.formatVersion != formatVer {
+		return nil, Error.Wrap(notExist("stat", ref))
+	}
+	return newPackedBlobInfo(ref, entry), nil
+}
+
+// Delete deletes blobs with the specified ref.
+//
+// It doesn't return an error if the blob isn't found.
+func (store *packedStore) Delete(ctx context.Context, ref storage.BlobRef) (err error) {
+	defer mon.Task()(&ctx)(&err)
+	return Error.Wrap(store.update(ctx, ref, packedRecordDelete, func(entry *packedEntry) bool {
+		return entry.trashedAt.IsZero()
+	}))
+}
+
+// DeleteWithStorageFormat deletes blobs with the specified ref and storage format version.
+func (store *packedStore) DeleteWithStorageFormat(ctx context.Context, ref storage.BlobRef, formatVer storage.FormatVersion) (err error) {
+	defer mon.Task()(&ctx)(&err)
+	return Error.Wrap(store.update(ctx, ref, packedRecordDelete, func(entry *packedEntry) bool {
+		return entry.trashedAt.IsZero() && entry.formatVersion == formatVer
+	}))
+}
+
+// Trash marks the blob with the specified ref as trash.
+func (store *packedStore) Trash(ctx context.Context, ref storage.BlobRef) (err error) {
+	defer mon.Task()(&ctx)(&err)
+	return Error.Wrap(store.update(ctx, ref, packedRecordTrash, func(entry *packedEntry) bool {
+		return entry.trashedAt.IsZero()
+	}))
+}
+
+// update appends a record of the kind for the blob, if the blob is stored and its
+// entry is selected by the filter.
+func (store *packedStore) update(ctx context.Context, ref storage.BlobRef, kind byte, filter func(*packedEntry) bool) (err error) {
+	ns := store.namespace(ref.Namespace, false)
+	if ns == nil {
+		return nil
+	}
+
+	ns.writeMu.Lock()
+	store.mu.Lock()
+	entry := ns.entries[string(ref.Key)]
+	selected := !ns.deleted && entry != nil && filter(entry)
+	store.mu.Unlock()
+	if !selected {
+		ns.writeMu.Unlock()
+		return nil
+	}
+
+	err = store.appendRecord(ns, packedRecord{
+		kind: kind,
+		key:  ref.Key,
+		time: store.dir.trashnow(),
+	}, nil)
+	ns.writeMu.Unlock()
+	if err != nil {
+		return err
+	}
+	return store.syncLog(ctx, ns)
+}
+
+// DeleteNamespace deletes the log files of a specific namespace.
+func (store *packedStore) DeleteNamespace(ctx context.Context, ref []byte) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	ns := store.namespace(ref, false)
+	if ns == nil {
+		return nil
+	}
+
+	ns.writeMu.Lock()
+	defer ns.writeMu.Unlock()
+
+	var group errs.Group
+	store.mu.Lock()
+	ns.deleted = true
+	for _, log := range ns.logs {
+		log.removed = true
+		if log.refs == 0 {
+			group.Add(log.file.Close())
+		}
+	}
+	ns.logs = nil
+	ns.entries = make(map[string]*packedEntry)
+	delete(store.namespaces, string(ref))
+	store.mu.Unlock()
+
+	group.Add(os.RemoveAll(ns.path))
+	return Error.Wrap(group.Err())
+}
+
+// RestoreTrash restores every blob in the trash.
+func (store *packedStore) RestoreTrash(ctx context.Context, namespace []byte) (keysRestored [][]byte, err error) {
+	defer mon.Task()(&ctx)(&err)
+	return store.RestoreTrashWithOptions(ctx, namespace, storage.RestoreTrashOptions{})
+}
+
+// RestoreTrashWithOptions restores the blobs in the trash, which are selected by the options.
+func (store *packedStore) RestoreTrashWithOptions(ctx context.Context, namespace []byte, opts storage.RestoreTrashOptions) (keysRestored [][]byte, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	var keys map[string]struct{}
+	if len(opts.Keys) > 0 {
+		keys = make(map[string]struct{}, len(opts.Keys))
+		for _, key := range opts.Keys {
+			keys[string(key)] = struct{}{}
+		}
+	}
+
+	keysRestored, err = store.updateTrash(ctx, namespace, packedRecordRestore, func(key string, entry *packedEntry) bool {
+		if keys != nil {
+			if _, ok := keys[key]; !ok {
+				return false
+			}
+		}
+		return !entry.trashedAt.Before(opts.TrashedAfter)
+	})
+	return keysRestored, Error.Wrap(err)
+}
+
+// EmptyTrash deletes the blobs, which were moved to the trash before trashedBefore, and
+// compacts the log files afterwards.
+func (store *packedStore) EmptyTrash(ctx context.Context, namespace []byte, trashedBefore time.Time) (bytesEmptied int64, keys [][]byte, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	var sizes int64
+	keys, err = store.updateTrash(ctx, namespace, packedRecordDelete, func(key string, entry *packedEntry) bool {
+		if entry.trashedAt.Before(trashedBefore) {
+			sizes += entry.size
+			return true
+		}
+		return false
+	})
+	if err != nil {
+		return 0, nil, Error.Wrap(err)
+	}
+
+	if err := store.Compact(ctx); err != nil {
+		store.log.Error("failed to compact log files", zap.Error(err))
+	}
+	return sizes, keys, nil
+}
+
+// updateTrash appends a record of the kind for every blob in the trash of the namespace,
+// which is selected by the filter, and returns the keys of the blobs.
+func (store *packedStore) updateTrash(ctx context.Context, namespace []byte, kind byte, filter func(key string, entry *packedEntry) bool) (keys [][]byte, err error) {
+	ns := store.namespace(namespace, false)
+	if ns == nil {
+		return nil, nil
+	}
+
+	err = func() error {
+		ns.writeMu.Lock()
+		defer ns.writeMu.Unlock()
+
+		store.mu.Lock()
+		for key, entry := range ns.entries {
+			if !entry.trashedAt.IsZero() && filter(key, entry) {
+				keys = append(keys, []byte(key))
+			}
+		}
+		store.mu.Unlock()
+
+		now := store.dir.trashnow()
+		for _, key := range keys {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			err := store.appendRecord(ns, packedRecord{kind: kind, key: key, time: now}, nil)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}()
+	if err != nil {
+		return nil, err
+	}
+	if err := store.syncLog(ctx, ns); err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+// Compact compacts the log files of all namespaces, which aren't active and of which
+// at least the configured part is deleted data.
+func (store *packedStore) Compact(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	store.mu.Lock()
+	namespaces := make([]*packedNamespace, 0, len(store.namespaces))
+	for _, ns := range store.namespaces {
+		namespaces = append(namespaces, ns)
+	}
+	store.mu.Unlock()
+
+	for _, ns := range namespaces {
+		for {
+			log, oldest := store.compactionCandidate(ns)
+			if log == nil {
+				break
+			}
+			if err := store.compactLog(ctx, ns, log, oldest); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// compactionCandidate returns a log file of the namespace, which needs compacting, and
+// whether it's the oldest log file.
+func (store *packedStore) compactionCandidate(ns *packedNamespace) (_ *packedLog, oldest bool) {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	ratio := store.config.PackedCompactionRatio
+	if ratio <= 0 {
+		return nil, false
+	}
+	// the active log file isn't compacted, and corrupted log files are always
+	// compacted.
+	for i := 0; i < len(ns.logs)-1; i++ {
+		log := ns.logs[i]
+		if log.size == 0 || log.corrupted || float64(log.size-log.live)/float64(log.size) >= ratio {
+			return log, i == 0
+		}
+	}
+	return nil, false
+}
+
+// compactLog copies the blobs of the log file, which aren't deleted, to the active log
+// file and removes the log file.
+//
+// The delete records of the log file are copied too, unless it's the oldest log file,
+// because otherwise an older log file could still contain the deleted blobs.
+func (store *packedStore) compactLog(ctx context.Context, ns *packedNamespace, log *packedLog, oldest bool) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	var offset int64
+	for offset < log.size {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		record, err := readPackedRecord(log.file, offset, log.size)
+		if err != nil {
+			return Error.New("compacting %q: %v", log.path, err)
+		}
+		dataOffset := offset + packedHeaderSize + int64(len(record.key))
+		offset = dataOffset + record.size
+
+		switch record.kind {
+		case packedRecordPut:
+			err = store.copyEntry(ns, log, dataOffset, record.key)
+		case packedRecordDelete:
+			if !oldest {
+				err = store.copyDelete(ns, record)
+			}
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	// the copies are synced before removing the log file.
+	if err := store.syncLog(ctx, ns); err != nil {
+		return err
+	}
+
+	ns.writeMu.Lock()
+	defer ns.writeMu.Unlock()
+
+	store.mu.Lock()
+	for i, other := range ns.logs {
+		if other == log {
+			ns.logs = append(ns.logs[:i], ns.logs[i+1:]...)
+			break
+		}
+	}
+	log.removed = true
+	remove := log.refs == 0
+	store.mu.Unlock()
+
+	mon.Event("packed_log_compacted")
+	if !remove {
+		return nil
+	}
+	return removeLog(log)
+}
+
+// copyEntry appends the blob stored at the offset of the log file to the active log
+// file, unless the blob was deleted or stored again since.
+func (store *packedStore) copyEntry(ns *packedNamespace, log *packedLog, dataOffset int64, key []byte) error {
+	ns.writeMu.Lock()
+	defer ns.writeMu.Unlock()
+
+	store.mu.Lock()
+	entry := ns.entries[string(key)]
+	var current packedEntry
+	if entry != nil {
+		current = *entry
+	}
+	store.mu.Unlock()
+	if entry == nil || current.log != log || current.offset != dataOffset {
+		return nil
+	}
+
+	err := store.appendRecord(ns, packedRecord{
+		kind:          packedRecordPut,
+		formatVersion: current.formatVersion,
+		key:           key,
+		size:          current.size,
+		time:          current.modTime,
+	}, io.NewSectionReader(log.file, current.offset, current.size))
+	if err != nil {
+		return err
+	}
+	if current.trashedAt.IsZero() {
+		return nil
+	}
+	return store.appendRecord(ns, packedRecord{
+		kind: packedRecordTrash,
+		key:  key,
+		time: current.trashedAt,
+	}, nil)
+}
+
+// copyDelete appends the delete record to the active log file, unless the blob was
+// stored again since.
+func (store *packedStore) copyDelete(ns *packedNamespace, record packedRecord) error {
+	ns.writeMu.Lock()
+	defer ns.writeMu.Unlock()
+
+	store.mu.Lock()
+	_, stored := ns.entries[string(record.key)]
+	store.mu.Unlock()
+	if stored {
+		return nil
+	}
+	return store.appendRecord(ns, record, nil)
+}
+
+// SpaceUsedForBlobs adds up the space used in all namespaces for blob storage.
+func (store *packedStore) SpaceUsedForBlobs(ctx context.Context) (_ int64, err error) {
+	defer mon.Task()(&ctx)(&err)
+	return store.spaceUsed(nil, false), nil
+}
+
+// SpaceUsedForBlobsInNamespace adds up how much is used in the given namespace for blob storage.
+func (store *packedStore) SpaceUsedForBlobsInNamespace(ctx context.Context, namespace []byte) (_ int64, err error) {
+	defer mon.Task()(&ctx)(&err)
+	return store.spaceUsed(namespace, false), nil
+}
+
+// SpaceUsedForTrash returns the total space used by the trash.
+func (store *packedStore) SpaceUsedForTrash(ctx context.Context) (_ int64, err error) {
+	defer mon.Task()(&ctx)(&err)
+	return store.spaceUsed(nil, true), nil
+}
+
+// spaceUsed adds up the sizes of the blobs in the namespace, or in all namespaces when
+// namespace is nil, which are in the trash or not.
+func (store *packedStore) spaceUsed(namespace []byte, trash bool) (total int64) {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	for _, ns := range store.namespaces {
+		if namespace != nil && string(ns.namespace) != string(namespace) {
+			continue
+		}
+		for _, entry := range ns.entries {
+			if entry.trashedAt.IsZero() != trash {
+				total += entry.size
+			}
+		}
+	}
+	return total
+}
+
+// hasTrash returns whether any blob is in the trash.
+func (store *packedStore) hasTrash(ctx context.Context) (bool, error) {
+	return store.spaceUsed(nil, true) > 0, nil
+}
+
+// FreeSpace returns how much space left in underlying directory.
+func (store *packedStore) FreeSpace(ctx context.Context) (int64, error) {
+	info, err := store.dir.Info(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return info.AvailableSpace, nil
+}
+
+// CheckWritability tests writability of the storage directory by creating and deleting a file.
+func (store *packedStore) CheckWritability(ctx context.Context) error {
+	f, err := os.CreateTemp(store.packeddir(), "write-test")
+	if err != nil {
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Remove(f.Name())
+}
+
+// ListNamespaces finds all known namespace IDs in use in local storage. They are not
+// guaranteed to contain any blobs.
+func (store *packedStore) ListNamespaces(ctx context.Context) (ids [][]byte, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	for _, ns := range store.namespaces {
+		ids = append(ids, ns.namespace)
+	}
+	return ids, nil
+}
+
+// WalkNamespace executes walkFunc for each locally stored blob in the given namespace. If walkFunc
+// returns a non-nil error, WalkNamespace will stop iterating and return the error immediately. The
+// ctx parameter is intended specifically to allow canceling iteration early.
+func (store *packedStore) WalkNamespace(ctx context.Context, namespace []byte, walkFunc func(storage.BlobInfo) error) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	var infos []storage.BlobInfo
+	store.mu.Lock()
+	if ns := store.namespaces[string(namespace)]; ns != nil {
+		infos = make([]storage.BlobInfo, 0, len(ns.entries))
+		for key, entry := range ns.entries {
+			if !entry.trashedAt.IsZero() {
+				continue
+			}
+			ref := storage.BlobRef{Namespace: ns.namespace, Key: []byte(key)}
+			infos = append(infos, newPackedBlobInfo(ref, *entry))
+		}
+	}
+	store.mu.Unlock()
+
+	for _, info := range infos {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := walkFunc(info); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// CreateVerificationFile creates a file to be used for storage directory verification.
+func (store *packedStore) CreateVerificationFile(ctx context.Context, id storj.NodeID) error {
+	return store.dir.CreateVerificationFile(ctx, id)
+}
+
+// VerifyStorageDir verifies that the storage directory is correct by checking for the existence and validity
+// of the verification file.
+func (store *packedStore) VerifyStorageDir(ctx context.Context, id storj.NodeID) error {
+	return store.dir.Verify(ctx, id)
+}
+
+// notExist returns the error of a blob, which isn't stored.
+func notExist(op string, ref storage.BlobRef) error {
+	return &os.PathError{Op: op, Path: pathEncoding.EncodeToString(ref.Key), Err: os.ErrNotExist}
+}
+
+// readDirNames returns the names of the entries of the directory. It returns no names
+// when the directory doesn't exist.
+func readDirNames(path string) (names []string, err error) {
+	dir, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer func() { err = errs.Combine(err, dir.Close()) }()
+
+	return dir.Readdirnames(-1)
+}
+
+// packedReader implements reading packed blobs.
+type packedReader struct {
+	section       *io.SectionReader
+	store         *packedStore
+	log           *packedLog
+	formatVersion storage.FormatVersion
+
+	mu     sync.Mutex
+	closed bool
+}
+
+// Read reads from the blob.
+func (blob *packedReader) Read(p []byte) (int, error) { return blob.section.Read(p) }
+
+// ReadAt reads from the blob at the offset.
+func (blob *packedReader) ReadAt(p []byte, off int64) (int, error) {
+	return blob.section.ReadAt(p, off)
+}
+
+// Seek seeks within the blob.
+func (blob *packedReader) Seek(offset int64, whence int) (int64, error) {
+	return blob.section.Seek(offset, whence)
+}
+
+// Size returns how large is the blob.
+func (blob *packedReader) Size() (int64, error) { return blob.section.Size(), nil }
+
+// StorageFormatVersion gets the storage format version being used by the blob.
+func (blob *packedReader) StorageFormatVersion() storage.FormatVersion { return blob.formatVersion }
+
+// Close releases the log file.
+func (blob *packedReader) Close() error {
+	blob.mu.Lock()
+	defer blob.mu.Unlock()
+
+	if blob.closed {
+		return nil
+	}
+	blob.closed = true
+	return blob.store.release(blob.log)
+}
+
+// packedWriter implements writing packed blobs.
+type packedWriter struct {
+	ref           storage.BlobRef
+	store         *packedStore
+	closed        bool
+	formatVersion storage.FormatVersion
+	buffer        *bufio.Writer
+	fh            *os.File
+}
+
+// Write adds data to the blob.
+func (blob *packedWriter) Write(p []byte) (int, error) {
+	return blob.buffer.Write(p)
+}
+
+// Cancel discards the blob.
+func (blob *packedWriter) Cancel(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if blob.closed {
+		return nil
+	}
+	blob.closed = true
+
+	err = blob.fh.Close()
+	removeErr := os.Remove(blob.fh.Name())
+	return Error.Wrap(errs.Combine(err, removeErr))
+}
+
+// Commit appends the blob to the active log file.
+func (blob *packedWriter) Commit(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if blob.closed {
+		return Error.New("already closed")
+	}
+	blob.closed = true
+
+	defer func() {
+		closeErr := blob.fh.Close()
+		removeErr := os.Remove(blob.fh.Name())
+		err = errs.Combine(err, closeErr, removeErr)
+	}()
+
+	if err := blob.buffer.Flush(); err != nil {
+		return Error.Wrap(err)
+	}
+	size, err := blob.fh.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	err = blob.store.commit(ctx, blob.ref, blob.formatVersion, blob.fh, size)
+	return Error.Wrap(err)
+}
+
+// Seek flushes any buffer and seeks the underlying file.
+func (blob *packedWriter) Seek(offset int64, whence int) (int64, error) {
+	if err := blob.buffer.Flush(); err != nil {
+		return 0, err
+	}
+
+	return blob.fh.Seek(offset, whence)
+}
+
+// Size returns how much has been written so far.
+func (blob *packedWriter) Size() (int64, error) {
+	return blob.Seek(0, io.SeekCurrent)
+}
+
+// StorageFormatVersion indicates what storage format version the blob is using.
+func (blob *packedWriter) StorageFormatVersion() storage.FormatVersion {
+	return blob.formatVersion
+}
+
+// packedBlobInfo is the information of a packed blob.
+type packedBlobInfo struct {
+	ref   storage.BlobRef
+	entry packedEntry
+}
+
+func newPackedBlobInfo(ref storage.BlobRef, entry packedEntry) storage.BlobInfo {
+	return &packedBlobInfo{ref: ref, entry: entry}
+}
+
+func (info *packedBlobInfo) BlobRef() storage.BlobRef {
+	return info.ref
+}
+
+func (info *packedBlobInfo) StorageFormatVersion() storage.FormatVersion {
+	return info.entry.formatVersion
+}
+
+func (info *packedBlobInfo) Stat(ctx context.Context) (os.FileInfo, error) {
+	return &packedFileInfo{
+		name:    pathEncoding.EncodeToString(info.ref.Key),
+		size:    info.entry.size,
+		modTime: info.entry.modTime,
+	}, nil
+}
+
+func (info *packedBlobInfo) FullPath(ctx context.Context) (string, error) {
+	return "", Error.New("packed blob has no file of its own; it's stored in %q", info.entry.log.path)
+}
+
+// packedFileInfo implements os.FileInfo for a packed blob.
+type packedFileInfo struct {
+	name    string
+	size    int64
+	modTime time.Time
+}
+
+func (info *packedFileInfo) Name() string       { return info.name }
+func (info *packedFileInfo) Size() int64        { return info.size }
+func (info *packedFileInfo) Mode() os.FileMode  { return blobPermission }
+func (info *packedFileInfo) ModTime() time.Time { return info.modTime }
+func (info *packedFileInfo) IsDir() bool        { return false }
+func (info *packedFileInfo) Sys() interface{}   { return nil }
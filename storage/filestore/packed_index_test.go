@@ -0,0 +1,44 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package filestore
+
+import (
+	"runtime"
+	"testing"
+	"time"
+
+	"storj.io/common/testrand"
+)
+
+// BenchmarkPackedIndex measures the memory used by the index of the packed store for
+// every blob.
+func BenchmarkPackedIndex(b *testing.B) {
+	keys := make([][]byte, b.N)
+	for i := range keys {
+		keys[i] = testrand.PieceID().Bytes()
+	}
+	log := &packedLog{}
+	ns := &packedNamespace{entries: make(map[string]*packedEntry)}
+
+	var before, after runtime.MemStats
+	runtime.GC()
+	runtime.ReadMemStats(&before)
+
+	b.ResetTimer()
+	for i, key := range keys {
+		ns.apply(log, int64(i), packedRecord{
+			kind:          packedRecordPut,
+			formatVersion: MaxFormatVersionSupported,
+			key:           key,
+			size:          2 << 20,
+			time:          time.Now(),
+		})
+	}
+	b.StopTimer()
+
+	runtime.GC()
+	runtime.ReadMemStats(&after)
+	b.ReportMetric(float64(after.HeapAlloc-before.HeapAlloc)/float64(b.N), "B/entry")
+	runtime.KeepAlive(ns)
+}
@@ -0,0 +1,270 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package filestore_test
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/zeebo/errs"
+	"go.uber.org/zap/zaptest"
+
+	"storj.io/common/memory"
+	"storj.io/common/testcontext"
+	"storj.io/common/testrand"
+	"storj.io/storj/storage"
+	"storj.io/storj/storage/filestore"
+)
+
+func TestPackedStore(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	log := zaptest.NewLogger(t)
+	config := filestore.DefaultConfig
+	config.PackedLogSize = 4 * memory.KiB
+
+	dir, err := filestore.NewDir(log, ctx.Dir("store"))
+	require.NoError(t, err)
+	store, err := filestore.NewPacked(log, dir, config)
+	require.NoError(t, err)
+
+	namespace := testrand.Bytes(namespaceSize)
+	blobs := map[string][]byte{}
+	var refs []storage.BlobRef
+	for i := 0; i < 10; i++ {
+		ref := storage.BlobRef{Namespace: namespace, Key: testrand.Bytes(keySize)}
+		data := testrand.BytesInt(1000 + i)
+		writeBlob(ctx, t, store, ref, data)
+		blobs[string(ref.Key)] = data
+		refs = append(refs, ref)
+	}
+
+	// a canceled blob isn't stored
+	canceled := storage.BlobRef{Namespace: namespace, Key: testrand.Bytes(keySize)}
+	writer, err := store.Create(ctx, canceled, -1)
+	require.NoError(t, err)
+	_, err = writer.Write(testrand.Bytes(100))
+	require.NoError(t, err)
+	require.NoError(t, writer.Cancel(ctx))
+	_, err = store.Open(ctx, canceled)
+	require.True(t, os.IsNotExist(err))
+
+	requireBlobs(ctx, t, store, namespace, blobs)
+
+	require.NoError(t, store.Delete(ctx, refs[0]))
+	delete(blobs, string(refs[0].Key))
+	_, err = store.Stat(ctx, refs[0])
+	require.True(t, errs.Is(err, os.ErrNotExist))
+
+	trashTime := time.Now()
+	dir.ReplaceTrashnow(func() time.Time { return trashTime })
+	for _, ref := range refs[1:6] {
+		require.NoError(t, store.Trash(ctx, ref))
+		delete(blobs, string(ref.Key))
+	}
+	requireBlobs(ctx, t, store, namespace, blobs)
+
+	trashUsed, err := store.SpaceUsedForTrash(ctx)
+	require.NoError(t, err)
+	require.EqualValues(t, 1001+1002+1003+1004+1005, trashUsed)
+
+	restored, err := store.RestoreTrashWithOptions(ctx, namespace, storage.RestoreTrashOptions{
+		Keys: [][]byte{refs[1].Key},
+	})
+	require.NoError(t, err)
+	require.Equal(t, [][]byte{refs[1].Key}, restored)
+	blobs[string(refs[1].Key)] = readBlob(ctx, t, store, refs[1])
+	require.Len(t, blobs[string(refs[1].Key)], 1001)
+
+	// the index is rebuilt from the log files when reopening
+	require.NoError(t, store.Close())
+	store, err = filestore.NewPacked(log, dir, config)
+	require.NoError(t, err)
+	defer ctx.Check(store.Close)
+	requireBlobs(ctx, t, store, namespace, blobs)
+
+	logsBefore := countLogs(t, ctx.Dir("store"))
+	emptied, keys, err := store.EmptyTrash(ctx, namespace, trashTime.Add(time.Second))
+	require.NoError(t, err)
+	require.EqualValues(t, 1002+1003+1004+1005, emptied)
+	require.Len(t, keys, 4)
+
+	// emptying the trash compacted the log files
+	require.Less(t, countLogs(t, ctx.Dir("store")), logsBefore)
+	requireBlobs(ctx, t, store, namespace, blobs)
+
+	trashUsed, err = store.SpaceUsedForTrash(ctx)
+	require.NoError(t, err)
+	require.Zero(t, trashUsed)
+}
+
+func TestPackedStoreTornRecord(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	log := zaptest.NewLogger(t)
+	dir, err := filestore.NewDir(log, ctx.Dir("store"))
+	require.NoError(t, err)
+	store, err := filestore.NewPacked(log, dir, filestore.DefaultConfig)
+	require.NoError(t, err)
+
+	namespace := testrand.Bytes(namespaceSize)
+	ref := storage.BlobRef{Namespace: namespace, Key: testrand.Bytes(keySize)}
+	data := testrand.BytesInt(1000)
+	writeBlob(ctx, t, store, ref, data)
+	require.NoError(t, store.Close())
+
+	// simulate a crash while appending a record
+	logs, err := filepath.Glob(filepath.Join(ctx.Dir("store"), "packed", "*", "*.log"))
+	require.NoError(t, err)
+	require.Len(t, logs, 1)
+	file, err := os.OpenFile(logs[0], os.O_WRONLY|os.O_APPEND, 0600)
+	require.NoError(t, err)
+	_, err = file.Write(testrand.Bytes(10))
+	require.NoError(t, err)
+	require.NoError(t, file.Close())
+
+	store, err = filestore.NewPacked(log, dir, filestore.DefaultConfig)
+	require.NoError(t, err)
+	require.Equal(t, data, readBlob(ctx, t, store, ref))
+	require.NoError(t, store.Close())
+
+	// simulate a crash, which left the end of the file zeroed
+	file, err = os.OpenFile(logs[0], os.O_WRONLY|os.O_APPEND, 0600)
+	require.NoError(t, err)
+	_, err = file.Write(make([]byte, 100))
+	require.NoError(t, err)
+	require.NoError(t, file.Close())
+
+	store, err = filestore.NewPacked(log, dir, filestore.DefaultConfig)
+	require.NoError(t, err)
+	require.Equal(t, data, readBlob(ctx, t, store, ref))
+
+	// appending after the truncated record works
+	other := storage.BlobRef{Namespace: namespace, Key: testrand.Bytes(keySize)}
+	otherData := testrand.BytesInt(500)
+	writeBlob(ctx, t, store, other, otherData)
+	require.NoError(t, store.Close())
+
+	store, err = filestore.NewPacked(log, dir, filestore.DefaultConfig)
+	require.NoError(t, err)
+	defer ctx.Check(store.Close)
+	requireBlobs(ctx, t, store, namespace, map[string][]byte{
+		string(ref.Key):   data,
+		string(other.Key): otherData,
+	})
+}
+
+func TestPackedStoreCorruptedLog(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	log := zaptest.NewLogger(t)
+	dir, err := filestore.NewDir(log, ctx.Dir("store"))
+	require.NoError(t, err)
+	store, err := filestore.NewPacked(log, dir, filestore.DefaultConfig)
+	require.NoError(t, err)
+
+	namespace := testrand.Bytes(namespaceSize)
+	var refs []storage.BlobRef
+	for i := 0; i < 3; i++ {
+		ref := storage.BlobRef{Namespace: namespace, Key: testrand.Bytes(keySize)}
+		writeBlob(ctx, t, store, ref, testrand.BytesInt(1000))
+		refs = append(refs, ref)
+	}
+	first := readBlob(ctx, t, store, refs[0])
+	require.NoError(t, store.Close())
+
+	// corrupt the checksum of the second record
+	logs, err := filepath.Glob(filepath.Join(ctx.Dir("store"), "packed", "*", "*.log"))
+	require.NoError(t, err)
+	require.Len(t, logs, 1)
+	stat, err := os.Stat(logs[0])
+	require.NoError(t, err)
+	file, err := os.OpenFile(logs[0], os.O_WRONLY, 0600)
+	require.NoError(t, err)
+	_, err = file.WriteAt([]byte{0xff, 0xff, 0xff, 0xff}, 24+keySize+1000+20)
+	require.NoError(t, err)
+	require.NoError(t, file.Close())
+
+	// the records before the corruption are loaded, and the log file isn't truncated
+	store, err = filestore.NewPacked(log, dir, filestore.DefaultConfig)
+	require.NoError(t, err)
+	defer ctx.Check(store.Close)
+	requireBlobs(ctx, t, store, namespace, map[string][]byte{string(refs[0].Key): first})
+	after, err := os.Stat(logs[0])
+	require.NoError(t, err)
+	require.Equal(t, stat.Size(), after.Size())
+
+	// nothing is appended to the corrupted log file
+	other := storage.BlobRef{Namespace: namespace, Key: testrand.Bytes(keySize)}
+	otherData := testrand.BytesInt(500)
+	writeBlob(ctx, t, store, other, otherData)
+	require.Equal(t, 2, countLogs(t, ctx.Dir("store")))
+
+	// the corrupted log file is compacted and kept with another name
+	_, _, err = store.EmptyTrash(ctx, namespace, time.Now())
+	require.NoError(t, err)
+	require.Equal(t, 1, countLogs(t, ctx.Dir("store")))
+	_, err = os.Stat(logs[0] + ".corrupt")
+	require.NoError(t, err)
+	requireBlobs(ctx, t, store, namespace, map[string][]byte{
+		string(refs[0].Key): first,
+		string(other.Key):   otherData,
+	})
+}
+
+func writeBlob(ctx context.Context, t *testing.T, store storage.Blobs, ref storage.BlobRef, data []byte) {
+	writer, err := store.Create(ctx, ref, int64(len(data)))
+	require.NoError(t, err)
+	_, err = writer.Write(data)
+	require.NoError(t, err)
+	require.NoError(t, writer.Commit(ctx))
+}
+
+func readBlob(ctx context.Context, t *testing.T, store storage.Blobs, ref storage.BlobRef) []byte {
+	reader, err := store.Open(ctx, ref)
+	require.NoError(t, err)
+	defer func() { require.NoError(t, reader.Close()) }()
+
+	data, err := io.ReadAll(reader)
+	require.NoError(t, err)
+	return data
+}
+
+// requireBlobs checks that exactly the blobs are stored in the namespace.
+func requireBlobs(ctx context.Context, t *testing.T, store storage.Blobs, namespace []byte, blobs map[string][]byte) {
+	walked := map[string]int64{}
+	err := store.WalkNamespace(ctx, namespace, func(info storage.BlobInfo) error {
+		stat, err := info.Stat(ctx)
+		require.NoError(t, err)
+		walked[string(info.BlobRef().Key)] = stat.Size()
+		return nil
+	})
+	require.NoError(t, err)
+	require.Len(t, walked, len(blobs))
+
+	var total int64
+	for key, data := range blobs {
+		require.EqualValues(t, len(data), walked[key])
+		require.Equal(t, data, readBlob(ctx, t, store, storage.BlobRef{Namespace: namespace, Key: []byte(key)}))
+		total += int64(len(data))
+	}
+
+	used, err := store.SpaceUsedForBlobsInNamespace(ctx, namespace)
+	require.NoError(t, err)
+	require.Equal(t, total, used)
+}
+
+func countLogs(t *testing.T, path string) int {
+	logs, err := filepath.Glob(filepath.Join(path, "packed", "*", "*.log"))
+	require.NoError(t, err)
+	return len(logs)
+}
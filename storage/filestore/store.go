@@ -37,11 +37,21 @@ func monFileInTrash(namespace []byte) *monkit.Meter {
 // Config is configuration for the blob store.
 type Config struct {
 	WriteBufferSize memory.Size `help:"in-memory buffer for uploads" default:"128KiB"`
+
+	Backend               string        `help:"how blobs are stored: 'files' stores every blob in its own file, 'packed' appends blobs to large log files; blobs stored by the other backend are migrated in the background" default:"files"`
+	PackedLogSize         memory.Size   `help:"the size of a log file of the packed backend, after which a new log file is started" default:"1GiB"`
+	PackedCompactionRatio float64       `help:"the part of a log file of the packed backend, which must be deleted data for compacting it (0 disables compaction)" default:"0.5"`
+	MigrationInterval     time.Duration `help:"how often to migrate the blobs stored by another backend to the configured backend" default:"1h"`
 }
 
 // DefaultConfig is the default value for Config.
 var DefaultConfig = Config{
 	WriteBufferSize: 128 * memory.KiB,
+
+	Backend:               BackendFiles,
+	PackedLogSize:         memory.GiB,
+	PackedCompactionRatio: 0.5,
+	MigrationInterval:     time.Hour,
 }
 
 // blobStore implements a blob store.
@@ -146,6 +156,14 @@ func (store *blobStore) RestoreTrash(ctx context.Context, namespace []byte) (key
 	return keysRestored, Error.Wrap(err)
 }
 
+// RestoreTrashWithOptions moves the pieces in the trash, which are selected by the options,
+// back into the regular location.
+func (store *blobStore) RestoreTrashWithOptions(ctx context.Context, namespace []byte, opts storage.RestoreTrashOptions) (keysRestored [][]byte, err error) {
+	defer mon.Task()(&ctx)(&err)
+	keysRestored, err = store.dir.RestoreTrashWithOptions(ctx, namespace, opts)
+	return keysRestored, Error.Wrap(err)
+}
+
 // // EmptyTrash removes all files in trash that have been there longer than trashExpiryDur.
 func (store *blobStore) EmptyTrash(ctx context.Context, namespace []byte, trashedBefore time.Time) (bytesEmptied int64, keys [][]byte, err error) {
 	defer mon.Task()(&ctx)(&err)
@@ -163,12 +181,18 @@ func (store *blobStore) GarbageCollect(ctx context.Context) (err error) {
 // Create creates a new blob that can be written.
 // Optionally takes a size argument for performance improvements, -1 is unknown size.
 func (store *blobStore) Create(ctx context.Context, ref storage.BlobRef, size int64) (_ storage.BlobWriter, err error) {
+	defer mon.Task()(&ctx)(&err)
+	return store.createWithStorageFormat(ctx, ref, size, MaxFormatVersionSupported)
+}
+
+// createWithStorageFormat creates a new blob with the storage format version that can be written.
+func (store *blobStore) createWithStorageFormat(ctx context.Context, ref storage.BlobRef, size int64, formatVer storage.FormatVersion) (_ storage.BlobWriter, err error) {
 	defer mon.Task()(&ctx)(&err)
 	file, err := store.dir.CreateTemporaryFile(ctx, size)
 	if err != nil {
 		return nil, Error.Wrap(err)
 	}
-	return newBlobWriter(ref, store, MaxFormatVersionSupported, file, store.config.WriteBufferSize.Int()), nil
+	return newBlobWriter(ref, store, formatVer, file, store.config.WriteBufferSize.Int()), nil
 }
 
 // SpaceUsedForBlobs adds up the space used in all namespaces for blob storage.
@@ -249,6 +273,30 @@ func (store *blobStore) SpaceUsedForTrash(ctx context.Context) (total int64, err
 	return total, err
 }
 
+// hasTrash returns whether any blob is in the trash.
+func (store *blobStore) hasTrash(ctx context.Context) (_ bool, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	namespaces, err := store.dir.listNamespacesInPath(ctx, store.dir.trashdir())
+	if err != nil {
+		return false, err
+	}
+
+	errFound := errs.New("found")
+	for _, namespace := range namespaces {
+		err := store.dir.walkNamespaceInPath(ctx, namespace, store.dir.trashdir(), func(storage.BlobInfo) error {
+			return errFound
+		})
+		if errors.Is(err, errFound) {
+			return true, nil
+		}
+		if err != nil {
+			return false, err
+		}
+	}
+	return false, nil
+}
+
 // FreeSpace returns how much space left in underlying directory.
 func (store *blobStore) FreeSpace(ctx context.Context) (int64, error) {
 	info, err := store.dir.Info(ctx)
@@ -286,12 +334,7 @@ func (store *blobStore) WalkNamespace(ctx context.Context, namespace []byte, wal
 // TestCreateV0 creates a new V0 blob that can be written. This is ONLY appropriate in test situations.
 func (store *blobStore) TestCreateV0(ctx context.Context, ref storage.BlobRef) (_ storage.BlobWriter, err error) {
 	defer mon.Task()(&ctx)(&err)
-
-	file, err := store.dir.CreateTemporaryFile(ctx, -1)
-	if err != nil {
-		return nil, Error.Wrap(err)
-	}
-	return newBlobWriter(ref, store, FormatV0, file, store.config.WriteBufferSize.Int()), nil
+	return store.createWithStorageFormat(ctx, ref, -1, FormatV0)
 }
 
 // CreateVerificationFile creates a file to be used for storage directory verification.
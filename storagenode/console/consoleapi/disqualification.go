@@ -0,0 +1,90 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package consoleapi
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	"storj.io/common/storj"
+	"storj.io/storj/storagenode/console"
+	"storj.io/storj/storagenode/nodestats"
+)
+
+// ErrDisqualificationAPI - console disqualification api error type.
+var ErrDisqualificationAPI = errs.Class("consoleapi disqualification")
+
+// Disqualification is an api controller that exposes the explanations of disqualifications.
+type Disqualification struct {
+	service   *console.Service
+	nodestats *nodestats.Service
+
+	log *zap.Logger
+}
+
+// NewDisqualification is a constructor for disqualification controller.
+func NewDisqualification(log *zap.Logger, service *console.Service, nodestats *nodestats.Service) *Disqualification {
+	return &Disqualification{
+		log:       log,
+		service:   service,
+		nodestats: nodestats,
+	}
+}
+
+// Explanation returns the explanation of the disqualification of the node by the satellite,
+// as it's sent by the satellite.
+func (controller *Disqualification) Explanation(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	w.Header().Set(contentType, applicationJSON)
+
+	satelliteID, err := storj.NodeIDFromString(mux.Vars(r)["id"])
+	if err != nil {
+		controller.serveJSONError(w, http.StatusBadRequest, ErrDisqualificationAPI.Wrap(err))
+		return
+	}
+
+	if err = controller.service.VerifySatelliteID(ctx, satelliteID); err != nil {
+		controller.serveJSONError(w, http.StatusNotFound, ErrDisqualificationAPI.Wrap(err))
+		return
+	}
+
+	explanation, err := controller.nodestats.GetDisqualificationExplanation(ctx, satelliteID)
+	if err != nil {
+		controller.serveJSONError(w, http.StatusInternalServerError, ErrDisqualificationAPI.Wrap(err))
+		return
+	}
+	if explanation == nil {
+		controller.serveJSONError(w, http.StatusNotFound, ErrDisqualificationAPI.New("no disqualification explanation"))
+		return
+	}
+
+	if _, err := w.Write(explanation); err != nil {
+		controller.log.Error("failed to write json response", zap.Error(ErrDisqualificationAPI.Wrap(err)))
+		return
+	}
+}
+
+// serveJSONError writes JSON error to response output stream.
+func (controller *Disqualification) serveJSONError(w http.ResponseWriter, status int, err error) {
+	w.WriteHeader(status)
+
+	var response struct {
+		Error string `json:"error"`
+	}
+
+	response.Error = err.Error()
+
+	err = json.NewEncoder(w).Encode(response)
+	if err != nil {
+		controller.log.Error("failed to write json error response", zap.Error(ErrDisqualificationAPI.Wrap(err)))
+		return
+	}
+}
@@ -0,0 +1,46 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package consoleapi
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	"storj.io/storj/storagenode/scrubber"
+)
+
+// ErrScrubberAPI - console scrubber api error type.
+var ErrScrubberAPI = errs.Class("consoleapi scrubber")
+
+// Scrubber is an api controller that exposes the progress and the findings of the piece scrubber.
+type Scrubber struct {
+	service *scrubber.Service
+
+	log *zap.Logger
+}
+
+// NewScrubber is a constructor for scrubber controller.
+func NewScrubber(log *zap.Logger, service *scrubber.Service) *Scrubber {
+	return &Scrubber{
+		log:     log,
+		service: service,
+	}
+}
+
+// Status returns the progress of the running and the last scrub, and the most recent findings.
+func (controller *Scrubber) Status(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	w.Header().Set(contentType, applicationJSON)
+
+	if err := json.NewEncoder(w).Encode(controller.service.Status()); err != nil {
+		controller.log.Error("failed to encode json response", zap.Error(ErrScrubberAPI.Wrap(err)))
+		return
+	}
+}
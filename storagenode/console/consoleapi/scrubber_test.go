@@ -0,0 +1,48 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package consoleapi_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"storj.io/common/testcontext"
+	"storj.io/storj/private/testplanet"
+	"storj.io/storj/storagenode"
+	"storj.io/storj/storagenode/scrubber"
+)
+
+func TestScrubberApi(t *testing.T) {
+	testplanet.Run(t,
+		testplanet.Config{
+			SatelliteCount:   1,
+			StorageNodeCount: 1,
+			Reconfigure: testplanet.Reconfigure{
+				StorageNode: func(index int, config *storagenode.Config) {
+					config.Scrubber.Enabled = true
+				},
+			},
+		},
+		func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+			sno := planet.StorageNodes[0]
+			sno.Scrubber.Loop.TriggerWait()
+
+			url := fmt.Sprintf("http://%s/api/sno/scrubber", sno.Console.Listener.Addr())
+			res, err := httpGet(ctx, url)
+			require.NoError(t, err)
+			defer ctx.Check(res.Body.Close)
+			require.Equal(t, http.StatusOK, res.StatusCode)
+
+			var status scrubber.Status
+			require.NoError(t, json.NewDecoder(res.Body).Decode(&status))
+			require.True(t, status.Enabled)
+			require.NotNil(t, status.Last)
+			require.Equal(t, sno.Scrubber.Status().Last.PiecesChecked, status.Last.PiecesChecked)
+		},
+	)
+}
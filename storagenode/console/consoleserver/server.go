@@ -21,8 +21,10 @@ import (
 	"storj.io/storj/private/web"
 	"storj.io/storj/storagenode/console"
 	"storj.io/storj/storagenode/console/consoleapi"
+	"storj.io/storj/storagenode/nodestats"
 	"storj.io/storj/storagenode/notifications"
 	"storj.io/storj/storagenode/payouts"
+	"storj.io/storj/storagenode/scrubber"
 )
 
 var (
@@ -46,6 +48,8 @@ type Server struct {
 	service       *console.Service
 	notifications *notifications.Service
 	payout        *payouts.Service
+	scrubber      *scrubber.Service
+	nodestats     *nodestats.Service
 	listener      net.Listener
 	assets        fs.FS
 
@@ -53,7 +57,7 @@ type Server struct {
 }
 
 // NewServer creates new instance of storagenode console web server.
-func NewServer(logger *zap.Logger, assets fs.FS, notifications *notifications.Service, service *console.Service, payout *payouts.Service, listener net.Listener) *Server {
+func NewServer(logger *zap.Logger, assets fs.FS, notifications *notifications.Service, service *console.Service, payout *payouts.Service, scrubber *scrubber.Service, nodestats *nodestats.Service, listener net.Listener) *Server {
 	server := Server{
 		log:           logger,
 		service:       service,
@@ -61,6 +65,8 @@ func NewServer(logger *zap.Logger, assets fs.FS, notifications *notifications.Se
 		assets:        assets,
 		notifications: notifications,
 		payout:        payout,
+		scrubber:      scrubber,
+		nodestats:     nodestats,
 	}
 
 	router := mux.NewRouter()
@@ -74,6 +80,12 @@ func NewServer(logger *zap.Logger, assets fs.FS, notifications *notifications.Se
 	storageNodeRouter.HandleFunc("/satellite/{id}", storageNodeController.Satellite).Methods(http.MethodGet)
 	storageNodeRouter.HandleFunc("/estimated-payout", storageNodeController.EstimatedPayout).Methods(http.MethodGet)
 
+	scrubberController := consoleapi.NewScrubber(server.log, server.scrubber)
+	storageNodeRouter.HandleFunc("/scrubber", scrubberController.Status).Methods(http.MethodGet)
+
+	disqualificationController := consoleapi.NewDisqualification(server.log, server.service, server.nodestats)
+	storageNodeRouter.HandleFunc("/satellite/{id}/disqualification", disqualificationController.Explanation).Methods(http.MethodGet)
+
 	notificationController := consoleapi.NewNotifications(server.log, server.notifications)
 	notificationRouter := router.PathPrefix("/api/notifications").Subrouter()
 	notificationRouter.StrictSlash(true)
@@ -16,8 +16,11 @@ import (
 
 	"storj.io/common/pb"
 	"storj.io/common/rpc"
+	"storj.io/common/signing"
 	"storj.io/common/storj"
 	"storj.io/common/sync2"
+	"storj.io/storj/private/nodeextpb"
+	"storj.io/storj/private/nodetag"
 	"storj.io/storj/storagenode/trust"
 )
 
@@ -38,6 +41,9 @@ type Config struct {
 
 	// Chore config values
 	Interval time.Duration `help:"how frequently the node contact chore should run" releaseDefault:"1h" devDefault:"30s"`
+
+	Tags           string `help:"comma-separated name=value tags of the node (e.g. provider=example), which are signed by the node and reported to the satellites" default:""`
+	SignedTagsPath string `help:"path of a JSON file with the tags of the node signed by tag authorities, which are reported to the satellites" default:""`
 }
 
 // NodeInfo contains information necessary for introducing storagenode to satellite.
@@ -47,6 +53,11 @@ type NodeInfo struct {
 	Version  pb.NodeVersion
 	Capacity pb.NodeCapacity
 	Operator pb.NodeOperator
+
+	// Tags are signed by the node on every check-in.
+	Tags []nodetag.Tag
+	// SignedTags are signed by tag authorities.
+	SignedTags []*nodetag.SignedTagSet
 }
 
 // Service is the contact service between storage nodes and satellites.
@@ -54,6 +65,7 @@ type Service struct {
 	log    *zap.Logger
 	rand   *rand.Rand
 	dialer rpc.Dialer
+	signer signing.Signer
 
 	mu   sync.Mutex
 	self NodeInfo
@@ -65,11 +77,12 @@ type Service struct {
 }
 
 // NewService creates a new contact service.
-func NewService(log *zap.Logger, dialer rpc.Dialer, self NodeInfo, trust *trust.Pool, quicStats *QUICStats) *Service {
+func NewService(log *zap.Logger, dialer rpc.Dialer, signer signing.Signer, self NodeInfo, trust *trust.Pool, quicStats *QUICStats) *Service {
 	return &Service{
 		log:       log,
 		rand:      rand.New(rand.NewSource(time.Now().UnixNano())),
 		dialer:    dialer,
+		signer:    signer,
 		trust:     trust,
 		self:      self,
 		quicStats: quicStats,
@@ -128,12 +141,14 @@ func (service *Service) pingSatelliteOnce(ctx context.Context, id storj.NodeID)
 	defer func() { err = errs.Combine(err, conn.Close()) }()
 
 	self := service.Local()
-	resp, err := pb.NewDRPCNodeClient(conn).CheckIn(ctx, &pb.CheckInRequest{
+	req := &pb.CheckInRequest{
 		Address:  self.Address,
 		Version:  &self.Version,
 		Capacity: &self.Capacity,
 		Operator: &self.Operator,
-	})
+	}
+
+	resp, err := pb.NewDRPCNodeClient(conn).CheckIn(ctx, req)
 	service.quicStats.SetStatus(false)
 	if err != nil {
 		return errPingSatellite.Wrap(err)
@@ -148,9 +163,30 @@ func (service *Service) pingSatelliteOnce(ctx context.Context, id storj.NodeID)
 	if resp.PingErrorMessage != "" {
 		service.log.Warn("Your node is still considered to be online but encountered an error.", zap.Stringer("Satellite ID", id), zap.String("Error", resp.GetPingErrorMessage()))
 	}
+
+	// invalid tags or satellites without tag support shouldn't make the node offline.
+	if err := service.sendTags(ctx, conn, self); err != nil {
+		service.log.Warn("failed to send node tags", zap.Stringer("Satellite ID", id), zap.Error(err))
+	}
 	return nil
 }
 
+// sendTags sends the signed tags of the node to the satellite. Nodes without
+// tags don't send any, so the satellite keeps their previous tags.
+func (service *Service) sendTags(ctx context.Context, conn *rpc.Conn, self NodeInfo) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	signedTags, err := service.signedTags(ctx, self)
+	if err != nil || len(signedTags) == 0 {
+		return err
+	}
+
+	_, err = nodeextpb.NewDRPCNodeTagsClient(conn).SetNodeTags(ctx, &nodeextpb.SetNodeTagsRequest{
+		Tags: nodetag.ToProtobuf(signedTags),
+	})
+	return err
+}
+
 // RequestPingMeQUIC sends pings request to satellite for a pingBack via QUIC.
 func (service *Service) RequestPingMeQUIC(ctx context.Context) (stats *QUICStats, err error) {
 	defer mon.Task()(&ctx)(&err)
@@ -219,6 +255,27 @@ func (service *Service) dialSatellite(ctx context.Context, id storj.NodeID) (*rp
 	return service.dialer.DialNodeURL(ctx, nodeurl)
 }
 
+// signedTags returns the tags of the node signed by itself together with the
+// tags signed by tag authorities.
+func (service *Service) signedTags(ctx context.Context, self NodeInfo) (_ []*nodetag.SignedTagSet, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if len(self.Tags) == 0 {
+		return self.SignedTags, nil
+	}
+
+	signed, err := nodetag.Sign(ctx, &nodetag.TagSet{
+		NodeID:   self.ID,
+		SignedAt: time.Now().UTC(),
+		Tags:     self.Tags,
+	}, service.signer)
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]*nodetag.SignedTagSet{signed}, self.SignedTags...), nil
+}
+
 // Local returns the storagenode info.
 func (service *Service) Local() NodeInfo {
 	service.mu.Lock()
@@ -17,6 +17,7 @@ import (
 	"storj.io/common/memory"
 	"storj.io/common/pb"
 	"storj.io/common/sync2"
+	"storj.io/storj/storage/filestore"
 	"storj.io/storj/storagenode/bandwidth"
 	"storj.io/storj/storagenode/contact"
 	"storj.io/storj/storagenode/pieces"
@@ -37,6 +38,8 @@ type DiskSpace struct {
 	Free          int64
 	Available     int64
 	Overused      int64
+	// Disks is the space of every disk, when the pieces are stored on several disks.
+	Disks []filestore.DiskStatus
 }
 
 // Config defines parameters for storage node disk and bandwidth usage monitoring.
@@ -214,6 +217,12 @@ func (service *Service) AvailableSpace(ctx context.Context) (_ int64, err error)
 	if diskStatus.DiskFree < freeSpaceForStorj {
 		freeSpaceForStorj = diskStatus.DiskFree
 	}
+	for _, disk := range diskStatus.Disks {
+		pathTag := monkit.NewSeriesTag("path", disk.Path)
+		mon.IntVal("disk_free_space", pathTag).Observe(disk.Free)
+		mon.IntVal("disk_used_space", pathTag).Observe(disk.Used)
+		mon.BoolVal("disk_healthy", pathTag).Observe(disk.Healthy)
+	}
 
 	mon.IntVal("allocated_space").Observe(service.allocatedDiskSpace)
 	mon.IntVal("used_space").Observe(usedSpace)
@@ -257,5 +266,6 @@ func (service *Service) DiskSpace(ctx context.Context) (_ DiskSpace, err error)
 		Free:          storageStatus.DiskFree,
 		Available:     available,
 		Overused:      overused,
+		Disks:         storageStatus.Disks,
 	}, nil
 }
@@ -5,6 +5,7 @@ package nodestats
 
 import (
 	"context"
+	"encoding/json"
 	"time"
 
 	"github.com/spacemonkeygo/monkit/v3"
@@ -13,7 +14,9 @@ import (
 
 	"storj.io/common/pb"
 	"storj.io/common/rpc"
+	"storj.io/common/rpc/rpcstatus"
 	"storj.io/common/storj"
+	"storj.io/storj/private/nodeextpb"
 	"storj.io/storj/storagenode/pricing"
 	"storj.io/storj/storagenode/reputation"
 	"storj.io/storj/storagenode/storageusage"
@@ -108,6 +111,28 @@ func (s *Service) GetReputationStats(ctx context.Context, satelliteID storj.Node
 	}, nil
 }
 
+// GetDisqualificationExplanation retrieves the explanation of the disqualification of the
+// node from particular satellite. The explanation is JSON encoded, as it's sent by the
+// satellite, and it's nil when the satellite has no explanation for the node.
+func (s *Service) GetDisqualificationExplanation(ctx context.Context, satelliteID storj.NodeID) (_ json.RawMessage, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	client, err := s.dial(ctx, satelliteID)
+	if err != nil {
+		return nil, NodeStatsServiceErr.Wrap(err)
+	}
+	defer func() { err = errs.Combine(err, client.Close()) }()
+
+	resp, err := nodeextpb.NewDRPCNodeStatsClient(client.conn).GetDisqualificationExplanation(ctx, &nodeextpb.GetDisqualificationExplanationRequest{})
+	if err != nil {
+		if rpcstatus.Code(err) == rpcstatus.NotFound {
+			return nil, nil
+		}
+		return nil, NodeStatsServiceErr.Wrap(err)
+	}
+	return json.RawMessage(resp.Explanation), nil
+}
+
 // GetDailyStorageUsage returns daily storage usage over a period of time for a particular satellite.
 func (s *Service) GetDailyStorageUsage(ctx context.Context, satelliteID storj.NodeID, from, to time.Time) (_ []storageusage.Stamp, err error) {
 	defer mon.Task()(&ctx)(&err)
@@ -29,6 +29,8 @@ import (
 	"storj.io/private/version"
 	"storj.io/storj/private/lifecycle"
 	"storj.io/storj/private/multinodepb"
+	"storj.io/storj/private/nodeextpb"
+	"storj.io/storj/private/nodetag"
 	"storj.io/storj/private/server"
 	"storj.io/storj/private/version/checker"
 	"storj.io/storj/storage"
@@ -53,6 +55,7 @@ import (
 	"storj.io/storj/storagenode/payouts/estimatedpayouts"
 	"storj.io/storj/storagenode/pieces"
 	"storj.io/storj/storagenode/piecestore"
+	"storj.io/storj/storagenode/piecestore/trafficlimit"
 	"storj.io/storj/storagenode/piecestore/usedserials"
 	"storj.io/storj/storagenode/piecetransfer"
 	"storj.io/storj/storagenode/preflight"
@@ -60,6 +63,7 @@ import (
 	"storj.io/storj/storagenode/reputation"
 	"storj.io/storj/storagenode/retain"
 	"storj.io/storj/storagenode/satellites"
+	"storj.io/storj/storagenode/scrubber"
 	"storj.io/storj/storagenode/storagenodedb"
 	"storj.io/storj/storagenode/storageusage"
 	"storj.io/storj/storagenode/trust"
@@ -89,6 +93,7 @@ type DB interface {
 	Orders() orders.DB
 	V0PieceInfo() pieces.V0PieceInfoDB
 	PieceExpirationDB() pieces.PieceExpirationDB
+	PieceIndexDB() pieces.PieceIndexDB
 	PieceSpaceUsedDB() pieces.PieceSpaceUsedDB
 	Bandwidth() bandwidth.DB
 	Reputation() reputation.DB
@@ -124,6 +129,8 @@ type Config struct {
 
 	Retain retain.Config
 
+	Scrubber scrubber.Config
+
 	Nodestats nodestats.Config
 
 	Console consoleserver.Config
@@ -149,6 +156,8 @@ func (config *Config) DatabaseConfig() storagenodedb.Config {
 		Info2:     filepath.Join(dbdir, "info.db"),
 		Pieces:    config.Storage.Path,
 		Filestore: config.Filestore,
+
+		ExtraPieces: config.Storage.ExtraPaths,
 	}
 }
 
@@ -246,12 +255,17 @@ type Peer struct {
 
 	Storage2 struct {
 		// TODO: lift things outside of it to organize better
-		Trust         *trust.Pool
-		Store         *pieces.Store
-		TrashChore    *pieces.TrashChore
-		BlobsCache    *pieces.BlobsUsageCache
+		Trust      *trust.Pool
+		Store      *pieces.Store
+		TrashChore *pieces.TrashChore
+		BlobsCache *pieces.BlobsUsageCache
+		// BlobsMigration is only set while blobs are migrated to another backend.
+		BlobsMigration *filestore.MigrationChore
+		// PieceIndex is only set when the piece index is enabled.
+		PieceIndex    *pieces.IndexedBlobs
 		CacheService  *pieces.CacheService
 		RetainService *retain.Service
+		Limiter       *trafficlimit.Limiter
 		PieceDeleter  *pieces.Deleter
 		Endpoint      *piecestore.Endpoint
 		Inspector     *inspector.Endpoint
@@ -261,6 +275,8 @@ type Peer struct {
 
 	Collector *collector.Service
 
+	Scrubber *scrubber.Service
+
 	NodeStats struct {
 		Service *nodestats.Service
 		Cache   *nodestats.Cache
@@ -433,9 +449,21 @@ func New(log *zap.Logger, full *identity.FullIdentity, db DB, revocationDB exten
 			},
 			Version: *pbVersion,
 		}
+
+		self.Tags, err = nodetag.ParseTags(c.Tags)
+		if err != nil {
+			return nil, errs.Combine(err, peer.Close())
+		}
+		if c.SignedTagsPath != "" {
+			self.SignedTags, err = nodetag.LoadSignedTagSets(c.SignedTagsPath)
+			if err != nil {
+				return nil, errs.Combine(err, peer.Close())
+			}
+		}
+
 		peer.Contact.PingStats = new(contact.PingStats)
 		peer.Contact.QUICStats = contact.NewQUICStats(peer.Server.IsQUICEnabled())
-		peer.Contact.Service = contact.NewService(peer.Log.Named("contact:service"), peer.Dialer, self, peer.Storage2.Trust, peer.Contact.QUICStats)
+		peer.Contact.Service = contact.NewService(peer.Log.Named("contact:service"), peer.Dialer, signing.SignerFromFullIdentity(peer.Identity), self, peer.Storage2.Trust, peer.Contact.QUICStats)
 
 		peer.Contact.Chore = contact.NewChore(peer.Log.Named("contact:chore"), config.Contact.Interval, peer.Contact.Service)
 		peer.Services.Add(lifecycle.Item{
@@ -451,7 +479,36 @@ func New(log *zap.Logger, full *identity.FullIdentity, db DB, revocationDB exten
 	}
 
 	{ // setup storage
-		peer.Storage2.BlobsCache = pieces.NewBlobsUsageCache(peer.Log.Named("blobscache"), peer.DB.Pieces())
+		var migrating []*filestore.MigratingBlobs
+		switch blobs := peer.DB.Pieces().(type) {
+		case *filestore.MigratingBlobs:
+			migrating = append(migrating, blobs)
+		case *filestore.MultiBlobs:
+			migrating = blobs.Migrating()
+		}
+		if len(migrating) > 0 {
+			peer.Storage2.BlobsMigration = filestore.NewMigrationChore(peer.Log.Named("blobs:migration"), migrating, config.Filestore.MigrationInterval)
+			peer.Services.Add(lifecycle.Item{
+				Name:  "blobs:migration",
+				Run:   peer.Storage2.BlobsMigration.Run,
+				Close: peer.Storage2.BlobsMigration.Close,
+			})
+			peer.Debug.Server.Panel.Add(
+				debug.Cycle("Blobs Migration", peer.Storage2.BlobsMigration.Loop))
+		}
+
+		blobs := peer.DB.Pieces()
+		if config.Pieces.EnableIndex {
+			peer.Storage2.PieceIndex = pieces.NewIndexedBlobs(peer.Log.Named("pieceindex"), blobs, peer.DB.PieceIndexDB())
+			peer.Services.Add(lifecycle.Item{
+				Name:  "pieceindex",
+				Run:   peer.Storage2.PieceIndex.Run,
+				Close: peer.Storage2.PieceIndex.Close,
+			})
+			blobs = peer.Storage2.PieceIndex
+		}
+
+		peer.Storage2.BlobsCache = pieces.NewBlobsUsageCache(peer.Log.Named("blobscache"), blobs)
 
 		peer.Storage2.Store = pieces.NewStore(peer.Log.Named("pieces"),
 			peer.Storage2.BlobsCache,
@@ -515,9 +572,12 @@ func New(log *zap.Logger, full *identity.FullIdentity, db DB, revocationDB exten
 		peer.Debug.Server.Panel.Add(
 			debug.Cycle("Piecestore Monitor", peer.Storage2.Monitor.Loop))
 
+		peer.Storage2.Limiter = trafficlimit.NewLimiter(config.Storage2.TrafficLimit)
+
 		peer.Storage2.RetainService = retain.NewService(
 			peer.Log.Named("retain"),
 			peer.Storage2.Store,
+			peer.Storage2.Limiter,
 			config.Retain,
 		)
 		peer.Services.Add(lifecycle.Item{
@@ -549,6 +609,7 @@ func New(log *zap.Logger, full *identity.FullIdentity, db DB, revocationDB exten
 			peer.OrdersStore,
 			peer.DB.Bandwidth(),
 			peer.UsedSerials,
+			peer.Storage2.Limiter,
 			config.Storage2,
 		)
 		if err != nil {
@@ -558,6 +619,9 @@ func New(log *zap.Logger, full *identity.FullIdentity, db DB, revocationDB exten
 		if err := pb.DRPCRegisterPiecestore(peer.Server.DRPC(), peer.Storage2.Endpoint); err != nil {
 			return nil, errs.Combine(err, peer.Close())
 		}
+		if err := nodeextpb.DRPCRegisterPiecestore(peer.Server.DRPC(), peer.Storage2.Endpoint); err != nil {
+			return nil, errs.Combine(err, peer.Close())
+		}
 
 		// TODO workaround for custom timeout for order sending request (read/write)
 		sc := config.Server
@@ -660,6 +724,20 @@ func New(log *zap.Logger, full *identity.FullIdentity, db DB, revocationDB exten
 		)
 	}
 
+	{ // setup piece scrubber
+		peer.Scrubber = scrubber.NewService(peer.Log.Named("scrubber"), peer.Storage2.Store, peer.Storage2.Trust,
+			filepath.Join(config.Storage.Path, "quarantine"), config.Scrubber)
+		if config.Scrubber.Enabled {
+			peer.Services.Add(lifecycle.Item{
+				Name:  "scrubber",
+				Run:   peer.Scrubber.Run,
+				Close: peer.Scrubber.Close,
+			})
+			peer.Debug.Server.Panel.Add(
+				debug.Cycle("Scrubber", peer.Scrubber.Loop))
+		}
+	}
+
 	{ // setup storage node operator dashboard
 		_, port, _ := net.SplitHostPort(peer.Addr())
 		peer.Console.Service, err = console.NewService(
@@ -707,6 +785,8 @@ func New(log *zap.Logger, full *identity.FullIdentity, db DB, revocationDB exten
 			peer.Notifications.Service,
 			peer.Console.Service,
 			peer.Payout.Service,
+			peer.Scrubber,
+			peer.NodeStats.Service,
 			peer.Console.Listener,
 		)
 
@@ -15,6 +15,7 @@ import (
 	"storj.io/common/storj"
 	"storj.io/common/sync2"
 	"storj.io/storj/storage"
+	"storj.io/storj/storage/filestore"
 )
 
 // CacheService updates the space used cache.
@@ -325,12 +326,18 @@ func (blobs *BlobsUsageCache) EmptyTrash(ctx context.Context, namespace []byte,
 
 // RestoreTrash restores the trash for the namespace and updates the cache.
 func (blobs *BlobsUsageCache) RestoreTrash(ctx context.Context, namespace []byte) ([][]byte, error) {
+	return blobs.RestoreTrashWithOptions(ctx, namespace, storage.RestoreTrashOptions{})
+}
+
+// RestoreTrashWithOptions restores the trash for the namespace, which is selected by the
+// options, and updates the cache.
+func (blobs *BlobsUsageCache) RestoreTrashWithOptions(ctx context.Context, namespace []byte, opts storage.RestoreTrashOptions) ([][]byte, error) {
 	satelliteID, err := storj.NodeIDFromBytes(namespace)
 	if err != nil {
 		return nil, err
 	}
 
-	keysRestored, err := blobs.Blobs.RestoreTrash(ctx, namespace)
+	keysRestored, err := blobs.Blobs.RestoreTrashWithOptions(ctx, namespace, opts)
 	if err != nil {
 		return nil, err
 	}
@@ -491,6 +498,11 @@ func (blobs *BlobsUsageCache) Close() error {
 	return nil
 }
 
+// DiskStatuses returns the status of every disk, when the blobs are stored on several disks.
+func (blobs *BlobsUsageCache) DiskStatuses(ctx context.Context) ([]filestore.DiskStatus, error) {
+	return diskStatuses(ctx, blobs.Blobs)
+}
+
 // TestCreateV0 creates a new V0 blob that can be written. This is only appropriate in test situations.
 func (blobs *BlobsUsageCache) TestCreateV0(ctx context.Context, ref storage.BlobRef) (_ storage.BlobWriter, err error) {
 	fStore := blobs.Blobs.(interface {
@@ -0,0 +1,515 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package pieces
+
+import (
+	"context"
+	"errors"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	"storj.io/common/storj"
+	"storj.io/storj/storage"
+	"storj.io/storj/storage/filestore"
+)
+
+// indexCheckBatchSize is the number of index entries, which are written at once while
+// checking the index.
+const indexCheckBatchSize = 1000
+
+// IndexedBlobs is a blob store, which maintains an index of the stored pieces, so that
+// walking a satellite's pieces and calculating the space used by them doesn't need to
+// stat every file.
+//
+// The index is updated after commits, deletions, trashing and restoring from the trash.
+// It's used for a satellite only after Run has checked it against the blob store,
+// until then the blob store is walked. Errors while updating the index are logged
+// instead of returned: a missing entry only keeps the piece from being garbage
+// collected and a stale entry only makes a deletion fail, so they don't lose data.
+//
+// The index of a satellite is marked dirty before its blobs are first changed, and
+// it's marked clean by Close, unless updating it failed. Run only checks the dirty
+// indexes, so the blob store is only walked after a crash or a failed update.
+//
+// architecture: Database
+type IndexedBlobs struct {
+	storage.Blobs
+	log *zap.Logger
+	db  PieceIndexDB
+
+	mu      sync.Mutex
+	checked map[storj.NodeID]struct{}
+	dirty   map[storj.NodeID]struct{} // the satellites, of which the index was marked dirty.
+	failed  map[storj.NodeID]struct{} // the satellites, of which updating the index failed.
+	closed  bool
+
+	// checkMu is held by Check while writing a batch of index entries, and by update
+	// while recording the removed pieces, so that Check doesn't add back a piece, which
+	// was removed while the blob store was walked.
+	checkMu  sync.Mutex
+	removing map[storj.NodeID]map[storj.PieceID]struct{}
+}
+
+// NewIndexedBlobs creates a new blob store, which maintains the piece index in db.
+func NewIndexedBlobs(log *zap.Logger, blobs storage.Blobs, db PieceIndexDB) *IndexedBlobs {
+	return &IndexedBlobs{
+		Blobs:    blobs,
+		log:      log,
+		db:       db,
+		checked:  map[storj.NodeID]struct{}{},
+		dirty:    map[storj.NodeID]struct{}{},
+		failed:   map[storj.NodeID]struct{}{},
+		removing: map[storj.NodeID]map[storj.PieceID]struct{}{},
+	}
+}
+
+// Run checks the index of every satellite, which wasn't marked clean at the last
+// shutdown, against the blob store once, after which the index is used for walking
+// the satellite's pieces.
+func (blobs *IndexedBlobs) Run(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	namespaces, err := blobs.Blobs.ListNamespaces(ctx)
+	if err != nil {
+		blobs.log.Error("failed to list namespaces for checking the piece index", zap.Error(err))
+		return nil
+	}
+
+	for _, namespace := range namespaces {
+		satellite, err := storj.NodeIDFromBytes(namespace)
+		if err != nil {
+			continue
+		}
+
+		clean, err := blobs.db.IsClean(ctx, satellite)
+		if err != nil {
+			blobs.log.Warn("failed to read the piece index state", zap.Stringer("Satellite ID", satellite), zap.Error(err))
+		}
+		// the index is dirty until the next clean shutdown.
+		if !blobs.markDirty(ctx, satellite) {
+			continue
+		}
+		if clean {
+			blobs.mu.Lock()
+			blobs.checked[satellite] = struct{}{}
+			blobs.mu.Unlock()
+			blobs.log.Info("piece index is clean", zap.Stringer("Satellite ID", satellite))
+			continue
+		}
+
+		if err := blobs.Check(ctx, satellite); err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			blobs.log.Error("failed to check the piece index", zap.Stringer("Satellite ID", satellite), zap.Error(err))
+		}
+	}
+	return nil
+}
+
+// Check adds the satellite's pieces, which are missing from the index, and removes the
+// entries of the pieces, which aren't stored anymore. After it succeeds the index is
+// used for walking the satellite's pieces.
+func (blobs *IndexedBlobs) Check(ctx context.Context, satellite storj.NodeID) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	// index entries, which are written while checking, are marked as checked at a later
+	// time, so they're kept and aren't replaced by the check.
+	started := time.Now()
+
+	blobs.checkMu.Lock()
+	blobs.removing[satellite] = map[storj.PieceID]struct{}{}
+	blobs.checkMu.Unlock()
+	defer func() {
+		blobs.checkMu.Lock()
+		delete(blobs.removing, satellite)
+		blobs.checkMu.Unlock()
+	}()
+
+	// put writes the batch without the pieces, which were removed since the check started.
+	put := func(batch []PieceIndexEntry) error {
+		blobs.checkMu.Lock()
+		defer blobs.checkMu.Unlock()
+
+		removing := blobs.removing[satellite]
+		entries := make([]PieceIndexEntry, 0, len(batch))
+		for _, entry := range batch {
+			if _, ok := removing[entry.PieceID]; !ok {
+				entries = append(entries, entry)
+			}
+		}
+		return blobs.db.Put(ctx, satellite, entries, started)
+	}
+
+	var pieces int
+	batch := make([]PieceIndexEntry, 0, indexCheckBatchSize)
+	err = blobs.Blobs.WalkNamespace(ctx, satellite.Bytes(), func(info storage.BlobInfo) error {
+		entry, ok, err := indexEntry(ctx, info)
+		if err != nil {
+			blobs.log.Warn("failed to stat blob for the piece index", zap.Stringer("Satellite ID", satellite), zap.Error(err))
+			return nil
+		}
+		if !ok {
+			return nil
+		}
+
+		pieces++
+		batch = append(batch, entry)
+		if len(batch) < indexCheckBatchSize {
+			return nil
+		}
+		err = put(batch)
+		batch = batch[:0]
+		return err
+	})
+	if err != nil {
+		return Error.Wrap(err)
+	}
+	if err := put(batch); err != nil {
+		return Error.Wrap(err)
+	}
+
+	removed, err := blobs.db.DeleteUncheckedBefore(ctx, satellite, started)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	blobs.mu.Lock()
+	blobs.checked[satellite] = struct{}{}
+	delete(blobs.failed, satellite)
+	blobs.mu.Unlock()
+
+	blobs.log.Info("piece index checked",
+		zap.Stringer("Satellite ID", satellite),
+		zap.Int("Pieces", pieces),
+		zap.Int64("Removed", removed),
+		zap.Duration("Duration", time.Since(started)))
+	return nil
+}
+
+// markDirty marks the index of the satellite dirty, unless it was already, and returns
+// whether it succeeded. It's called before changing the blobs of the satellite, so
+// that a crash before updating the index leaves it dirty.
+func (blobs *IndexedBlobs) markDirty(ctx context.Context, satellite storj.NodeID) bool {
+	blobs.mu.Lock()
+	_, dirty := blobs.dirty[satellite]
+	blobs.mu.Unlock()
+	if dirty {
+		return true
+	}
+
+	if err := blobs.db.SetClean(ctx, satellite, false); err != nil {
+		blobs.log.Error("failed to mark the piece index dirty", zap.Stringer("Satellite ID", satellite), zap.Error(err))
+		blobs.mu.Lock()
+		blobs.failed[satellite] = struct{}{}
+		blobs.mu.Unlock()
+		return false
+	}
+
+	blobs.mu.Lock()
+	blobs.dirty[satellite] = struct{}{}
+	blobs.mu.Unlock()
+	return true
+}
+
+// markNamespaceDirty marks the index of the namespace's satellite dirty.
+func (blobs *IndexedBlobs) markNamespaceDirty(ctx context.Context, namespace []byte) {
+	if satellite, err := storj.NodeIDFromBytes(namespace); err == nil {
+		blobs.markDirty(ctx, satellite)
+	}
+}
+
+// isChecked returns whether the index of the satellite can be used for walking its pieces.
+func (blobs *IndexedBlobs) isChecked(satellite storj.NodeID) bool {
+	blobs.mu.Lock()
+	defer blobs.mu.Unlock()
+	_, ok := blobs.checked[satellite]
+	return ok
+}
+
+// Create creates a new blob, which is added to the index when it's committed.
+func (blobs *IndexedBlobs) Create(ctx context.Context, ref storage.BlobRef, size int64) (storage.BlobWriter, error) {
+	blobs.markNamespaceDirty(ctx, ref.Namespace)
+	writer, err := blobs.Blobs.Create(ctx, ref, size)
+	if err != nil {
+		return nil, err
+	}
+	return &indexedBlobWriter{BlobWriter: writer, blobs: blobs, ref: ref}, nil
+}
+
+// Delete deletes the blob and removes it from the index.
+func (blobs *IndexedBlobs) Delete(ctx context.Context, ref storage.BlobRef) error {
+	blobs.markNamespaceDirty(ctx, ref.Namespace)
+	err := blobs.Blobs.Delete(ctx, ref)
+	blobs.update(ctx, ref.Namespace, [][]byte{ref.Key})
+	return err
+}
+
+// DeleteWithStorageFormat deletes the blob with the storage format and updates the index.
+func (blobs *IndexedBlobs) DeleteWithStorageFormat(ctx context.Context, ref storage.BlobRef, formatVer storage.FormatVersion) error {
+	blobs.markNamespaceDirty(ctx, ref.Namespace)
+	err := blobs.Blobs.DeleteWithStorageFormat(ctx, ref, formatVer)
+	blobs.update(ctx, ref.Namespace, [][]byte{ref.Key})
+	return err
+}
+
+// DeleteNamespace deletes the blobs of the namespace and its index.
+func (blobs *IndexedBlobs) DeleteNamespace(ctx context.Context, namespace []byte) error {
+	err := blobs.Blobs.DeleteNamespace(ctx, namespace)
+	if satellite, idErr := storj.NodeIDFromBytes(namespace); idErr == nil {
+		blobs.mu.Lock()
+		delete(blobs.checked, satellite)
+		delete(blobs.dirty, satellite)
+		delete(blobs.failed, satellite)
+		blobs.mu.Unlock()
+
+		if indexErr := blobs.db.DeleteSatellite(ctx, satellite); indexErr != nil {
+			blobs.log.Warn("failed to delete the piece index", zap.Stringer("Satellite ID", satellite), zap.Error(indexErr))
+		}
+	}
+	return err
+}
+
+// Trash moves the blob to the trash and removes it from the index.
+func (blobs *IndexedBlobs) Trash(ctx context.Context, ref storage.BlobRef) error {
+	blobs.markNamespaceDirty(ctx, ref.Namespace)
+	err := blobs.Blobs.Trash(ctx, ref)
+	blobs.update(ctx, ref.Namespace, [][]byte{ref.Key})
+	return err
+}
+
+// RestoreTrash restores the trash of the namespace and adds the restored blobs to the index.
+func (blobs *IndexedBlobs) RestoreTrash(ctx context.Context, namespace []byte) ([][]byte, error) {
+	return blobs.RestoreTrashWithOptions(ctx, namespace, storage.RestoreTrashOptions{})
+}
+
+// RestoreTrashWithOptions restores the trash of the namespace, which is selected by the
+// options, and adds the restored blobs to the index.
+func (blobs *IndexedBlobs) RestoreTrashWithOptions(ctx context.Context, namespace []byte, opts storage.RestoreTrashOptions) ([][]byte, error) {
+	blobs.markNamespaceDirty(ctx, namespace)
+	keys, err := blobs.Blobs.RestoreTrashWithOptions(ctx, namespace, opts)
+	blobs.update(ctx, namespace, keys)
+	return keys, err
+}
+
+// WalkNamespace executes walkFunc for each blob in the namespace. The index is walked
+// instead of the blob store when it was checked.
+func (blobs *IndexedBlobs) WalkNamespace(ctx context.Context, namespace []byte, walkFunc func(storage.BlobInfo) error) error {
+	satellite, err := storj.NodeIDFromBytes(namespace)
+	if err != nil || !blobs.isChecked(satellite) {
+		return blobs.Blobs.WalkNamespace(ctx, namespace, walkFunc)
+	}
+
+	return blobs.db.Walk(ctx, satellite, func(entry PieceIndexEntry) error {
+		return walkFunc(&indexedBlobInfo{
+			blobs: blobs.Blobs,
+			ref:   storage.BlobRef{Namespace: namespace, Key: entry.PieceID.Bytes()},
+			entry: entry,
+		})
+	})
+}
+
+// SpaceUsedForBlobsInNamespace returns the space used by the blobs in the namespace. It's
+// calculated from the index when it was checked.
+func (blobs *IndexedBlobs) SpaceUsedForBlobsInNamespace(ctx context.Context, namespace []byte) (int64, error) {
+	satellite, err := storj.NodeIDFromBytes(namespace)
+	if err != nil || !blobs.isChecked(satellite) {
+		return blobs.Blobs.SpaceUsedForBlobsInNamespace(ctx, namespace)
+	}
+	return blobs.db.SpaceUsed(ctx, satellite)
+}
+
+// update adds the blobs of the namespace, which are stored, to the index and removes
+// the others.
+func (blobs *IndexedBlobs) update(ctx context.Context, namespace []byte, keys [][]byte) {
+	satellite, err := storj.NodeIDFromBytes(namespace)
+	if err != nil || len(keys) == 0 {
+		return
+	}
+
+	var stored []PieceIndexEntry
+	var removed []storj.PieceID
+	for _, key := range keys {
+		pieceID, err := storj.PieceIDFromBytes(key)
+		if err != nil {
+			continue
+		}
+
+		info, err := blobs.Blobs.Stat(ctx, storage.BlobRef{Namespace: namespace, Key: key})
+		if err != nil {
+			if !errors.Is(err, os.ErrNotExist) {
+				blobs.log.Warn("failed to stat blob for the piece index", zap.Stringer("Satellite ID", satellite), zap.Stringer("Piece ID", pieceID), zap.Error(err))
+			}
+			removed = append(removed, pieceID)
+			continue
+		}
+
+		entry, ok, err := indexEntry(ctx, info)
+		if err != nil || !ok {
+			removed = append(removed, pieceID)
+			continue
+		}
+		stored = append(stored, entry)
+	}
+
+	// a running check must not add the removed pieces back.
+	if len(removed) > 0 {
+		blobs.checkMu.Lock()
+		if removing := blobs.removing[satellite]; removing != nil {
+			for _, pieceID := range removed {
+				removing[pieceID] = struct{}{}
+			}
+		}
+		blobs.checkMu.Unlock()
+	}
+
+	var failed bool
+	if err := blobs.db.Put(ctx, satellite, stored, time.Now()); err != nil {
+		blobs.log.Warn("failed to add pieces to the piece index", zap.Stringer("Satellite ID", satellite), zap.Error(err))
+		failed = true
+	}
+	if err := blobs.db.Delete(ctx, satellite, removed); err != nil {
+		blobs.log.Warn("failed to remove pieces from the piece index", zap.Stringer("Satellite ID", satellite), zap.Error(err))
+		failed = true
+	}
+	if !failed {
+		return
+	}
+
+	blobs.mu.Lock()
+	blobs.failed[satellite] = struct{}{}
+	closed := blobs.closed
+	blobs.mu.Unlock()
+	if closed {
+		// the index may have been marked clean already.
+		if err := blobs.db.SetClean(ctx, satellite, false); err != nil {
+			blobs.log.Error("failed to mark the piece index dirty", zap.Stringer("Satellite ID", satellite), zap.Error(err))
+		}
+	}
+}
+
+// Close marks the index of the checked satellites clean, unless updating it failed,
+// so that the next Run doesn't need to check it.
+func (blobs *IndexedBlobs) Close() error {
+	blobs.mu.Lock()
+	if blobs.closed {
+		blobs.mu.Unlock()
+		return nil
+	}
+	blobs.closed = true
+	var clean []storj.NodeID
+	for satellite := range blobs.checked {
+		if _, failed := blobs.failed[satellite]; !failed {
+			clean = append(clean, satellite)
+		}
+	}
+	blobs.mu.Unlock()
+
+	ctx := context.Background()
+	var group errs.Group
+	for _, satellite := range clean {
+		group.Add(blobs.db.SetClean(ctx, satellite, true))
+	}
+	return Error.Wrap(group.Err())
+}
+
+// DiskStatuses returns the status of every disk, when the blobs are stored on several disks.
+func (blobs *IndexedBlobs) DiskStatuses(ctx context.Context) ([]filestore.DiskStatus, error) {
+	return diskStatuses(ctx, blobs.Blobs)
+}
+
+// TestCreateV0 creates a new V0 blob that can be written. This is only appropriate in test situations.
+func (blobs *IndexedBlobs) TestCreateV0(ctx context.Context, ref storage.BlobRef) (_ storage.BlobWriter, err error) {
+	fStore := blobs.Blobs.(interface {
+		TestCreateV0(ctx context.Context, ref storage.BlobRef) (_ storage.BlobWriter, err error)
+	})
+	blobs.markNamespaceDirty(ctx, ref.Namespace)
+	writer, err := fStore.TestCreateV0(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+	return &indexedBlobWriter{BlobWriter: writer, blobs: blobs, ref: ref}, nil
+}
+
+// indexEntry returns the index entry of the blob. ok is false when the blob isn't a piece.
+func indexEntry(ctx context.Context, info storage.BlobInfo) (entry PieceIndexEntry, ok bool, err error) {
+	pieceID, err := storj.PieceIDFromBytes(info.BlobRef().Key)
+	if err != nil {
+		return PieceIndexEntry{}, false, nil
+	}
+	stat, err := info.Stat(ctx)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return PieceIndexEntry{}, false, nil
+		}
+		return PieceIndexEntry{}, false, err
+	}
+	return PieceIndexEntry{
+		PieceID:       pieceID,
+		FormatVersion: info.StorageFormatVersion(),
+		Size:          stat.Size(),
+		ModTime:       stat.ModTime(),
+	}, true, nil
+}
+
+// indexedBlobWriter adds the blob to the index when it's committed.
+type indexedBlobWriter struct {
+	storage.BlobWriter
+	blobs *IndexedBlobs
+	ref   storage.BlobRef
+}
+
+// Commit commits the blob and adds it to the index.
+func (writer *indexedBlobWriter) Commit(ctx context.Context) error {
+	if err := writer.BlobWriter.Commit(ctx); err != nil {
+		return err
+	}
+	writer.blobs.update(ctx, writer.ref.Namespace, [][]byte{writer.ref.Key})
+	return nil
+}
+
+// indexedBlobInfo is the info of a blob, which is read from the index.
+type indexedBlobInfo struct {
+	blobs storage.Blobs
+	ref   storage.BlobRef
+	entry PieceIndexEntry
+}
+
+// BlobRef returns the reference of the blob.
+func (info *indexedBlobInfo) BlobRef() storage.BlobRef { return info.ref }
+
+// StorageFormatVersion returns the storage format version of the blob.
+func (info *indexedBlobInfo) StorageFormatVersion() storage.FormatVersion {
+	return info.entry.FormatVersion
+}
+
+// FullPath returns the full path of the blob from the blob store.
+func (info *indexedBlobInfo) FullPath(ctx context.Context) (string, error) {
+	blobInfo, err := info.blobs.StatWithStorageFormat(ctx, info.ref, info.entry.FormatVersion)
+	if err != nil {
+		return "", err
+	}
+	return blobInfo.FullPath(ctx)
+}
+
+// Stat returns the file info of the blob from the index.
+func (info *indexedBlobInfo) Stat(ctx context.Context) (os.FileInfo, error) {
+	return indexedFileInfo{entry: info.entry}, nil
+}
+
+// indexedFileInfo is the file info of a blob, which is read from the index.
+type indexedFileInfo struct {
+	entry PieceIndexEntry
+}
+
+func (info indexedFileInfo) Name() string       { return info.entry.PieceID.String() }
+func (info indexedFileInfo) Size() int64        { return info.entry.Size }
+func (info indexedFileInfo) Mode() os.FileMode  { return 0 }
+func (info indexedFileInfo) ModTime() time.Time { return info.entry.ModTime }
+func (info indexedFileInfo) IsDir() bool        { return false }
+func (info indexedFileInfo) Sys() interface{}   { return nil }
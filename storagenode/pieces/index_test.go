@@ -0,0 +1,259 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package pieces_test
+
+import (
+	"bytes"
+	"context"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"storj.io/common/memory"
+	"storj.io/common/storj"
+	"storj.io/common/testcontext"
+	"storj.io/common/testrand"
+	"storj.io/storj/storage"
+	"storj.io/storj/storage/filestore"
+	"storj.io/storj/storagenode"
+	"storj.io/storj/storagenode/pieces"
+	"storj.io/storj/storagenode/storagenodedb/storagenodedbtest"
+)
+
+func TestIndexedBlobs(t *testing.T) {
+	storagenodedbtest.Run(t, func(ctx *testcontext.Context, t *testing.T, db storagenode.DB) {
+		log := zaptest.NewLogger(t)
+		dir, err := filestore.NewDir(log, ctx.Dir("store"))
+		require.NoError(t, err)
+		blobs := filestore.New(log, dir, filestore.DefaultConfig)
+		defer ctx.Check(blobs.Close)
+
+		satellite := testrand.NodeID()
+		indexDB := db.PieceIndexDB()
+
+		// pieces stored before the index was enabled
+		unindexedStore := pieces.NewStore(log, blobs, nil, db.PieceExpirationDB(), nil, pieces.DefaultConfig)
+		existing := []storj.PieceID{testrand.PieceID(), testrand.PieceID()}
+		for _, pieceID := range existing {
+			writeAPiece(ctx, t, unindexedStore, satellite, pieceID, testrand.Bytes(memory.KiB), time.Now(), nil, filestore.FormatV1)
+		}
+
+		// a stale entry of a piece, which was deleted while the index was disabled
+		stale := testrand.PieceID()
+		require.NoError(t, indexDB.Put(ctx, satellite, []pieces.PieceIndexEntry{{
+			PieceID:       stale,
+			FormatVersion: filestore.FormatV1,
+			Size:          100,
+			ModTime:       time.Now(),
+		}}, time.Now().Add(-time.Hour)))
+
+		indexed := pieces.NewIndexedBlobs(log, blobs, indexDB)
+		store := pieces.NewStore(log, indexed, nil, db.PieceExpirationDB(), nil, pieces.DefaultConfig)
+
+		// the blob store is walked until the index is checked
+		require.ElementsMatch(t, existing, walkPieceIDs(ctx, t, store, satellite))
+
+		require.NoError(t, indexed.Run(ctx))
+		require.ElementsMatch(t, existing, indexPieceIDs(ctx, t, indexDB, satellite))
+
+		// committed pieces are added to the index with their expiration
+		expiration := time.Now().Add(24 * time.Hour).UTC().Truncate(time.Second)
+		added := testrand.PieceID()
+		writeAPiece(ctx, t, store, satellite, added, testrand.Bytes(2*memory.KiB), time.Now(), &expiration, filestore.FormatV1)
+		require.NoError(t, store.SetExpiration(ctx, satellite, added, expiration))
+		all := append([]storj.PieceID{added}, existing...)
+		require.ElementsMatch(t, all, indexPieceIDs(ctx, t, indexDB, satellite))
+		require.NoError(t, indexDB.Walk(ctx, satellite, func(entry pieces.PieceIndexEntry) error {
+			if entry.PieceID == added {
+				require.True(t, entry.Expiration.Equal(expiration), entry.Expiration)
+			} else {
+				require.True(t, entry.Expiration.IsZero())
+			}
+			return nil
+		}))
+
+		// the sizes are the same as in the blob store
+		indexedTotal, indexedContentSize, err := store.SpaceUsedBySatellite(ctx, satellite)
+		require.NoError(t, err)
+		total, contentSize, err := unindexedStore.SpaceUsedBySatellite(ctx, satellite)
+		require.NoError(t, err)
+		require.Equal(t, total, indexedTotal)
+		require.Equal(t, contentSize, indexedContentSize)
+		require.EqualValues(t, 4*memory.KiB, indexedContentSize)
+
+		// trashed pieces are removed from the index, and added back when restored
+		require.NoError(t, store.Trash(ctx, satellite, existing[0]))
+		require.ElementsMatch(t, []storj.PieceID{added, existing[1]}, walkPieceIDs(ctx, t, store, satellite))
+		require.NoError(t, store.RestoreTrash(ctx, satellite))
+		require.ElementsMatch(t, all, walkPieceIDs(ctx, t, store, satellite))
+
+		// deleted pieces are removed from the index
+		require.NoError(t, store.Delete(ctx, satellite, added))
+		require.ElementsMatch(t, existing, walkPieceIDs(ctx, t, store, satellite))
+
+		// pieces can be trashed while walking them, like during garbage collection
+		require.NoError(t, store.WalkSatellitePieces(ctx, satellite, func(access pieces.StoredPieceAccess) error {
+			return store.Trash(ctx, satellite, access.PieceID())
+		}))
+		require.Empty(t, walkPieceIDs(ctx, t, store, satellite))
+		require.NoError(t, store.RestoreTrash(ctx, satellite))
+
+		// the index is walked instead of the blob store after it was checked
+		require.NoError(t, blobs.Delete(ctx, storage.BlobRef{Namespace: satellite.Bytes(), Key: existing[0].Bytes()}))
+		require.ElementsMatch(t, existing, walkPieceIDs(ctx, t, store, satellite))
+
+		// and the next check removes the piece from the index
+		require.NoError(t, indexed.Check(ctx, satellite))
+		require.ElementsMatch(t, existing[1:], walkPieceIDs(ctx, t, store, satellite))
+
+		require.NoError(t, indexed.DeleteNamespace(ctx, satellite.Bytes()))
+		require.Empty(t, indexPieceIDs(ctx, t, indexDB, satellite))
+	})
+}
+
+func TestIndexedBlobsCleanShutdown(t *testing.T) {
+	storagenodedbtest.Run(t, func(ctx *testcontext.Context, t *testing.T, db storagenode.DB) {
+		log := zaptest.NewLogger(t)
+		dir, err := filestore.NewDir(log, ctx.Dir("store"))
+		require.NoError(t, err)
+		blobs := filestore.New(log, dir, filestore.DefaultConfig)
+		defer ctx.Check(blobs.Close)
+
+		satellite := testrand.NodeID()
+		indexDB := db.PieceIndexDB()
+
+		indexed := pieces.NewIndexedBlobs(log, blobs, indexDB)
+		store := pieces.NewStore(log, indexed, nil, db.PieceExpirationDB(), nil, pieces.DefaultConfig)
+		pieceIDs := []storj.PieceID{testrand.PieceID(), testrand.PieceID()}
+		for _, pieceID := range pieceIDs {
+			writeAPiece(ctx, t, store, satellite, pieceID, testrand.Bytes(memory.KiB), time.Now(), nil, filestore.FormatV1)
+		}
+
+		// the index is dirty while it's used, and clean after closing
+		require.NoError(t, indexed.Run(ctx))
+		clean, err := indexDB.IsClean(ctx, satellite)
+		require.NoError(t, err)
+		require.False(t, clean)
+		require.NoError(t, indexed.Close())
+		clean, err = indexDB.IsClean(ctx, satellite)
+		require.NoError(t, err)
+		require.True(t, clean)
+
+		// a clean index isn't checked, so a blob deleted behind its back stays indexed
+		require.NoError(t, blobs.Delete(ctx, storage.BlobRef{Namespace: satellite.Bytes(), Key: pieceIDs[0].Bytes()}))
+		indexed = pieces.NewIndexedBlobs(log, blobs, indexDB)
+		store = pieces.NewStore(log, indexed, nil, db.PieceExpirationDB(), nil, pieces.DefaultConfig)
+		require.NoError(t, indexed.Run(ctx))
+		require.ElementsMatch(t, pieceIDs, walkPieceIDs(ctx, t, store, satellite))
+
+		// without closing, like after a crash, the next run checks the index
+		indexed = pieces.NewIndexedBlobs(log, blobs, indexDB)
+		store = pieces.NewStore(log, indexed, nil, db.PieceExpirationDB(), nil, pieces.DefaultConfig)
+		require.NoError(t, indexed.Run(ctx))
+		require.ElementsMatch(t, pieceIDs[1:], walkPieceIDs(ctx, t, store, satellite))
+	})
+}
+
+// walkHookBlobs calls hook for every blob walked in the blob store.
+type walkHookBlobs struct {
+	storage.Blobs
+	hook func(storage.BlobInfo)
+}
+
+func (blobs *walkHookBlobs) WalkNamespace(ctx context.Context, namespace []byte, walkFunc func(storage.BlobInfo) error) error {
+	return blobs.Blobs.WalkNamespace(ctx, namespace, func(info storage.BlobInfo) error {
+		err := walkFunc(info)
+		blobs.hook(info)
+		return err
+	})
+}
+
+func TestIndexedBlobsCheckConcurrentDelete(t *testing.T) {
+	storagenodedbtest.Run(t, func(ctx *testcontext.Context, t *testing.T, db storagenode.DB) {
+		log := zaptest.NewLogger(t)
+		dir, err := filestore.NewDir(log, ctx.Dir("store"))
+		require.NoError(t, err)
+		blobs := filestore.New(log, dir, filestore.DefaultConfig)
+		defer ctx.Check(blobs.Close)
+
+		satellite := testrand.NodeID()
+		unindexedStore := pieces.NewStore(log, blobs, nil, db.PieceExpirationDB(), nil, pieces.DefaultConfig)
+		pieceIDs := []storj.PieceID{testrand.PieceID(), testrand.PieceID(), testrand.PieceID()}
+		for _, pieceID := range pieceIDs {
+			writeAPiece(ctx, t, unindexedStore, satellite, pieceID, testrand.Bytes(memory.KiB), time.Now(), nil, filestore.FormatV1)
+		}
+
+		// every piece is deleted right after the check walked it, before its batch is written
+		hooked := &walkHookBlobs{Blobs: blobs}
+		indexed := pieces.NewIndexedBlobs(log, hooked, db.PieceIndexDB())
+		var deleted []storj.PieceID
+		hooked.hook = func(info storage.BlobInfo) {
+			require.NoError(t, indexed.Delete(ctx, info.BlobRef()))
+			pieceID, err := storj.PieceIDFromBytes(info.BlobRef().Key)
+			require.NoError(t, err)
+			deleted = append(deleted, pieceID)
+		}
+
+		require.NoError(t, indexed.Check(ctx, satellite))
+		require.ElementsMatch(t, pieceIDs, deleted)
+		require.Empty(t, indexPieceIDs(ctx, t, db.PieceIndexDB(), satellite))
+	})
+}
+
+func TestPieceIndexDBWalkBatches(t *testing.T) {
+	storagenodedbtest.Run(t, func(ctx *testcontext.Context, t *testing.T, db storagenode.DB) {
+		indexDB := db.PieceIndexDB()
+		satellite := testrand.NodeID()
+
+		var entries []pieces.PieceIndexEntry
+		var expected []storj.PieceID
+		for i := 0; i < 2500; i++ {
+			pieceID := testrand.PieceID()
+			entries = append(entries, pieces.PieceIndexEntry{
+				PieceID:       pieceID,
+				FormatVersion: filestore.FormatV1,
+				Size:          int64(i),
+				ModTime:       time.Now(),
+			})
+			expected = append(expected, pieceID)
+		}
+		require.NoError(t, indexDB.Put(ctx, satellite, entries, time.Now()))
+		// entries of other satellites aren't walked
+		require.NoError(t, indexDB.Put(ctx, testrand.NodeID(), entries[:10], time.Now()))
+
+		sort.Slice(expected, func(i, k int) bool { return bytes.Compare(expected[i][:], expected[k][:]) < 0 })
+		require.Equal(t, expected, indexPieceIDs(ctx, t, indexDB, satellite))
+
+		used, err := indexDB.SpaceUsed(ctx, satellite)
+		require.NoError(t, err)
+		require.EqualValues(t, 2499*2500/2, used)
+
+		// entries, which were checked later, aren't replaced
+		stale := entries[0]
+		stale.Size = 1000000
+		require.NoError(t, indexDB.Put(ctx, satellite, []pieces.PieceIndexEntry{stale}, time.Now().Add(-time.Hour)))
+		used, err = indexDB.SpaceUsed(ctx, satellite)
+		require.NoError(t, err)
+		require.EqualValues(t, 2499*2500/2, used)
+	})
+}
+
+func walkPieceIDs(ctx context.Context, t *testing.T, store *pieces.Store, satellite storj.NodeID) (pieceIDs []storj.PieceID) {
+	require.NoError(t, store.WalkSatellitePieces(ctx, satellite, func(access pieces.StoredPieceAccess) error {
+		pieceIDs = append(pieceIDs, access.PieceID())
+		return nil
+	}))
+	return pieceIDs
+}
+
+func indexPieceIDs(ctx context.Context, t *testing.T, db pieces.PieceIndexDB, satellite storj.NodeID) (pieceIDs []storj.PieceID) {
+	require.NoError(t, db.Walk(ctx, satellite, func(entry pieces.PieceIndexEntry) error {
+		pieceIDs = append(pieceIDs, entry.PieceID)
+		return nil
+	}))
+	return pieceIDs
+}
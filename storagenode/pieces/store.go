@@ -6,8 +6,10 @@ package pieces
 import (
 	"context"
 	"database/sql"
+	"fmt"
 	"io"
 	"os"
+	"path/filepath"
 	"time"
 
 	"github.com/spacemonkeygo/monkit/v3"
@@ -68,6 +70,8 @@ type PieceExpirationDB interface {
 	Trash(ctx context.Context, satelliteID storj.NodeID, pieceID storj.PieceID) error
 	// RestoreTrash marks all piece as not being in trash
 	RestoreTrash(ctx context.Context, satelliteID storj.NodeID) error
+	// RestoreTrashPiece marks a piece as not being in trash
+	RestoreTrashPiece(ctx context.Context, satelliteID storj.NodeID, pieceID storj.PieceID) error
 }
 
 // V0PieceInfoDB stores meta information about pieces stored with storage format V0 (where
@@ -124,6 +128,41 @@ type PieceSpaceUsedDB interface {
 	UpdateTrashTotal(ctx context.Context, newTotal int64) error
 }
 
+// PieceIndexEntry is an entry of the piece index.
+type PieceIndexEntry struct {
+	PieceID       storj.PieceID
+	FormatVersion storage.FormatVersion
+	// Size is the size of the blob, including the piece header.
+	Size    int64
+	ModTime time.Time
+	// Expiration is read from the piece expirations, it's zero when the piece doesn't expire.
+	Expiration time.Time
+}
+
+// PieceIndexDB stores an index of the pieces, which are stored in the blob store, so
+// that they can be walked without reading the directories.
+//
+// architecture: Database
+type PieceIndexDB interface {
+	// Put adds or replaces the index entries of the satellite's pieces and marks them as checked at the given time,
+	// unless they were checked later
+	Put(ctx context.Context, satellite storj.NodeID, entries []PieceIndexEntry, checkedAt time.Time) error
+	// Delete removes the index entries of the satellite's pieces
+	Delete(ctx context.Context, satellite storj.NodeID, pieceIDs []storj.PieceID) error
+	// DeleteSatellite removes all the index entries and the state of the satellite
+	DeleteSatellite(ctx context.Context, satellite storj.NodeID) error
+	// SetClean sets whether the index of the satellite is up to date with the blob store
+	SetClean(ctx context.Context, satellite storj.NodeID, clean bool) error
+	// IsClean returns whether the index of the satellite was marked clean
+	IsClean(ctx context.Context, satellite storj.NodeID) (bool, error)
+	// DeleteUncheckedBefore removes the index entries of the satellite, which weren't checked since the given time
+	DeleteUncheckedBefore(ctx context.Context, satellite storj.NodeID, checkedBefore time.Time) (int64, error)
+	// Walk calls walkFunc for every index entry of the satellite ordered by the piece ID
+	Walk(ctx context.Context, satellite storj.NodeID, walkFunc func(PieceIndexEntry) error) error
+	// SpaceUsed returns the total size of the satellite's indexed pieces
+	SpaceUsed(ctx context.Context, satellite storj.NodeID) (int64, error)
+}
+
 // StoredPieceAccess allows inspection and manipulation of a piece during iteration with
 // WalkSatellitePieces-type methods.
 type StoredPieceAccess interface {
@@ -157,6 +196,7 @@ type SatelliteUsage struct {
 type Config struct {
 	WritePreallocSize memory.Size `help:"file preallocated for uploading" default:"4MiB"`
 	DeleteToTrash     bool        `help:"move pieces to trash upon deletion. Warning: if set to false, you risk disqualification for failed audits if a satellite database is restored from backup." default:"true"`
+	EnableIndex       bool        `help:"maintain an index of the stored pieces, which is used for garbage collection and space calculations instead of walking the piece directories" default:"false"`
 }
 
 // DefaultConfig is the default value for the Config.
@@ -374,6 +414,40 @@ func (store *Store) Trash(ctx context.Context, satellite storj.NodeID, pieceID s
 	return Error.Wrap(err)
 }
 
+// Quarantine copies the specified piece into the directory dir and deletes it from the
+// store. Unlike the trash, the directory is never emptied or restored, so the piece is
+// kept for inspection until it's removed by the operator. It returns the path of the copy.
+func (store *Store) Quarantine(ctx context.Context, satellite storj.NodeID, pieceID storj.PieceID, formatVersion storage.FormatVersion, dir string) (path string, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	reader, err := store.blobs.OpenWithStorageFormat(ctx, storage.BlobRef{
+		Namespace: satellite.Bytes(),
+		Key:       pieceID.Bytes(),
+	}, formatVersion)
+	if err != nil {
+		return "", Error.Wrap(err)
+	}
+	defer func() { err = errs.Combine(err, Error.Wrap(reader.Close())) }()
+
+	satelliteDir := filepath.Join(dir, satellite.String())
+	if err := os.MkdirAll(satelliteDir, 0700); err != nil {
+		return "", Error.Wrap(err)
+	}
+	path = filepath.Join(satelliteDir, fmt.Sprintf("%s.sj%d", pieceID.String(), formatVersion))
+
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
+	if err != nil {
+		return "", Error.Wrap(err)
+	}
+	_, err = io.Copy(file, reader)
+	err = errs.Combine(err, file.Sync(), file.Close())
+	if err != nil {
+		return "", Error.Wrap(err)
+	}
+
+	return path, store.Delete(ctx, satellite, pieceID)
+}
+
 // EmptyTrash deletes pieces in the trash that have been in there longer than trashExpiryInterval.
 func (store *Store) EmptyTrash(ctx context.Context, satelliteID storj.NodeID, trashedBefore time.Time) (err error) {
 	defer mon.Task()(&ctx)(&err)
@@ -405,6 +479,53 @@ func (store *Store) RestoreTrash(ctx context.Context, satelliteID storj.NodeID)
 	return Error.Wrap(store.expirationInfo.RestoreTrash(ctx, satelliteID))
 }
 
+// RestoreTrashOptions restricts which pieces are restored from the trash. The zero value
+// restores all pieces.
+type RestoreTrashOptions struct {
+	// TrashedAfter restores only the pieces moved to the trash at or after it, when it isn't zero.
+	TrashedAfter time.Time
+	// PieceIDs restores only the listed pieces, when it isn't empty.
+	PieceIDs []storj.PieceID
+}
+
+// IsZero returns whether the options restore all pieces.
+func (opts RestoreTrashOptions) IsZero() bool {
+	return opts.TrashedAfter.IsZero() && len(opts.PieceIDs) == 0
+}
+
+// RestoreTrashWithOptions restores the pieces in the trash, which are selected by the options.
+// It allows restoring only the pieces trashed by a bad garbage collection, without restoring
+// the pieces which were deleted legitimately before.
+func (store *Store) RestoreTrashWithOptions(ctx context.Context, satelliteID storj.NodeID, opts RestoreTrashOptions) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if opts.IsZero() {
+		return store.RestoreTrash(ctx, satelliteID)
+	}
+
+	blobOpts := storage.RestoreTrashOptions{
+		TrashedAfter: opts.TrashedAfter,
+	}
+	for _, pieceID := range opts.PieceIDs {
+		blobOpts.Keys = append(blobOpts.Keys, pieceID.Bytes())
+	}
+
+	keysRestored, err := store.blobs.RestoreTrashWithOptions(ctx, satelliteID.Bytes(), blobOpts)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	for _, key := range keysRestored {
+		pieceID, parseErr := storj.PieceIDFromBytes(key)
+		if parseErr != nil {
+			err = errs.Combine(err, parseErr)
+			continue
+		}
+		err = errs.Combine(err, store.expirationInfo.RestoreTrashPiece(ctx, satelliteID, pieceID))
+	}
+	return Error.Wrap(err)
+}
+
 // MigrateV0ToV1 will migrate a piece stored with storage format v0 to storage
 // format v1. If the piece is not stored as a v0 piece it will return an error.
 // The follow failures are possible:
@@ -708,6 +829,8 @@ func (store *Store) GetV0PieceInfo(ctx context.Context, satellite storj.NodeID,
 type StorageStatus struct {
 	DiskUsed int64
 	DiskFree int64
+	// Disks is the status of every disk, when the pieces are stored on several disks.
+	Disks []filestore.DiskStatus
 }
 
 // StorageStatus returns information about the disk.
@@ -717,12 +840,28 @@ func (store *Store) StorageStatus(ctx context.Context) (_ StorageStatus, err err
 	if err != nil {
 		return StorageStatus{}, err
 	}
+	disks, err := diskStatuses(ctx, store.blobs)
+	if err != nil {
+		return StorageStatus{}, err
+	}
 	return StorageStatus{
 		DiskUsed: -1, // TODO set value
 		DiskFree: diskFree,
+		Disks:    disks,
 	}, nil
 }
 
+// diskStatuses returns the status of every disk, when the blobs are stored on several disks.
+func diskStatuses(ctx context.Context, blobs storage.Blobs) ([]filestore.DiskStatus, error) {
+	disks, ok := blobs.(interface {
+		DiskStatuses(ctx context.Context) ([]filestore.DiskStatus, error)
+	})
+	if !ok {
+		return nil, nil
+	}
+	return disks.DiskStatuses(ctx)
+}
+
 // CheckWritability tests writability of the storage directory by creating and deleting a file.
 func (store *Store) CheckWritability(ctx context.Context) error {
 	return store.blobs.CheckWritability(ctx)
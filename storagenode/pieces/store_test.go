@@ -468,6 +468,76 @@ func TestTrashAndRestore(t *testing.T) {
 	})
 }
 
+func TestRestoreTrashWithOptions(t *testing.T) {
+	storagenodedbtest.Run(t, func(ctx *testcontext.Context, t *testing.T, db storagenode.DB) {
+		dir, err := filestore.NewDir(zaptest.NewLogger(t), ctx.Dir("store"))
+		require.NoError(t, err)
+
+		blobs := filestore.New(zaptest.NewLogger(t), dir, filestore.DefaultConfig)
+		defer ctx.Check(blobs.Close)
+
+		store := pieces.NewStore(zaptest.NewLogger(t), blobs, nil, db.PieceExpirationDB(), nil, pieces.DefaultConfig)
+
+		satelliteID := testrand.NodeID()
+		now := time.Now()
+		expiration := now.Add(24 * time.Hour)
+
+		// the first piece was trashed earlier than the others.
+		pieceIDs := []storj.PieceID{testrand.PieceID(), testrand.PieceID(), testrand.PieceID()}
+		for i, pieceID := range pieceIDs {
+			writeAPiece(ctx, t, store, satelliteID, pieceID, testrand.Bytes(memory.KB), now, &expiration, filestore.FormatV1)
+			require.NoError(t, store.SetExpiration(ctx, satelliteID, pieceID, expiration))
+
+			trashedAt := now.Add(-30 * time.Minute)
+			if i == 0 {
+				trashedAt = now.Add(-2 * time.Hour)
+			}
+			dir.ReplaceTrashnow(func() time.Time { return trashedAt })
+			require.NoError(t, store.Trash(ctx, satelliteID, pieceID))
+		}
+
+		requireRestored := func(restored ...bool) {
+			t.Helper()
+
+			expired, err := store.GetExpired(ctx, expiration.Add(time.Hour), 1000)
+			require.NoError(t, err)
+
+			for i, pieceID := range pieceIDs {
+				r, err := store.Reader(ctx, satelliteID, pieceID)
+				if restored[i] {
+					require.NoError(t, err)
+					require.NoError(t, r.Close())
+				} else {
+					require.Error(t, err)
+				}
+
+				var found bool
+				for _, info := range expired {
+					found = found || info.PieceID == pieceID
+				}
+				require.Equal(t, restored[i], found)
+			}
+		}
+		requireRestored(false, false, false)
+
+		// only the listed pieces trashed after the time are restored.
+		require.NoError(t, store.RestoreTrashWithOptions(ctx, satelliteID, pieces.RestoreTrashOptions{
+			TrashedAfter: now.Add(-time.Hour),
+			PieceIDs:     []storj.PieceID{pieceIDs[0], pieceIDs[1]},
+		}))
+		requireRestored(false, true, false)
+
+		require.NoError(t, store.RestoreTrashWithOptions(ctx, satelliteID, pieces.RestoreTrashOptions{
+			TrashedAfter: now.Add(-time.Hour),
+		}))
+		requireRestored(false, true, true)
+
+		// the zero options restore everything.
+		require.NoError(t, store.RestoreTrashWithOptions(ctx, satelliteID, pieces.RestoreTrashOptions{}))
+		requireRestored(true, true, true)
+	})
+}
+
 func verifyPieceData(ctx context.Context, t testing.TB, store *pieces.Store, satelliteID storj.NodeID, pieceID storj.PieceID, formatVer storage.FormatVersion, expected []byte, expiration time.Time, publicKey storj.PiecePublicKey) {
 	r, err := store.ReaderWithStorageFormat(ctx, satelliteID, pieceID, formatVer)
 	require.NoError(t, err)
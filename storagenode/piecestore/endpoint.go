@@ -28,11 +28,13 @@ import (
 	"storj.io/common/storj"
 	"storj.io/common/sync2"
 	"storj.io/drpc"
+	"storj.io/storj/private/nodeextpb"
 	"storj.io/storj/storagenode/bandwidth"
 	"storj.io/storj/storagenode/monitor"
 	"storj.io/storj/storagenode/orders"
 	"storj.io/storj/storagenode/orders/ordersfile"
 	"storj.io/storj/storagenode/pieces"
+	"storj.io/storj/storagenode/piecestore/trafficlimit"
 	"storj.io/storj/storagenode/piecestore/usedserials"
 	"storj.io/storj/storagenode/retain"
 	"storj.io/storj/storagenode/trust"
@@ -45,6 +47,7 @@ var (
 // OldConfig contains everything necessary for a server.
 type OldConfig struct {
 	Path                   string         `help:"path to store data in" default:"$CONFDIR/storage"`
+	ExtraPaths             []string       `help:"additional existing paths to store pieces in, usually on other disks. new pieces are stored in the healthy path with the most free space" default:""`
 	WhitelistedSatellites  storj.NodeURLs `help:"a comma-separated list of approved satellite node urls (unused)" devDefault:"" releaseDefault:""`
 	AllocatedDiskSpace     memory.Size    `user:"true" help:"total allocated disk space in bytes" default:"1TB"`
 	AllocatedBandwidth     memory.Size    `user:"true" help:"total allocated bandwidth in bytes (deprecated)" default:"0B"`
@@ -70,7 +73,8 @@ type Config struct {
 	MinUploadSpeedGraceDuration       time.Duration `help:"if MinUploadSpeed is configured, after a period of time after the client initiated the upload, the server will flag unusually slow upload client" default:"0h0m10s"`
 	MinUploadSpeedCongestionThreshold float64       `help:"if the portion defined by the total number of alive connection per MaxConcurrentRequest reaches this threshold, a slow upload client will no longer be monitored and flagged" default:"0.8"`
 
-	Trust trust.Config
+	Trust        trust.Config
+	TrafficLimit trafficlimit.Config
 
 	Monitor monitor.Config
 	Orders  orders.Config
@@ -100,12 +104,13 @@ type Endpoint struct {
 	usage        bandwidth.DB
 	usedSerials  *usedserials.Table
 	pieceDeleter *pieces.Deleter
+	limiter      *trafficlimit.Limiter
 
 	liveRequests int32
 }
 
 // NewEndpoint creates a new piecestore endpoint.
-func NewEndpoint(log *zap.Logger, signer signing.Signer, trust *trust.Pool, monitor *monitor.Service, retain *retain.Service, pingStats pingStatsSource, store *pieces.Store, pieceDeleter *pieces.Deleter, ordersStore *orders.FileStore, usage bandwidth.DB, usedSerials *usedserials.Table, config Config) (*Endpoint, error) {
+func NewEndpoint(log *zap.Logger, signer signing.Signer, trust *trust.Pool, monitor *monitor.Service, retain *retain.Service, pingStats pingStatsSource, store *pieces.Store, pieceDeleter *pieces.Deleter, ordersStore *orders.FileStore, usage bandwidth.DB, usedSerials *usedserials.Table, limiter *trafficlimit.Limiter, config Config) (*Endpoint, error) {
 	return &Endpoint{
 		log:    log,
 		config: config,
@@ -121,6 +126,7 @@ func NewEndpoint(log *zap.Logger, signer signing.Signer, trust *trust.Pool, moni
 		usage:        usage,
 		usedSerials:  usedSerials,
 		pieceDeleter: pieceDeleter,
+		limiter:      limiter,
 
 		liveRequests: 0,
 	}, nil
@@ -237,6 +243,11 @@ func (endpoint *Endpoint) Upload(stream pb.DRPCPiecestore_UploadStream) (err err
 		return err
 	}
 
+	class := trafficlimit.ClassOf(limit.Action)
+	if err := endpoint.limiter.WaitOperation(ctx, limit.SatelliteId, class); err != nil {
+		return rpcstatus.Wrap(rpcstatus.Unavailable, err)
+	}
+
 	availableSpace, err := endpoint.monitor.AvailableSpace(ctx)
 	if err != nil {
 		return rpcstatus.Wrap(rpcstatus.Internal, err)
@@ -382,6 +393,9 @@ func (endpoint *Endpoint) Upload(stream pb.DRPCPiecestore_UploadStream) (err err
 			if availableSpace < 0 {
 				return rpcstatus.Error(rpcstatus.Internal, "out of space")
 			}
+			if err := endpoint.limiter.WaitIngress(ctx, limit.SatelliteId, class, chunkSize); err != nil {
+				return rpcstatus.Wrap(rpcstatus.Unavailable, err)
+			}
 			if _, err := pieceWriter.Write(message.Chunk.Data); err != nil {
 				return rpcstatus.Wrap(rpcstatus.Internal, err)
 			}
@@ -510,6 +524,8 @@ func (endpoint *Endpoint) Download(stream pb.DRPCPiecestore_DownloadStream) (err
 		return err
 	}
 
+	class := trafficlimit.ClassOf(limit.Action)
+
 	var pieceReader *pieces.Reader
 	defer func() {
 		endTime := time.Now().UTC()
@@ -547,6 +563,10 @@ func (endpoint *Endpoint) Download(stream pb.DRPCPiecestore_DownloadStream) (err
 		}
 	}()
 
+	if err := endpoint.limiter.WaitOperation(ctx, limit.SatelliteId, class); err != nil {
+		return rpcstatus.Wrap(rpcstatus.Unavailable, err)
+	}
+
 	pieceReader, err = endpoint.store.Reader(ctx, limit.SatelliteId, limit.PieceId)
 	if err != nil {
 		if os.IsNotExist(err) {
@@ -610,6 +630,13 @@ func (endpoint *Endpoint) Download(stream pb.DRPCPiecestore_DownloadStream) (err
 				return nil //nolint: nilerr // We don't need to return an error when client cancels.
 			}
 
+			if err := endpoint.limiter.WaitEgress(ctx, limit.SatelliteId, class, chunkSize); err != nil {
+				if errs2.IsCanceled(err) {
+					return nil
+				}
+				return rpcstatus.Wrap(rpcstatus.Unavailable, err)
+			}
+
 			chunkData := make([]byte, chunkSize)
 			_, err = pieceReader.Seek(currentOffset, io.SeekStart)
 			if err != nil {
@@ -740,25 +767,57 @@ func (endpoint *Endpoint) beginSaveOrder(limit *pb.OrderLimit) (_commit func(ctx
 func (endpoint *Endpoint) RestoreTrash(ctx context.Context, restoreTrashReq *pb.RestoreTrashRequest) (res *pb.RestoreTrashResponse, err error) {
 	defer mon.Task()(&ctx)(&err)
 
+	err = endpoint.restoreTrash(ctx, pieces.RestoreTrashOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return &pb.RestoreTrashResponse{}, nil
+}
+
+// RestoreTrashWithOptions restores the trashed items for the satellite issuing the call,
+// which are selected by the options of the request.
+func (endpoint *Endpoint) RestoreTrashWithOptions(ctx context.Context, req *nodeextpb.RestoreTrashWithOptionsRequest) (_ *nodeextpb.RestoreTrashWithOptionsResponse, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	opts := pieces.RestoreTrashOptions{
+		PieceIDs: req.PieceIds,
+	}
+	if req.TrashedAfter != nil {
+		opts.TrashedAfter = *req.TrashedAfter
+	}
+
+	err = endpoint.restoreTrash(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	return &nodeextpb.RestoreTrashWithOptionsResponse{}, nil
+}
+
+// restoreTrash restores the trashed items for the satellite issuing the call, which
+// are selected by the options.
+func (endpoint *Endpoint) restoreTrash(ctx context.Context, opts pieces.RestoreTrashOptions) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
 	peer, err := identity.PeerIdentityFromContext(ctx)
 	if err != nil {
-		return nil, rpcstatus.Wrap(rpcstatus.Unauthenticated, err)
+		return rpcstatus.Wrap(rpcstatus.Unauthenticated, err)
 	}
 
 	err = endpoint.trust.VerifySatelliteID(ctx, peer.ID)
 	if err != nil {
-		return nil, rpcstatus.Error(rpcstatus.PermissionDenied, "RestoreTrash called with untrusted ID")
+		return rpcstatus.Error(rpcstatus.PermissionDenied, "RestoreTrash called with untrusted ID")
 	}
 
-	endpoint.log.Info("restore trash started", zap.Stringer("Satellite ID", peer.ID))
-	err = endpoint.store.RestoreTrash(ctx, peer.ID)
+	endpoint.log.Info("restore trash started", zap.Stringer("Satellite ID", peer.ID),
+		zap.Time("Trashed After", opts.TrashedAfter), zap.Int("Piece IDs", len(opts.PieceIDs)))
+	err = endpoint.store.RestoreTrashWithOptions(ctx, peer.ID, opts)
 	if err != nil {
 		endpoint.log.Error("restore trash failed", zap.Stringer("Satellite ID", peer.ID), zap.Error(err))
-		return nil, rpcstatus.Wrap(rpcstatus.Internal, err)
+		return rpcstatus.Wrap(rpcstatus.Internal, err)
 	}
 	endpoint.log.Info("restore trash finished", zap.Stringer("Satellite ID", peer.ID))
 
-	return &pb.RestoreTrashResponse{}, nil
+	return nil
 }
 
 // Retain keeps only piece ids specified in the request.
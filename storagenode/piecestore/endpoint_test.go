@@ -25,6 +25,7 @@ import (
 	"storj.io/common/storj"
 	"storj.io/common/testcontext"
 	"storj.io/common/testrand"
+	"storj.io/storj/private/nodeextpb"
 	"storj.io/storj/private/testblobs"
 	"storj.io/storj/private/testplanet"
 	"storj.io/storj/storagenode"
@@ -626,6 +627,56 @@ func TestDeletePieces(t *testing.T) {
 	})
 }
 
+func TestRestoreTrashWithOptions(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount: 1, StorageNodeCount: 1, UplinkCount: 0,
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		satellite := planet.Satellites[0]
+		node := planet.StorageNodes[0]
+		store := node.Storage2.Store
+
+		var pieceIDs []storj.PieceID
+		for i := 0; i < 2; i++ {
+			pieceID := testrand.PieceID()
+			writer, err := store.Writer(ctx, satellite.ID(), pieceID, pb.PieceHashAlgorithm_SHA256)
+			require.NoError(t, err)
+			_, err = writer.Write(testrand.Bytes(memory.KiB))
+			require.NoError(t, err)
+			require.NoError(t, writer.Commit(ctx, &pb.PieceHeader{}))
+			require.NoError(t, store.Trash(ctx, satellite.ID(), pieceID))
+			pieceIDs = append(pieceIDs, pieceID)
+		}
+
+		conn, err := satellite.Dialer.DialNodeURL(ctx, node.NodeURL())
+		require.NoError(t, err)
+		defer ctx.Check(conn.Close)
+
+		client := nodeextpb.NewDRPCPiecestoreClient(conn)
+
+		// no piece is trashed after the time.
+		trashedAfter := time.Now().Add(time.Hour)
+		_, err = client.RestoreTrashWithOptions(ctx, &nodeextpb.RestoreTrashWithOptionsRequest{
+			TrashedAfter: &trashedAfter,
+		})
+		require.NoError(t, err)
+		_, err = store.Reader(ctx, satellite.ID(), pieceIDs[0])
+		require.Error(t, err)
+
+		// only the listed piece is restored.
+		_, err = client.RestoreTrashWithOptions(ctx, &nodeextpb.RestoreTrashWithOptionsRequest{
+			PieceIds: pieceIDs[:1],
+		})
+		require.NoError(t, err)
+
+		reader, err := store.Reader(ctx, satellite.ID(), pieceIDs[0])
+		require.NoError(t, err)
+		require.NoError(t, reader.Close())
+
+		_, err = store.Reader(ctx, satellite.ID(), pieceIDs[1])
+		require.Error(t, err)
+	})
+}
+
 func TestTooManyRequests(t *testing.T) {
 	const uplinkCount = 6
 	const maxConcurrent = 3
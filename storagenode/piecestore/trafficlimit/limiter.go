@@ -0,0 +1,202 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+// Package trafficlimit implements token bucket limits for the traffic and the piece operations
+// of a storage node.
+package trafficlimit
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/spacemonkeygo/monkit/v3"
+	"github.com/zeebo/errs"
+	"golang.org/x/time/rate"
+
+	"storj.io/common/memory"
+	"storj.io/common/pb"
+	"storj.io/common/storj"
+)
+
+var (
+	mon = monkit.Package()
+
+	// Error is the default error class for throttling errors.
+	Error = errs.Class("trafficlimit")
+)
+
+// Budget contains the limits of a class of traffic. A zero limit is unlimited.
+type Budget struct {
+	Ingress    memory.Size `help:"maximum amount of piece data received per second. 0 is unlimited" default:"0B"`
+	Egress     memory.Size `help:"maximum amount of piece data sent per second. 0 is unlimited" default:"0B"`
+	Operations int         `help:"maximum number of piece operations started per second. 0 is unlimited" default:"0"`
+}
+
+// Config defines the limits for customer and background traffic.
+type Config struct {
+	PerSatellite bool `help:"if set to true, the limits apply to every satellite separately, instead of to all satellites together" default:"false"`
+	Customer     Budget
+	Background   Budget
+}
+
+// Class is the class of traffic, which has its own budget.
+type Class int
+
+const (
+	// Unlimited traffic isn't throttled, e.g. audits, which must not time out.
+	Unlimited Class = iota
+	// Customer traffic are uploads and downloads of customers.
+	Customer
+	// Background traffic is repair traffic and garbage collection.
+	Background
+)
+
+// ClassOf returns the class of traffic of the piece action.
+func ClassOf(action pb.PieceAction) Class {
+	switch action {
+	case pb.PieceAction_PUT, pb.PieceAction_GET:
+		return Customer
+	case pb.PieceAction_PUT_REPAIR, pb.PieceAction_GET_REPAIR:
+		return Background
+	default:
+		return Unlimited
+	}
+}
+
+// String implements fmt.Stringer.
+func (class Class) String() string {
+	switch class {
+	case Unlimited:
+		return "unlimited"
+	case Customer:
+		return "customer"
+	case Background:
+		return "background"
+	default:
+		return "unknown"
+	}
+}
+
+// waitDurations are the durations of waiting for the buckets of a class, indexed by the class.
+var waitDurations = [...]*monkit.DurationVal{
+	Unlimited:  mon.DurationVal("trafficlimit_wait_duration", monkit.NewSeriesTag("class", Unlimited.String())),
+	Customer:   mon.DurationVal("trafficlimit_wait_duration", monkit.NewSeriesTag("class", Customer.String())),
+	Background: mon.DurationVal("trafficlimit_wait_duration", monkit.NewSeriesTag("class", Background.String())),
+}
+
+// buckets are the token buckets of a budget.
+type buckets struct {
+	ingress    *rate.Limiter
+	egress     *rate.Limiter
+	operations *rate.Limiter
+}
+
+// bucketsKey identifies the buckets of a class of a satellite, or of all satellites.
+type bucketsKey struct {
+	satellite storj.NodeID
+	class     Class
+}
+
+// Limiter throttles the traffic and the piece operations with token buckets.
+//
+// A nil Limiter doesn't limit anything.
+type Limiter struct {
+	config Config
+
+	mu      sync.Mutex
+	buckets map[bucketsKey]*buckets
+}
+
+// NewLimiter creates a new limiter.
+func NewLimiter(config Config) *Limiter {
+	return &Limiter{
+		config:  config,
+		buckets: make(map[bucketsKey]*buckets),
+	}
+}
+
+// WaitIngress waits until n bytes of the class may be received from the satellite.
+func (limiter *Limiter) WaitIngress(ctx context.Context, satellite storj.NodeID, class Class, n int64) error {
+	if limiter == nil || class == Unlimited {
+		return nil
+	}
+	return wait(ctx, limiter.get(satellite, class).ingress, class, n)
+}
+
+// WaitEgress waits until n bytes of the class may be sent for the satellite.
+func (limiter *Limiter) WaitEgress(ctx context.Context, satellite storj.NodeID, class Class, n int64) error {
+	if limiter == nil || class == Unlimited {
+		return nil
+	}
+	return wait(ctx, limiter.get(satellite, class).egress, class, n)
+}
+
+// WaitOperation waits until a piece operation of the class may be started for the satellite.
+func (limiter *Limiter) WaitOperation(ctx context.Context, satellite storj.NodeID, class Class) error {
+	if limiter == nil || class == Unlimited {
+		return nil
+	}
+	return wait(ctx, limiter.get(satellite, class).operations, class, 1)
+}
+
+// get returns the buckets of the class for the satellite, creating them when necessary.
+func (limiter *Limiter) get(satellite storj.NodeID, class Class) *buckets {
+	key := bucketsKey{class: class}
+	if limiter.config.PerSatellite {
+		key.satellite = satellite
+	}
+
+	limiter.mu.Lock()
+	defer limiter.mu.Unlock()
+
+	b, ok := limiter.buckets[key]
+	if !ok {
+		budget := limiter.config.Customer
+		if class == Background {
+			budget = limiter.config.Background
+		}
+		b = &buckets{
+			ingress:    newBucket(budget.Ingress.Int64()),
+			egress:     newBucket(budget.Egress.Int64()),
+			operations: newBucket(int64(budget.Operations)),
+		}
+		limiter.buckets[key] = b
+	}
+	return b
+}
+
+// newBucket creates a token bucket, which holds the tokens of a second. A non-positive
+// rate returns nil, which is unlimited.
+func newBucket(perSecond int64) *rate.Limiter {
+	if perSecond <= 0 {
+		return nil
+	}
+	return rate.NewLimiter(rate.Limit(perSecond), int(perSecond))
+}
+
+// wait takes n tokens from the bucket. Amounts above the bucket size are taken in several steps.
+func wait(ctx context.Context, bucket *rate.Limiter, class Class, n int64) error {
+	if bucket == nil || n <= 0 {
+		return nil
+	}
+
+	start := time.Now()
+	defer func() { waitDurations[class].Observe(time.Since(start)) }()
+
+	burst := int64(bucket.Burst())
+	for n > 0 {
+		take := n
+		if take > burst {
+			take = burst
+		}
+		if err := bucket.WaitN(ctx, int(take)); err != nil {
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return ctxErr
+			}
+			return Error.Wrap(err)
+		}
+		n -= take
+	}
+	return nil
+}
@@ -0,0 +1,96 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package trafficlimit_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"storj.io/common/memory"
+	"storj.io/common/pb"
+	"storj.io/common/testcontext"
+	"storj.io/common/testrand"
+	"storj.io/storj/storagenode/piecestore/trafficlimit"
+)
+
+func TestClassOf(t *testing.T) {
+	require.Equal(t, trafficlimit.Customer, trafficlimit.ClassOf(pb.PieceAction_PUT))
+	require.Equal(t, trafficlimit.Customer, trafficlimit.ClassOf(pb.PieceAction_GET))
+	require.Equal(t, trafficlimit.Background, trafficlimit.ClassOf(pb.PieceAction_PUT_REPAIR))
+	require.Equal(t, trafficlimit.Background, trafficlimit.ClassOf(pb.PieceAction_GET_REPAIR))
+	require.Equal(t, trafficlimit.Unlimited, trafficlimit.ClassOf(pb.PieceAction_GET_AUDIT))
+}
+
+func TestLimiter(t *testing.T) {
+	ctx := testcontext.New(t)
+
+	satellite, other := testrand.NodeID(), testrand.NodeID()
+
+	// nil limiters and unlimited traffic don't wait
+	var unset *trafficlimit.Limiter
+	require.NoError(t, unset.WaitIngress(ctx, satellite, trafficlimit.Customer, memory.GiB.Int64()))
+
+	limiter := trafficlimit.NewLimiter(trafficlimit.Config{
+		PerSatellite: true,
+		Customer: trafficlimit.Budget{
+			Ingress:    10 * memory.KiB,
+			Egress:     10 * memory.KiB,
+			Operations: 10,
+		},
+	})
+	require.NoError(t, limiter.WaitIngress(ctx, satellite, trafficlimit.Unlimited, memory.GiB.Int64()))
+	require.NoError(t, limiter.WaitEgress(ctx, satellite, trafficlimit.Background, memory.GiB.Int64()))
+
+	// the bucket holds a second of traffic
+	start := time.Now()
+	require.NoError(t, limiter.WaitIngress(ctx, satellite, trafficlimit.Customer, 10*memory.KiB.Int64()))
+	require.Less(t, time.Since(start), 500*time.Millisecond)
+
+	// the budget of another satellite isn't affected
+	require.NoError(t, limiter.WaitIngress(ctx, other, trafficlimit.Customer, 10*memory.KiB.Int64()))
+	require.Less(t, time.Since(start), 500*time.Millisecond)
+
+	// ingress and egress have separate budgets
+	require.NoError(t, limiter.WaitEgress(ctx, satellite, trafficlimit.Customer, 10*memory.KiB.Int64()))
+	require.Less(t, time.Since(start), 500*time.Millisecond)
+
+	// more traffic has to wait for the bucket to refill
+	require.NoError(t, limiter.WaitIngress(ctx, satellite, trafficlimit.Customer, 2*memory.KiB.Int64()))
+	require.Greater(t, time.Since(start), 150*time.Millisecond)
+
+	// waiting is canceled with the context
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	require.ErrorIs(t, limiter.WaitIngress(canceled, satellite, trafficlimit.Customer, 10*memory.KiB.Int64()), context.Canceled)
+
+	// operations are limited per second
+	for i := 0; i < 10; i++ {
+		require.NoError(t, limiter.WaitOperation(ctx, other, trafficlimit.Customer))
+	}
+	start = time.Now()
+	require.NoError(t, limiter.WaitOperation(ctx, other, trafficlimit.Customer))
+	require.Greater(t, time.Since(start), 50*time.Millisecond)
+}
+
+func TestLimiterShared(t *testing.T) {
+	ctx := testcontext.New(t)
+
+	limiter := trafficlimit.NewLimiter(trafficlimit.Config{
+		Background: trafficlimit.Budget{Egress: 10 * memory.KiB},
+	})
+
+	// without per satellite limits, all satellites share the budget
+	start := time.Now()
+	require.NoError(t, limiter.WaitEgress(ctx, testrand.NodeID(), trafficlimit.Background, 10*memory.KiB.Int64()))
+	require.NoError(t, limiter.WaitEgress(ctx, testrand.NodeID(), trafficlimit.Background, 2*memory.KiB.Int64()))
+	require.Greater(t, time.Since(start), 150*time.Millisecond)
+
+	// amounts above the bucket size are taken in several steps
+	start = time.Now()
+	require.NoError(t, limiter.WaitEgress(ctx, testrand.NodeID(), trafficlimit.Background, 12*memory.KiB.Int64()))
+	require.Greater(t, time.Since(start), time.Second)
+}
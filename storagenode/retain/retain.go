@@ -17,6 +17,7 @@ import (
 	"storj.io/common/bloomfilter"
 	"storj.io/common/storj"
 	"storj.io/storj/storagenode/pieces"
+	"storj.io/storj/storagenode/piecestore/trafficlimit"
 )
 
 var (
@@ -100,11 +101,12 @@ type Service struct {
 	closed     chan struct{}
 	started    bool
 
-	store *pieces.Store
+	store   *pieces.Store
+	limiter *trafficlimit.Limiter
 }
 
 // NewService creates a new retain service.
-func NewService(log *zap.Logger, store *pieces.Store, config Config) *Service {
+func NewService(log *zap.Logger, store *pieces.Store, limiter *trafficlimit.Limiter, config Config) *Service {
 	return &Service{
 		log:    log,
 		config: config,
@@ -114,7 +116,8 @@ func NewService(log *zap.Logger, store *pieces.Store, config Config) *Service {
 		working: make(map[storj.NodeID]struct{}),
 		closed:  make(chan struct{}),
 
-		store: store,
+		store:   store,
+		limiter: limiter,
 	}
 }
 
@@ -438,6 +441,9 @@ func (s *Service) retainPieces(ctx context.Context, req Request) (err error) {
 // trash wraps retains piece deletion to monitor moving retained piece to trash error during garbage collection.
 func (s *Service) trash(ctx context.Context, satelliteID storj.NodeID, pieceID storj.PieceID) (err error) {
 	defer mon.Task()(&ctx, satelliteID)(&err)
+	if err := s.limiter.WaitOperation(ctx, satelliteID, trafficlimit.Background); err != nil {
+		return err
+	}
 	return s.store.Trash(ctx, satelliteID, pieceID)
 }
 
@@ -104,19 +104,19 @@ func TestRetainPieces(t *testing.T) {
 			}
 		}
 
-		retainEnabled := retain.NewService(zaptest.NewLogger(t), store, retain.Config{
+		retainEnabled := retain.NewService(zaptest.NewLogger(t), store, nil, retain.Config{
 			Status:      retain.Enabled,
 			Concurrency: 1,
 			MaxTimeSkew: 0,
 		})
 
-		retainDisabled := retain.NewService(zaptest.NewLogger(t), store, retain.Config{
+		retainDisabled := retain.NewService(zaptest.NewLogger(t), store, nil, retain.Config{
 			Status:      retain.Disabled,
 			Concurrency: 1,
 			MaxTimeSkew: 0,
 		})
 
-		retainDebug := retain.NewService(zaptest.NewLogger(t), store, retain.Config{
+		retainDebug := retain.NewService(zaptest.NewLogger(t), store, nil, retain.Config{
 			Status:      retain.Debug,
 			Concurrency: 1,
 			MaxTimeSkew: 0,
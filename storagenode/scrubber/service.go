@@ -0,0 +1,323 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+// Package scrubber implements the background verification of stored pieces against their hashes.
+package scrubber
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/spacemonkeygo/monkit/v3"
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	"storj.io/common/errs2"
+	"storj.io/common/memory"
+	"storj.io/common/pb"
+	"storj.io/common/storj"
+	"storj.io/common/sync2"
+	"storj.io/storj/storagenode/pieces"
+	"storj.io/storj/storagenode/trust"
+)
+
+var (
+	mon = monkit.Package()
+
+	// Error is the default error class for the scrubber.
+	Error = errs.Class("scrubber")
+)
+
+// maxFindings is the number of most recent findings, which are kept for reporting.
+const maxFindings = 100
+
+// readBufferSize is the size of the chunks in which piece content is read and throttled.
+const readBufferSize = 256 * memory.KiB
+
+// Config defines parameters for the piece scrubber.
+type Config struct {
+	Enabled  bool          `help:"whether stored pieces are periodically verified against their hashes" default:"false"`
+	Interval time.Duration `help:"how frequently a scrub of all stored pieces is started" default:"168h0m0s"`
+	ReadRate memory.Size   `help:"maximum amount of piece data read per second while scrubbing" default:"4MiB"`
+}
+
+// Progress is the progress of a single scrub of all stored pieces.
+type Progress struct {
+	StartedAt  time.Time `json:"startedAt"`
+	FinishedAt time.Time `json:"finishedAt"`
+	// BytesTotal is an approximation of the piece data, which is verified by the scrub.
+	BytesTotal    int64 `json:"bytesTotal"`
+	BytesChecked  int64 `json:"bytesChecked"`
+	PiecesChecked int64 `json:"piecesChecked"`
+	PiecesCorrupt int64 `json:"piecesCorrupt"`
+	PiecesFailed  int64 `json:"piecesFailed"`
+}
+
+// Finding is a piece, which is corrupt or couldn't be verified.
+type Finding struct {
+	SatelliteID storj.NodeID  `json:"satelliteId"`
+	PieceID     storj.PieceID `json:"pieceId"`
+	Path        string        `json:"path"`
+	FoundAt     time.Time     `json:"foundAt"`
+	Error       string        `json:"error"`
+	// Quarantined is set, when the piece was moved to the quarantine directory because its
+	// content doesn't match its hash. Path is then the path of the quarantined copy.
+	Quarantined bool `json:"quarantined"`
+}
+
+// Status contains the progress and the findings of the scrubber.
+type Status struct {
+	Enabled bool `json:"enabled"`
+	// Current is the progress of the running scrub, if any.
+	Current *Progress `json:"current"`
+	// Last is the progress of the last finished scrub, if any.
+	Last *Progress `json:"last"`
+	// Findings are the most recent findings, newest first.
+	Findings []Finding `json:"findings"`
+}
+
+// Service periodically reads all stored pieces, recomputes their hashes and compares them
+// with the hashes stored with the pieces. Corrupt pieces are moved to a quarantine directory,
+// so that they aren't served anymore. The quarantine directory isn't touched by emptying or
+// restoring the trash, so a corrupt piece is never restored into the store.
+//
+// The progress isn't persisted, so a restart of the node starts a new scrub. On nodes, which
+// restart more frequently than a scrub finishes, the pieces walked last are never verified,
+// which is why the scrubber is disabled by default.
+//
+// architecture: Chore
+type Service struct {
+	log        *zap.Logger
+	store      *pieces.Store
+	trust      *trust.Pool
+	quarantine string
+	config     Config
+
+	mu       sync.Mutex
+	current  *Progress
+	last     *Progress
+	findings []Finding
+
+	Loop *sync2.Cycle
+}
+
+// NewService creates a new piece scrubber, which moves corrupt pieces into the directory
+// quarantine.
+func NewService(log *zap.Logger, store *pieces.Store, trust *trust.Pool, quarantine string, config Config) *Service {
+	return &Service{
+		log:        log,
+		store:      store,
+		trust:      trust,
+		quarantine: quarantine,
+		config:     config,
+		Loop:       sync2.NewCycle(config.Interval),
+	}
+}
+
+// Run runs the scrubber.
+func (service *Service) Run(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	return service.Loop.Run(ctx, func(ctx context.Context) error {
+		err := service.Scrub(ctx)
+		if err != nil && !errs2.IsCanceled(err) {
+			service.log.Error("error during scrubbing pieces", zap.Error(err))
+		}
+		return nil
+	})
+}
+
+// Close stops the scrubber.
+func (service *Service) Close() (err error) {
+	service.Loop.Close()
+	return nil
+}
+
+// Status returns the progress and the findings of the scrubber.
+func (service *Service) Status() Status {
+	service.mu.Lock()
+	defer service.mu.Unlock()
+
+	status := Status{
+		Enabled:  service.config.Enabled,
+		Findings: append([]Finding(nil), service.findings...),
+	}
+	if service.current != nil {
+		current := *service.current
+		status.Current = &current
+	}
+	if service.last != nil {
+		last := *service.last
+		status.Last = &last
+	}
+	return status
+}
+
+// Scrub verifies all pieces of the trusted satellites once.
+func (service *Service) Scrub(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	progress := Progress{StartedAt: time.Now()}
+	_, progress.BytesTotal, err = service.store.SpaceUsedForPieces(ctx)
+	if err != nil {
+		service.log.Warn("unable to estimate the size of stored pieces", zap.Error(err))
+	}
+	service.update(func() { service.current = &progress })
+	defer service.update(func() { service.current = nil })
+
+	throttle := newThrottle(service.config.ReadRate)
+	for _, satellite := range service.trust.GetSatellites(ctx) {
+		err := service.store.WalkSatellitePieces(ctx, satellite, func(access pieces.StoredPieceAccess) error {
+			return service.scrubPiece(ctx, satellite, access, &progress, throttle)
+		})
+		if err != nil {
+			return Error.Wrap(err)
+		}
+	}
+
+	service.update(func() {
+		progress.FinishedAt = time.Now()
+		last := progress
+		service.last = &last
+	})
+	service.log.Info("scrubbed pieces",
+		zap.Int64("Pieces", progress.PiecesChecked),
+		zap.Int64("Corrupt", progress.PiecesCorrupt),
+		zap.Int64("Failed", progress.PiecesFailed))
+	return nil
+}
+
+// scrubPiece verifies a single piece and quarantines it, when it's corrupt.
+func (service *Service) scrubPiece(ctx context.Context, satellite storj.NodeID, access pieces.StoredPieceAccess, progress *Progress, throttle *throttle) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	pieceID := access.PieceID()
+	corrupt, checked, verifyErr := service.verify(ctx, satellite, access, throttle)
+	if verifyErr != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if errors.Is(verifyErr, os.ErrNotExist) {
+			// the piece was deleted while scrubbing.
+			return nil
+		}
+	}
+
+	finding := Finding{
+		SatelliteID: satellite,
+		PieceID:     pieceID,
+		FoundAt:     time.Now(),
+	}
+	switch {
+	case verifyErr != nil:
+		mon.Counter("scrubber_failed_pieces").Inc(1)
+		finding.Error = verifyErr.Error()
+		service.log.Warn("unable to verify piece", zap.Stringer("Satellite ID", satellite), zap.Stringer("Piece ID", pieceID), zap.Error(verifyErr))
+	case corrupt:
+		mon.Counter("scrubber_corrupt_pieces").Inc(1)
+		finding.Error = "piece content doesn't match its hash"
+	}
+	if verifyErr != nil || corrupt {
+		finding.Path, _ = access.FullPath(ctx)
+	}
+	if corrupt {
+		path, err := service.store.Quarantine(ctx, satellite, pieceID, access.StorageFormatVersion(), service.quarantine)
+		if err != nil {
+			service.log.Error("unable to quarantine corrupt piece", zap.Stringer("Satellite ID", satellite), zap.Stringer("Piece ID", pieceID), zap.String("Path", finding.Path), zap.Error(err))
+		} else {
+			finding.Path = path
+			finding.Quarantined = true
+			service.log.Warn("quarantined corrupt piece", zap.Stringer("Satellite ID", satellite), zap.Stringer("Piece ID", pieceID), zap.String("Path", path))
+		}
+	}
+
+	service.update(func() {
+		progress.PiecesChecked++
+		progress.BytesChecked += checked
+		switch {
+		case verifyErr != nil:
+			progress.PiecesFailed++
+		case corrupt:
+			progress.PiecesCorrupt++
+		default:
+			return
+		}
+		service.findings = append([]Finding{finding}, service.findings...)
+		if len(service.findings) > maxFindings {
+			service.findings = service.findings[:maxFindings]
+		}
+	})
+	return nil
+}
+
+// verify reads the piece content and compares its hash with the stored hash. It returns
+// the number of piece bytes read.
+func (service *Service) verify(ctx context.Context, satellite storj.NodeID, access pieces.StoredPieceAccess, throttle *throttle) (corrupt bool, checked int64, err error) {
+	reader, err := service.store.ReaderWithStorageFormat(ctx, satellite, access.PieceID(), access.StorageFormatVersion())
+	if err != nil {
+		return false, 0, err
+	}
+	defer func() { err = errs.Combine(err, reader.Close()) }()
+
+	pieceHash, _, err := service.store.GetHashAndLimit(ctx, satellite, access.PieceID(), reader)
+	if err != nil {
+		return false, 0, err
+	}
+
+	hash := pb.NewHashFromAlgorithm(pieceHash.HashAlgorithm)
+	buffer := make([]byte, readBufferSize.Int())
+	for {
+		n, err := reader.Read(buffer)
+		checked += int64(n)
+		_, _ = hash.Write(buffer[:n])
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return false, checked, err
+		}
+		if werr := throttle.wait(ctx, int64(n)); werr != nil {
+			return false, checked, werr
+		}
+	}
+
+	return !bytes.Equal(hash.Sum(nil), pieceHash.Hash), checked, nil
+}
+
+// update modifies the status of the scrubber.
+func (service *Service) update(fn func()) {
+	service.mu.Lock()
+	defer service.mu.Unlock()
+	fn()
+}
+
+// throttle limits the rate at which piece data is read.
+type throttle struct {
+	rate    int64
+	started time.Time
+	read    int64
+}
+
+// newThrottle creates a throttle for the rate in bytes per second. A non-positive rate
+// doesn't limit reading.
+func newThrottle(rate memory.Size) *throttle {
+	return &throttle{rate: rate.Int64(), started: time.Now()}
+}
+
+// wait waits until n more bytes may be read.
+func (throttle *throttle) wait(ctx context.Context, n int64) error {
+	if throttle.rate <= 0 {
+		return ctx.Err()
+	}
+	throttle.read += n
+	due := throttle.started.Add(time.Duration(float64(throttle.read) / float64(throttle.rate) * float64(time.Second)))
+	if !sync2.Sleep(ctx, time.Until(due)) {
+		return ctx.Err()
+	}
+	return nil
+}
@@ -0,0 +1,136 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package scrubber_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"storj.io/common/memory"
+	"storj.io/common/pb"
+	"storj.io/common/rpc"
+	"storj.io/common/storj"
+	"storj.io/common/testcontext"
+	"storj.io/common/testrand"
+	"storj.io/storj/storage"
+	"storj.io/storj/storage/filestore"
+	"storj.io/storj/storagenode"
+	"storj.io/storj/storagenode/pieces"
+	"storj.io/storj/storagenode/scrubber"
+	"storj.io/storj/storagenode/storagenodedb/storagenodedbtest"
+	"storj.io/storj/storagenode/trust"
+)
+
+func TestScrub(t *testing.T) {
+	storagenodedbtest.Run(t, func(ctx *testcontext.Context, t *testing.T, db storagenode.DB) {
+		log := zaptest.NewLogger(t)
+		dir, err := filestore.NewDir(log, ctx.Dir("store"))
+		require.NoError(t, err)
+		blobs := filestore.New(log, dir, filestore.DefaultConfig)
+		defer ctx.Check(blobs.Close)
+
+		store := pieces.NewStore(log, blobs, nil, db.PieceExpirationDB(), nil, pieces.DefaultConfig)
+
+		satellite := testrand.NodeID()
+		pool, err := trust.NewPool(log, trust.Dialer(rpc.Dialer{}), trust.Config{
+			Sources:   []trust.Source{&trust.StaticURLSource{URL: trust.SatelliteURL{ID: satellite, Host: "localhost", Port: 7777}}},
+			CachePath: ctx.File("trust-cache.json"),
+		}, db.Satellites())
+		require.NoError(t, err)
+		require.NoError(t, pool.Refresh(ctx))
+
+		healthy := []storj.PieceID{testrand.PieceID(), testrand.PieceID()}
+		for _, pieceID := range healthy {
+			writePiece(ctx, t, store, satellite, pieceID, testrand.Bytes(memory.KiB))
+		}
+		corrupt := testrand.PieceID()
+		writePiece(ctx, t, store, satellite, corrupt, testrand.Bytes(memory.KiB))
+
+		// flip a bit of the corrupt piece's content
+		info, err := blobs.Stat(ctx, storage.BlobRef{Namespace: satellite.Bytes(), Key: corrupt.Bytes()})
+		require.NoError(t, err)
+		path, err := info.FullPath(ctx)
+		require.NoError(t, err)
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		data[pieces.V1PieceHeaderReservedArea+10] ^= 1
+		require.NoError(t, os.WriteFile(path, data, 0644))
+
+		quarantine := ctx.Dir("quarantine")
+		service := scrubber.NewService(log, store, pool, quarantine, scrubber.Config{
+			Enabled:  true,
+			Interval: time.Hour,
+			ReadRate: 100 * memory.KiB,
+		})
+		defer ctx.Check(service.Close)
+
+		status := service.Status()
+		require.True(t, status.Enabled)
+		require.Nil(t, status.Current)
+		require.Nil(t, status.Last)
+
+		require.NoError(t, service.Scrub(ctx))
+
+		status = service.Status()
+		require.Nil(t, status.Current)
+		require.NotNil(t, status.Last)
+		require.False(t, status.Last.FinishedAt.IsZero())
+		require.EqualValues(t, 3, status.Last.PiecesChecked)
+		require.EqualValues(t, 3*memory.KiB, status.Last.BytesChecked)
+		require.EqualValues(t, 3*memory.KiB, status.Last.BytesTotal)
+		require.EqualValues(t, 1, status.Last.PiecesCorrupt)
+		require.Zero(t, status.Last.PiecesFailed)
+
+		require.Len(t, status.Findings, 1)
+		finding := status.Findings[0]
+		require.Equal(t, satellite, finding.SatelliteID)
+		require.Equal(t, corrupt, finding.PieceID)
+		require.Equal(t, filepath.Join(quarantine, satellite.String(), corrupt.String()+".sj1"), finding.Path)
+		require.True(t, finding.Quarantined)
+
+		// the corrupt piece is moved to the quarantine unchanged, the others are kept
+		quarantined, err := os.ReadFile(finding.Path)
+		require.NoError(t, err)
+		require.Equal(t, data, quarantined)
+		_, err = store.Reader(ctx, satellite, corrupt)
+		require.ErrorIs(t, err, os.ErrNotExist)
+		for _, pieceID := range healthy {
+			reader, err := store.Reader(ctx, satellite, pieceID)
+			require.NoError(t, err)
+			require.NoError(t, reader.Close())
+		}
+
+		// the quarantine isn't part of the trash, so it can't be restored
+		trashed, err := store.SpaceUsedForTrash(ctx)
+		require.NoError(t, err)
+		require.Zero(t, trashed)
+		require.NoError(t, store.RestoreTrash(ctx, satellite))
+		_, err = store.Reader(ctx, satellite, corrupt)
+		require.ErrorIs(t, err, os.ErrNotExist)
+
+		// the next scrub doesn't find anything new
+		require.NoError(t, service.Scrub(ctx))
+		status = service.Status()
+		require.EqualValues(t, 2, status.Last.PiecesChecked)
+		require.Zero(t, status.Last.PiecesCorrupt)
+		require.Len(t, status.Findings, 1)
+	})
+}
+
+func writePiece(ctx *testcontext.Context, t *testing.T, store *pieces.Store, satellite storj.NodeID, pieceID storj.PieceID, data []byte) {
+	writer, err := store.Writer(ctx, satellite, pieceID, pb.PieceHashAlgorithm_SHA256)
+	require.NoError(t, err)
+	_, err = writer.Write(data)
+	require.NoError(t, err)
+	require.NoError(t, writer.Commit(ctx, &pb.PieceHeader{
+		Hash:          writer.Hash(),
+		HashAlgorithm: pb.PieceHashAlgorithm_SHA256,
+		CreationTime:  time.Now(),
+	}))
+}
@@ -83,6 +83,43 @@ type Config struct {
 	Driver    string // if unset, uses sqlite3
 	Pieces    string
 	Filestore filestore.Config
+	// ExtraPieces are additional directories for pieces, usually on other disks.
+	ExtraPieces []string
+}
+
+// openPieces opens the blob store of the pieces, which spans the extra pieces directories
+// too when they're configured.
+func openPieces(log *zap.Logger, piecesDir *filestore.Dir, config Config) (storage.Blobs, error) {
+	pieces, err := filestore.OpenBlobs(log, piecesDir, config.Filestore)
+	if err != nil || len(config.ExtraPieces) == 0 {
+		return pieces, err
+	}
+
+	disks := []filestore.Disk{{Path: piecesDir.Path(), Blobs: pieces}}
+	for _, path := range config.ExtraPieces {
+		blobs, err := openExtraPieces(log, path, config.Filestore)
+		if err != nil {
+			// a failing disk only makes the pieces on it unavailable.
+			log.Error("extra pieces directory is unavailable", zap.String("Path", path), zap.Error(err))
+			continue
+		}
+		disks = append(disks, filestore.Disk{Path: path, Blobs: blobs})
+	}
+	return filestore.NewMulti(log.Named("disks"), disks), nil
+}
+
+// openExtraPieces opens the blob store of an extra pieces directory.
+func openExtraPieces(log *zap.Logger, path string, config filestore.Config) (storage.Blobs, error) {
+	// the directory must exist, so that the pieces aren't stored on the parent disk when
+	// the disk isn't mounted.
+	if _, err := os.Stat(path); err != nil {
+		return nil, err
+	}
+	dir, err := filestore.NewDir(log, path)
+	if err != nil {
+		return nil, err
+	}
+	return filestore.OpenBlobs(log, dir, config)
 }
 
 // DB contains access to different database tables.
@@ -99,6 +136,7 @@ type DB struct {
 	bandwidthDB       *bandwidthDB
 	ordersDB          *ordersDB
 	pieceExpirationDB *pieceExpirationDB
+	pieceIndexDB      *pieceIndexDB
 	pieceSpaceUsedDB  *pieceSpaceUsedDB
 	reputationDB      *reputationDB
 	storageUsageDB    *storageUsageDB
@@ -119,13 +157,18 @@ func OpenNew(ctx context.Context, log *zap.Logger, config Config) (*DB, error) {
 		return nil, err
 	}
 
-	pieces := filestore.New(log, piecesDir, config.Filestore)
+	pieces, err := openPieces(log, piecesDir, config)
+	if err != nil {
+		return nil, err
+	}
 
 	deprecatedInfoDB := &deprecatedInfoDB{}
 	v0PieceInfoDB := &v0PieceInfoDB{}
 	bandwidthDB := &bandwidthDB{}
 	ordersDB := &ordersDB{}
 	pieceExpirationDB := &pieceExpirationDB{}
+	// the piece index is stored in the piece expiration database.
+	pieceIndexDB := &pieceIndexDB{dbContainerImpl: &pieceExpirationDB.dbContainerImpl}
 	pieceSpaceUsedDB := &pieceSpaceUsedDB{}
 	reputationDB := &reputationDB{}
 	storageUsageDB := &storageUsageDB{}
@@ -149,6 +192,7 @@ func OpenNew(ctx context.Context, log *zap.Logger, config Config) (*DB, error) {
 		bandwidthDB:       bandwidthDB,
 		ordersDB:          ordersDB,
 		pieceExpirationDB: pieceExpirationDB,
+		pieceIndexDB:      pieceIndexDB,
 		pieceSpaceUsedDB:  pieceSpaceUsedDB,
 		reputationDB:      reputationDB,
 		storageUsageDB:    storageUsageDB,
@@ -187,13 +231,18 @@ func OpenExisting(ctx context.Context, log *zap.Logger, config Config) (*DB, err
 		return nil, err
 	}
 
-	pieces := filestore.New(log, piecesDir, config.Filestore)
+	pieces, err := openPieces(log, piecesDir, config)
+	if err != nil {
+		return nil, err
+	}
 
 	deprecatedInfoDB := &deprecatedInfoDB{}
 	v0PieceInfoDB := &v0PieceInfoDB{}
 	bandwidthDB := &bandwidthDB{}
 	ordersDB := &ordersDB{}
 	pieceExpirationDB := &pieceExpirationDB{}
+	// the piece index is stored in the piece expiration database.
+	pieceIndexDB := &pieceIndexDB{dbContainerImpl: &pieceExpirationDB.dbContainerImpl}
 	pieceSpaceUsedDB := &pieceSpaceUsedDB{}
 	reputationDB := &reputationDB{}
 	storageUsageDB := &storageUsageDB{}
@@ -217,6 +266,7 @@ func OpenExisting(ctx context.Context, log *zap.Logger, config Config) (*DB, err
 		bandwidthDB:       bandwidthDB,
 		ordersDB:          ordersDB,
 		pieceExpirationDB: pieceExpirationDB,
+		pieceIndexDB:      pieceIndexDB,
 		pieceSpaceUsedDB:  pieceSpaceUsedDB,
 		reputationDB:      reputationDB,
 		storageUsageDB:    storageUsageDB,
@@ -464,7 +514,7 @@ func (db *DB) preflight(ctx context.Context, dbName string, dbContainer DBContai
 
 // Close closes any resources.
 func (db *DB) Close() error {
-	return db.closeDatabases()
+	return errs.Combine(db.closeDatabases(), db.pieces.Close())
 }
 
 // closeDatabases closes all the SQLite database connections and removes them from the associated maps.
@@ -521,6 +571,11 @@ func (db *DB) PieceExpirationDB() pieces.PieceExpirationDB {
 	return db.pieceExpirationDB
 }
 
+// PieceIndexDB returns the instance of the PieceIndex database.
+func (db *DB) PieceIndexDB() pieces.PieceIndexDB {
+	return db.pieceIndexDB
+}
+
 // PieceSpaceUsedDB returns the instance of the PieceSpacedUsed database.
 func (db *DB) PieceSpaceUsedDB() pieces.PieceSpaceUsedDB {
 	return db.pieceSpaceUsedDB
@@ -2035,6 +2090,35 @@ func (db *DB) Migration(ctx context.Context) *migrate.Migration {
 					return errs.Wrap(err)
 				}),
 			},
+			{
+				DB:          &db.pieceExpirationDB.DB,
+				Description: "Add piece_index table to piece_expiration db",
+				Version:     55,
+				Action: migrate.SQL{
+					`CREATE TABLE piece_index (
+						satellite_id   BLOB      NOT NULL,
+						piece_id       BLOB      NOT NULL,
+						format_version INTEGER   NOT NULL,
+						size           INTEGER   NOT NULL,
+						mod_time       TIMESTAMP NOT NULL,
+						checked_at     TIMESTAMP NOT NULL,
+						PRIMARY KEY ( satellite_id, piece_id )
+					)`,
+					`CREATE INDEX idx_piece_index_checked_at ON piece_index(satellite_id, checked_at)`,
+				},
+			},
+			{
+				DB:          &db.pieceExpirationDB.DB,
+				Description: "Add piece_index_states table to piece_expiration db",
+				Version:     56,
+				Action: migrate.SQL{
+					`CREATE TABLE piece_index_states (
+						satellite_id BLOB    NOT NULL,
+						clean        INTEGER NOT NULL,
+						PRIMARY KEY ( satellite_id )
+					)`,
+				},
+			},
 		},
 	}
 }
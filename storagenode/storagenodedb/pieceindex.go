@@ -0,0 +1,206 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package storagenodedb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/zeebo/errs"
+
+	"storj.io/common/storj"
+	"storj.io/private/tagsql"
+	"storj.io/storj/storage"
+	"storj.io/storj/storagenode/pieces"
+)
+
+// ErrPieceIndex represents errors from the piece index database.
+var ErrPieceIndex = errs.Class("pieceindexdb")
+
+// pieceIndexWalkBatchSize is the number of index entries, which are read at once while walking.
+const pieceIndexWalkBatchSize = 1000
+
+// pieceIndexDB stores the piece index in the piece expiration database, so that the
+// expirations can be joined to the index entries.
+type pieceIndexDB struct {
+	*dbContainerImpl
+}
+
+// Put adds or replaces the index entries of the satellite's pieces and marks them as
+// checked at the given time. Entries, which were checked later, aren't replaced.
+func (db *pieceIndexDB) Put(ctx context.Context, satellite storj.NodeID, entries []pieces.PieceIndexEntry, checkedAt time.Time) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if len(entries) == 0 {
+		return nil
+	}
+
+	query := `INSERT INTO piece_index(satellite_id, piece_id, format_version, size, mod_time, checked_at)
+			VALUES(?,?,?,?,?,?)
+			ON CONFLICT(satellite_id, piece_id) DO UPDATE SET
+				format_version = excluded.format_version,
+				size = excluded.size,
+				mod_time = excluded.mod_time,
+				checked_at = excluded.checked_at
+			WHERE piece_index.checked_at <= excluded.checked_at`
+
+	return ErrPieceIndex.Wrap(withTx(ctx, db.GetDB(), func(tx tagsql.Tx) error {
+		for _, entry := range entries {
+			_, err := tx.ExecContext(ctx, query, satellite, entry.PieceID, int(entry.FormatVersion), entry.Size, entry.ModTime.UTC(), checkedAt.UTC())
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}))
+}
+
+// Delete removes the index entries of the satellite's pieces.
+func (db *pieceIndexDB) Delete(ctx context.Context, satellite storj.NodeID, pieceIDs []storj.PieceID) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if len(pieceIDs) == 0 {
+		return nil
+	}
+
+	query := `DELETE FROM piece_index WHERE satellite_id = ? AND piece_id = ?`
+
+	return ErrPieceIndex.Wrap(withTx(ctx, db.GetDB(), func(tx tagsql.Tx) error {
+		for _, pieceID := range pieceIDs {
+			if _, err := tx.ExecContext(ctx, query, satellite, pieceID); err != nil {
+				return err
+			}
+		}
+		return nil
+	}))
+}
+
+// DeleteSatellite removes all the index entries and the state of the satellite.
+func (db *pieceIndexDB) DeleteSatellite(ctx context.Context, satellite storj.NodeID) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	return ErrPieceIndex.Wrap(withTx(ctx, db.GetDB(), func(tx tagsql.Tx) error {
+		if _, err := tx.ExecContext(ctx, `DELETE FROM piece_index WHERE satellite_id = ?`, satellite); err != nil {
+			return err
+		}
+		_, err := tx.ExecContext(ctx, `DELETE FROM piece_index_states WHERE satellite_id = ?`, satellite)
+		return err
+	}))
+}
+
+// SetClean sets whether the index of the satellite is up to date with the blob store.
+func (db *pieceIndexDB) SetClean(ctx context.Context, satellite storj.NodeID, clean bool) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	_, err = db.ExecContext(ctx, `
+		INSERT INTO piece_index_states(satellite_id, clean) VALUES(?,?)
+			ON CONFLICT(satellite_id) DO UPDATE SET clean = excluded.clean
+	`, satellite, clean)
+	return ErrPieceIndex.Wrap(err)
+}
+
+// IsClean returns whether the index of the satellite was marked clean.
+func (db *pieceIndexDB) IsClean(ctx context.Context, satellite storj.NodeID) (clean bool, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	err = db.QueryRowContext(ctx, `
+		SELECT clean FROM piece_index_states WHERE satellite_id = ?
+	`, satellite).Scan(&clean)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	return clean, ErrPieceIndex.Wrap(err)
+}
+
+// DeleteUncheckedBefore removes the index entries of the satellite, which weren't checked
+// since the given time.
+func (db *pieceIndexDB) DeleteUncheckedBefore(ctx context.Context, satellite storj.NodeID, checkedBefore time.Time) (deleted int64, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	result, err := db.ExecContext(ctx, `
+		DELETE FROM piece_index
+			WHERE satellite_id = ?
+				AND checked_at < ?
+	`, satellite, checkedBefore.UTC())
+	if err != nil {
+		return 0, ErrPieceIndex.Wrap(err)
+	}
+	deleted, err = result.RowsAffected()
+	return deleted, ErrPieceIndex.Wrap(err)
+}
+
+// Walk calls walkFunc for every index entry of the satellite ordered by the piece ID.
+//
+// The entries are read in batches, so walkFunc may modify the index.
+func (db *pieceIndexDB) Walk(ctx context.Context, satellite storj.NodeID, walkFunc func(pieces.PieceIndexEntry) error) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	var cursor storj.PieceID
+	first := true
+	for {
+		batch, err := db.walkBatch(ctx, satellite, cursor, first)
+		if err != nil {
+			return ErrPieceIndex.Wrap(err)
+		}
+		for _, entry := range batch {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			if err := walkFunc(entry); err != nil {
+				return err
+			}
+		}
+		if len(batch) < pieceIndexWalkBatchSize {
+			return nil
+		}
+		cursor, first = batch[len(batch)-1].PieceID, false
+	}
+}
+
+// walkBatch returns the next batch of the satellite's index entries after the cursor.
+func (db *pieceIndexDB) walkBatch(ctx context.Context, satellite storj.NodeID, cursor storj.PieceID, first bool) (batch []pieces.PieceIndexEntry, err error) {
+	rows, err := db.QueryContext(ctx, `
+		SELECT pi.piece_id, pi.format_version, pi.size, pi.mod_time, pe.piece_expiration
+			FROM piece_index pi
+			LEFT JOIN piece_expirations pe
+				ON pe.satellite_id = pi.satellite_id AND pe.piece_id = pi.piece_id
+			WHERE pi.satellite_id = ?
+				AND (? OR pi.piece_id > ?)
+			ORDER BY pi.piece_id
+			LIMIT ?
+	`, satellite, first, cursor, pieceIndexWalkBatchSize)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { err = errs.Combine(err, rows.Close()) }()
+
+	for rows.Next() {
+		var entry pieces.PieceIndexEntry
+		var formatVersion int
+		var expiration sql.NullTime
+		err := rows.Scan(&entry.PieceID, &formatVersion, &entry.Size, &entry.ModTime, &expiration)
+		if err != nil {
+			return nil, err
+		}
+		entry.FormatVersion = storage.FormatVersion(formatVersion)
+		if expiration.Valid {
+			entry.Expiration = expiration.Time
+		}
+		batch = append(batch, entry)
+	}
+	return batch, rows.Err()
+}
+
+// SpaceUsed returns the total size of the satellite's indexed pieces.
+func (db *pieceIndexDB) SpaceUsed(ctx context.Context, satellite storj.NodeID) (_ int64, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	var total sql.NullInt64
+	err = db.QueryRowContext(ctx, `
+		SELECT SUM(size) FROM piece_index WHERE satellite_id = ?
+	`, satellite).Scan(&total)
+	return total.Int64, ErrPieceIndex.Wrap(err)
+}
@@ -392,11 +392,64 @@ func Schema() map[string]*dbschema.Schema {
 						},
 					},
 				},
+				{
+					Name:       "piece_index",
+					PrimaryKey: []string{"piece_id", "satellite_id"},
+					Columns: []*dbschema.Column{
+						{
+							Name:       "checked_at",
+							Type:       "TIMESTAMP",
+							IsNullable: false,
+						},
+						{
+							Name:       "format_version",
+							Type:       "INTEGER",
+							IsNullable: false,
+						},
+						{
+							Name:       "mod_time",
+							Type:       "TIMESTAMP",
+							IsNullable: false,
+						},
+						{
+							Name:       "piece_id",
+							Type:       "BLOB",
+							IsNullable: false,
+						},
+						{
+							Name:       "satellite_id",
+							Type:       "BLOB",
+							IsNullable: false,
+						},
+						{
+							Name:       "size",
+							Type:       "INTEGER",
+							IsNullable: false,
+						},
+					},
+				},
+				{
+					Name:       "piece_index_states",
+					PrimaryKey: []string{"satellite_id"},
+					Columns: []*dbschema.Column{
+						{
+							Name:       "clean",
+							Type:       "INTEGER",
+							IsNullable: false,
+						},
+						{
+							Name:       "satellite_id",
+							Type:       "BLOB",
+							IsNullable: false,
+						},
+					},
+				},
 			},
 			Indexes: []*dbschema.Index{
 				{Name: "idx_piece_expirations_deletion_failed_at", Table: "piece_expirations", Columns: []string{"deletion_failed_at"}, Unique: false, Partial: ""},
 				{Name: "idx_piece_expirations_piece_expiration", Table: "piece_expirations", Columns: []string{"piece_expiration"}, Unique: false, Partial: ""},
 				{Name: "idx_piece_expirations_trashed", Table: "piece_expirations", Columns: []string{"satellite_id", "trash"}, Unique: false, Partial: "trash = 1"},
+				{Name: "idx_piece_index_checked_at", Table: "piece_index", Columns: []string{"satellite_id", "checked_at"}, Unique: false, Partial: ""},
 			},
 		},
 		"piece_spaced_used": {
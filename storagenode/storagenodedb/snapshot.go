@@ -122,6 +122,21 @@ func (db *DB) Snapshot(ctx context.Context) *migrate.Migration {
 					`CREATE INDEX idx_piece_expirations_trashed
 						ON piece_expirations(satellite_id, trash)
 						WHERE trash = 1`,
+					`CREATE TABLE piece_index (
+						satellite_id   BLOB      NOT NULL,
+						piece_id       BLOB      NOT NULL,
+						format_version INTEGER   NOT NULL,
+						size           INTEGER   NOT NULL,
+						mod_time       TIMESTAMP NOT NULL,
+						checked_at     TIMESTAMP NOT NULL,
+						PRIMARY KEY ( satellite_id, piece_id )
+					)`,
+					`CREATE INDEX idx_piece_index_checked_at ON piece_index(satellite_id, checked_at)`,
+					`CREATE TABLE piece_index_states (
+						satellite_id BLOB    NOT NULL,
+						clean        INTEGER NOT NULL,
+						PRIMARY KEY ( satellite_id )
+					)`,
 				},
 			},
 			{
@@ -83,6 +83,37 @@ func TestInMemoryConcurrency(t *testing.T) {
 	testConcurrency(t, ctx, db)
 }
 
+func TestExtraPieces(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	log := zaptest.NewLogger(t)
+
+	storageDir := ctx.Dir("storage")
+	extraDir := ctx.Dir("extra")
+	cfg := storagenodedb.Config{
+		Pieces:    storageDir,
+		Storage:   storageDir,
+		Info:      filepath.Join(storageDir, "piecestore.db"),
+		Info2:     filepath.Join(storageDir, "info.db"),
+		Filestore: filestore.DefaultConfig,
+		// a missing extra directory only makes its pieces unavailable.
+		ExtraPieces: []string{extraDir, filepath.Join(ctx.Dir("unmounted"), "missing")},
+	}
+
+	db, err := storagenodedb.OpenNew(ctx, log, cfg)
+	require.NoError(t, err)
+	defer ctx.Check(db.Close)
+
+	blobs, ok := db.Pieces().(*filestore.MultiBlobs)
+	require.True(t, ok)
+
+	disks := blobs.Disks()
+	require.Len(t, disks, 2)
+	require.Equal(t, storageDir, disks[0].Path)
+	require.Equal(t, extraDir, disks[1].Path)
+}
+
 func testConcurrency(t *testing.T, ctx *testcontext.Context, db *storagenodedb.DB) {
 	t.Run("Sqlite", func(t *testing.T) {
 		runtime.GOMAXPROCS(2)
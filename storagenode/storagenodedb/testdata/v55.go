@@ -0,0 +1,54 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package testdata
+
+import "storj.io/storj/storagenode/storagenodedb"
+
+var v55 = MultiDBState{
+	Version: 55,
+	DBStates: DBStates{
+		storagenodedb.UsedSerialsDBName:    v54.DBStates[storagenodedb.UsedSerialsDBName],
+		storagenodedb.StorageUsageDBName:   v54.DBStates[storagenodedb.StorageUsageDBName],
+		storagenodedb.ReputationDBName:     v54.DBStates[storagenodedb.ReputationDBName],
+		storagenodedb.PieceSpaceUsedDBName: v54.DBStates[storagenodedb.PieceSpaceUsedDBName],
+		storagenodedb.PieceInfoDBName:      v54.DBStates[storagenodedb.PieceInfoDBName],
+		storagenodedb.PieceExpirationDBName: &DBState{
+			SQL: `
+				-- table to hold expiration data (and only expirations. no other pieceinfo)
+				CREATE TABLE piece_expirations (
+					satellite_id       BLOB      NOT NULL,
+					piece_id           BLOB      NOT NULL,
+					piece_expiration   TIMESTAMP NOT NULL, -- date when it can be deleted
+					deletion_failed_at TIMESTAMP,
+					trash              INTEGER NOT NULL DEFAULT 0,
+					PRIMARY KEY ( satellite_id, piece_id )
+				);
+				CREATE INDEX idx_piece_expirations_piece_expiration ON piece_expirations(piece_expiration);
+				CREATE INDEX idx_piece_expirations_deletion_failed_at ON piece_expirations(deletion_failed_at);
+				CREATE INDEX idx_piece_expirations_trashed ON piece_expirations(satellite_id, trash) WHERE trash = 1;
+				CREATE TABLE piece_index (
+					satellite_id   BLOB      NOT NULL,
+					piece_id       BLOB      NOT NULL,
+					format_version INTEGER   NOT NULL,
+					size           INTEGER   NOT NULL,
+					mod_time       TIMESTAMP NOT NULL,
+					checked_at     TIMESTAMP NOT NULL,
+					PRIMARY KEY ( satellite_id, piece_id )
+				);
+				CREATE INDEX idx_piece_index_checked_at ON piece_index(satellite_id, checked_at);
+			`,
+			NewData: `
+				INSERT INTO piece_index VALUES(X'0ed28abb2813e184a1e98b0f6605c4911ea468c7e8433eb583e0fca7ceac3000', X'0ed28abb2813e184a1e98b0f6605c4911ea468c7e8433eb583e0fca7ceac3000', 1, 2048, '2022-08-01 10:00:00+00:00', '2022-08-02 10:00:00+00:00');
+			`,
+		},
+		storagenodedb.OrdersDBName:         v54.DBStates[storagenodedb.OrdersDBName],
+		storagenodedb.BandwidthDBName:      v54.DBStates[storagenodedb.BandwidthDBName],
+		storagenodedb.SatellitesDBName:     v54.DBStates[storagenodedb.SatellitesDBName],
+		storagenodedb.DeprecatedInfoDBName: v54.DBStates[storagenodedb.DeprecatedInfoDBName],
+		storagenodedb.NotificationsDBName:  v54.DBStates[storagenodedb.NotificationsDBName],
+		storagenodedb.HeldAmountDBName:     v54.DBStates[storagenodedb.HeldAmountDBName],
+		storagenodedb.PricingDBName:        v54.DBStates[storagenodedb.PricingDBName],
+		storagenodedb.APIKeysDBName:        v54.DBStates[storagenodedb.APIKeysDBName],
+	},
+}
@@ -0,0 +1,60 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package testdata
+
+import "storj.io/storj/storagenode/storagenodedb"
+
+var v56 = MultiDBState{
+	Version: 56,
+	DBStates: DBStates{
+		storagenodedb.UsedSerialsDBName:    v55.DBStates[storagenodedb.UsedSerialsDBName],
+		storagenodedb.StorageUsageDBName:   v55.DBStates[storagenodedb.StorageUsageDBName],
+		storagenodedb.ReputationDBName:     v55.DBStates[storagenodedb.ReputationDBName],
+		storagenodedb.PieceSpaceUsedDBName: v55.DBStates[storagenodedb.PieceSpaceUsedDBName],
+		storagenodedb.PieceInfoDBName:      v55.DBStates[storagenodedb.PieceInfoDBName],
+		storagenodedb.PieceExpirationDBName: &DBState{
+			SQL: `
+				-- table to hold expiration data (and only expirations. no other pieceinfo)
+				CREATE TABLE piece_expirations (
+					satellite_id       BLOB      NOT NULL,
+					piece_id           BLOB      NOT NULL,
+					piece_expiration   TIMESTAMP NOT NULL, -- date when it can be deleted
+					deletion_failed_at TIMESTAMP,
+					trash              INTEGER NOT NULL DEFAULT 0,
+					PRIMARY KEY ( satellite_id, piece_id )
+				);
+				CREATE INDEX idx_piece_expirations_piece_expiration ON piece_expirations(piece_expiration);
+				CREATE INDEX idx_piece_expirations_deletion_failed_at ON piece_expirations(deletion_failed_at);
+				CREATE INDEX idx_piece_expirations_trashed ON piece_expirations(satellite_id, trash) WHERE trash = 1;
+				CREATE TABLE piece_index (
+					satellite_id   BLOB      NOT NULL,
+					piece_id       BLOB      NOT NULL,
+					format_version INTEGER   NOT NULL,
+					size           INTEGER   NOT NULL,
+					mod_time       TIMESTAMP NOT NULL,
+					checked_at     TIMESTAMP NOT NULL,
+					PRIMARY KEY ( satellite_id, piece_id )
+				);
+				CREATE INDEX idx_piece_index_checked_at ON piece_index(satellite_id, checked_at);
+				CREATE TABLE piece_index_states (
+					satellite_id BLOB    NOT NULL,
+					clean        INTEGER NOT NULL,
+					PRIMARY KEY ( satellite_id )
+				);
+				INSERT INTO piece_index VALUES(X'0ed28abb2813e184a1e98b0f6605c4911ea468c7e8433eb583e0fca7ceac3000', X'0ed28abb2813e184a1e98b0f6605c4911ea468c7e8433eb583e0fca7ceac3000', 1, 2048, '2022-08-01 10:00:00+00:00', '2022-08-02 10:00:00+00:00');
+			`,
+			NewData: `
+				INSERT INTO piece_index_states VALUES(X'0ed28abb2813e184a1e98b0f6605c4911ea468c7e8433eb583e0fca7ceac3000', 1);
+			`,
+		},
+		storagenodedb.OrdersDBName:         v55.DBStates[storagenodedb.OrdersDBName],
+		storagenodedb.BandwidthDBName:      v55.DBStates[storagenodedb.BandwidthDBName],
+		storagenodedb.SatellitesDBName:     v55.DBStates[storagenodedb.SatellitesDBName],
+		storagenodedb.DeprecatedInfoDBName: v55.DBStates[storagenodedb.DeprecatedInfoDBName],
+		storagenodedb.NotificationsDBName:  v55.DBStates[storagenodedb.NotificationsDBName],
+		storagenodedb.HeldAmountDBName:     v55.DBStates[storagenodedb.HeldAmountDBName],
+		storagenodedb.PricingDBName:        v55.DBStates[storagenodedb.PricingDBName],
+		storagenodedb.APIKeysDBName:        v55.DBStates[storagenodedb.APIKeysDBName],
+	},
+}